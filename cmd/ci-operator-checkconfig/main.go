@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/sirupsen/logrus"
 
@@ -31,6 +32,7 @@ type options struct {
 	config.Options
 
 	resolver           registry.Resolver
+	deprecations       *registry.DeprecationChecker
 	ciOPConfigAgent    agents.ConfigAgent
 	clusterProfiles    api.ClusterProfilesMap
 	clusterClaimOwners api.ClusterClaimOwnersMap
@@ -125,11 +127,12 @@ func (o *options) loadResolver(path string) error {
 	if path == "" {
 		return nil
 	}
-	refs, chains, workflows, _, _, _, observers, err := load.Registry(path, load.RegistryFlag(0))
+	contents, err := load.LoadRegistry(path, load.RegistryFlag(0))
 	if err != nil {
 		return err
 	}
-	o.resolver = registry.NewResolver(refs, chains, workflows, observers)
+	o.resolver = registry.NewResolver(contents.References, contents.Chains, contents.Workflows, contents.Observers)
+	o.deprecations = registry.NewDeprecationChecker(contents.Chains, contents.Workflows, contents.Deprecations)
 	return nil
 }
 
@@ -138,6 +141,15 @@ func (o *options) validateConfiguration(
 	seenCh chan<- promotedTag,
 	configuration api.ReleaseBuildConfiguration,
 ) error {
+	if o.deprecations != nil {
+		warnings, err := o.deprecations.Check(configuration, time.Now())
+		for _, warning := range warnings {
+			logrus.WithFields(api.LogFieldsFor(configuration.Metadata)).Warn(warning)
+		}
+		if err != nil {
+			return err
+		}
+	}
 	if o.resolver != nil {
 		if c, err := registry.ResolveConfig(o.resolver, configuration); err != nil {
 			return err
@@ -320,6 +320,7 @@ func main() {
 		l("config"),
 		l("resolve"),
 		l("clusterProfile"),
+		l("usage"),
 		l("configGeneration"),
 		l("registryGeneration"),
 		l("integratedStream"),
@@ -329,6 +330,7 @@ func main() {
 		l(""),
 		l("search"),
 		l("job"),
+		l("deprecated"),
 		l("reference"),
 		l("chain"),
 		l("workflow"),
@@ -341,6 +343,7 @@ func main() {
 	http.HandleFunc("/mergeConfigsWithInjectedTest", handler(registryserver.ResolveAndMergeConfigsAndInjectTest(configAgent, registryAgent, configresolverMetrics)).ServeHTTP)
 	http.HandleFunc("/resolve", handler(registryserver.ResolveLiteralConfig(registryAgent, configresolverMetrics)).ServeHTTP)
 	http.HandleFunc("/clusterProfile", handler(registryserver.ResolveClusterProfile(registryAgent, configresolverMetrics)).ServeHTTP)
+	http.HandleFunc("/usage", handler(registryserver.ResolveUsage(registryserver.NewUsageIndexer(configAgent, registryAgent), configresolverMetrics)).ServeHTTP)
 	http.HandleFunc("/configGeneration", handler(getConfigGeneration(configAgent)).ServeHTTP)
 	http.HandleFunc("/registryGeneration", handler(getRegistryGeneration(registryAgent)).ServeHTTP)
 	cache := memoryCache{Client: ocClient, CacheDuration: time.Minute}
@@ -54,6 +54,7 @@ import (
 	"k8s.io/client-go/util/retry"
 	"k8s.io/klog/v2"
 	utilpointer "k8s.io/utils/pointer"
+	"sigs.k8s.io/boskos/common"
 	controllerruntime "sigs.k8s.io/controller-runtime"
 	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
 	crcontrollerutil "sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
@@ -62,6 +63,7 @@ import (
 	_ "sigs.k8s.io/prow/pkg/cache"
 	"sigs.k8s.io/prow/pkg/config/secret"
 	prowio "sigs.k8s.io/prow/pkg/io"
+	"sigs.k8s.io/prow/pkg/kube"
 	"sigs.k8s.io/prow/pkg/logrusutil"
 	"sigs.k8s.io/prow/pkg/pod-utils/downwardapi"
 	"sigs.k8s.io/prow/pkg/version"
@@ -83,20 +85,26 @@ import (
 	"github.com/openshift/ci-tools/pkg/api"
 	"github.com/openshift/ci-tools/pkg/api/configresolver"
 	"github.com/openshift/ci-tools/pkg/api/nsttl"
+	"github.com/openshift/ci-tools/pkg/api/ocplifecycle"
+	"github.com/openshift/ci-tools/pkg/criticalpath"
 	"github.com/openshift/ci-tools/pkg/defaults"
+	"github.com/openshift/ci-tools/pkg/dryrun"
 	gsm "github.com/openshift/ci-tools/pkg/gsm-secrets"
 	"github.com/openshift/ci-tools/pkg/interrupt"
 	"github.com/openshift/ci-tools/pkg/junit"
 	"github.com/openshift/ci-tools/pkg/labeledclient"
 	"github.com/openshift/ci-tools/pkg/lease"
+	leaseserver "github.com/openshift/ci-tools/pkg/lease/server"
 	"github.com/openshift/ci-tools/pkg/load"
 	"github.com/openshift/ci-tools/pkg/metrics"
+	"github.com/openshift/ci-tools/pkg/otlp"
 	"github.com/openshift/ci-tools/pkg/registry"
 	"github.com/openshift/ci-tools/pkg/registry/server"
 	"github.com/openshift/ci-tools/pkg/results"
 	"github.com/openshift/ci-tools/pkg/secrets"
 	"github.com/openshift/ci-tools/pkg/steps"
 	"github.com/openshift/ci-tools/pkg/steps/multi_stage"
+	releasesteps "github.com/openshift/ci-tools/pkg/steps/release"
 	tooldetector "github.com/openshift/ci-tools/pkg/tool-detector"
 	"github.com/openshift/ci-tools/pkg/util"
 	"github.com/openshift/ci-tools/pkg/util/gzip"
@@ -201,6 +209,9 @@ var (
 const CustomProwMetadata = "custom-prow-metadata.json"
 
 func main() {
+	// the timeout of the job is enforced by the entrypoint which starts this
+	// process, so it is measured from this point
+	started := time.Now()
 	censor, closer, err := setupLogger()
 	if err != nil {
 		logrus.WithError(err).Fatal("Could not set up logging.")
@@ -266,13 +277,22 @@ func main() {
 		opt.Report(results.ForReason("loading_args").ForError(err))
 		os.Exit(1)
 	}
+	opt.jobSpec.SetStartTime(started)
 
 	ctx := context.TODO()
-	opt.metricsAgent, err = metrics.NewMetricsAgent(ctx, opt.clusterConfig, opt.censor)
-	if err != nil {
-		logrus.WithError(err).Error("Failed to create metrics agent...Skipping metrics.")
-	} else {
-		go opt.metricsAgent.Run()
+	if opt.dryRunDir == "" {
+		opt.metricsAgent, err = metrics.NewMetricsAgent(ctx, opt.clusterConfig, opt.censor)
+		if err != nil {
+			logrus.WithError(err).Error("Failed to create metrics agent...Skipping metrics.")
+			if opt.otlpTracesEndpoint != "" || opt.traceFile != "" {
+				// the trace is built from the events recorded by the agent
+				opt.metricsAgent = metrics.NewTracingAgent(ctx)
+			}
+		}
+		if opt.metricsAgent != nil {
+			go opt.metricsAgent.Run()
+			opt.configureTracing()
+		}
 	}
 
 	opt.metricsAgent.Record(metrics.NewInsightsEvent(metrics.InsightStarted, metrics.Context{"job_spec": opt.jobSpec.MetricsData()}))
@@ -380,9 +400,14 @@ type options struct {
 	targets stringSlice
 	promote bool
 
-	verbose    bool
-	help       bool
-	printGraph bool
+	verbose          bool
+	help             bool
+	printGraph       bool
+	printGraphFormat string
+	dryRunDir        string
+
+	otlpTracesEndpoint string
+	traceFile          string
 
 	writeParams string
 	artifactDir string
@@ -395,6 +420,7 @@ type options struct {
 	idleCleanupDurationSet bool
 	cleanupDuration        time.Duration
 	cleanupDurationSet     bool
+	resultCacheNamespace   string
 
 	inputHash                  string
 	secrets                    []*coreapi.Secret
@@ -408,11 +434,16 @@ type options struct {
 	nodeName                   string
 	leaseServer                string
 	leaseServerCredentialsFile string
+	leaseServerConfig          string
 	leaseAcquireTimeout        time.Duration
+	leasePriority              string
+	leaseServerPriorities      bool
 	leaseClient                lease.Client
 	clusterProfiles            []metrics.ClusterProfileForTarget
 
 	givePrAuthorAccessToNamespace bool
+	debugHold                     time.Duration
+	parallelStepGroups            bool
 	impersonateUser               string
 	authors                       []string
 
@@ -438,6 +469,10 @@ type options struct {
 	uploadSecretPath string
 	uploadSecret     *coreapi.Secret
 
+	signingKeyPath      string
+	signingKeySecret    *coreapi.Secret
+	signingWithLocalKey bool
+
 	cloneAuthConfig *steps.CloneAuthConfig
 
 	resultsOptions results.Options
@@ -453,6 +488,9 @@ type options struct {
 	targetAdditionalSuffix string
 	manifestToolDockerCfg  string
 	localRegistryDNS       string
+	buildBackend           string
+	buildahImage           string
+	cosignImage            string
 
 	restrictNetworkAccess       bool
 	enableSecretsStoreCSIDriver bool
@@ -483,12 +521,19 @@ func bindOptions(flag *flag.FlagSet) *options {
 	flag.DurationVar(&opt.podPendingTimeout, "pod-pending-timeout", 60*time.Minute, "Maximum amount of time created pods can spend before the running state. For test pods, this applies to each container. For builds, it applies to the build execution as a whole.")
 	flag.StringVar(&opt.leaseServer, "lease-server", leaseServerAddress, "Address of the server that manages leases. Required if any test is configured to acquire a lease.")
 	flag.StringVar(&opt.leaseServerCredentialsFile, "lease-server-credentials-file", "", "The path to credentials file used to access the lease server. The content is of the form <username>:<password>.")
+	flag.StringVar(&opt.leaseServerConfig, "lease-server-config", "", "Path to a Boskos resource configuration. When set, leases for these resources are served by a lease server running within ci-operator instead of --lease-server. Meant for local clusters and integration tests.")
 	flag.DurationVar(&opt.leaseAcquireTimeout, "lease-acquire-timeout", leaseAcquireTimeout, "Maximum amount of time to wait for lease acquisition")
+	flag.StringVar(&opt.leasePriority, "lease-priority", "", "Priority of the requests for leases, one of rehearsal, presubmit, periodic, postsubmit or release-blocking. Derived from the type of the job if unset. Requires --lease-server-config or --lease-server-priorities.")
+	flag.BoolVar(&opt.leaseServerPriorities, "lease-server-priorities", false, "The server at --lease-server was started with the lease-server command and queues requests by priority. Requests are sent without a priority otherwise, as Boskos serves them in the order they were made.")
 	flag.StringVar(&opt.registryPath, "registry", "", "Path to the step registry directory")
 	flag.StringVar(&opt.configSpecPath, "config", "", "The configuration file. If not specified the CONFIG_SPEC environment variable or the configresolver will be used.")
 	flag.StringVar(&opt.unresolvedConfigPath, "unresolved-config", "", "The configuration file, before resolution. If not specified the UNRESOLVED_CONFIG environment variable will be used, if set.")
 	flag.Var(&opt.targets, "target", "One or more targets in the configuration to build. Only steps that are required for this target will be run.")
-	flag.BoolVar(&opt.printGraph, "print-graph", opt.printGraph, "Print a directed graph of the build steps and exit. Intended for use with the golang digraph utility.")
+	flag.BoolVar(&opt.printGraph, "print-graph", opt.printGraph, "Print a directed graph of the build steps required for --target and exit. By default, intended for use with the golang digraph utility.")
+	flag.StringVar(&opt.printGraphFormat, "print-graph-format", string(api.GraphFormatDigraph), fmt.Sprintf("Format of the graph printed with --print-graph, one of %v.", api.GraphFormats))
+	flag.StringVar(&opt.dryRunDir, "dry-run-dir", "", "Render the objects each step would create as YAML to this directory without running the steps, then exit. No cluster is required.")
+	flag.StringVar(&opt.otlpTracesEndpoint, "otlp-traces-endpoint", "", "Send a trace of the execution to this OTLP/HTTP collector endpoint, e.g. http://collector:4318/v1/traces.")
+	flag.StringVar(&opt.traceFile, "trace-file", "", "Append a trace of the execution, encoded as OTLP JSON, to this file.")
 
 	// add to the graph of things we run or create
 	flag.Var(&opt.templatePaths, "template", "A set of paths to optional templates to add as stages to this job. Each template is expected to contain at least one restart=Never pod. Parameters are filled from environment or from the automatic parameters generated by the operator.")
@@ -502,6 +547,7 @@ func bindOptions(flag *flag.FlagSet) *options {
 	flag.StringVar(&opt.baseNamespace, "base-namespace", "stable", "Namespace to read builds from, defaults to stable.")
 	flag.DurationVar(&opt.idleCleanupDuration, "delete-when-idle", opt.idleCleanupDuration, "If no pod is running for longer than this interval, delete the namespace. Set to zero to retain the contents. Requires the namespace TTL controller to be deployed.")
 	flag.DurationVar(&opt.cleanupDuration, "delete-after", opt.cleanupDuration, "If namespace exists for longer than this interval, delete the namespace. Set to zero to retain the contents. Requires the namespace TTL controller to be deployed.")
+	flag.StringVar(&opt.resultCacheNamespace, "result-cache-namespace", "", "Record passing results of tests which opt into result caching in this namespace and reuse them instead of running tests with identical inputs. If unset, results are not reused.")
 
 	// actions to add to the graph
 	flag.BoolVar(&opt.promote, "promote", false, "When all other targets complete, publish the set of images built by this job into the release configuration.")
@@ -513,6 +559,8 @@ func bindOptions(flag *flag.FlagSet) *options {
 	// experimental flags
 	flag.StringVar(&opt.gitRef, "git-ref", "", "Populate the job spec from this local Git reference. If JOB_SPEC is set, the refs field will be overwritten.")
 	flag.BoolVar(&opt.givePrAuthorAccessToNamespace, "give-pr-author-access-to-namespace", true, "Give view access to the temporarily created namespace to the PR author.")
+	flag.DurationVar(&opt.debugHold, "debug-hold", 0, "When a step of a multi-stage test fails, replace its pod by a paused copy with the same environment and mounts for this long, or until the copy is deleted, before the test continues. Instructions to connect are written to the log and the artifacts. Disabled if unset.")
+	flag.BoolVar(&opt.parallelStepGroups, "parallel-step-groups", false, "Execute the steps of `parallel` blocks of multi-stage tests concurrently. This requires a version of the entrypoint-wrapper image which supports --merge-shared-dir to be published first: older versions fail on the unknown flag. When disabled, the steps are executed sequentially.")
 	flag.StringVar(&opt.impersonateUser, "as", "", "Username to impersonate")
 	flag.BoolVar(&opt.restrictNetworkAccess, "restrict-network-access", false, "Restrict network access to 10.0.0.0/8 (RedHat intranet).")
 	flag.BoolVar(&opt.enableSecretsStoreCSIDriver, "enable-secrets-store-csi-driver", false, "Use Secrets Store CSI driver for accessing multi-stage credentials.")
@@ -533,6 +581,8 @@ func bindOptions(flag *flag.FlagSet) *options {
 
 	flag.StringVar(&opt.pullSecretPath, "image-import-pull-secret", "", "A set of dockercfg credentials used to import images for the tag_specification.")
 	flag.StringVar(&opt.pushSecretPath, "image-mirror-push-secret", "", "A set of dockercfg credentials used to mirror images for the promotion.")
+	flag.StringVar(&opt.signingKeyPath, "promotion-signing-key", "", fmt.Sprintf("A directory holding a cosign private key in %s and optionally its password in %s. When set, promoted images are signed and their provenance is attested.", api.PromotionSigningKeyKey, api.PromotionSigningKeyPasswordKey))
+	flag.BoolVar(&opt.signingWithLocalKey, "promotion-signing-local-key", false, "Sign promoted images and attest their provenance with a key pair generated for the job. Only useful to test the signing.")
 	flag.StringVar(&opt.uploadSecretPath, "gcs-upload-secret", "", "GCS credentials used to upload logs and artifacts.")
 
 	flag.StringVar(&opt.hiveKubeconfigPath, "hive-kubeconfig", "", "Path to the kubeconfig file to use for requests to Hive.")
@@ -544,6 +594,9 @@ func bindOptions(flag *flag.FlagSet) *options {
 
 	flag.StringVar(&opt.manifestToolDockerCfg, "manifest-tool-dockercfg", "/secrets/manifest-tool/.dockerconfigjson", "The dockercfg file path to be used to push the manifest listed image after build. This is being used by the manifest-tool binary.")
 	flag.StringVar(&opt.localRegistryDNS, "local-registry-dns", "image-registry.openshift-image-registry.svc:5000", "Defines the target image registry.")
+	flag.StringVar(&opt.buildBackend, "build-backend", "", fmt.Sprintf("The default system which builds the images of the configuration on this build cluster, one of %q or %q. Configurations may select a different one with build_backend. OpenShift Builds are used if unset.", api.BuildBackendOpenShift, api.BuildBackendBuildah))
+	flag.StringVar(&opt.buildahImage, "buildah-image", steps.DefaultBuildahImage, "The image which runs the builds of the buildah build backend.")
+	flag.StringVar(&opt.cosignImage, "cosign-image", steps.DefaultCosignImage, "The image which runs cosign to attach SBOMs to images and to sign promoted images. The image needs no shell.")
 
 	opt.resultsOptions.Bind(flag)
 	return opt
@@ -583,10 +636,43 @@ func (o *options) Complete() error {
 	if o.unresolvedConfigPath != "" && o.configSpecPath != "" {
 		return errors.New("cannot set --config and --unresolved-config at the same time")
 	}
+	if !slices.Contains(api.GraphFormats, api.GraphFormat(o.printGraphFormat)) {
+		return fmt.Errorf("invalid --print-graph-format %q, must be one of %v", o.printGraphFormat, api.GraphFormats)
+	}
+	if o.printGraphFormat != string(api.GraphFormatDigraph) && !o.printGraph {
+		return errors.New("--print-graph-format can only be used with --print-graph")
+	}
+	if o.printGraph && o.dryRunDir != "" {
+		return errors.New("cannot set --print-graph and --dry-run-dir at the same time")
+	}
+	if (o.otlpTracesEndpoint != "" || o.traceFile != "") && o.dryRunDir != "" {
+		return errors.New("--otlp-traces-endpoint and --trace-file cannot be used with --dry-run-dir, which does not execute any step")
+	}
 	if o.unresolvedConfigPath != "" && o.resolverAddress == "" {
 		return errors.New("cannot request resolved config with --unresolved-config unless providing --resolver-address")
 	}
 
+	if o.buildBackend != "" {
+		if err := api.BuildBackend(o.buildBackend).Validate(); err != nil {
+			return fmt.Errorf("invalid --build-backend: %w", err)
+		}
+	}
+
+	if o.debugHold < 0 {
+		return fmt.Errorf("--debug-hold must not be negative, got %s", o.debugHold)
+	}
+	if o.leasePriority != "" {
+		if _, err := lease.ParsePriority(o.leasePriority); err != nil {
+			return fmt.Errorf("invalid --lease-priority: %w", err)
+		}
+		if !o.leasePrioritiesSupported() {
+			return errors.New("--lease-priority requires a lease server which queues requests by priority, set --lease-server-config or --lease-server-priorities")
+		}
+	}
+	if o.debugHold > 0 && !o.givePrAuthorAccessToNamespace {
+		logrus.Warn("--debug-hold is set but the authors of the pull request are not given access to the namespace.")
+	}
+
 	if o.enableSecretsStoreCSIDriver && o.gsmConfigPath == "" {
 		return fmt.Errorf("--gsm-config is required when --enable-secrets-store-csi-driver is enabled")
 	}
@@ -723,21 +809,23 @@ func (o *options) Complete() error {
 		o.templates = append(o.templates, template)
 	}
 
-	clusterConfig, err := util.LoadClusterConfig()
-	if err != nil {
-		return fmt.Errorf("failed to load cluster config: %w", err)
-	}
+	if o.dryRunDir == "" {
+		clusterConfig, err := util.LoadClusterConfig()
+		if err != nil {
+			return fmt.Errorf("failed to load cluster config: %w", err)
+		}
 
-	if len(o.impersonateUser) > 0 {
-		clusterConfig.Impersonate = rest.ImpersonationConfig{UserName: o.impersonateUser}
-	}
+		if len(o.impersonateUser) > 0 {
+			clusterConfig.Impersonate = rest.ImpersonationConfig{UserName: o.impersonateUser}
+		}
 
-	if o.verbose {
-		clusterConfig.ContentType = "application/json"
-		clusterConfig.AcceptContentTypes = "application/json"
-	}
+		if o.verbose {
+			clusterConfig.ContentType = "application/json"
+			clusterConfig.AcceptContentTypes = "application/json"
+		}
 
-	o.clusterConfig = clusterConfig
+		o.clusterConfig = clusterConfig
+	}
 
 	if o.pullSecretPath != "" {
 		if o.pullSecret, err = getDockerConfigSecret(api.RegistryPullCredentialsSecret, o.pullSecretPath); err != nil {
@@ -750,6 +838,15 @@ func (o *options) Complete() error {
 		}
 	}
 
+	if o.signingKeyPath != "" && o.signingWithLocalKey {
+		return errors.New("--promotion-signing-key and --promotion-signing-local-key are mutually exclusive")
+	}
+	if o.signingKeyPath != "" {
+		if o.signingKeySecret, err = getSigningKeySecret(o.signingKeyPath); err != nil {
+			return fmt.Errorf("could not get signing key from path %s: %w", o.signingKeyPath, err)
+		}
+	}
+
 	if o.uploadSecretPath != "" {
 		gcsSecretName := resolveGCSCredentialsSecret(o.jobSpec)
 		if o.uploadSecret, err = getSecret(gcsSecretName, o.uploadSecretPath); err != nil {
@@ -786,7 +883,7 @@ func (o *options) Complete() error {
 }
 
 func (o *options) isLeaseClientAvailable() bool {
-	return o.leaseServer != "" && o.leaseServerCredentialsFile != ""
+	return o.leaseServerConfig != "" || (o.leaseServer != "" && o.leaseServerCredentialsFile != "")
 }
 
 func (o *options) ToGraphConfig() *defaults.Config {
@@ -806,15 +903,22 @@ func (o *options) ToGraphConfig() *defaults.Config {
 		CloneAuthConfig:        o.cloneAuthConfig,
 		PullSecret:             o.pullSecret,
 		PushSecret:             o.pushSecret,
+		PromotionSigning:       o.promotionSigning(),
 		Censor:                 o.censor,
 		HiveKubeconfig:         o.hiveKubeconfig,
 		NodeName:               o.nodeName,
 		TargetAdditionalSuffix: o.targetAdditionalSuffix,
 		ManifestToolDockerCfg:  o.manifestToolDockerCfg,
 		LocalRegistryDNS:       o.localRegistryDNS,
+		BuildBackend:           api.BuildBackend(o.buildBackend),
+		BuildahImage:           o.buildahImage,
+		CosignImage:            o.cosignImage,
 		MetricsAgent:           o.metricsAgent,
 		SkippedImages:          o.skippedImages,
 		ClusterProfileGetter:   o.resolverClient.ClusterProfile,
+		ResultCacheNamespace:   o.resultCacheNamespace,
+		DebugHold:              o.debugHold,
+		ParallelStepGroups:     o.parallelStepGroups,
 	}
 }
 
@@ -872,20 +976,29 @@ func overrideMultiStageParams(o *options) error {
 
 	// for any multi-stage tests, go ahead and inject the passed-in parameters. Note that parameters explicitly passed
 	// in to ci-operator will take precedence.
+	var errs []error
 	for _, test := range o.configSpec.Tests {
 		if test.MultiStageTestConfigurationLiteral != nil {
 			if test.MultiStageTestConfigurationLiteral.Environment == nil {
 				test.MultiStageTestConfigurationLiteral.Environment = make(api.TestEnvironment)
 			}
 
+			var steps []api.LiteralTestStep
+			for _, phase := range [][]api.LiteralTestStep{test.MultiStageTestConfigurationLiteral.Pre, test.MultiStageTestConfigurationLiteral.Test, test.MultiStageTestConfigurationLiteral.Post} {
+				steps = append(steps, phase...)
+			}
 			for paramName, paramVal := range multiStageParams {
 				valueWithoutQuotes := strings.Trim(paramVal, `"'`)
+				if err := api.ValidateParameterValue(steps, paramName, valueWithoutQuotes); err != nil {
+					errs = append(errs, fmt.Errorf("invalid --multi-stage-param %s for test %s: %w", paramName, test.As, err))
+					continue
+				}
 				test.MultiStageTestConfigurationLiteral.Environment[paramName] = valueWithoutQuotes
 			}
 		}
 	}
 
-	return nil
+	return utilerrors.NewAggregate(errs)
 }
 
 // applyEnvOverrides processes environment variables with override prefixes and applies them to the test configurations.
@@ -1031,6 +1144,10 @@ func (o *options) Run() (errs []error) {
 		cancel()
 	}
 
+	if o.dryRunDir != "" {
+		return o.dryRun(ctx)
+	}
+
 	httpSrvMux := http.NewServeMux()
 	httpSrv = &http.Server{
 		Handler:     httpSrvMux,
@@ -1043,6 +1160,13 @@ func (o *options) Run() (errs []error) {
 		return
 	}
 
+	if o.leaseServerConfig != "" {
+		if err := o.startLocalLeaseServer(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("run local lease server: %w", err))
+			return
+		}
+	}
+
 	var leaseClient *lease.Client
 	if o.isLeaseClientAvailable() {
 		leaseClient = &o.leaseClient
@@ -1136,7 +1260,7 @@ func (o *options) Run() (errs []error) {
 	}
 	logrus.Infof("Running %s", strings.Join(nodeNames(stepList), ", "))
 	if o.printGraph {
-		if err := printDigraph(os.Stdout, stepList); err != nil {
+		if err := api.PrintGraph(os.Stdout, stepList, api.GraphFormat(o.printGraphFormat)); err != nil {
 			errs = append(errs, fmt.Errorf("could not print graph: %w", err))
 			return
 		}
@@ -1198,10 +1322,15 @@ func (o *options) Run() (errs []error) {
 		o.metricsAgent.Record(metrics.NewInsightsEvent(metrics.InsightExecutionStarted, metrics.Context{"started_after": time.Since(start).Seconds()}))
 		// execute the graph
 		suites, graphDetails, errs := steps.Run(ctx, nodes, o.metricsAgent)
+		graph.MergeFrom(graphDetails...)
+		analysis := criticalpath.Analyze(*graph)
+		if suites != nil && len(suites.Suites) != 0 {
+			suites.Suites[0].Properties = append(suites.Suites[0].Properties, analysis.Properties()...)
+		}
 		if err := o.writeJUnit(suites, "operator"); err != nil {
 			logrus.WithError(err).Warn("Unable to write JUnit result.")
 		}
-		graph.MergeFrom(graphDetails...)
+		o.writeCriticalPath(analysis)
 		// Rewrite the Metadata JSON to catch custom metadata if it has been generated by the job
 		if err := o.writeMetadataJSON(); err != nil {
 			logrus.WithError(err).Warn("Unable to update metadata.json for build")
@@ -1642,7 +1771,7 @@ func (o *options) initializeNamespace() error {
 
 	}
 
-	for _, secret := range []*coreapi.Secret{o.pullSecret, o.pushSecret, o.uploadSecret} {
+	for _, secret := range []*coreapi.Secret{o.pullSecret, o.pushSecret, o.uploadSecret, o.signingKeySecret} {
 		if secret != nil {
 			secret.Immutable = utilpointer.Bool(true)
 			if err := client.Create(ctx, secret); err != nil && !kerrors.IsAlreadyExists(err) {
@@ -2029,6 +2158,7 @@ func (o *options) writeFailingJUnit(errs []error) {
 		testCases = append(testCases, &junit.TestCase{
 			Name: "initialize",
 			FailureOutput: &junit.FailureOutput{
+				Type:   string(results.ClassificationFor(err)),
 				Output: err.Error(),
 			},
 		})
@@ -2051,6 +2181,51 @@ func (o *options) writeFailingJUnit(errs []error) {
 	}
 }
 
+// configureTracing sets up the export of a trace of the execution, tagged with
+// the job and the tested repository, if requested.
+func (o *options) configureTracing() {
+	var exporters []otlp.Exporter
+	if o.otlpTracesEndpoint != "" {
+		exporters = append(exporters, otlp.NewHTTPExporter(o.otlpTracesEndpoint, &http.Client{Timeout: 30 * time.Second}))
+	}
+	if o.traceFile != "" {
+		exporters = append(exporters, otlp.NewFileExporter(o.traceFile))
+	}
+	if len(exporters) == 0 {
+		return
+	}
+	info := o.getResolverInfo(o.jobSpec)
+	attributes := map[string]string{
+		"service.name": "ci-operator",
+		"org":          info.Org,
+		"repo":         info.Repo,
+		"branch":       info.Branch,
+		"test":         strings.Join(o.targets.values, ","),
+		"job":          o.jobSpec.Job,
+		"build_id":     o.jobSpec.BuildID,
+		"prow_job_id":  o.jobSpec.ProwJobID,
+	}
+	if info.Variant != "" {
+		attributes["variant"] = info.Variant
+	}
+	traceID := o.jobSpec.ProwJobID
+	if traceID == "" {
+		traceID = o.jobSpec.Job + "/" + o.jobSpec.BuildID
+	}
+	o.metricsAgent.ExportTraces(traceID, attributes, exporters...)
+}
+
+// writeCriticalPath saves the analysis of the executed step graph as artifacts.
+func (o *options) writeCriticalPath(analysis *criticalpath.Analysis) {
+	serialized, err := json.Marshal(analysis)
+	if err != nil {
+		logrus.WithError(err).Warn("Failed to marshal the critical path analysis.")
+		return
+	}
+	_ = api.SaveArtifact(o.censor, criticalpath.JSONFilename, serialized)
+	_ = api.SaveArtifact(o.censor, criticalpath.SummaryFilename, []byte(analysis.Summary()))
+}
+
 func (o *options) writeJUnit(suites *junit.TestSuites, name string) error {
 	if suites == nil {
 		return nil
@@ -2146,22 +2321,97 @@ func loadLeaseCredentials(leaseServerCredentialsFile string) (string, func() []b
 	return username, passwordGetter, nil
 }
 
+// startLocalLeaseServer serves the leases for the resources in the configured
+// file from within ci-operator and points the lease client to it.
+func (o *options) startLocalLeaseServer(ctx context.Context) error {
+	config, err := common.ParseConfig(o.leaseServerConfig)
+	if err != nil {
+		return fmt.Errorf("failed to load lease server configuration: %w", err)
+	}
+	server, err := leaseserver.New(logrus.WithField("component", "lease-server"), config)
+	if err != nil {
+		return fmt.Errorf("failed to create lease server: %w", err)
+	}
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return fmt.Errorf("listen tcp on 127.0.0.1: %w", err)
+	}
+	srv := &http.Server{
+		Handler:           server.Handler(),
+		BaseContext:       func(net.Listener) context.Context { return ctx },
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	go server.Run(ctx)
+	go func() {
+		if err := srv.Serve(ln); !errors.Is(err, http.ErrServerClosed) {
+			logrus.WithError(err).Error("Lease server has been aborted unexpectedly.")
+		}
+	}()
+	o.leaseServer = "http://" + ln.Addr().String()
+	logrus.Infof("Serving leases for the resources in %s at %s", o.leaseServerConfig, o.leaseServer)
+	return nil
+}
+
 func (o *options) initializeLeaseClient() error {
 	var err error
 	owner := o.namespace + "-" + o.jobSpec.UniqueHash()
-	username, passwordGetter, err := loadLeaseCredentials(o.leaseServerCredentialsFile)
-	if err != nil {
-		return fmt.Errorf("failed to load lease credentials: %w", err)
+	var username string
+	var passwordGetter func() []byte
+	if o.leaseServerCredentialsFile != "" {
+		if username, passwordGetter, err = loadLeaseCredentials(o.leaseServerCredentialsFile); err != nil {
+			return fmt.Errorf("failed to load lease credentials: %w", err)
+		}
 	}
 
 	o.metricsAgent.Record(metrics.NewInsightsEvent(metrics.InsightLeaseCredentials, metrics.Context{"lease_server": o.leaseServer, "username": username}))
 
-	if o.leaseClient, err = lease.NewClient(owner, o.leaseServer, username, passwordGetter, 60, o.leaseAcquireTimeout); err != nil {
+	var opts []lease.ClientOptions
+	if o.leasePrioritiesSupported() {
+		priority, org := o.leasePriorityAndOrg()
+		logrus.Debugf("Requesting leases with %s priority for organization %q.", priority, org)
+		opts = append(opts, lease.WithPriority(priority, org))
+	}
+	if o.leaseClient, err = lease.NewClient(owner, o.leaseServer, username, passwordGetter, 60, o.leaseAcquireTimeout, opts...); err != nil {
 		return fmt.Errorf("failed to create the lease client: %w", err)
 	}
 	return nil
 }
 
+// leasePrioritiesSupported determines whether the lease server queues requests
+// by priority, which is only the case of the lease-server command.  Boskos
+// ignores priorities, so they are not sent to it.
+func (o *options) leasePrioritiesSupported() bool {
+	return o.leaseServerConfig != "" || o.leaseServerPriorities
+}
+
+// leasePriorityAndOrg determines the priority of the requests for leases from
+// the labels of the job unless it is set explicitly, and the organization the
+// requests are made for.  The labels are not available to ci-operator, so the
+// ones the priority depends on are derived the way they are generated.
+func (o *options) leasePriorityAndOrg() (lease.Priority, string) {
+	labels := map[string]string{kube.ProwJobTypeLabel: string(o.jobSpec.Type)}
+	if strings.HasPrefix(o.jobSpec.Job, "rehearse-") {
+		labels[lease.RehearsalLabel] = "true"
+	}
+	if o.configSpec != nil {
+		// the label is set on jobs which test the candidate payloads from the
+		// release controller, see prowgen
+		if version := ocplifecycle.ProvidesSignalForVersion(o.configSpec); version != "" {
+			labels[lease.JobReleaseLabel] = version
+		}
+	}
+	priority := lease.PriorityForLabels(labels)
+	if o.leasePriority != "" {
+		// validated in Complete
+		priority, _ = lease.ParsePriority(o.leasePriority)
+	}
+	org := o.jobSpec.Metadata.Org
+	if org == "" && o.jobSpec.Refs != nil {
+		org = o.jobSpec.Refs.Org
+	}
+	return priority, org
+}
+
 func (o *options) startLeaseHearthbeating() chan struct{} {
 	stopChan := make(chan struct{})
 	t := time.NewTicker(30 * time.Second)
@@ -2272,18 +2522,34 @@ func nodeNames(nodes []*api.StepNode) []string {
 	return names
 }
 
-func printDigraph(w io.Writer, steps api.OrderedStepList) error {
-	for i, step := range steps {
-		req := step.Step.Requires()
-		// Only the first `i` elements can fulfill the requirements since
-		// `OrderedStepList` is a topological order.
-		for _, other := range steps[:i] {
-			if api.HasAnyLinks(req, other.Step.Creates()) {
-				if _, err := fmt.Fprintf(w, "%s %s\n", step.Step.Name(), other.Step.Name()); err != nil {
-					return err
-				}
-			}
-		}
+// dryRun builds the execution graph with offline clients and writes the
+// objects each step would create to the dry-run directory.
+func (o *options) dryRun(ctx context.Context) []error {
+	cfg := o.ToGraphConfig()
+	cfg.InjectedTest = o.injectTest != ""
+	buildSteps, _, err := defaults.FromConfigDryRun(ctx, cfg)
+	if err != nil {
+		return []error{results.ForReason("defaulting_config").WithError(err).Errorf("failed to generate steps from config: %v", err)}
+	}
+	// the input hash cannot be resolved without a cluster, use a stable
+	// namespace so the output can be compared between runs
+	if len(o.namespace) == 0 {
+		o.namespace = "ci-op-{id}"
+	}
+	o.namespace = strings.Replace(o.namespace, "{id}", "dry-run", -1)
+	o.jobSpec.SetNamespace(o.namespace)
+	nodes, err := api.BuildPartialGraph(buildSteps, o.targets.values)
+	if err != nil {
+		return []error{results.ForReason("building_graph").WithError(err).Errorf("could not build execution graph: %v", err)}
+	}
+	api.ResolveMultiArch(nodes)
+	stepList, sortErrs := nodes.TopologicalSort()
+	if len(sortErrs) > 0 {
+		return append([]error{results.ForReason("building_graph").ForError(errors.New("could not sort nodes"))}, sortErrs...)
+	}
+	logrus.Infof("Rendering %s to %s", strings.Join(nodeNames(stepList), ", "), o.dryRunDir)
+	if err := dryrun.Run(stepList, o.dryRunDir); err != nil {
+		return []error{fmt.Errorf("could not render objects: %w", err)}
 	}
 	return nil
 }
@@ -2441,6 +2707,41 @@ func getSecret(name, filename string) (*coreapi.Secret, error) {
 	}, nil
 }
 
+// getSigningKeySecret reads the cosign key which signs promoted images and
+// its optional password from a directory.
+func getSigningKeySecret(dir string) (*coreapi.Secret, error) {
+	secret := &coreapi.Secret{
+		Data:       map[string][]byte{},
+		ObjectMeta: metav1.ObjectMeta{Name: api.PromotionSigningKeySecret},
+		Type:       coreapi.SecretTypeOpaque,
+	}
+	key, err := os.ReadFile(filepath.Join(dir, api.PromotionSigningKeyKey))
+	if err != nil {
+		return nil, fmt.Errorf("could not read signing key: %w", err)
+	}
+	secret.Data[api.PromotionSigningKeyKey] = key
+	password, err := os.ReadFile(filepath.Join(dir, api.PromotionSigningKeyPasswordKey))
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("could not read signing key password: %w", err)
+	}
+	if err == nil {
+		secret.Data[api.PromotionSigningKeyPasswordKey] = bytes.TrimSpace(password)
+	}
+	return secret, nil
+}
+
+// promotionSigning configures the signing of promoted images, which is
+// disabled unless a key is given.
+func (o *options) promotionSigning() *releasesteps.Signing {
+	switch {
+	case o.signingWithLocalKey:
+		return &releasesteps.Signing{LocalKey: true, Image: o.cosignImage}
+	case o.signingKeySecret != nil:
+		return &releasesteps.Signing{Image: o.cosignImage}
+	}
+	return nil
+}
+
 func resolveGCSCredentialsSecret(jobSpec *api.JobSpec) string {
 	if jobSpec.DecorationConfig != nil && jobSpec.DecorationConfig.GCSCredentialsSecret != nil {
 		return *jobSpec.DecorationConfig.GCSCredentialsSecret
@@ -2590,11 +2891,17 @@ func (o *options) loadConfig(info *api.Metadata, gcsReader gcsFileReader) (*api.
 		return nil, fmt.Errorf("invalid configuration: %w\nvalue:\n%s", err, raw)
 	}
 	if o.registryPath != "" {
-		refs, chains, workflows, _, _, _, observers, err := load.Registry(o.registryPath, load.RegistryFlag(0))
+		contents, err := load.LoadRegistry(o.registryPath, load.RegistryFlag(0))
 		if err != nil {
 			return nil, fmt.Errorf("failed to load registry: %w", err)
 		}
-		configSpec, err = registry.ResolveConfig(registry.NewResolver(refs, chains, workflows, observers), configSpec)
+		resolver := registry.NewResolver(contents.References, contents.Chains, contents.Workflows, contents.Observers)
+		checker := registry.NewDeprecationChecker(contents.Chains, contents.Workflows, contents.Deprecations)
+		var warnings []string
+		configSpec, warnings, err = checker.ResolveConfig(resolver, configSpec, time.Now())
+		for _, warning := range warnings {
+			logrus.Warn(warning)
+		}
 		if err != nil {
 			return nil, fmt.Errorf("failed to resolve configuration: %w", err)
 		}
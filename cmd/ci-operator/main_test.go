@@ -27,6 +27,7 @@ import (
 	imagev1 "github.com/openshift/api/image/v1"
 
 	"github.com/openshift/ci-tools/pkg/api"
+	"github.com/openshift/ci-tools/pkg/lease"
 	"github.com/openshift/ci-tools/pkg/results"
 	"github.com/openshift/ci-tools/pkg/secrets"
 	"github.com/openshift/ci-tools/pkg/steps"
@@ -1102,7 +1103,7 @@ func TestBuildPartialGraph(t *testing.T) {
 						},
 						To: api.PipelineImageStreamTagReference("oc-bin-image"),
 					},
-					&api.ReleaseBuildConfiguration{}, api.ResourceConfiguration{}, nil, nil, nil, nil, nil,
+					&api.ReleaseBuildConfiguration{}, api.ResourceConfiguration{}, nil, nil, nil, nil, nil, "", "", "",
 				),
 				steps.OutputImageTagStep(api.OutputImageTagStepConfiguration{From: api.PipelineImageStreamTagReference("oc-bin-image")}, nil, nil),
 				steps.ImagesReadyStep(steps.OutputImageTagStep(api.OutputImageTagStepConfiguration{From: api.PipelineImageStreamTagReference("oc-bin-image")}, nil, nil).Creates()),
@@ -1369,6 +1370,36 @@ func TestMultiStageParams(t *testing.T) {
 				"OTHERPARAM": "OTHERVAL",
 			},
 		},
+		{
+			id:          "typed param",
+			inputParams: stringSlice{[]string{"FIPS_ENABLED=true"}},
+			testConfig: []api.TestStepConfiguration{
+				{
+					As: "e2e",
+					MultiStageTestConfigurationLiteral: &api.MultiStageTestConfigurationLiteral{
+						Pre: []api.LiteralTestStep{{As: "install", Environment: []api.StepParameter{{Name: "FIPS_ENABLED", Type: api.StepParameterTypeBool}}}},
+					},
+				},
+			},
+			expectedParams: map[string]string{
+				"FIPS_ENABLED": "true",
+			},
+		},
+		{
+			id:          "typed param with invalid value",
+			inputParams: stringSlice{[]string{"FIPS_ENABLED=ture"}},
+			testConfig: []api.TestStepConfiguration{
+				{
+					As: "e2e",
+					MultiStageTestConfigurationLiteral: &api.MultiStageTestConfigurationLiteral{
+						Pre: []api.LiteralTestStep{{As: "install", Environment: []api.StepParameter{{Name: "FIPS_ENABLED", Type: api.StepParameterTypeBool}}}},
+					},
+				},
+			},
+			expectedErrs: []string{
+				`invalid --multi-stage-param FIPS_ENABLED for test e2e: invalid value for parameter FIPS_ENABLED of step install: "ture" is not a bool, expected true or false`,
+			},
+		},
 	}
 
 	t.Parallel()
@@ -1909,3 +1940,70 @@ func TestGetClusterProfileNamesFromTargets(t *testing.T) {
 		})
 	}
 }
+
+func TestLeasePriorityAndOrg(t *testing.T) {
+	candidate := &api.ReleaseBuildConfiguration{
+		InputConfiguration: api.InputConfiguration{
+			Releases: map[string]api.UnresolvedRelease{
+				api.LatestReleaseName: {Candidate: &api.Candidate{Version: "4.18"}},
+			},
+		},
+	}
+	for _, tc := range []struct {
+		name             string
+		o                options
+		expectedPriority lease.Priority
+		expectedOrg      string
+	}{
+		{
+			name: "presubmit",
+			o: options{jobSpec: &api.JobSpec{JobSpec: downwardapi.JobSpec{
+				Type: prowapi.PresubmitJob,
+				Job:  "pull-ci-org-repo-master-e2e",
+				Refs: &prowapi.Refs{Org: "org"},
+			}}},
+			expectedPriority: lease.PriorityPresubmit,
+			expectedOrg:      "org",
+		},
+		{
+			name: "rehearsal",
+			o: options{jobSpec: &api.JobSpec{JobSpec: downwardapi.JobSpec{
+				Type: prowapi.PresubmitJob,
+				Job:  "rehearse-1234-pull-ci-org-repo-master-e2e",
+				Refs: &prowapi.Refs{Org: "openshift"},
+			}, Metadata: api.Metadata{Org: "org"}}},
+			expectedPriority: lease.PriorityRehearsal,
+			expectedOrg:      "org",
+		},
+		{
+			name: "periodic",
+			o: options{jobSpec: &api.JobSpec{JobSpec: downwardapi.JobSpec{
+				Type: prowapi.PeriodicJob,
+			}, Metadata: api.Metadata{Org: "org"}}, configSpec: &api.ReleaseBuildConfiguration{}},
+			expectedPriority: lease.PriorityPeriodic,
+			expectedOrg:      "org",
+		},
+		{
+			name: "periodic testing candidate payloads is release-blocking",
+			o: options{jobSpec: &api.JobSpec{JobSpec: downwardapi.JobSpec{
+				Type: prowapi.PeriodicJob,
+			}, Metadata: api.Metadata{Org: "openshift"}}, configSpec: candidate},
+			expectedPriority: lease.PriorityReleaseBlocking,
+			expectedOrg:      "openshift",
+		},
+		{
+			name: "explicit priority",
+			o: options{jobSpec: &api.JobSpec{JobSpec: downwardapi.JobSpec{
+				Type: prowapi.PeriodicJob,
+			}, Metadata: api.Metadata{Org: "openshift"}}, configSpec: candidate, leasePriority: "presubmit"},
+			expectedPriority: lease.PriorityPresubmit,
+			expectedOrg:      "openshift",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			priority, org := tc.o.leasePriorityAndOrg()
+			testhelper.Diff(t, "priority", priority, tc.expectedPriority)
+			testhelper.Diff(t, "org", org, tc.expectedOrg)
+		})
+	}
+}
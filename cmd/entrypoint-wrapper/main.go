@@ -1,6 +1,7 @@
 package main
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"flag"
@@ -13,6 +14,7 @@ import (
 	"os/signal"
 	"path"
 	"path/filepath"
+	"sort"
 	"strings"
 	"syscall"
 	"time"
@@ -29,6 +31,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/wait"
 	coreclientset "k8s.io/client-go/kubernetes/typed/core/v1"
 	"k8s.io/client-go/rest"
+	"k8s.io/client-go/util/retry"
 
 	"github.com/openshift/ci-tools/pkg/api"
 	"github.com/openshift/ci-tools/pkg/util"
@@ -82,6 +85,8 @@ type options struct {
 	rwKubeconfig     bool
 	uploadKubeconfig bool
 	updateSharedDir  bool
+	mergeSharedDir   bool
+	sharedDirBase    map[string][]byte
 	cmd              []string
 	client           coreclientset.SecretInterface
 }
@@ -92,6 +97,7 @@ func bindOptions(flag *flag.FlagSet) *options {
 	flag.StringVar(&opt.waitPath, "wait-for-file", "", "Wait for a file to appear at this path before starting the program")
 	flag.StringVar(&opt.waitTimeoutStr, "wait-timeout", "", "Used with --wait-for-file, maximum wait time before starting the program")
 	flag.StringVar(&opt.mode, "mode", manageKubeconfigMode, fmt.Sprintf("Set how kubeconfig should be managed. Allowed values are: %s, %s or %s", manageKubeconfigMode, skipKubeconfigMode, observerMode))
+	flag.BoolVar(&opt.mergeSharedDir, "merge-shared-dir", false, "Only apply the changes made to the shared directory by this step to the secret, instead of replacing its content")
 	return opt
 }
 
@@ -159,6 +165,15 @@ func (o *options) run() (exitCode int, err error) {
 	if err := copyDir(o.dstPath, o.srcPath); err != nil {
 		return errorCode, fmt.Errorf("failed to copy secret mount: %w", err)
 	}
+	if o.mergeSharedDir {
+		// the mounted secret is updated by other steps running at the same
+		// time, so the initial content has to be recorded before it changes
+		base, err := util.SecretFromDir(o.dstPath)
+		if err != nil {
+			return errorCode, fmt.Errorf("failed to read initial shared directory content: %w", err)
+		}
+		o.sharedDirBase = base.Data
+	}
 	if o.waitPath != "" {
 		if err := waitForFile(o.waitPath, o.waitTimeout); err != nil {
 			return errorCode, fmt.Errorf("failed to wait for file: %w", err)
@@ -167,7 +182,7 @@ func (o *options) run() (exitCode int, err error) {
 	var errs []error
 	ctx, cancel := context.WithCancel(context.Background())
 	if o.uploadKubeconfig {
-		go uploadKubeconfig(ctx, o.client, o.name, o.dstPath, o.dry)
+		go uploadKubeconfig(ctx, o.updateSecret, o.dstPath)
 	}
 	if exitCode, err = o.execCmd(); err != nil {
 		errs = append(errs, fmt.Errorf("failed to execute wrapped command: %w", err))
@@ -177,7 +192,7 @@ func (o *options) run() (exitCode int, err error) {
 	// not to race with the post-execution one
 	cancel()
 	if o.updateSharedDir {
-		if err := createSecret(o.client, o.name, o.dstPath, o.dry); err != nil {
+		if err := o.updateSecret(); err != nil {
 			errs = append(errs, fmt.Errorf("failed to create/update secret: %w", err))
 			return errorCode, utilerrors.NewAggregate(errs)
 		}
@@ -416,6 +431,15 @@ func manageKubeconfig(proc *exec.Cmd) error {
 	return nil
 }
 
+// updateSecret propagates the content of the shared directory to the secret,
+// either replacing its content or merging only the changes made by this step.
+func (o *options) updateSecret() error {
+	if o.mergeSharedDir {
+		return mergeSecret(o.client, o.name, o.dstPath, o.sharedDirBase, o.dry)
+	}
+	return createSecret(o.client, o.name, o.dstPath, o.dry)
+}
+
 func createSecret(client coreclientset.SecretInterface, name, dir string, dry bool) error {
 	if _, err := os.Stat(dir); err != nil {
 		if os.IsNotExist(err) {
@@ -443,6 +467,69 @@ func createSecret(client coreclientset.SecretInterface, name, dir string, dry bo
 	return nil
 }
 
+// mergeSecret applies the files added, modified and removed in the directory
+// with respect to `base` on top of the current content of the secret.  This
+// allows steps executed concurrently to share the same secret without losing
+// each other's changes.
+func mergeSecret(client coreclientset.SecretInterface, name, dir string, base map[string][]byte, dry bool) error {
+	if _, err := os.Stat(dir); err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to stat directory %q: %w", dir, err)
+	}
+	current, err := util.SecretFromDir(dir)
+	if err != nil {
+		return fmt.Errorf("failed to generate secret: %w", err)
+	}
+	changed, removed := diffSecretData(base, current.Data)
+	if dry {
+		current.Name = name
+		current.Data = changed
+		if err := encoder.Encode(current, os.Stdout); err != nil {
+			return fmt.Errorf("failed to log secret: %w", err)
+		}
+		return nil
+	}
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		secret, err := client.Get(context.TODO(), name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		if secret.Data == nil {
+			secret.Data = map[string][]byte{}
+		}
+		for k, v := range changed {
+			secret.Data[k] = v
+		}
+		for _, k := range removed {
+			delete(secret.Data, k)
+		}
+		if _, err := client.Update(context.TODO(), secret, metav1.UpdateOptions{}); err != nil {
+			return fmt.Errorf("failed to update secret: %w", err)
+		}
+		return nil
+	})
+}
+
+// diffSecretData returns the keys added or modified in `data` with respect to
+// `base` and the keys present in `base` which were removed.
+func diffSecretData(base, data map[string][]byte) (changed map[string][]byte, removed []string) {
+	changed = map[string][]byte{}
+	for k, v := range data {
+		if old, ok := base[k]; !ok || !bytes.Equal(old, v) {
+			changed[k] = v
+		}
+	}
+	for k := range base {
+		if _, ok := data[k]; !ok {
+			removed = append(removed, k)
+		}
+	}
+	sort.Strings(removed)
+	return changed, removed
+}
+
 // uploadKubeconfig will do a best-effort attempt at uploading a kubeconfig
 // file if one does not exist at the time we start running but one does get
 // created while executing the command
@@ -451,7 +538,7 @@ func createSecret(client coreclientset.SecretInterface, name, dir string, dry bo
 // make a minimally functional kubeconfig available for tasks that need to run
 // before the final complete kubeconfig is available for general usage. An example
 // use case is for observers to start observing while install is still in progress.
-func uploadKubeconfig(ctx context.Context, client coreclientset.SecretInterface, name, dir string, dry bool) {
+func uploadKubeconfig(ctx context.Context, updateSecret func() error, dir string) {
 	if _, err := os.Stat(path.Join(dir, "kubeconfig")); err == nil {
 		// kubeconfig already exists, no need to do anything
 		return
@@ -461,7 +548,7 @@ func uploadKubeconfig(ctx context.Context, client coreclientset.SecretInterface,
 	if err := wait.PollUntil(time.Second, func() (done bool, err error) {
 		if !minimalUploaded {
 			if _, uploadErr = os.Stat(path.Join(dir, "kubeconfig-minimal")); uploadErr == nil {
-				uploadErr = createSecret(client, name, dir, dry)
+				uploadErr = updateSecret()
 				if uploadErr == nil {
 					minimalUploaded = true
 				}
@@ -472,7 +559,7 @@ func uploadKubeconfig(ctx context.Context, client coreclientset.SecretInterface,
 			return false, nil
 		}
 		// kubeconfig exists, we can upload it
-		uploadErr = createSecret(client, name, dir, dry)
+		uploadErr = updateSecret()
 		return uploadErr == nil, nil // retry errors
 	}, ctx.Done()); err != nil && !errors.Is(err, wait.ErrWaitTimeout) {
 		log.Printf("Failed to upload $KUBECONFIG: %v: %v\n", err, uploadErr)
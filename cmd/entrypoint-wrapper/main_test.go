@@ -1,13 +1,19 @@
 package main
 
 import (
+	"context"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
 
+	coreapi "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
 	"github.com/openshift/ci-tools/pkg/api"
 )
 
@@ -88,6 +94,9 @@ func TestManageHome(t *testing.T) {
 
 	for _, testCase := range testCases {
 		t.Run(testCase.testName, func(t *testing.T) {
+			if testCase.fileMode != nil && os.Geteuid() == 0 {
+				t.Skip("permissions are not enforced for root")
+			}
 			cmd := exec.Command("fake")
 			dir := t.TempDir()
 
@@ -122,3 +131,48 @@ func TestManageHome(t *testing.T) {
 		})
 	}
 }
+
+func TestMergeSecret(t *testing.T) {
+	// the secret has been updated by another step since this one started
+	secret := &coreapi.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "ns"},
+		Data: map[string][]byte{
+			"unchanged": []byte("unchanged"),
+			"modified":  []byte("old"),
+			"removed":   []byte("removed"),
+			"other":     []byte("from another step"),
+		},
+	}
+	base := map[string][]byte{
+		"unchanged": []byte("unchanged"),
+		"modified":  []byte("old"),
+		"removed":   []byte("removed"),
+	}
+	dir := t.TempDir()
+	for k, v := range map[string]string{
+		"unchanged": "unchanged",
+		"modified":  "new",
+		"added":     "added",
+	} {
+		if err := os.WriteFile(filepath.Join(dir, k), []byte(v), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	client := fake.NewSimpleClientset(secret).CoreV1().Secrets("ns")
+	if err := mergeSecret(client, "test", dir, base, false); err != nil {
+		t.Fatal(err)
+	}
+	got, err := client.Get(context.Background(), "test", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string][]byte{
+		"unchanged": []byte("unchanged"),
+		"modified":  []byte("new"),
+		"added":     []byte("added"),
+		"other":     []byte("from another step"),
+	}
+	if diff := cmp.Diff(expected, got.Data); diff != "" {
+		t.Errorf("unexpected secret data: %s", diff)
+	}
+}
@@ -0,0 +1,112 @@
+// lease-proxy-client manages the leases of a job from its step pods through
+// the lease proxy of ci-operator.
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/openshift/ci-tools/pkg/api"
+	"github.com/openshift/ci-tools/pkg/lease/proxy"
+)
+
+const usage = `Usage: lease-proxy-client <command> [flags] [names...]
+
+Commands:
+  acquire --type <type> [--count <n>] [--ttl <duration>]
+        Lease resources and print their names. Leases acquired with a TTL are
+        released when it passes unless they are extended.
+  release <name>...
+        Release leases before the job ends.
+  list
+        Print the leases held by the job.
+  status <name>
+        Print a lease held by the job, including the time it has left.
+  extend --ttl <duration> <name>
+        Keep a lease acquired with a TTL for another TTL from now.
+
+The proxy is found with $%s and requests are authenticated with
+$%s, which ci-operator gives to all steps.
+`
+
+func main() {
+	client := proxy.NewClient(os.Getenv(api.LeaseProxyServerURLEnvVarName), os.Getenv(api.LeaseProxyTokenEnvVarName))
+	if err := run(os.Args[1:], client, os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string, client *proxy.Client, out io.Writer) error {
+	if len(args) == 0 {
+		return fmt.Errorf(usage, api.LeaseProxyServerURLEnvVarName, api.LeaseProxyTokenEnvVarName)
+	}
+	command := args[0]
+	fs := flag.NewFlagSet(command, flag.ContinueOnError)
+	rtype := fs.String("type", "", "Type of the resources to lease.")
+	count := fs.Uint("count", 1, "Number of resources to lease.")
+	ttl := fs.Duration("ttl", 0, "Time after which leases are released unless they are extended.")
+	if err := fs.Parse(args[1:]); err != nil {
+		return err
+	}
+	names := fs.Args()
+
+	switch command {
+	case "acquire":
+		if *rtype == "" {
+			return errors.New("--type is required")
+		}
+		leased, err := client.Acquire(*rtype, *count, *ttl)
+		if err != nil {
+			return err
+		}
+		for _, name := range leased {
+			fmt.Fprintln(out, name)
+		}
+		return nil
+	case "release":
+		if len(names) == 0 {
+			return errors.New("at least one lease name is required")
+		}
+		return client.Release(names...)
+	case "list":
+		leases, err := client.List()
+		if err != nil {
+			return err
+		}
+		return printJSON(out, leases)
+	case "status", "extend":
+		if len(names) != 1 {
+			return fmt.Errorf("%s requires exactly one lease name", command)
+		}
+		var lease proxy.Lease
+		var err error
+		if command == "status" {
+			lease, err = client.Status(names[0])
+		} else {
+			if *ttl == 0 {
+				return errors.New("--ttl is required")
+			}
+			lease, err = client.Extend(names[0], *ttl)
+		}
+		if err != nil {
+			return err
+		}
+		return printJSON(out, lease)
+	default:
+		return fmt.Errorf("unknown command %q\n"+usage, command, api.LeaseProxyServerURLEnvVarName, api.LeaseProxyTokenEnvVarName)
+	}
+}
+
+func printJSON(out io.Writer, v interface{}) error {
+	raw, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(out, string(raw))
+	return err
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sirupsen/logrus"
+
+	"github.com/openshift/ci-tools/pkg/lease"
+	"github.com/openshift/ci-tools/pkg/lease/proxy"
+	"github.com/openshift/ci-tools/pkg/testhelper"
+)
+
+func TestRun(t *testing.T) {
+	leaseClient := lease.NewFakeClient("owner", "", 1, nil, nil, nil)
+	srvMux := &http.ServeMux{}
+	proxy.New(logrus.NewEntry(&logrus.Logger{}), func() lease.Client { return leaseClient }, proxy.WithToken("token")).RegisterHandlers(srvMux)
+	server := httptest.NewServer(srvMux)
+	defer server.Close()
+	client := proxy.NewClient(server.URL, "token")
+
+	for _, tc := range []struct {
+		name        string
+		args        []string
+		expected    string
+		expectedErr error
+	}{
+		{
+			name:     "acquire",
+			args:     []string{"acquire", "--type", "aws-1", "--count", "2"},
+			expected: "aws-1_0\naws-1_1\n",
+		},
+		{
+			name:     "release",
+			args:     []string{"release", "aws-1_0"},
+			expected: "",
+		},
+		{
+			name: "list",
+			args: []string{"list"},
+			expected: `[
+  {
+    "name": "aws-1_1",
+    "type": "aws-1",
+    "acquired": "2026-01-01T00:00:00Z"
+  }
+]
+`,
+		},
+		{
+			name:        "extend without a TTL",
+			args:        []string{"extend", "aws-1_1"},
+			expectedErr: errors.New("--ttl is required"),
+		},
+		{
+			name:        "acquire without a type",
+			args:        []string{"acquire"},
+			expectedErr: errors.New("--type is required"),
+		},
+		{
+			name:        "status of a lease of another job",
+			args:        []string{"status", "aws-1_0"},
+			expectedErr: errors.New("GET /lease/status: 404 Not Found: Lease aws-1_0 is not held by this job"),
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var out bytes.Buffer
+			err := run(tc.args, client, &out)
+			if diff := cmp.Diff(tc.expectedErr, err, testhelper.EquateErrorMessage); diff != "" {
+				t.Fatalf("unexpected error: %s", diff)
+			}
+			testhelper.Diff(t, "output", out.String(), tc.expected)
+		})
+	}
+}
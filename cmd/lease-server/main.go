@@ -0,0 +1,125 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/boskos/common"
+	prowConfig "sigs.k8s.io/prow/pkg/config"
+	prowflagutil "sigs.k8s.io/prow/pkg/flagutil"
+	"sigs.k8s.io/prow/pkg/interrupts"
+	"sigs.k8s.io/prow/pkg/logrusutil"
+	"sigs.k8s.io/prow/pkg/metrics"
+
+	leaseserver "github.com/openshift/ci-tools/pkg/lease/server"
+)
+
+type options struct {
+	configPath      string
+	stateFile       string
+	credentialsFile string
+	port            int
+	expiry          time.Duration
+	orgShare        float64
+	gracePeriod     time.Duration
+	logLevel        string
+
+	instrumentationOptions prowflagutil.InstrumentationOptions
+}
+
+func gatherOptions() options {
+	o := options{}
+	fs := flag.NewFlagSet(os.Args[0], flag.ExitOnError)
+	fs.StringVar(&o.configPath, "config", "", "Path to the Boskos configuration of the resources to lease.")
+	fs.StringVar(&o.stateFile, "state-file", "", "Path to the file the state of the resources is saved to and restored from. The state is not persisted if unset.")
+	fs.StringVar(&o.credentialsFile, "credentials-file", "", "Path to a file of the form <username>:<password> holding the credentials clients must use. Clients are not authenticated if unset.")
+	fs.IntVar(&o.port, "port", 8080, "Port to serve the Boskos API on.")
+	fs.DurationVar(&o.expiry, "expiry", leaseserver.DefaultExpiry, "Time after which leases that were not updated are returned to the pool.")
+	fs.Float64Var(&o.orgShare, "org-share", 0, "Share of the resources of a type a single organization may hold while other organizations are waiting for them, between 0 and 1. Organizations are not limited if unset.")
+	fs.DurationVar(&o.gracePeriod, "grace-period", 5*time.Second, "On shutdown, try to handle remaining requests for at most this duration.")
+	fs.StringVar(&o.logLevel, "log-level", "info", "Level at which to log output.")
+	o.instrumentationOptions.AddFlags(fs)
+	if err := fs.Parse(os.Args[1:]); err != nil {
+		logrus.WithError(err).Fatalf("cannot parse args: '%s'", os.Args[1:])
+	}
+	return o
+}
+
+func (o *options) validate() error {
+	if o.configPath == "" {
+		return errors.New("--config is required")
+	}
+	if o.expiry <= 0 {
+		return errors.New("--expiry must be positive")
+	}
+	if o.orgShare < 0 || o.orgShare > 1 {
+		return errors.New("--org-share must be between 0 and 1")
+	}
+	if _, err := logrus.ParseLevel(o.logLevel); err != nil {
+		return fmt.Errorf("invalid --log-level: %w", err)
+	}
+	return o.instrumentationOptions.Validate(false)
+}
+
+func (o *options) serverOptions() ([]leaseserver.Option, error) {
+	opts := []leaseserver.Option{leaseserver.WithExpiry(o.expiry), leaseserver.WithOrgShare(o.orgShare)}
+	if o.stateFile != "" {
+		opts = append(opts, leaseserver.WithStateFile(o.stateFile))
+	}
+	if o.credentialsFile != "" {
+		raw, err := os.ReadFile(o.credentialsFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read credentials: %w", err)
+		}
+		username, password, found := strings.Cut(strings.TrimSpace(string(raw)), ":")
+		if !found {
+			return nil, errors.New("credentials must be of the form <username>:<password>")
+		}
+		opts = append(opts, leaseserver.WithCredentials(username, password))
+	}
+	return opts, nil
+}
+
+func main() {
+	logrusutil.ComponentInit()
+	o := gatherOptions()
+	if err := o.validate(); err != nil {
+		logrus.WithError(err).Fatal("Invalid options.")
+	}
+	level, _ := logrus.ParseLevel(o.logLevel)
+	logrus.SetLevel(level)
+
+	config, err := common.ParseConfig(o.configPath)
+	if err != nil {
+		logrus.WithError(err).Fatal("Failed to load the configuration.")
+	}
+	opts, err := o.serverOptions()
+	if err != nil {
+		logrus.WithError(err).Fatal("Failed to configure the server.")
+	}
+	server, err := leaseserver.New(logrus.WithField("component", "lease-server"), config, opts...)
+	if err != nil {
+		logrus.WithError(err).Fatal("Failed to create the server.")
+	}
+
+	if err := leaseserver.RegisterMetrics(prometheus.DefaultRegisterer); err != nil {
+		logrus.WithError(err).Fatal("Failed to register metrics.")
+	}
+	metrics.ExposeMetrics("lease-server", prowConfig.PushGateway{}, o.instrumentationOptions.MetricsPort)
+	interrupts.Run(server.Run)
+	interrupts.ListenAndServe(&http.Server{
+		Addr:              ":" + strconv.Itoa(o.port),
+		Handler:           server.Handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}, o.gracePeriod)
+	interrupts.WaitForGracefulShutdown()
+}
@@ -51,6 +51,8 @@ type options struct {
 
 	stickyLabelAuthors prowflagutil.Strings
 
+	debugHold time.Duration
+
 	webhookSecretFile        string
 	githubEventServerOptions githubeventserver.Options
 	github                   prowflagutil.GitHubOptions
@@ -76,6 +78,7 @@ func gatherOptions() (options, error) {
 	fs.IntVar(&o.moreLimit, "more-limit", 20, "Upper limit of jobs attempted to rehearse with more command (if more jobs are being touched, only this many will be rehearsed)")
 	fs.IntVar(&o.maxLimit, "max-limit", 35, "Upper limit of jobs attempted to rehearse with max command (if more jobs are being touched, only this many will be rehearsed)")
 
+	fs.DurationVar(&o.debugHold, "debug-hold", 2*time.Hour, "How long failing steps of rehearsals requested for debugging are held for inspection")
 	fs.Var(&o.stickyLabelAuthors, "sticky-label-author", "PR Author for which the 'rehearsals-ack' label will not be removed upon a new push. Can be passed multiple times.")
 	fs.StringVar(&o.webhookSecretFile, "hmac-secret-file", "/etc/webhook/hmac", "Path to the file containing the GitHub HMAC secret.")
 
@@ -147,6 +150,7 @@ func rehearsalConfigFromOptions(o options) rehearse.RehearsalConfig {
 		GCSBucket:          o.gcsBucket,
 		GCSCredentialsFile: o.gcsCredentialsFile,
 		GCSBrowserPrefix:   o.gcsBrowserPrefix,
+		DebugHold:          o.debugHold,
 	}
 }
 
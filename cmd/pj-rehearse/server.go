@@ -35,6 +35,7 @@ const (
 	rehearseAutoAck            = "/pj-rehearse auto-ack"
 	rehearseAbort              = "/pj-rehearse abort"
 	rehearseAllowNetworkAccess = "/pj-rehearse network-access-allowed"
+	rehearseDebug              = "/pj-rehearse debug"
 )
 
 var commentRegex = regexp.MustCompile(`(?m)^/pj-rehearse\f*.*$`)
@@ -73,6 +74,12 @@ func (s *server) helpProvider(_ []prowconfig.OrgRepo) (*pluginhelp.PluginHelp, e
 		WhoCanUse:   "Anyone can use on trusted PRs",
 		Examples:    []string{fmt.Sprintf("%s {some-test} {another-test}", rehearseNormal)},
 	})
+	pluginHelp.AddCommand(pluginhelp.Command{
+		Usage:       fmt.Sprintf("%s {test-name}", rehearseDebug),
+		Description: fmt.Sprintf("Run one or more specific rehearsals, holding the pods of failing steps for %s so that they can be inspected in the test namespace", s.rehearsalConfig.DebugHold),
+		WhoCanUse:   "Anyone can use on trusted PRs",
+		Examples:    []string{fmt.Sprintf("%s {some-test} {another-test}", rehearseDebug)},
+	})
 	pluginHelp.AddCommand(pluginhelp.Command{
 		Usage:       rehearseAck,
 		Description: fmt.Sprintf("Acknowledge the rehearsal result (either passing, failing, or skipped), and add the '%s' label allowing merge once other requirements are met.", rehearse.RehearsalsAckLabel),
@@ -372,6 +379,11 @@ func (s *server) handlePotentialCommands(pullRequest *github.PullRequest, commen
 				s.commentAffectedJobsOnPR(pullRequest, logger)
 			case rehearseAbort:
 				s.rehearsalConfig.AbortAllRehearsalJobs(org, repo, number, logger)
+			case rehearseDebug:
+				message := fmt.Sprintf("@%s: `%s` requires the names of the tests to rehearse, separated by a space", user, rehearseDebug)
+				if err := s.ghc.CreateComment(org, repo, number, message); err != nil {
+					logger.WithError(err).Error("failed to create comment")
+				}
 			default:
 				if rehearsalsTriggered {
 					message := fmt.Sprintf("@%s: requesting more than one rehearsal in one comment is not supported. If you would like to rehearse multiple specific jobs, please separate the job names by a space in a single command.", user)
@@ -420,9 +432,13 @@ func (s *server) handlePotentialCommands(pullRequest *github.PullRequest, commen
 					continue
 				}
 				requestedOnly := command != rehearseNormal && command != rehearseMore && command != rehearseMax && command != rehearseAutoAck
+				debug := strings.HasPrefix(command, rehearseDebug+" ")
 
 				if requestedOnly {
 					rawJobs := strings.TrimPrefix(command, rehearseNormal+" ")
+					if debug {
+						rawJobs = strings.TrimPrefix(command, rehearseDebug+" ")
+					}
 					requestedJobs := strings.Split(rawJobs, " ")
 					var unaffected []string
 					presubmits, periodics, unaffected = rehearse.FilterJobsByRequested(requestedJobs, presubmits, periodics, logger)
@@ -450,6 +466,10 @@ func (s *server) handlePotentialCommands(pullRequest *github.PullRequest, commen
 						continue
 					}
 
+					if debug {
+						rehearse.AddDebugHold(presubmitsToRehearse, rc.DebugHold)
+					}
+
 					if err := prConfig.Prow.ValidateJobConfig(); err != nil {
 						logger.WithError(err).Error("validation of job config failed")
 						s.reportFailure("config validation failed", err, org, repo, user, number, false, false, logger)
@@ -624,6 +644,7 @@ func (s *server) getUsageDetailsLines() []string {
 		fmt.Sprintf("Comment: `%s` to run up to %d rehearsals", rehearseNormal, rc.NormalLimit),
 		fmt.Sprintf("Comment: `%s` to opt-out of rehearsals", rehearseSkip),
 		fmt.Sprintf("Comment: `%s {test-name}`, with each test separated by a space, to run one or more specific rehearsals", rehearseNormal),
+		fmt.Sprintf("Comment: `%s {test-name}`, with each test separated by a space, to run one or more specific rehearsals and hold the pods of failing steps for %s for debugging", rehearseDebug, rc.DebugHold),
 		fmt.Sprintf("Comment: `%s` to run up to %d rehearsals", rehearseMore, rc.MoreLimit),
 		fmt.Sprintf("Comment: `%s` to run up to %d rehearsals", rehearseMax, rc.MaxLimit),
 		fmt.Sprintf("Comment: `%s` to run up to %d rehearsals, and add the `%s` label on success", rehearseAutoAck, rc.NormalLimit, rehearse.RehearsalsAckLabel),
@@ -31,7 +31,7 @@ var (
 			Name: "ci_operator_error_rate",
 			Help: "number of errors, sorted by label/type",
 		},
-		[]string{"job_name", "type", "state", "reason", "cluster"},
+		[]string{"job_name", "type", "state", "reason", "cluster", "classification"},
 	)
 	podScalerHighResourceCounter = prometheus.NewCounterVec(
 		prometheus.CounterOpts{
@@ -121,12 +121,18 @@ func handleError(w http.ResponseWriter, err error) {
 }
 
 func withErrorRate(request *results.Request) {
+	classification := request.Classification
+	if classification == "" && request.State == results.StateFailed {
+		// older clients do not classify their failures
+		classification = string(results.ClassificationUnknown)
+	}
 	labels := prometheus.Labels{
-		"job_name": request.JobName,
-		"type":     request.Type,
-		"state":    request.State,
-		"reason":   request.Reason,
-		"cluster":  request.Cluster,
+		"job_name":       request.JobName,
+		"type":           request.Type,
+		"state":          request.State,
+		"reason":         request.Reason,
+		"cluster":        request.Cluster,
+		"classification": classification,
 	}
 	errorRate.With(labels).Inc()
 }
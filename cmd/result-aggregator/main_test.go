@@ -10,6 +10,7 @@ import (
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	dto "github.com/prometheus/client_model/go"
 
 	"github.com/openshift/ci-tools/pkg/results"
 	"github.com/openshift/ci-tools/pkg/testhelper"
@@ -234,3 +235,43 @@ func TestValidatePodScalerRequest(t *testing.T) {
 		})
 	}
 }
+
+func TestWithErrorRate(t *testing.T) {
+	var testCases = []struct {
+		name           string
+		request        *results.Request
+		classification string
+	}{
+		{
+			name:           "classified failure",
+			request:        &results.Request{JobName: "classified", Type: "presubmit", State: results.StateFailed, Reason: "acquiring_lease", Cluster: "build01", Classification: "infra"},
+			classification: "infra",
+		},
+		{
+			name:           "unclassified failure from older client",
+			request:        &results.Request{JobName: "unclassified", Type: "presubmit", State: results.StateFailed, Reason: "unknown", Cluster: "build01"},
+			classification: "unknown",
+		},
+		{
+			name:    "success",
+			request: &results.Request{JobName: "success", Type: "presubmit", State: results.StateSucceeded, Reason: "unknown", Cluster: "build01"},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			withErrorRate(testCase.request)
+			r := testCase.request
+			counter, err := errorRate.GetMetricWithLabelValues(r.JobName, r.Type, r.State, r.Reason, r.Cluster, testCase.classification)
+			if err != nil {
+				t.Fatalf("failed to get metric: %v", err)
+			}
+			var metric dto.Metric
+			if err := counter.Write(&metric); err != nil {
+				t.Fatalf("failed to write metric: %v", err)
+			}
+			if value := metric.GetCounter().GetValue(); value != 1 {
+				t.Errorf("expected the counter to be 1, got %v", value)
+			}
+		})
+	}
+}
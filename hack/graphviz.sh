@@ -10,6 +10,9 @@
 #
 #     $ ci-operator … --print-graph | hack/graphviz.sh > out.png
 #     $ ci-operator … --print-graph | hack/graphviz.sh | $image_viewer -
+#
+# `ci-operator --print-graph --print-graph-format=dot` generates Graphviz input
+# directly, including the links between steps as labels of the edges.
 set -euo pipefail
 
 awk_prog="$(cat <<'EOF'
@@ -145,9 +145,10 @@ func (config *ReleaseBuildConfiguration) WithPresubmitFrom(source *ReleaseBuildC
 
 	// TODO: handle resources, likely needs to be union, with max(config, source) on conflicts
 
-	for i := range source.Tests {
-		if source.Tests[i].As == test {
-			test := source.Tests[i]
+	tests := ExpandTestMatrices(source.Tests)
+	for i := range tests {
+		if tests[i].As == test {
+			test := tests[i]
 			test.Interval = nil
 			test.Cron = nil
 			test.MinimumInterval = nil
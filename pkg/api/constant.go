@@ -31,6 +31,11 @@ const (
 
 	PromotionQuayTaggerKubeconfigSecret = "promotion-quay-tagger-kubeconfig"
 
+	// PromotionSigningKeySecret holds the cosign key which signs promoted images
+	PromotionSigningKeySecret      = "promotion-signing-key"
+	PromotionSigningKeyKey         = "cosign.key"
+	PromotionSigningKeyPasswordKey = "cosign.password"
+
 	ReleaseAnnotationSoftDelete = "release.openshift.io/soft-delete"
 
 	// DPTPRequesterLabel is the label on a Kubernates CR whose value indicates the automated tool that requests the CR
@@ -110,6 +115,8 @@ const (
 	CIOperatorHTTPServerIPEnvVarName = "HTTP_SERVER_IP"
 	CIOperatorHTTPServerPort         = 8080
 	LeaseProxyServerURLEnvVarName    = "LEASE_PROXY_SERVER_URL"
+	LeaseProxyTokenEnvVarName        = "LEASE_PROXY_TOKEN"
+	LeaseProxyClientEnvVarName       = "LEASE_PROXY_CLIENT"
 	LeaseProxyConfigMapName          = "lease-proxy"
 )
 
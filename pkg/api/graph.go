@@ -33,6 +33,21 @@ type MultiArchStep interface {
 	AddArchitectures([]string)
 }
 
+// ObjectRenderer is a step that can generate the objects it would create in
+// the cluster without running, which is used by dry runs.  Values which are
+// only known once the dependencies of the step have run are rendered using
+// DryRunPlaceholder.
+// +k8s:deepcopy-gen=false
+type ObjectRenderer interface {
+	RenderObjects() ([]ctrlruntimeclient.Object, error)
+}
+
+// DryRunPlaceholder is rendered in dry runs in place of a value which is not
+// known without running the steps of the graph.
+func DryRunPlaceholder(name string) string {
+	return fmt.Sprintf("<dry-run:%s>", name)
+}
+
 // CommonStep is a self-contained bit of work that the
 // build pipeline needs to do.
 // +k8s:deepcopy-gen=false
@@ -143,6 +158,10 @@ func (l *internalImageStreamLink) UnsatisfiableError() string {
 	return ""
 }
 
+func (l *internalImageStreamLink) String() string {
+	return l.name
+}
+
 // internalImageStreamTagLink describes a specific tag in
 // an ImageStream in the test's namespace
 type internalImageStreamTagLink struct {
@@ -167,6 +186,10 @@ func (l *internalImageStreamTagLink) UnsatisfiableError() string {
 	return l.unsatisfiableError
 }
 
+func (l *internalImageStreamTagLink) String() string {
+	return l.name + ":" + l.tag
+}
+
 func AllStepsLink() StepLink {
 	return allStepsLink{}
 }
@@ -181,6 +204,10 @@ func (_ allStepsLink) UnsatisfiableError() string {
 	return ""
 }
 
+func (_ allStepsLink) String() string {
+	return "all steps"
+}
+
 func ExternalImageLink(ref ImageStreamTagReference) StepLink {
 	return &externalImageLink{
 		namespace: ref.Namespace,
@@ -208,6 +235,10 @@ func (l *externalImageLink) UnsatisfiableError() string {
 	return ""
 }
 
+func (l *externalImageLink) String() string {
+	return l.namespace + "/" + l.name + ":" + l.tag
+}
+
 type StepLinkOptions struct {
 	// UnsatisfiableError holds a human-understandable explanation
 	// of where exactly in the config the requirement came from and
@@ -263,6 +294,10 @@ func (l *imagesReadyLink) UnsatisfiableError() string {
 	return ""
 }
 
+func (l *imagesReadyLink) String() string {
+	return "images ready"
+}
+
 func RPMRepoLink() StepLink {
 	return &rpmRepoLink{}
 }
@@ -282,6 +317,36 @@ func (l *rpmRepoLink) UnsatisfiableError() string {
 	return ""
 }
 
+func (l *rpmRepoLink) String() string {
+	return "rpm repo"
+}
+
+// ServedArtifactsLink describes artifacts served over HTTP under the name.
+func ServedArtifactsLink(name string) StepLink {
+	return &servedArtifactsLink{name: name}
+}
+
+type servedArtifactsLink struct {
+	name string
+}
+
+func (l *servedArtifactsLink) SatisfiedBy(other StepLink) bool {
+	switch link := other.(type) {
+	case *servedArtifactsLink:
+		return l.name == link.name
+	default:
+		return false
+	}
+}
+
+func (l *servedArtifactsLink) UnsatisfiableError() string {
+	return fmt.Sprintf("no artifacts named %q are configured in served_artifacts", l.name)
+}
+
+func (l *servedArtifactsLink) String() string {
+	return "served artifacts " + l.name
+}
+
 func LeaseProxyServerLink() StepLink {
 	return &leaseProxyServerLink{}
 }
@@ -301,6 +366,10 @@ func (*leaseProxyServerLink) UnsatisfiableError() string {
 	return ""
 }
 
+func (*leaseProxyServerLink) String() string {
+	return "lease proxy server"
+}
+
 // ReleaseImagesLink describes the content of a stable(-foo)?
 // ImageStream in the test namespace.
 func ReleaseImagesLink(name string) StepLink {
@@ -323,6 +392,7 @@ func Comparer() cmp.Option {
 		internalImageStreamLink{},
 		internalImageStreamTagLink{},
 		externalImageLink{},
+		servedArtifactsLink{},
 	)
 }
 
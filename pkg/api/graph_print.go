@@ -0,0 +1,124 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// GraphFormat is a format in which a step graph can be printed
+type GraphFormat string
+
+const (
+	// GraphFormatDigraph prints one dependency per line, as expected
+	// by the golang digraph utility
+	GraphFormatDigraph GraphFormat = "digraph"
+	// GraphFormatDOT prints a Graphviz graph with the links between
+	// steps as labels of the edges
+	GraphFormatDOT GraphFormat = "dot"
+	// GraphFormatMermaid prints a Mermaid flowchart with the links
+	// between steps as labels of the edges
+	GraphFormatMermaid GraphFormat = "mermaid"
+	// GraphFormatJSON prints every step with the links it requires
+	// and creates and the steps it depends on
+	GraphFormatJSON GraphFormat = "json"
+)
+
+// GraphFormats are all the supported formats
+var GraphFormats = []GraphFormat{GraphFormatDigraph, GraphFormatDOT, GraphFormatMermaid, GraphFormatJSON}
+
+// graphEdge records that the step at index `to` requires the links
+// created by the step at index `from`
+type graphEdge struct {
+	from, to int
+	links    []string
+}
+
+func graphEdges(steps OrderedStepList) []graphEdge {
+	var edges []graphEdge
+	for i, step := range steps {
+		for j, other := range steps[:i] {
+			var links []string
+			for _, created := range other.Step.Creates() {
+				for _, required := range step.Step.Requires() {
+					if required.SatisfiedBy(created) {
+						links = append(links, fmt.Sprint(created))
+						break
+					}
+				}
+			}
+			if len(links) > 0 {
+				edges = append(edges, graphEdge{from: j, to: i, links: links})
+			}
+		}
+	}
+	return edges
+}
+
+// graphNode is the JSON representation of a step in the graph
+// +k8s:deepcopy-gen=false
+type graphNode struct {
+	Name         string   `json:"name"`
+	Description  string   `json:"description"`
+	Requires     []string `json:"requires"`
+	Creates      []string `json:"creates"`
+	Dependencies []string `json:"dependencies"`
+}
+
+// PrintGraph writes the steps and the dependencies between them in the
+// requested format.
+func PrintGraph(w io.Writer, steps OrderedStepList, format GraphFormat) error {
+	edges := graphEdges(steps)
+	var out strings.Builder
+	switch format {
+	case GraphFormatDigraph:
+		for _, edge := range edges {
+			fmt.Fprintf(&out, "%s %s\n", steps[edge.to].Step.Name(), steps[edge.from].Step.Name())
+		}
+	case GraphFormatDOT:
+		out.WriteString("digraph steps {\n\trankdir=LR;\n")
+		for _, step := range steps {
+			fmt.Fprintf(&out, "\t%q [tooltip=%q];\n", step.Step.Name(), step.Step.Description())
+		}
+		for _, edge := range edges {
+			fmt.Fprintf(&out, "\t%q -> %q [label=%q];\n", steps[edge.from].Step.Name(), steps[edge.to].Step.Name(), strings.Join(edge.links, "\n"))
+		}
+		out.WriteString("}\n")
+	case GraphFormatMermaid:
+		// step names are not valid identifiers, so nodes are identified
+		// by their position and labeled with their names
+		escape := strings.NewReplacer(`"`, "#quot;").Replace
+		out.WriteString("flowchart LR\n")
+		for i, step := range steps {
+			fmt.Fprintf(&out, "\tstep%d[\"%s\"]\n", i, escape(step.Step.Name()))
+		}
+		for _, edge := range edges {
+			fmt.Fprintf(&out, "\tstep%d -->|\"%s\"| step%d\n", edge.from, escape(strings.Join(edge.links, "<br>")), edge.to)
+		}
+	case GraphFormatJSON:
+		nodes := make([]graphNode, 0, len(steps))
+		for _, step := range steps {
+			node := graphNode{Name: step.Step.Name(), Description: step.Step.Description(), Requires: []string{}, Creates: []string{}, Dependencies: []string{}}
+			for _, link := range step.Step.Requires() {
+				node.Requires = append(node.Requires, fmt.Sprint(link))
+			}
+			for _, link := range step.Step.Creates() {
+				node.Creates = append(node.Creates, fmt.Sprint(link))
+			}
+			nodes = append(nodes, node)
+		}
+		for _, edge := range edges {
+			nodes[edge.to].Dependencies = append(nodes[edge.to].Dependencies, steps[edge.from].Step.Name())
+		}
+		raw, err := json.MarshalIndent(nodes, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal graph: %w", err)
+		}
+		out.Write(append(raw, '\n'))
+	default:
+		return fmt.Errorf("unknown graph format %q, must be one of %v", format, GraphFormats)
+	}
+	_, err := io.WriteString(w, out.String())
+	return err
+}
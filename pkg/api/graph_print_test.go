@@ -0,0 +1,38 @@
+package api
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/openshift/ci-tools/pkg/testhelper"
+)
+
+func printGraphSteps() OrderedStepList {
+	root := &fakeStep{name: "[input:root]", creates: []StepLink{InternalImageLink(PipelineImageStreamTagReferenceRoot)}}
+	src := &fakeStep{name: "src", requires: []StepLink{InternalImageLink(PipelineImageStreamTagReferenceRoot)}, creates: []StepLink{InternalImageLink(PipelineImageStreamTagReferenceSource)}}
+	release := &fakeStep{name: "[release-inputs]", creates: []StepLink{ReleaseImagesLink(LatestReleaseName)}}
+	bin := &fakeStep{name: "bin", requires: []StepLink{InternalImageLink(PipelineImageStreamTagReferenceSource)}, creates: []StepLink{InternalImageLink(PipelineImageStreamTagReferenceBinaries)}}
+	image := &fakeStep{name: "image", requires: []StepLink{InternalImageLink(PipelineImageStreamTagReferenceBinaries)}, creates: []StepLink{InternalImageLink("image"), ImagesReadyLink()}}
+	unit := &fakeStep{name: "unit", requires: []StepLink{InternalImageLink(PipelineImageStreamTagReferenceSource)}}
+	e2e := &fakeStep{name: "e2e", requires: []StepLink{ReleaseImagesLink(LatestReleaseName), ImagesReadyLink(), InternalImageLink(PipelineImageStreamTagReferenceSource)}}
+	var steps OrderedStepList
+	for _, step := range []*fakeStep{root, src, release, bin, image, unit, e2e} {
+		steps = append(steps, &StepNode{Step: step})
+	}
+	return steps
+}
+
+func TestPrintGraph(t *testing.T) {
+	for _, format := range GraphFormats {
+		t.Run(string(format), func(t *testing.T) {
+			var out bytes.Buffer
+			if err := PrintGraph(&out, printGraphSteps(), format); err != nil {
+				t.Fatalf("failed to print graph: %v", err)
+			}
+			testhelper.CompareWithFixture(t, out.String())
+		})
+	}
+	if err := PrintGraph(&bytes.Buffer{}, printGraphSteps(), "svg"); err == nil {
+		t.Error("expected an error for an unknown format")
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"runtime/debug"
+	"time"
 
 	"github.com/sirupsen/logrus"
 
@@ -27,6 +28,9 @@ type JobSpec struct {
 	// if set, any new artifacts will be a child of this object
 	owner *meta.OwnerReference
 
+	// startTime is when the job started
+	startTime time.Time
+
 	Metadata               Metadata `json:"metadata,omitempty"`
 	Target                 string   `json:"target,omitempty"`
 	TargetAdditionalSuffix string   `json:"target_additional_suffix,omitempty"`
@@ -58,6 +62,16 @@ func (s *JobSpec) SetOwner(owner *meta.OwnerReference) {
 	s.owner = owner
 }
 
+// StartTime returns when the job started, from which its timeout is
+// measured, or the zero time if it is not known.
+func (s *JobSpec) StartTime() time.Time {
+	return s.startTime
+}
+
+func (s *JobSpec) SetStartTime(startTime time.Time) {
+	s.startTime = startTime
+}
+
 // Inputs returns the definition of the job as an input to
 // the execution graph.
 func (s *JobSpec) Inputs() InputDefinition {
@@ -0,0 +1,110 @@
+package api
+
+import "strings"
+
+// ExpandTestMatrices replaces every test with a matrix by the tests generated
+// for the combinations of its values. Other tests are returned unchanged.
+func ExpandTestMatrices(tests []TestStepConfiguration) []TestStepConfiguration {
+	var ret []TestStepConfiguration
+	for _, test := range tests {
+		ret = append(ret, test.ExpandMatrix()...)
+	}
+	return ret
+}
+
+// ExpandMatrix returns the tests generated from the matrix of the test, in
+// the order of the values of its dimensions, or the test itself if it has no
+// matrix. Combinations excluded by an override do not generate a test.
+func (config TestStepConfiguration) ExpandMatrix() []TestStepConfiguration {
+	if config.Matrix == nil {
+		return []TestStepConfiguration{config}
+	}
+	var ret []TestStepConfiguration
+	for _, combination := range config.Matrix.combinations() {
+		names := map[string]string{}
+		env := TestEnvironment{}
+		suffix := []string{config.As}
+		for i, value := range combination {
+			dimension := config.Matrix.Dimensions[i].Env
+			names[dimension] = value.Name
+			env[dimension] = value.Name
+			if value.Value != nil {
+				env[dimension] = *value.Value
+			}
+			suffix = append(suffix, value.Name)
+		}
+		var test TestStepConfiguration
+		config.DeepCopyInto(&test)
+		test.Matrix = nil
+		test.As = strings.Join(suffix, "-")
+		excluded := false
+		for _, override := range config.Matrix.Overrides {
+			if !override.matches(names) {
+				continue
+			}
+			if override.Exclude {
+				excluded = true
+				break
+			}
+			for k, v := range override.Env {
+				env[k] = v
+			}
+			if override.Optional != nil {
+				test.Optional = *override.Optional
+			}
+			if override.AlwaysRun != nil {
+				alwaysRun := *override.AlwaysRun
+				test.AlwaysRun = &alwaysRun
+			}
+		}
+		if excluded {
+			continue
+		}
+		switch {
+		case test.MultiStageTestConfiguration != nil:
+			test.MultiStageTestConfiguration.Environment = withMatrixEnvironment(test.MultiStageTestConfiguration.Environment, env)
+		case test.MultiStageTestConfigurationLiteral != nil:
+			test.MultiStageTestConfigurationLiteral.Environment = withMatrixEnvironment(test.MultiStageTestConfigurationLiteral.Environment, env)
+		}
+		ret = append(ret, test)
+	}
+	return ret
+}
+
+// combinations lists the combinations of values of the dimensions, varying
+// the last dimension fastest.
+func (m *TestMatrix) combinations() [][]MatrixValue {
+	ret := [][]MatrixValue{nil}
+	for _, dimension := range m.Dimensions {
+		var next [][]MatrixValue
+		for _, combination := range ret {
+			for _, value := range dimension.Values {
+				next = append(next, append(append([]MatrixValue(nil), combination...), value))
+			}
+		}
+		ret = next
+	}
+	return ret
+}
+
+func (o MatrixOverride) matches(names map[string]string) bool {
+	for env, name := range o.Match {
+		if names[env] != name {
+			return false
+		}
+	}
+	return true
+}
+
+// withMatrixEnvironment sets the parameters of a combination in the
+// environment of a test, taking precedence over the values set by the test.
+func withMatrixEnvironment(env, matrix TestEnvironment) TestEnvironment {
+	ret := TestEnvironment{}
+	for k, v := range env {
+		ret[k] = v
+	}
+	for k, v := range matrix {
+		ret[k] = v
+	}
+	return ret
+}
@@ -0,0 +1,63 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"k8s.io/utils/ptr"
+)
+
+func TestExpandTestMatrices(t *testing.T) {
+	tests := []TestStepConfiguration{
+		{As: "unit", ContainerTestConfiguration: &ContainerTestConfiguration{From: "src"}},
+		{
+			As: "e2e",
+			Matrix: &TestMatrix{
+				Dimensions: []MatrixDimension{
+					{Env: "NETWORK_TYPE", Values: []MatrixValue{{Name: "ovn", Value: ptr.To("OVNKubernetes")}, {Name: "sdn", Value: ptr.To("OpenShiftSDN")}}},
+					{Env: "IP_STACK", Values: []MatrixValue{{Name: "ipv4"}, {Name: "dualstack"}}},
+				},
+				Overrides: []MatrixOverride{
+					{Match: map[string]string{"NETWORK_TYPE": "sdn", "IP_STACK": "dualstack"}, Exclude: true},
+					{Match: map[string]string{"IP_STACK": "dualstack"}, Env: TestEnvironment{"EXTRA": "yes"}, AlwaysRun: ptr.To(false)},
+				},
+			},
+			MultiStageTestConfiguration: &MultiStageTestConfiguration{
+				Workflow:    ptr.To("ipi-aws"),
+				Environment: TestEnvironment{"NETWORK_TYPE": "ignored", "OTHER": "kept"},
+			},
+		},
+	}
+	expected := []TestStepConfiguration{
+		{As: "unit", ContainerTestConfiguration: &ContainerTestConfiguration{From: "src"}},
+		{
+			As: "e2e-ovn-ipv4",
+			MultiStageTestConfiguration: &MultiStageTestConfiguration{
+				Workflow:    ptr.To("ipi-aws"),
+				Environment: TestEnvironment{"NETWORK_TYPE": "OVNKubernetes", "IP_STACK": "ipv4", "OTHER": "kept"},
+			},
+		},
+		{
+			As:        "e2e-ovn-dualstack",
+			AlwaysRun: ptr.To(false),
+			MultiStageTestConfiguration: &MultiStageTestConfiguration{
+				Workflow:    ptr.To("ipi-aws"),
+				Environment: TestEnvironment{"NETWORK_TYPE": "OVNKubernetes", "IP_STACK": "dualstack", "OTHER": "kept", "EXTRA": "yes"},
+			},
+		},
+		{
+			As: "e2e-sdn-ipv4",
+			MultiStageTestConfiguration: &MultiStageTestConfiguration{
+				Workflow:    ptr.To("ipi-aws"),
+				Environment: TestEnvironment{"NETWORK_TYPE": "OpenShiftSDN", "IP_STACK": "ipv4", "OTHER": "kept"},
+			},
+		},
+	}
+	if diff := cmp.Diff(expected, ExpandTestMatrices(tests)); diff != "" {
+		t.Errorf("incorrect expansion: %s", diff)
+	}
+	if tests[1].MultiStageTestConfiguration.Environment["NETWORK_TYPE"] != "ignored" {
+		t.Error("expanding the matrix modified the original test")
+	}
+}
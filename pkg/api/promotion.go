@@ -20,6 +20,8 @@ const (
 
 	PromotionStepName     = "promotion"
 	PromotionQuayStepName = "promotion-quay"
+	// PromotionPreviewStepName reports what a promotion would change
+	PromotionPreviewStepName = "promotion-preview"
 
 	PromotionExcludeImageWildcard = "*"
 )
@@ -0,0 +1,107 @@
+package api
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// ValidateType checks that the type information of the parameter is
+// consistent: enums need allowed values and regexes a valid pattern.
+func (p StepParameter) ValidateType() error {
+	if p.Type != StepParameterTypeEnum && len(p.AllowedValues) != 0 {
+		return fmt.Errorf("allowed_values can only be set for parameters of type %s", StepParameterTypeEnum)
+	}
+	if p.Type != StepParameterTypeRegex && p.Pattern != "" {
+		return fmt.Errorf("pattern can only be set for parameters of type %s", StepParameterTypeRegex)
+	}
+	switch p.Type {
+	case "", StepParameterTypeBool, StepParameterTypeInt, StepParameterTypeDuration:
+	case StepParameterTypeEnum:
+		if len(p.AllowedValues) == 0 {
+			return fmt.Errorf("parameters of type %s must set allowed_values", StepParameterTypeEnum)
+		}
+		if duplicates := duplicateValues(p.AllowedValues); len(duplicates) != 0 {
+			return fmt.Errorf("allowed_values contains duplicates: %s", strings.Join(duplicates, ", "))
+		}
+	case StepParameterTypeRegex:
+		if p.Pattern == "" {
+			return fmt.Errorf("parameters of type %s must set a pattern", StepParameterTypeRegex)
+		}
+		if _, err := regexp.Compile(p.Pattern); err != nil {
+			return fmt.Errorf("invalid pattern: %w", err)
+		}
+	default:
+		return fmt.Errorf("unknown type %q, expected one of %s, %s, %s, %s or %s", p.Type, StepParameterTypeBool, StepParameterTypeInt, StepParameterTypeEnum, StepParameterTypeRegex, StepParameterTypeDuration)
+	}
+	return nil
+}
+
+func duplicateValues(values []string) []string {
+	seen, duplicates := sets.New[string](), sets.New[string]()
+	for _, value := range values {
+		if seen.Has(value) {
+			duplicates.Insert(value)
+		}
+		seen.Insert(value)
+	}
+	return sets.List(duplicates)
+}
+
+// ValidateValue checks that a value is of the type of the parameter. Empty
+// values and the values of untyped parameters are always valid.
+func (p StepParameter) ValidateValue(value string) error {
+	if value == "" {
+		return nil
+	}
+	switch p.Type {
+	case StepParameterTypeBool:
+		if value != "true" && value != "false" {
+			return fmt.Errorf("%q is not a bool, expected true or false", value)
+		}
+	case StepParameterTypeInt:
+		if _, err := strconv.Atoi(value); err != nil {
+			return fmt.Errorf("%q is not an int", value)
+		}
+	case StepParameterTypeEnum:
+		if !sets.New[string](p.AllowedValues...).Has(value) {
+			return fmt.Errorf("%q is not one of the allowed values: %s", value, strings.Join(p.AllowedValues, ", "))
+		}
+	case StepParameterTypeRegex:
+		pattern, err := regexp.Compile(`^(?:` + p.Pattern + `)$`)
+		if err != nil {
+			return fmt.Errorf("invalid pattern: %w", err)
+		}
+		if !pattern.MatchString(value) {
+			return fmt.Errorf("%q does not match the pattern %s", value, p.Pattern)
+		}
+	case StepParameterTypeDuration:
+		if _, err := time.ParseDuration(value); err != nil {
+			return fmt.Errorf("%q is not a duration", value)
+		}
+	}
+	return nil
+}
+
+// ValidateParameterValue checks the value of a parameter against its
+// declarations in the steps, returning an error for every declaration the
+// value does not satisfy.
+func ValidateParameterValue(steps []LiteralTestStep, name, value string) error {
+	var errs []error
+	for _, step := range steps {
+		for _, param := range step.Environment {
+			if param.Name != name {
+				continue
+			}
+			if err := param.ValidateValue(value); err != nil {
+				errs = append(errs, fmt.Errorf("invalid value for parameter %s of step %s: %w", name, step.As, err))
+			}
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
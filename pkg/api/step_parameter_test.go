@@ -0,0 +1,176 @@
+package api
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/openshift/ci-tools/pkg/testhelper"
+)
+
+func TestStepParameterValidateType(t *testing.T) {
+	var testCases = []struct {
+		name     string
+		param    StepParameter
+		expected error
+	}{
+		{
+			name:  "untyped",
+			param: StepParameter{Name: "P"},
+		},
+		{
+			name:  "bool",
+			param: StepParameter{Name: "P", Type: StepParameterTypeBool},
+		},
+		{
+			name:  "enum",
+			param: StepParameter{Name: "P", Type: StepParameterTypeEnum, AllowedValues: []string{"a", "b"}},
+		},
+		{
+			name:  "regex",
+			param: StepParameter{Name: "P", Type: StepParameterTypeRegex, Pattern: "[0-9]+"},
+		},
+		{
+			name:     "unknown type",
+			param:    StepParameter{Name: "P", Type: "float"},
+			expected: errors.New(`unknown type "float", expected one of bool, int, enum, regex or duration`),
+		},
+		{
+			name:     "enum without allowed values",
+			param:    StepParameter{Name: "P", Type: StepParameterTypeEnum},
+			expected: errors.New("parameters of type enum must set allowed_values"),
+		},
+		{
+			name:     "enum with duplicate allowed values",
+			param:    StepParameter{Name: "P", Type: StepParameterTypeEnum, AllowedValues: []string{"a", "b", "a"}},
+			expected: errors.New("allowed_values contains duplicates: a"),
+		},
+		{
+			name:     "allowed values for another type",
+			param:    StepParameter{Name: "P", Type: StepParameterTypeBool, AllowedValues: []string{"a"}},
+			expected: errors.New("allowed_values can only be set for parameters of type enum"),
+		},
+		{
+			name:     "regex without pattern",
+			param:    StepParameter{Name: "P", Type: StepParameterTypeRegex},
+			expected: errors.New("parameters of type regex must set a pattern"),
+		},
+		{
+			name:     "invalid pattern",
+			param:    StepParameter{Name: "P", Type: StepParameterTypeRegex, Pattern: "[0-9"},
+			expected: errors.New("invalid pattern: error parsing regexp: missing closing ]: `[0-9`"),
+		},
+		{
+			name:     "pattern for another type",
+			param:    StepParameter{Name: "P", Pattern: "[0-9]+"},
+			expected: errors.New("pattern can only be set for parameters of type regex"),
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			if diff := cmp.Diff(testCase.expected, testCase.param.ValidateType(), testhelper.EquateErrorMessage); diff != "" {
+				t.Errorf("unexpected error: %s", diff)
+			}
+		})
+	}
+}
+
+func TestStepParameterValidateValue(t *testing.T) {
+	var testCases = []struct {
+		name     string
+		param    StepParameter
+		value    string
+		expected error
+	}{
+		{
+			name:  "untyped",
+			param: StepParameter{Name: "P"},
+			value: "anything",
+		},
+		{
+			name:  "empty value",
+			param: StepParameter{Name: "P", Type: StepParameterTypeInt},
+		},
+		{
+			name:  "bool",
+			param: StepParameter{Name: "P", Type: StepParameterTypeBool},
+			value: "true",
+		},
+		{
+			name:     "invalid bool",
+			param:    StepParameter{Name: "P", Type: StepParameterTypeBool},
+			value:    "ture",
+			expected: errors.New(`"ture" is not a bool, expected true or false`),
+		},
+		{
+			name:  "int",
+			param: StepParameter{Name: "P", Type: StepParameterTypeInt},
+			value: "-3",
+		},
+		{
+			name:     "invalid int",
+			param:    StepParameter{Name: "P", Type: StepParameterTypeInt},
+			value:    "3.5",
+			expected: errors.New(`"3.5" is not an int`),
+		},
+		{
+			name:  "enum",
+			param: StepParameter{Name: "P", Type: StepParameterTypeEnum, AllowedValues: []string{"ovn", "sdn"}},
+			value: "sdn",
+		},
+		{
+			name:     "invalid enum",
+			param:    StepParameter{Name: "P", Type: StepParameterTypeEnum, AllowedValues: []string{"ovn", "sdn"}},
+			value:    "calico",
+			expected: errors.New(`"calico" is not one of the allowed values: ovn, sdn`),
+		},
+		{
+			name:  "regex",
+			param: StepParameter{Name: "P", Type: StepParameterTypeRegex, Pattern: "4\\.[0-9]+"},
+			value: "4.21",
+		},
+		{
+			name:     "regex matching only a part of the value",
+			param:    StepParameter{Name: "P", Type: StepParameterTypeRegex, Pattern: "4\\.[0-9]+"},
+			value:    "4.21-rc",
+			expected: errors.New(`"4.21-rc" does not match the pattern 4\.[0-9]+`),
+		},
+		{
+			name:  "duration",
+			param: StepParameter{Name: "P", Type: StepParameterTypeDuration},
+			value: "1h30m",
+		},
+		{
+			name:     "invalid duration",
+			param:    StepParameter{Name: "P", Type: StepParameterTypeDuration},
+			value:    "90",
+			expected: errors.New(`"90" is not a duration`),
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			if diff := cmp.Diff(testCase.expected, testCase.param.ValidateValue(testCase.value), testhelper.EquateErrorMessage); diff != "" {
+				t.Errorf("unexpected error: %s", diff)
+			}
+		})
+	}
+}
+
+func TestValidateParameterValue(t *testing.T) {
+	steps := []LiteralTestStep{
+		{As: "install", Environment: []StepParameter{{Name: "FIPS_ENABLED", Type: StepParameterTypeBool}}},
+		{As: "e2e", Environment: []StepParameter{{Name: "FIPS_ENABLED"}, {Name: "TEST_SUITE"}}},
+		{As: "gather"},
+	}
+	if err := ValidateParameterValue(steps, "FIPS_ENABLED", "true"); err != nil {
+		t.Errorf("expected a valid value to pass, got %v", err)
+	}
+	if err := ValidateParameterValue(steps, "UNDECLARED", "ture"); err != nil {
+		t.Errorf("expected values of undeclared parameters to pass, got %v", err)
+	}
+	expected := errors.New(`invalid value for parameter FIPS_ENABLED of step install: "ture" is not a bool, expected true or false`)
+	if diff := cmp.Diff(expected, ValidateParameterValue(steps, "FIPS_ENABLED", "ture"), testhelper.EquateErrorMessage); diff != "" {
+		t.Errorf("unexpected error: %s", diff)
+	}
+}
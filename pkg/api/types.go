@@ -3,6 +3,7 @@ package api
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"k8s.io/apimachinery/pkg/util/sets"
 	prowv1 "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
@@ -89,6 +90,11 @@ type ReleaseBuildConfiguration struct {
 	// DO NOT set this in the config
 	RpmBuildLocationList []RefLocation `json:"rpm_build_location_list,omitempty"`
 
+	// ServedArtifacts are directories of built images which are served over
+	// HTTP to the tests of the job, so build outputs like binaries, charts or
+	// bundles can be shared between steps without baking them into images.
+	ServedArtifacts []ServedArtifactsConfiguration `json:"served_artifacts,omitempty"`
+
 	// CanonicalGoRepository is a directory path that represents
 	// the desired location of the contents of this repository in
 	// Go. If specified the location of the repository we are
@@ -114,6 +120,11 @@ type ReleaseBuildConfiguration struct {
 	// The image name (To field) should match the cmd tool name for this to work correctly.
 	BuildImagesIfAffected bool `json:"build_images_if_affected,omitempty"`
 
+	// BuildBackend selects the system which builds the images. When unset,
+	// the default of the build cluster is used, which is OpenShift Builds
+	// unless the cluster is configured otherwise.
+	BuildBackend BuildBackend `json:"build_backend,omitempty"`
+
 	// Operator describes the operator bundle(s) that is built by the project
 	Operator *OperatorStepConfiguration `json:"operator,omitempty"`
 
@@ -440,6 +451,27 @@ const (
 	NodeArchitecturePPC64le NodeArchitecture = "ppc64le"
 )
 
+// BuildBackend describes the system which executes image builds
+type BuildBackend string
+
+func (b BuildBackend) Validate() error {
+	switch b {
+	case BuildBackendOpenShift, BuildBackendBuildah:
+		return nil
+	}
+
+	return fmt.Errorf("invalid build backend %s, expected one of %v or %v", b, BuildBackendOpenShift, BuildBackendBuildah)
+}
+
+const (
+	// BuildBackendOpenShift executes image builds as OpenShift Builds.
+	BuildBackendOpenShift BuildBackend = "openshift"
+	// BuildBackendBuildah executes image builds with buildah in plain
+	// Kubernetes pods, pushing the results to the pipeline image stream
+	// through the local registry.
+	BuildBackendBuildah BuildBackend = "buildah"
+)
+
 type ReleaseStream string
 
 const (
@@ -631,6 +663,7 @@ type StepConfiguration struct {
 	ProjectDirectoryImageBuildStepConfiguration *ProjectDirectoryImageBuildStepConfiguration `json:"project_directory_image_build_step,omitempty"`
 	RPMImageInjectionStepConfiguration          *RPMImageInjectionStepConfiguration          `json:"rpm_image_injection_step,omitempty"`
 	RPMServeStepConfiguration                   *RPMServeStepConfiguration                   `json:"rpm_serve_step,omitempty"`
+	ArtifactServeStepConfiguration              *ServedArtifactsConfiguration                `json:"artifact_serve_step,omitempty"`
 	OutputImageTagStepConfiguration             *OutputImageTagStepConfiguration             `json:"output_image_tag_step,omitempty"`
 	ReleaseImagesTagStepConfiguration           *ReleaseTagConfiguration                     `json:"release_images_tag_step,omitempty"`
 	ResolvedReleaseImagesStepConfiguration      *ReleaseConfiguration                        `json:"resolved_release_images_step,omitempty"`
@@ -867,6 +900,16 @@ type TestStepConfiguration struct {
 	// Only applicable to presubmits and periodics
 	ShardCount *int `json:"shard_count,omitempty"`
 
+	// Matrix describes parameters whose combinations of values each generate
+	// a copy of this test, with the values set in its environment.
+	// Only applicable to multi-stage tests.
+	Matrix *TestMatrix `json:"matrix,omitempty"`
+
+	// ResultCache allows a passing result of this test to be reused by later
+	// executions whose configuration and images are identical, instead of
+	// running the test again. Only applicable to container and multi-stage tests.
+	ResultCache *TestResultCache `json:"result_cache,omitempty"`
+
 	// Only one of the following can be not-null.
 	ContainerTestConfiguration                                *ContainerTestConfiguration                                `json:"container,omitempty"`
 	MultiStageTestConfiguration                               *MultiStageTestConfiguration                               `json:"steps,omitempty"`
@@ -902,6 +945,68 @@ func (config TestStepConfiguration) GetClusterProfileName() string {
 	}
 }
 
+// TestMatrix describes the tests generated from a test for each combination
+// of the values of its dimensions.
+type TestMatrix struct {
+	// Dimensions are the parameters and the values they take. The name of
+	// each generated test is the name of the original test followed by the
+	// names of the values of the combination, in the order of the dimensions.
+	Dimensions []MatrixDimension `json:"dimensions"`
+	// Overrides change the tests generated for some combinations.
+	Overrides []MatrixOverride `json:"overrides,omitempty"`
+}
+
+// MatrixDimension is a parameter of the tests generated from a matrix.
+type MatrixDimension struct {
+	// Env is the name of the parameter set in the environment of the tests.
+	Env string `json:"env"`
+	// Values are the values the parameter takes.
+	Values []MatrixValue `json:"values"`
+}
+
+// MatrixValue is one of the values of a dimension.
+type MatrixValue struct {
+	// Name identifies the value in the names of the generated tests.
+	Name string `json:"name"`
+	// Value is set as the parameter. Defaults to the name.
+	Value *string `json:"value,omitempty"`
+}
+
+// MatrixOverride changes the tests generated for the combinations it matches.
+type MatrixOverride struct {
+	// Match selects combinations by the names of their values, keyed by the
+	// parameter of the dimension. Dimensions which are not listed match any
+	// value.
+	Match map[string]string `json:"match"`
+	// Exclude removes the matching combinations.
+	Exclude bool `json:"exclude,omitempty"`
+	// Env sets additional parameters in the environment of matching tests.
+	Env TestEnvironment `json:"env,omitempty"`
+	// Optional overrides whether matching tests are optional.
+	Optional *bool `json:"optional,omitempty"`
+	// AlwaysRun overrides whether matching tests run on every PR.
+	AlwaysRun *bool `json:"always_run,omitempty"`
+}
+
+// DefaultResultCacheMaxAge is how long passing results are reused when the
+// maximum age is not configured.
+const DefaultResultCacheMaxAge = 24 * time.Hour
+
+// TestResultCache configures the reuse of passing results of a test.
+type TestResultCache struct {
+	// MaxAge is how long after it passed a result can be reused.
+	// Defaults to 24h.
+	MaxAge *prowv1.Duration `json:"max_age,omitempty"`
+}
+
+// MaxAgeOrDefault returns the configured maximum age or the default one.
+func (c TestResultCache) MaxAgeOrDefault() time.Duration {
+	if c.MaxAge == nil {
+		return DefaultResultCacheMaxAge
+	}
+	return c.MaxAge.Duration
+}
+
 // Cloud is the name of a cloud provider, e.g., aws cluster topology, etc.
 type Cloud string
 
@@ -966,6 +1071,8 @@ type RegistryReference struct {
 	LiteralTestStep `json:",inline"`
 	// Documentation describes what the step being referenced does.
 	Documentation string `json:"documentation,omitempty"`
+	// Deprecation marks the step as deprecated.
+	Deprecation *RegistryDeprecation `json:"deprecation,omitempty"`
 }
 
 // RegistryChainConfig is the struct that chain references are unmarshalled into.
@@ -986,6 +1093,8 @@ type RegistryChain struct {
 	Environment []StepParameter `json:"env,omitempty"`
 	// Leases lists resources that should be acquired for the test.
 	Leases []StepLease `json:"leases,omitempty"`
+	// Deprecation marks the chain as deprecated.
+	Deprecation *RegistryDeprecation `json:"deprecation,omitempty"`
 }
 
 // RegistryWorkflowConfig is the struct that workflow references are unmarshalled into.
@@ -1002,6 +1111,22 @@ type RegistryWorkflow struct {
 	Steps MultiStageTestConfiguration `json:"steps,omitempty"`
 	// Documentation describes what the workflow does.
 	Documentation string `json:"documentation,omitempty"`
+	// Deprecation marks the workflow as deprecated.
+	Deprecation *RegistryDeprecation `json:"deprecation,omitempty"`
+}
+
+// RegistryDeprecation describes why a registry component is deprecated and
+// when it is going to be removed.  Tests using deprecated components get
+// warnings until the removal date, after which they fail.
+type RegistryDeprecation struct {
+	// Reason explains why the component is deprecated.
+	Reason string `json:"reason"`
+	// Replacement is the name of the component of the same type to use
+	// instead, optional.
+	Replacement string `json:"replacement,omitempty"`
+	// RemovalDate is the date in the YYYY-MM-DD format from which tests using
+	// the component fail, optional.
+	RemovalDate string `json:"removal_date,omitempty"`
 }
 
 // RegistryObserverConfig is the struct that observer configs are unmarshalled into
@@ -1119,6 +1244,79 @@ type LiteralTestStep struct {
 	// NodeArchitecture is the architecture for the node where the test will run.
 	// If set, the generated test pod will include a nodeSelector for this architecture.
 	NodeArchitecture *NodeArchitecture `json:"node_architecture,omitempty"`
+	// ParallelGroup is the name of the group of steps this step is executed
+	// concurrently with. Consecutive steps in a phase which share the same
+	// group are started together and the phase only moves on when all of them
+	// have finished. This is only set when resolving `parallel` blocks and
+	// configurations setting it by hand are rejected.
+	ParallelGroup string `json:"parallel_group,omitempty"`
+	// Retry determines whether the step is executed again when it fails.
+	Retry *StepRetryPolicy `json:"retry,omitempty"`
+	// When lists conditions on parameters of the step which must all hold
+	// for it to be executed. Steps whose conditions do not hold are skipped
+	// without creating a pod.
+	When []StepCondition `json:"when,omitempty"`
+	// ServedArtifacts lists the served artifacts of the configuration which
+	// the step uses. The URL of each is exposed in the
+	// ARTIFACTS_URL_<NAME> environment variable.
+	ServedArtifacts []string `json:"served_artifacts,omitempty"`
+}
+
+// StepConditionOperator determines how the value of a parameter is compared
+// to the values of a condition.
+type StepConditionOperator string
+
+const (
+	// StepConditionOperatorIn holds when the value is one of the values.
+	StepConditionOperatorIn StepConditionOperator = "in"
+	// StepConditionOperatorNotIn holds when the value is none of the values.
+	StepConditionOperatorNotIn StepConditionOperator = "notin"
+)
+
+// StepCondition is a condition on the value of a parameter, evaluated
+// before the step is executed. Parameters which are not set have an empty
+// value.
+type StepCondition struct {
+	// Env is the name of the parameter, either declared by the step or set
+	// in the environment of the test.
+	Env string `json:"env"`
+	// Operator is either `in` or `notin`.
+	Operator StepConditionOperator `json:"operator"`
+	// Values are the values the parameter is compared to.
+	Values []string `json:"values"`
+}
+
+// Holds determines whether the condition holds for the value of the parameter.
+func (c StepCondition) Holds(value string) bool {
+	in := false
+	for _, v := range c.Values {
+		if v == value {
+			in = true
+			break
+		}
+	}
+	return in == (c.Operator == StepConditionOperatorIn)
+}
+
+func (c StepCondition) String() string {
+	return fmt.Sprintf("%s %s (%s)", c.Env, c.Operator, strings.Join(c.Values, ", "))
+}
+
+// StepRetryPolicy configures how a failed step is retried. A new pod is
+// created for every attempt. When neither exit codes nor reasons are set,
+// every failure is retried.
+type StepRetryPolicy struct {
+	// MaxAttempts is the number of times the step is executed at most,
+	// including the first attempt.
+	MaxAttempts int `json:"max_attempts"`
+	// Backoff is how long to wait between attempts.
+	Backoff *prowv1.Duration `json:"backoff,omitempty"`
+	// ExitCodes lists the exit codes of the test container which cause the
+	// step to be retried.
+	ExitCodes []int32 `json:"exit_codes,omitempty"`
+	// Reasons lists the failure reasons, such as `pod_pending`, which cause
+	// the step to be retried.
+	Reasons []string `json:"reasons,omitempty"`
 }
 
 // StepParameter is a variable set by the test, with an optional default.
@@ -1129,8 +1327,33 @@ type StepParameter struct {
 	Default *string `json:"default,omitempty"`
 	// Documentation is a textual description of the parameter.
 	Documentation string `json:"documentation,omitempty"`
+	// Type of the values of the parameter, optional. The default and the
+	// values set for typed parameters are validated, an empty value is always
+	// valid and leaves the parameter unset.
+	Type StepParameterType `json:"type,omitempty"`
+	// AllowedValues lists the values of an `enum` parameter.
+	AllowedValues []string `json:"allowed_values,omitempty"`
+	// Pattern is the regular expression the values of a `regex` parameter
+	// must match entirely.
+	Pattern string `json:"pattern,omitempty"`
 }
 
+// StepParameterType is the type of the values of a step parameter.
+type StepParameterType string
+
+const (
+	// StepParameterTypeBool parameters are either `true` or `false`.
+	StepParameterTypeBool StepParameterType = "bool"
+	// StepParameterTypeInt parameters are decimal integers.
+	StepParameterTypeInt StepParameterType = "int"
+	// StepParameterTypeEnum parameters are one of the allowed values.
+	StepParameterTypeEnum StepParameterType = "enum"
+	// StepParameterTypeRegex parameters match the pattern.
+	StepParameterTypeRegex StepParameterType = "regex"
+	// StepParameterTypeDuration parameters are durations like `1h30m`.
+	StepParameterTypeDuration StepParameterType = "duration"
+)
+
 // CredentialReference defines a secret to mount into a step and where to mount it.
 type CredentialReference struct {
 	// As is an optional string under which the secret will be stored on the file system.
@@ -1210,8 +1433,8 @@ func (s *LiteralTestStep) FromImageTag() (PipelineImageStreamTagReference, bool)
 }
 
 // TestStep is the struct that a user's configuration gets unmarshalled into.
-// It can contain either a LiteralTestStep, Reference, or Chain. If more than one is filled in an
-// the same time, config validation will fail.
+// It can contain either a LiteralTestStep, Reference, Chain, or Parallel group. If more than one is
+// filled in an the same time, config validation will fail.
 type TestStep struct {
 	// LiteralTestStep is a full test step definition.
 	*LiteralTestStep `json:",inline,omitempty"`
@@ -1219,6 +1442,46 @@ type TestStep struct {
 	Reference *string `json:"ref,omitempty"`
 	// Chain is the name of a step chain reference.
 	Chain *string `json:"chain,omitempty"`
+	// Parallel is a group of steps which are executed concurrently. Until
+	// the entrypoint-wrapper image supporting this is rolled out everywhere,
+	// the steps are executed sequentially unless ci-operator is run with
+	// --parallel-step-groups.
+	Parallel []ParallelTestStep `json:"parallel,omitempty"`
+	// When lists conditions which must all hold for the step, or for each
+	// step of the chain or parallel group, to be executed. They are added
+	// to the conditions of the steps when the test is resolved.
+	When []StepCondition `json:"when,omitempty"`
+}
+
+// ParallelTestStep is a member of a parallel group. It can contain either a
+// LiteralTestStep or a Reference; chains cannot be run concurrently.
+type ParallelTestStep struct {
+	// LiteralTestStep is a full test step definition.
+	*LiteralTestStep `json:",inline,omitempty"`
+	// Reference is the name of a step reference.
+	Reference *string `json:"ref,omitempty"`
+}
+
+// TestStep returns the member of the parallel group as a TestStep.
+func (s ParallelTestStep) TestStep() TestStep {
+	return TestStep{LiteralTestStep: s.LiteralTestStep, Reference: s.Reference}
+}
+
+// FlattenParallelSteps returns the list of steps with every parallel group
+// replaced by its members, for callers which only care about which steps are
+// executed and not about their concurrency.
+func FlattenParallelSteps(steps []TestStep) []TestStep {
+	var ret []TestStep
+	for _, step := range steps {
+		if step.Parallel != nil {
+			for _, s := range step.Parallel {
+				ret = append(ret, s.TestStep())
+			}
+		} else {
+			ret = append(ret, step)
+		}
+	}
+	return ret
 }
 
 // MultiStageTestConfiguration is a flexible configuration mode that allows tighter control over
@@ -1253,6 +1516,8 @@ type MultiStageTestConfiguration struct {
 	// they fail. The given step must explicitly ask for being ignored by setting
 	// the OptionalOnSuccess flag to true.
 	AllowBestEffortPostSteps *bool `json:"allow_best_effort_post_steps,omitempty"`
+	// Budgets limit how long each phase of the test can run.
+	Budgets *PhaseBudgets `json:"budgets,omitempty"`
 	// Observers are the observers that should be running
 	Observers *Observers `json:"observers,omitempty"`
 	// DependencyOverrides allows a step to override a dependency with a fully-qualified pullspec. This will probably only ever
@@ -1298,6 +1563,8 @@ type MultiStageTestConfigurationLiteral struct {
 	// they fail. The given step must explicitly ask for being ignored by setting
 	// the OptionalOnSuccess flag to true.
 	AllowBestEffortPostSteps *bool `json:"allow_best_effort_post_steps,omitempty"`
+	// Budgets limit how long each phase of the test can run.
+	Budgets *PhaseBudgets `json:"budgets,omitempty"`
 	// Observers are the observers that need to be run
 	Observers []Observer `json:"observers,omitempty"`
 	// DependencyOverrides allows a step to override a dependency with a fully-qualified pullspec. This will probably only ever
@@ -1313,6 +1580,19 @@ type MultiStageTestConfigurationLiteral struct {
 	Timeout *prowv1.Duration `json:"timeout,omitempty"`
 }
 
+// PhaseBudgets limit the time the phases of a multi-stage test can take. A
+// phase which runs out of time is interrupted and its steps are failed.
+type PhaseBudgets struct {
+	// Pre limits the time the `pre` steps can take.
+	Pre *prowv1.Duration `json:"pre,omitempty"`
+	// Test limits the time the `test` steps can take.
+	Test *prowv1.Duration `json:"test,omitempty"`
+	// Post limits the time the `post` steps can take. This time is reserved
+	// at the end of the job: `pre` and `test` steps are interrupted early
+	// enough for the `post` steps to tear down what they set up.
+	Post *prowv1.Duration `json:"post,omitempty"`
+}
+
 // TestEnvironment has the values of parameters for multi-stage tests.
 type TestEnvironment map[string]string
 
@@ -2853,10 +3133,45 @@ type ProjectDirectoryImageBuildStepConfiguration struct {
 	// Ref is an optional string linking to the extra_ref in "org.repo" format that this belongs to
 	Ref string `json:"ref,omitempty"`
 
+	// SBOM requests a software bill of materials for the image once it is
+	// built. The SBOM is stored as an artifact of the job, attached to the
+	// image and promoted with it.
+	SBOM *SBOMConfiguration `json:"sbom,omitempty"`
+
 	// isBundleImage indicates that this build step is a bundle image
 	isBundleImage bool
 }
 
+// SBOMConfiguration describes the software bill of materials generated for
+// an image.
+type SBOMConfiguration struct {
+	// Format is the format of the SBOM, spdx or cyclonedx. Defaults to spdx.
+	Format SBOMFormat `json:"format,omitempty"`
+}
+
+// SBOMFormat is the format of a software bill of materials
+type SBOMFormat string
+
+const (
+	SBOMFormatSPDX      SBOMFormat = "spdx"
+	SBOMFormatCycloneDX SBOMFormat = "cyclonedx"
+)
+
+// FormatOrDefault returns the format of the SBOM, defaulting to SPDX.
+func (c *SBOMConfiguration) FormatOrDefault() SBOMFormat {
+	if c.Format == "" {
+		return SBOMFormatSPDX
+	}
+	return c.Format
+}
+
+// SBOMTag is the tag under which the SBOM of the image with the given digest
+// is attached in the repository of the image, following the convention of
+// cosign.
+func SBOMTag(digest string) string {
+	return strings.Replace(digest, ":", "-", 1) + ".sbom"
+}
+
 func (config ProjectDirectoryImageBuildStepConfiguration) TargetName() string {
 	return string(config.To)
 }
@@ -2969,6 +3284,24 @@ func (config RPMServeStepConfiguration) TargetName() string {
 	return "[serve:rpms]"
 }
 
+// ServedArtifactsConfiguration describes a directory of an image which is
+// served over HTTP to the steps of the job.
+type ServedArtifactsConfiguration struct {
+	// Name identifies the artifacts. Test steps which list the name in
+	// their served_artifacts find the URL to the served directory in the
+	// ARTIFACTS_URL_<NAME> environment variable.
+	Name string `json:"name"`
+	// From is the image holding the artifacts. The image serves them itself,
+	// like the RPM server does, so it must provide bash and python 2 or 3.
+	From PipelineImageStreamTagReference `json:"from"`
+	// Path is the absolute path of the directory in the image which is served.
+	Path string `json:"path"`
+}
+
+func (config ServedArtifactsConfiguration) TargetName() string {
+	return fmt.Sprintf("[serve:artifacts-%s]", config.Name)
+}
+
 const (
 	// PipelineImageStream is the name of the
 	// ImageStream used to hold images built
@@ -882,6 +882,23 @@ func (in *LiteralTestStep) DeepCopyInto(out *LiteralTestStep) {
 		*out = new(NodeArchitecture)
 		**out = **in
 	}
+	if in.Retry != nil {
+		in, out := &in.Retry, &out.Retry
+		*out = new(StepRetryPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.When != nil {
+		in, out := &in.When, &out.When
+		*out = make([]StepCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ServedArtifacts != nil {
+		in, out := &in.ServedArtifacts, &out.ServedArtifacts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LiteralTestStep.
@@ -894,6 +911,87 @@ func (in *LiteralTestStep) DeepCopy() *LiteralTestStep {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MatrixDimension) DeepCopyInto(out *MatrixDimension) {
+	*out = *in
+	if in.Values != nil {
+		in, out := &in.Values, &out.Values
+		*out = make([]MatrixValue, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MatrixDimension.
+func (in *MatrixDimension) DeepCopy() *MatrixDimension {
+	if in == nil {
+		return nil
+	}
+	out := new(MatrixDimension)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MatrixOverride) DeepCopyInto(out *MatrixOverride) {
+	*out = *in
+	if in.Match != nil {
+		in, out := &in.Match, &out.Match
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make(TestEnvironment, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Optional != nil {
+		in, out := &in.Optional, &out.Optional
+		*out = new(bool)
+		**out = **in
+	}
+	if in.AlwaysRun != nil {
+		in, out := &in.AlwaysRun, &out.AlwaysRun
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MatrixOverride.
+func (in *MatrixOverride) DeepCopy() *MatrixOverride {
+	if in == nil {
+		return nil
+	}
+	out := new(MatrixOverride)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MatrixValue) DeepCopyInto(out *MatrixValue) {
+	*out = *in
+	if in.Value != nil {
+		in, out := &in.Value, &out.Value
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MatrixValue.
+func (in *MatrixValue) DeepCopy() *MatrixValue {
+	if in == nil {
+		return nil
+	}
+	out := new(MatrixValue)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MemoryBackedVolume) DeepCopyInto(out *MemoryBackedVolume) {
 	*out = *in
@@ -1003,6 +1101,11 @@ func (in *MultiStageTestConfiguration) DeepCopyInto(out *MultiStageTestConfigura
 		*out = new(bool)
 		**out = **in
 	}
+	if in.Budgets != nil {
+		in, out := &in.Budgets, &out.Budgets
+		*out = new(PhaseBudgets)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Observers != nil {
 		in, out := &in.Observers, &out.Observers
 		*out = new(Observers)
@@ -1097,6 +1200,11 @@ func (in *MultiStageTestConfigurationLiteral) DeepCopyInto(out *MultiStageTestCo
 		*out = new(bool)
 		**out = **in
 	}
+	if in.Budgets != nil {
+		in, out := &in.Budgets, &out.Budgets
+		*out = new(PhaseBudgets)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Observers != nil {
 		in, out := &in.Observers, &out.Observers
 		*out = make([]Observer, len(*in))
@@ -1458,6 +1566,31 @@ func (in *OutputImageTagStepConfiguration) DeepCopy() *OutputImageTagStepConfigu
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ParallelTestStep) DeepCopyInto(out *ParallelTestStep) {
+	*out = *in
+	if in.LiteralTestStep != nil {
+		in, out := &in.LiteralTestStep, &out.LiteralTestStep
+		*out = new(LiteralTestStep)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Reference != nil {
+		in, out := &in.Reference, &out.Reference
+		*out = new(string)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ParallelTestStep.
+func (in *ParallelTestStep) DeepCopy() *ParallelTestStep {
+	if in == nil {
+		return nil
+	}
+	out := new(ParallelTestStep)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ParsedVersion) DeepCopyInto(out *ParsedVersion) {
 	*out = *in
@@ -1473,6 +1606,36 @@ func (in *ParsedVersion) DeepCopy() *ParsedVersion {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PhaseBudgets) DeepCopyInto(out *PhaseBudgets) {
+	*out = *in
+	if in.Pre != nil {
+		in, out := &in.Pre, &out.Pre
+		*out = new(prowjobsv1.Duration)
+		**out = **in
+	}
+	if in.Test != nil {
+		in, out := &in.Test, &out.Test
+		*out = new(prowjobsv1.Duration)
+		**out = **in
+	}
+	if in.Post != nil {
+		in, out := &in.Post, &out.Post
+		*out = new(prowjobsv1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PhaseBudgets.
+func (in *PhaseBudgets) DeepCopy() *PhaseBudgets {
+	if in == nil {
+		return nil
+	}
+	out := new(PhaseBudgets)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PipelineImageCacheStepConfiguration) DeepCopyInto(out *PipelineImageCacheStepConfiguration) {
 	*out = *in
@@ -1546,6 +1709,11 @@ func (in *ProjectDirectoryImageBuildStepConfiguration) DeepCopyInto(out *Project
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.SBOM != nil {
+		in, out := &in.SBOM, &out.SBOM
+		*out = new(SBOMConfiguration)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectDirectoryImageBuildStepConfiguration.
@@ -1734,6 +1902,11 @@ func (in *RegistryChain) DeepCopyInto(out *RegistryChain) {
 		*out = make([]StepLease, len(*in))
 		copy(*out, *in)
 	}
+	if in.Deprecation != nil {
+		in, out := &in.Deprecation, &out.Deprecation
+		*out = new(RegistryDeprecation)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RegistryChain.
@@ -1762,6 +1935,21 @@ func (in *RegistryChainConfig) DeepCopy() *RegistryChainConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RegistryDeprecation) DeepCopyInto(out *RegistryDeprecation) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RegistryDeprecation.
+func (in *RegistryDeprecation) DeepCopy() *RegistryDeprecation {
+	if in == nil {
+		return nil
+	}
+	out := new(RegistryDeprecation)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RegistryObserver) DeepCopyInto(out *RegistryObserver) {
 	*out = *in
@@ -1798,6 +1986,11 @@ func (in *RegistryObserverConfig) DeepCopy() *RegistryObserverConfig {
 func (in *RegistryReference) DeepCopyInto(out *RegistryReference) {
 	*out = *in
 	in.LiteralTestStep.DeepCopyInto(&out.LiteralTestStep)
+	if in.Deprecation != nil {
+		in, out := &in.Deprecation, &out.Deprecation
+		*out = new(RegistryDeprecation)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RegistryReference.
@@ -1830,6 +2023,11 @@ func (in *RegistryReferenceConfig) DeepCopy() *RegistryReferenceConfig {
 func (in *RegistryWorkflow) DeepCopyInto(out *RegistryWorkflow) {
 	*out = *in
 	in.Steps.DeepCopyInto(&out.Steps)
+	if in.Deprecation != nil {
+		in, out := &in.Deprecation, &out.Deprecation
+		*out = new(RegistryDeprecation)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RegistryWorkflow.
@@ -1898,6 +2096,11 @@ func (in *ReleaseBuildConfiguration) DeepCopyInto(out *ReleaseBuildConfiguration
 		*out = make([]RefLocation, len(*in))
 		copy(*out, *in)
 	}
+	if in.ServedArtifacts != nil {
+		in, out := &in.ServedArtifacts, &out.ServedArtifacts
+		*out = make([]ServedArtifactsConfiguration, len(*in))
+		copy(*out, *in)
+	}
 	if in.CanonicalGoRepository != nil {
 		in, out := &in.CanonicalGoRepository, &out.CanonicalGoRepository
 		*out = new(string)
@@ -2080,6 +2283,21 @@ func (in *ResourceRequirements) DeepCopy() *ResourceRequirements {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SBOMConfiguration) DeepCopyInto(out *SBOMConfiguration) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SBOMConfiguration.
+func (in *SBOMConfiguration) DeepCopy() *SBOMConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(SBOMConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Secret) DeepCopyInto(out *Secret) {
 	*out = *in
@@ -2095,6 +2313,21 @@ func (in *Secret) DeepCopy() *Secret {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServedArtifactsConfiguration) DeepCopyInto(out *ServedArtifactsConfiguration) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServedArtifactsConfiguration.
+func (in *ServedArtifactsConfiguration) DeepCopy() *ServedArtifactsConfiguration {
+	if in == nil {
+		return nil
+	}
+	out := new(ServedArtifactsConfiguration)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SourceStepConfiguration) DeepCopyInto(out *SourceStepConfiguration) {
 	*out = *in
@@ -2110,6 +2343,26 @@ func (in *SourceStepConfiguration) DeepCopy() *SourceStepConfiguration {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StepCondition) DeepCopyInto(out *StepCondition) {
+	*out = *in
+	if in.Values != nil {
+		in, out := &in.Values, &out.Values
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StepCondition.
+func (in *StepCondition) DeepCopy() *StepCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(StepCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *StepConfiguration) DeepCopyInto(out *StepConfiguration) {
 	*out = *in
@@ -2153,6 +2406,11 @@ func (in *StepConfiguration) DeepCopyInto(out *StepConfiguration) {
 		*out = new(RPMServeStepConfiguration)
 		**out = **in
 	}
+	if in.ArtifactServeStepConfiguration != nil {
+		in, out := &in.ArtifactServeStepConfiguration, &out.ArtifactServeStepConfiguration
+		*out = new(ServedArtifactsConfiguration)
+		**out = **in
+	}
 	if in.OutputImageTagStepConfiguration != nil {
 		in, out := &in.OutputImageTagStepConfiguration, &out.OutputImageTagStepConfiguration
 		*out = new(OutputImageTagStepConfiguration)
@@ -2268,6 +2526,11 @@ func (in *StepParameter) DeepCopyInto(out *StepParameter) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.AllowedValues != nil {
+		in, out := &in.AllowedValues, &out.AllowedValues
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StepParameter.
@@ -2280,6 +2543,36 @@ func (in *StepParameter) DeepCopy() *StepParameter {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StepRetryPolicy) DeepCopyInto(out *StepRetryPolicy) {
+	*out = *in
+	if in.Backoff != nil {
+		in, out := &in.Backoff, &out.Backoff
+		*out = new(prowjobsv1.Duration)
+		**out = **in
+	}
+	if in.ExitCodes != nil {
+		in, out := &in.ExitCodes, &out.ExitCodes
+		*out = make([]int32, len(*in))
+		copy(*out, *in)
+	}
+	if in.Reasons != nil {
+		in, out := &in.Reasons, &out.Reasons
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StepRetryPolicy.
+func (in *StepRetryPolicy) DeepCopy() *StepRetryPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(StepRetryPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TargetSpec) DeepCopyInto(out *TargetSpec) {
 	*out = *in
@@ -2342,6 +2635,55 @@ func (in TestEnvironment) DeepCopy() TestEnvironment {
 	return *out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TestMatrix) DeepCopyInto(out *TestMatrix) {
+	*out = *in
+	if in.Dimensions != nil {
+		in, out := &in.Dimensions, &out.Dimensions
+		*out = make([]MatrixDimension, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Overrides != nil {
+		in, out := &in.Overrides, &out.Overrides
+		*out = make([]MatrixOverride, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TestMatrix.
+func (in *TestMatrix) DeepCopy() *TestMatrix {
+	if in == nil {
+		return nil
+	}
+	out := new(TestMatrix)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TestResultCache) DeepCopyInto(out *TestResultCache) {
+	*out = *in
+	if in.MaxAge != nil {
+		in, out := &in.MaxAge, &out.MaxAge
+		*out = new(prowjobsv1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TestResultCache.
+func (in *TestResultCache) DeepCopy() *TestResultCache {
+	if in == nil {
+		return nil
+	}
+	out := new(TestResultCache)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TestStep) DeepCopyInto(out *TestStep) {
 	*out = *in
@@ -2360,6 +2702,20 @@ func (in *TestStep) DeepCopyInto(out *TestStep) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.Parallel != nil {
+		in, out := &in.Parallel, &out.Parallel
+		*out = make([]ParallelTestStep, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.When != nil {
+		in, out := &in.When, &out.When
+		*out = make([]StepCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TestStep.
@@ -2441,6 +2797,16 @@ func (in *TestStepConfiguration) DeepCopyInto(out *TestStepConfiguration) {
 		*out = new(int)
 		**out = **in
 	}
+	if in.Matrix != nil {
+		in, out := &in.Matrix, &out.Matrix
+		*out = new(TestMatrix)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ResultCache != nil {
+		in, out := &in.ResultCache, &out.ResultCache
+		*out = new(TestResultCache)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.ContainerTestConfiguration != nil {
 		in, out := &in.ContainerTestConfiguration, &out.ContainerTestConfiguration
 		*out = new(ContainerTestConfiguration)
@@ -183,6 +183,9 @@ func printTreeSteps(o *options, steps []api.TestStep, level uint) {
 	for _, s := range steps {
 		if s.Chain != nil {
 			printTreeChain(o, *s.Chain, level)
+		} else if s.Parallel != nil {
+			printTreeLevel(level, "parallel:\n")
+			printTreeSteps(o, api.FlattenParallelSteps([]api.TestStep{s}), level+1)
 		} else if s.Reference != nil {
 			printTreeStep(*s.Reference, level)
 		} else if s.LiteralTestStep != nil {
@@ -0,0 +1,195 @@
+// Package criticalpath analyzes an executed ci-operator step graph to find the
+// chain of steps which determined the wall-clock duration of the execution
+// and the steps which spent most of their time blocked on their dependencies.
+package criticalpath
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/openshift/ci-tools/pkg/api"
+	"github.com/openshift/ci-tools/pkg/junit"
+)
+
+const (
+	// JSONFilename is the name of the artifact holding the serialized analysis.
+	JSONFilename = "ci-operator-critical-path.json"
+	// SummaryFilename is the name of the artifact holding the summary.
+	SummaryFilename = "ci-operator-critical-path.txt"
+)
+
+// Analysis describes the timing of an executed step graph.
+type Analysis struct {
+	// StartedAt is when the first step started.
+	StartedAt time.Time `json:"started_at"`
+	// FinishedAt is when the last step finished.
+	FinishedAt time.Time `json:"finished_at"`
+	// Duration is the wall-clock time spent executing the graph.
+	Duration time.Duration `json:"duration"`
+	// CriticalPath lists the names of the steps, in order of execution, which
+	// each had to finish before the next one could start and which together
+	// span the execution of the graph.
+	CriticalPath []string `json:"critical_path"`
+	// Steps holds the timing of every step which was executed, in the order
+	// they were started.
+	Steps []Step `json:"steps"`
+}
+
+// Step describes the timing of a single step.
+type Step struct {
+	Name       string    `json:"name"`
+	StartedAt  time.Time `json:"started_at"`
+	FinishedAt time.Time `json:"finished_at"`
+	// Waiting is the time between the moment the step could have started,
+	// when the last of its dependencies finished or the execution started if
+	// it has none, and its start.
+	Waiting time.Duration `json:"waiting"`
+	// Running is the time between the start and the end of the step.
+	Running time.Duration `json:"running"`
+	// MostlyWaiting is set when the step spent more time waiting for its
+	// dependencies than running.
+	MostlyWaiting bool `json:"mostly_waiting,omitempty"`
+	// Substeps holds the timing of the sub-steps, relative to the start of
+	// this step.
+	Substeps []Step `json:"substeps,omitempty"`
+}
+
+// Analyze computes the critical path of the graph. Steps which were not
+// executed or did not finish are ignored.
+func Analyze(graph api.CIOperatorStepGraph) *Analysis {
+	byName := map[string]api.CIOperatorStepDetails{}
+	for _, step := range graph {
+		if step.StartedAt != nil && step.FinishedAt != nil {
+			byName[step.StepName] = step
+		}
+	}
+	ret := &Analysis{}
+	if len(byName) == 0 {
+		return ret
+	}
+	var last string
+	for name, step := range byName {
+		if ret.StartedAt.IsZero() || step.StartedAt.Before(ret.StartedAt) {
+			ret.StartedAt = *step.StartedAt
+		}
+		if step.FinishedAt.After(ret.FinishedAt) || (step.FinishedAt.Equal(ret.FinishedAt) && name < last) {
+			ret.FinishedAt, last = *step.FinishedAt, name
+		}
+	}
+	ret.Duration = ret.FinishedAt.Sub(ret.StartedAt)
+	for _, step := range byName {
+		ready := ret.StartedAt
+		for _, dependency := range step.Dependencies {
+			if dep, ok := byName[dependency]; ok && dep.FinishedAt.After(ready) {
+				ready = *dep.FinishedAt
+			}
+		}
+		ret.Steps = append(ret.Steps, newStep(step.CIOperatorStepDetailInfo, ready, step.Substeps))
+	}
+	sort.Slice(ret.Steps, func(i, j int) bool {
+		if !ret.Steps[i].StartedAt.Equal(ret.Steps[j].StartedAt) {
+			return ret.Steps[i].StartedAt.Before(ret.Steps[j].StartedAt)
+		}
+		return ret.Steps[i].Name < ret.Steps[j].Name
+	})
+	// Walk back from the step which finished last, following the dependency
+	// which finished last: that is the one the step was waiting on.
+	for name := last; name != ""; {
+		ret.CriticalPath = append([]string{name}, ret.CriticalPath...)
+		var next string
+		var finishedAt time.Time
+		for _, dependency := range byName[name].Dependencies {
+			step, ok := byName[dependency]
+			if !ok {
+				continue
+			}
+			if step.FinishedAt.After(finishedAt) || (step.FinishedAt.Equal(finishedAt) && dependency < next) {
+				next, finishedAt = dependency, *step.FinishedAt
+			}
+		}
+		name = next
+	}
+	return ret
+}
+
+// newStep describes the timing of a step which could start at `ready`.
+func newStep(info api.CIOperatorStepDetailInfo, ready time.Time, substeps []api.CIOperatorStepDetailInfo) Step {
+	ret := Step{
+		Name:       info.StepName,
+		StartedAt:  *info.StartedAt,
+		FinishedAt: *info.FinishedAt,
+		Running:    info.FinishedAt.Sub(*info.StartedAt),
+	}
+	if info.StartedAt.After(ready) {
+		ret.Waiting = info.StartedAt.Sub(ready)
+	}
+	ret.MostlyWaiting = ret.Waiting > ret.Running
+	for _, sub := range substeps {
+		if sub.StartedAt != nil && sub.FinishedAt != nil {
+			ret.Substeps = append(ret.Substeps, newStep(sub, *info.StartedAt, nil))
+		}
+	}
+	sort.SliceStable(ret.Substeps, func(i, j int) bool {
+		return ret.Substeps[i].StartedAt.Before(ret.Substeps[j].StartedAt)
+	})
+	return ret
+}
+
+func (a *Analysis) step(name string) *Step {
+	for i := range a.Steps {
+		if a.Steps[i].Name == name {
+			return &a.Steps[i]
+		}
+	}
+	return nil
+}
+
+// Summary formats the analysis for humans.
+func (a *Analysis) Summary() string {
+	if len(a.CriticalPath) == 0 {
+		return "No steps were executed.\n"
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "The step graph was executed in %s.\n\n", a.Duration.Truncate(time.Second))
+	fmt.Fprintf(&b, "Critical path:\n")
+	for _, name := range a.CriticalPath {
+		step := a.step(name)
+		fmt.Fprintf(&b, "  %s: waited for %s, ran for %s\n", name, step.Waiting.Truncate(time.Second), step.Running.Truncate(time.Second))
+		for _, sub := range step.Substeps {
+			fmt.Fprintf(&b, "    %s: started after %s, ran for %s\n", sub.Name, sub.Waiting.Truncate(time.Second), sub.Running.Truncate(time.Second))
+		}
+	}
+	var waiting []string
+	for _, step := range a.Steps {
+		if step.MostlyWaiting {
+			waiting = append(waiting, fmt.Sprintf("  %s: waited for %s, ran for %s\n", step.Name, step.Waiting.Truncate(time.Second), step.Running.Truncate(time.Second)))
+		}
+	}
+	if len(waiting) != 0 {
+		fmt.Fprintf(&b, "\nSteps which spent most of their time waiting on dependencies:\n%s", strings.Join(waiting, ""))
+	}
+	return b.String()
+}
+
+// Properties formats the analysis as junit test suite properties.
+func (a *Analysis) Properties() []*junit.TestSuiteProperty {
+	if len(a.CriticalPath) == 0 {
+		return nil
+	}
+	var waiting []string
+	for _, step := range a.Steps {
+		if step.MostlyWaiting {
+			waiting = append(waiting, step.Name)
+		}
+	}
+	ret := []*junit.TestSuiteProperty{
+		{Name: "critical_path", Value: strings.Join(a.CriticalPath, ",")},
+		{Name: "critical_path_duration_seconds", Value: fmt.Sprintf("%.0f", a.Duration.Seconds())},
+	}
+	if len(waiting) != 0 {
+		ret = append(ret, &junit.TestSuiteProperty{Name: "steps_mostly_waiting", Value: strings.Join(waiting, ",")})
+	}
+	return ret
+}
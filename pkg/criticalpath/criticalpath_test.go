@@ -0,0 +1,68 @@
+package criticalpath
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/openshift/ci-tools/pkg/api"
+	"github.com/openshift/ci-tools/pkg/junit"
+	"github.com/openshift/ci-tools/pkg/testhelper"
+)
+
+func TestAnalyze(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	at := func(minutes int) *time.Time {
+		ret := start.Add(time.Duration(minutes) * time.Minute)
+		return &ret
+	}
+	step := func(name string, started, finished int, dependencies ...string) api.CIOperatorStepDetails {
+		return api.CIOperatorStepDetails{CIOperatorStepDetailInfo: api.CIOperatorStepDetailInfo{
+			StepName:     name,
+			Dependencies: dependencies,
+			StartedAt:    at(started),
+			FinishedAt:   at(finished),
+		}}
+	}
+	e2e := step("e2e", 30, 90, "src", "images")
+	e2e.Substeps = []api.CIOperatorStepDetailInfo{
+		{StepName: "e2e-test", StartedAt: at(40), FinishedAt: at(85)},
+		{StepName: "e2e-pre", StartedAt: at(31), FinishedAt: at(40)},
+	}
+	graph := api.CIOperatorStepGraph{
+		step("[input:root]", 0, 2),
+		step("src", 2, 10, "[input:root]"),
+		step("bin", 10, 20, "src"),
+		step("images", 20, 30, "bin"),
+		step("unit", 18, 20, "src"),
+		e2e,
+		{CIOperatorStepDetailInfo: api.CIOperatorStepDetailInfo{StepName: "skipped", Dependencies: []string{"e2e"}}},
+	}
+	analysis := Analyze(graph)
+	if diff := cmp.Diff([]string{"[input:root]", "src", "bin", "images", "e2e"}, analysis.CriticalPath); diff != "" {
+		t.Errorf("incorrect critical path: %s", diff)
+	}
+	if analysis.Duration != 90*time.Minute {
+		t.Errorf("expected a duration of 90m, got %s", analysis.Duration)
+	}
+	expectedProperties := []*junit.TestSuiteProperty{
+		{Name: "critical_path", Value: "[input:root],src,bin,images,e2e"},
+		{Name: "critical_path_duration_seconds", Value: "5400"},
+		{Name: "steps_mostly_waiting", Value: "unit"},
+	}
+	if diff := cmp.Diff(expectedProperties, analysis.Properties()); diff != "" {
+		t.Errorf("incorrect properties: %s", diff)
+	}
+	testhelper.CompareWithFixture(t, analysis.Summary())
+}
+
+func TestAnalyzeEmpty(t *testing.T) {
+	analysis := Analyze(api.CIOperatorStepGraph{{CIOperatorStepDetailInfo: api.CIOperatorStepDetailInfo{StepName: "step"}}})
+	if len(analysis.CriticalPath) != 0 || analysis.Properties() != nil {
+		t.Errorf("expected an empty analysis, got %+v", analysis)
+	}
+	if summary := analysis.Summary(); summary != "No steps were executed.\n" {
+		t.Errorf("unexpected summary: %q", summary)
+	}
+}
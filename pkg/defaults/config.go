@@ -21,6 +21,7 @@ import (
 	"github.com/openshift/ci-tools/pkg/steps"
 	"github.com/openshift/ci-tools/pkg/steps/loggingclient"
 	"github.com/openshift/ci-tools/pkg/steps/multi_stage"
+	releasesteps "github.com/openshift/ci-tools/pkg/steps/release"
 )
 
 type Config struct {
@@ -38,6 +39,7 @@ type Config struct {
 	CloneAuthConfig        *steps.CloneAuthConfig
 	PullSecret             *coreapi.Secret
 	PushSecret             *coreapi.Secret
+	PromotionSigning       *releasesteps.Signing
 	Censor                 *secrets.DynamicCensor
 	HiveKubeconfig         *rest.Config
 	NodeName               string
@@ -45,6 +47,9 @@ type Config struct {
 	TargetAdditionalSuffix string
 	ManifestToolDockerCfg  string
 	LocalRegistryDNS       string
+	BuildBackend           api.BuildBackend
+	BuildahImage           string
+	CosignImage            string
 	IntegratedStreams      map[string]*configresolver.IntegratedStream
 	InjectedTest           bool
 	GSMConfig              *multi_stage.GSMConfiguration
@@ -52,6 +57,9 @@ type Config struct {
 	SkippedImages          sets.Set[string]
 	params                 *api.DeferredParameters
 	ClusterProfileGetter   func(profileName string) (*api.ClusterProfileDetails, error)
+	ResultCacheNamespace   string
+	DebugHold              time.Duration
+	ParallelStepGroups     bool
 
 	HTTPServerAddr string
 	HTTPServerMux  *http.ServeMux
@@ -2,6 +2,7 @@ package defaults
 
 import (
 	"context"
+	"crypto/rand"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -30,8 +31,10 @@ import (
 
 	"github.com/openshift/ci-tools/pkg/api"
 	"github.com/openshift/ci-tools/pkg/dockerfile"
+	"github.com/openshift/ci-tools/pkg/dryrun"
 	"github.com/openshift/ci-tools/pkg/kubernetes"
 	"github.com/openshift/ci-tools/pkg/labeledclient"
+	"github.com/openshift/ci-tools/pkg/lease"
 	"github.com/openshift/ci-tools/pkg/release/official"
 	"github.com/openshift/ci-tools/pkg/results"
 	"github.com/openshift/ci-tools/pkg/steps"
@@ -98,6 +101,27 @@ func FromConfig(ctx context.Context, cfg *Config) ([]api.Step, []api.Step, error
 	return fromConfig(ctx, cfg)
 }
 
+// FromConfigDryRun generates the final execution graph like FromConfig, but
+// every client is replaced by an offline implementation from the dryrun
+// package so that the steps can be executed without a cluster.
+func FromConfigDryRun(ctx context.Context, cfg *Config) ([]api.Step, []api.Step, error) {
+	crclient := dryrun.NewClient()
+	client := loggingclient.New(labeledclient.WrapWithWatch(crclient, cfg.JobSpec), cfg.MetricsAgent)
+	if cfg.LeaseClient == nil {
+		leaseClient := lease.NewFakeClient("ci-operator", "", 0, nil, nil, nil)
+		cfg.LeaseClient = &leaseClient
+	}
+	cfg.kubeClient = client
+	cfg.buildClient = steps.NewBuildClient(client, dryrun.NewRESTClient(), cfg.NodeArchitectures, cfg.ManifestToolDockerCfg, cfg.LocalRegistryDNS, cfg.MetricsAgent)
+	cfg.templateClient = steps.NewTemplateClient(client, dryrun.NewRESTClient())
+	cfg.podClient = dryrun.NewPodClient(client, cfg.PodPendingTimeout)
+	cfg.hiveClient = crclient
+	cfg.httpClient = dryrun.HTTPClient{}
+	cfg.params = api.NewDeferredParameters(nil)
+
+	return fromConfig(ctx, cfg)
+}
+
 func fromConfig(ctx context.Context, cfg *Config) ([]api.Step, []api.Step, error) {
 	requiredNames := sets.New[string]()
 	for _, target := range cfg.RequiredTargets {
@@ -121,6 +145,7 @@ func fromConfig(ctx context.Context, cfg *Config) ([]api.Step, []api.Step, error
 	}
 	rawSteps = append(cfg.GraphConf.Steps, rawSteps...)
 	rawSteps = append(rawSteps, stepsForImageOverrides(utils.GetOverriddenImages())...)
+	releaseDiffer := releasesteps.NewReleaseDiffer(cfg.podClient, cfg.JobSpec, cfg.Censor)
 
 	for _, leaseProxyServerStep := range leaseProxyServerStep(cfg) {
 		buildSteps = append(buildSteps, leaseProxyServerStep)
@@ -186,7 +211,7 @@ func fromConfig(ctx context.Context, cfg *Config) ([]api.Step, []api.Step, error
 						Name:               resolveConfig.Integration.Name,
 						IncludeBuiltImages: resolveConfig.Integration.IncludeBuiltImages,
 						ReferencePolicy:    resolveConfig.Integration.ReferencePolicy,
-					}, cfg.CIConfig.Resources, cfg.podClient, cfg.JobSpec, cfg.PullSecret)
+					}, cfg.CIConfig.Resources, cfg.podClient, cfg.JobSpec, cfg.PullSecret, releaseDiffer)
 					for _, s := range []api.Step{snapshot, assemble} {
 						buildSteps = append(buildSteps, s)
 						addProvidesForStep(s, cfg.params)
@@ -227,13 +252,15 @@ func fromConfig(ctx context.Context, cfg *Config) ([]api.Step, []api.Step, error
 				logrus.Infof("Skipping image %s: tool is not affected by code changes", imgConfig.To)
 				continue
 			}
-			step = steps.ProjectDirectoryImageBuildStep(*imgConfig, cfg.CIConfig, cfg.CIConfig.Resources, cfg.buildClient, cfg.podClient, cfg.JobSpec, cfg.PullSecret, cfg.MetricsAgent)
+			step = steps.ProjectDirectoryImageBuildStep(*imgConfig, cfg.CIConfig, cfg.CIConfig.Resources, cfg.buildClient, cfg.podClient, cfg.JobSpec, cfg.PullSecret, cfg.MetricsAgent, buildBackendFor(cfg), cfg.BuildahImage, cfg.CosignImage)
 		} else if rawStep.ProjectDirectoryImageBuildInputs != nil {
 			step = steps.GitSourceStep(*rawStep.ProjectDirectoryImageBuildInputs, cfg.CIConfig.Resources, cfg.buildClient, cfg.podClient, cfg.JobSpec, cfg.CloneAuthConfig, cfg.PullSecret, cfg.MetricsAgent)
 		} else if rawStep.RPMImageInjectionStepConfiguration != nil {
 			step = steps.RPMImageInjectionStep(*rawStep.RPMImageInjectionStepConfiguration, cfg.CIConfig.Resources, cfg.buildClient, cfg.podClient, cfg.JobSpec, cfg.PullSecret, cfg.MetricsAgent)
 		} else if rawStep.RPMServeStepConfiguration != nil {
 			step = steps.RPMServerStep(*rawStep.RPMServeStepConfiguration, cfg.kubeClient, cfg.JobSpec)
+		} else if rawStep.ArtifactServeStepConfiguration != nil {
+			step = steps.ArtifactServerStep(*rawStep.ArtifactServeStepConfiguration, cfg.kubeClient, cfg.JobSpec)
 		} else if rawStep.OutputImageTagStepConfiguration != nil {
 			if cfg.SkippedImages.Has(string(rawStep.OutputImageTagStepConfiguration.From)) {
 				continue
@@ -276,7 +303,7 @@ func fromConfig(ctx context.Context, cfg *Config) ([]api.Step, []api.Step, error
 					releaseTagCfg := *rawStep.ReleaseImagesTagStepConfiguration
 					releaseTagCfg.ReferencePolicy = &referencePolicy
 					releaseTagCfg.IncludeBuiltImages = name == api.LatestReleaseName
-					releaseStep = releasesteps.AssembleReleaseStep(name, cfg.NodeName, &releaseTagCfg, cfg.CIConfig.Resources, cfg.podClient, cfg.JobSpec, cfg.PullSecret)
+					releaseStep = releasesteps.AssembleReleaseStep(name, cfg.NodeName, &releaseTagCfg, cfg.CIConfig.Resources, cfg.podClient, cfg.JobSpec, cfg.PullSecret, releaseDiffer)
 				}
 				overridableSteps = append(overridableSteps, releaseStep)
 				addProvidesForStep(releaseStep, cfg.params)
@@ -292,7 +319,7 @@ func fromConfig(ctx context.Context, cfg *Config) ([]api.Step, []api.Step, error
 	}
 
 	for _, template := range cfg.Templates {
-		step := steps.TemplateExecutionStep(template, cfg.params, cfg.podClient, cfg.templateClient, cfg.JobSpec, cfg.CIConfig.Resources)
+		step := steps.TemplateExecutionStep(template, cfg.params, cfg.podClient, cfg.templateClient, cfg.JobSpec, cfg.CIConfig.Resources, cfg.Censor)
 		var hasClusterType, hasUseLease bool
 		for _, p := range template.Parameters {
 			hasClusterType = hasClusterType || p.Name == "CLUSTER_TYPE"
@@ -344,18 +371,44 @@ func fromConfig(ctx context.Context, cfg *Config) ([]api.Step, []api.Step, error
 			return nil, nil, fmt.Errorf("cannot promote images, no promotion configuration defined")
 		}
 
-		promotionSteps = append(promotionSteps, releasesteps.PromotionStep(api.PromotionStepName, cfg.CIConfig, requiredNames, cfg.JobSpec, cfg.podClient, cfg.PushSecret, registryDomain(cfg.CIConfig.PromotionConfiguration), api.DefaultMirrorFunc, api.DefaultTargetNameFunc, cfg.NodeArchitectures))
+		promotionSteps = append(promotionSteps, releasesteps.PromotionStep(api.PromotionStepName, cfg.CIConfig, requiredNames, cfg.JobSpec, cfg.podClient, cfg.PushSecret, registryDomain(cfg.CIConfig.PromotionConfiguration), api.DefaultMirrorFunc, api.DefaultTargetNameFunc, cfg.NodeArchitectures, cfg.PromotionSigning))
 		// Used primarily (only?) by the ci-chat-bot
 		if cfg.CIConfig.PromotionConfiguration.RegistryOverride != "" {
 			logrus.Info("No images to promote to quay.io if the registry is overridden")
 		} else {
-			promotionSteps = append(promotionSteps, releasesteps.PromotionStep(api.PromotionQuayStepName, cfg.CIConfig, requiredNames, cfg.JobSpec, cfg.podClient, cfg.PushSecret, api.QuayOpenShiftCIRepo, api.QuayCombinedMirrorFunc, api.QuayTargetNameFunc, cfg.NodeArchitectures))
+			promotionSteps = append(promotionSteps, releasesteps.PromotionStep(api.PromotionQuayStepName, cfg.CIConfig, requiredNames, cfg.JobSpec, cfg.podClient, cfg.PushSecret, api.QuayOpenShiftCIRepo, api.QuayCombinedMirrorFunc, api.QuayTargetNameFunc, cfg.NodeArchitectures, cfg.PromotionSigning))
 		}
+	} else if previewsPromotion(cfg) {
+		promotionSteps = append(promotionSteps, releasesteps.PromotionPreviewStep(cfg.CIConfig, requiredNames, cfg.JobSpec, cfg.podClient, cfg.Censor))
 	}
 
 	return append(overridableSteps, buildSteps...), promotionSteps, nil
 }
 
+// previewsPromotion determines whether the job reports what promoting its
+// images would change, which presubmits building the images of a promoting
+// repository do so reviewers see the impact before merging.
+func previewsPromotion(cfg *Config) bool {
+	if cfg.CIConfig.PromotionConfiguration == nil || cfg.JobSpec == nil || cfg.JobSpec.Type != prowapi.PresubmitJob {
+		return false
+	}
+	for _, target := range cfg.RequiredTargets {
+		if target == "[images]" {
+			return true
+		}
+	}
+	return false
+}
+
+// buildBackendFor returns the backend which builds the images of the
+// configuration, which may override the default of the build cluster.
+func buildBackendFor(cfg *Config) api.BuildBackend {
+	if cfg.CIConfig.BuildBackend != "" {
+		return cfg.CIConfig.BuildBackend
+	}
+	return cfg.BuildBackend
+}
+
 func stepsForImageOverrides(overriddenImages map[string]string) []api.StepConfiguration {
 	var overrideSteps []api.StepConfiguration
 	for tag, value := range overriddenImages {
@@ -402,14 +455,21 @@ func stepForTest(cfg *Config, inputImages inputImageSet, c *api.TestStepConfigur
 		if len(leases) != 0 || ipPoolLease.ResourceType != "" {
 			params = api.NewDeferredParameters(params)
 		}
+		var debugHold *multi_stage.DebugHold
+		if cfg.DebugHold > 0 {
+			debugHold = &multi_stage.DebugHold{Duration: cfg.DebugHold, Censor: cfg.Censor}
+		}
 		var ret []api.Step
-		step := multi_stage.MultiStageTestStep(*c, cfg.CIConfig, params, cfg.podClient, cfg.JobSpec, leases, cfg.NodeName, cfg.TargetAdditionalSuffix, nil, cfg.GSMConfig != nil, cfg.GSMConfig, isLeaseProxyServerAvailable(cfg), retry.DefaultRetry)
+		step := multi_stage.MultiStageTestStep(*c, cfg.CIConfig, params, cfg.podClient, cfg.JobSpec, leases, cfg.NodeName, cfg.TargetAdditionalSuffix, nil, cfg.GSMConfig != nil, cfg.GSMConfig, isLeaseProxyServerAvailable(cfg), retry.DefaultRetry, debugHold, cfg.ParallelStepGroups)
 		if ipPoolLease.ResourceType != "" {
 			step = steps.IPPoolStep(cfg.LeaseClient, cfg.podClient, ipPoolLease, step, params, cfg.JobSpec.Namespace, cfg.MetricsAgent)
 		}
 		if len(leases) != 0 {
 			step = steps.LeaseStep(cfg.LeaseClient, leases, step, cfg.JobSpec.Namespace, cfg.MetricsAgent, cfg.kubeClient, cfg.ClusterProfileGetter)
 		}
+		if c.ResultCache != nil && cfg.ResultCacheNamespace != "" {
+			step = steps.ResultCacheStep(*c, cfg.CIConfig, step, cfg.kubeClient, cfg.ResultCacheNamespace, cfg.JobSpec)
+		}
 		if c.ClusterClaim != nil {
 			step = steps.ClusterClaimStep(c.As, c.ClusterClaim, cfg.hiveClient, cfg.kubeClient, cfg.JobSpec, step, cfg.Censor)
 			name := c.ClusterClaim.ClaimRelease(c.As).ReleaseName
@@ -428,7 +488,7 @@ func stepForTest(cfg *Config, inputImages inputImageSet, c *api.TestStepConfigur
 			return nil, nil
 		}
 		params := api.NewDeferredParameters(cfg.params)
-		step, err := clusterinstall.E2ETestStep(*c.OpenshiftInstallerClusterTestConfiguration, *c, params, cfg.podClient, cfg.templateClient, cfg.JobSpec, cfg.CIConfig.Resources)
+		step, err := clusterinstall.E2ETestStep(*c.OpenshiftInstallerClusterTestConfiguration, *c, params, cfg.podClient, cfg.templateClient, cfg.JobSpec, cfg.CIConfig.Resources, cfg.Censor)
 		if err != nil {
 			return nil, fmt.Errorf("unable to create end to end test step: %w", err)
 		}
@@ -441,6 +501,9 @@ func stepForTest(cfg *Config, inputImages inputImageSet, c *api.TestStepConfigur
 		return []api.Step{step}, nil
 	}
 	step := steps.TestStep(*c, cfg.CIConfig.Resources, cfg.podClient, cfg.JobSpec, cfg.NodeName)
+	if c.ResultCache != nil && cfg.ResultCacheNamespace != "" {
+		step = steps.ResultCacheStep(*c, cfg.CIConfig, step, cfg.kubeClient, cfg.ResultCacheNamespace, cfg.JobSpec)
+	}
 	if c.ClusterClaim != nil {
 		step = steps.ClusterClaimStep(c.As, c.ClusterClaim, cfg.hiveClient, cfg.kubeClient, cfg.JobSpec, step, cfg.Censor)
 	}
@@ -736,6 +799,10 @@ func FromConfigStatic(config *api.ReleaseBuildConfiguration) api.GraphConfigurat
 		}})
 	}
 
+	for i := range config.ServedArtifacts {
+		buildSteps = append(buildSteps, api.StepConfiguration{ArtifactServeStepConfiguration: &config.ServedArtifacts[i]})
+	}
+
 	for alias, baseImage := range config.BaseImages {
 		config := api.InputImageTagStepConfiguration{
 			InputImage: api.InputImage{
@@ -1253,6 +1320,11 @@ func leaseProxyServerStep(cfg *Config) []api.Step {
 	}
 
 	logger := logrus.NewEntry(logrus.StandardLogger()).WithField("step", "lease-proxy-server")
-	step := steps.LeaseProxyStep(logger, cfg.HTTPServerAddr, cfg.HTTPServerMux, cfg.LeaseClient)
+	// steps authenticate with the token, so the proxy only serves this job
+	token := rand.Text()
+	if cfg.Censor != nil {
+		cfg.Censor.AddSecrets(token)
+	}
+	step := steps.LeaseProxyStep(logger, cfg.HTTPServerAddr, token, cfg.HTTPServerMux, cfg.LeaseClient)
 	return append(ret, step)
 }
@@ -8,8 +8,12 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"os"
+	"path/filepath"
 	"sort"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
@@ -23,12 +27,14 @@ import (
 	prowapi "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
 	"sigs.k8s.io/prow/pkg/pod-utils/downwardapi"
 
+	buildapi "github.com/openshift/api/build/v1"
 	imageapi "github.com/openshift/api/image/v1"
 	templateapi "github.com/openshift/api/template/v1"
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
 
 	"github.com/openshift/ci-tools/pkg/api"
 	"github.com/openshift/ci-tools/pkg/api/configresolver"
+	"github.com/openshift/ci-tools/pkg/dryrun"
 	"github.com/openshift/ci-tools/pkg/kubernetes"
 	"github.com/openshift/ci-tools/pkg/lease"
 	"github.com/openshift/ci-tools/pkg/release"
@@ -43,6 +49,9 @@ func init() {
 	if err := imageapi.AddToScheme(scheme.Scheme); err != nil {
 		panic(fmt.Sprintf("failed to register imagev1 scheme: %v", err))
 	}
+	if err := buildapi.AddToScheme(scheme.Scheme); err != nil {
+		panic(fmt.Sprintf("failed to register buildv1 scheme: %v", err))
+	}
 }
 
 func addCloneRefs(cfg *api.SourceStepConfiguration) *api.SourceStepConfiguration {
@@ -1867,6 +1876,7 @@ func TestFromConfig(t *testing.T) {
 		expectedSteps:     []string{"[output-images]", "[images]", "lease-proxy-server"},
 		expectedParams: map[string]string{
 			"LEASE_PROXY_SERVER_URL": "http://10.0.0.1:8080",
+			"LEASE_PROXY_TOKEN":      "token",
 		},
 	}} {
 		t.Run(tc.name, func(t *testing.T) {
@@ -1886,6 +1896,7 @@ func TestFromConfig(t *testing.T) {
 				params.Add(k, func() (string, error) { return v, nil })
 			}
 			graphConf := FromConfigStatic(&tc.config)
+			censor := secrets.NewDynamicCensor()
 			cfg := &Config{
 				Clients: Clients{
 					LeaseClientEnabled: tc.enableLeaseClient,
@@ -1908,7 +1919,7 @@ func TestFromConfig(t *testing.T) {
 				PullSecret:             pullSecret,
 				PushSecret:             pushSecret,
 				params:                 params,
-				Censor:                 &secrets.DynamicCensor{},
+				Censor:                 &censor,
 				NodeName:               api.ServiceDomainAPPCI,
 				TargetAdditionalSuffix: "",
 				NodeArchitectures:      nil,
@@ -1935,6 +1946,15 @@ func TestFromConfig(t *testing.T) {
 			if err != nil {
 				t.Fatal(err)
 			}
+			if token, ok := paramMap[api.LeaseProxyTokenEnvVarName]; ok {
+				// the token is random, but must be censored
+				raw := []byte(token)
+				censor.Censor(&raw)
+				if token == "" || string(raw) == token {
+					t.Errorf("expected a censored lease proxy token, got %q", token)
+				}
+				paramMap[api.LeaseProxyTokenEnvVarName] = "token"
+			}
 			if tc.expectedParams == nil {
 				tc.expectedParams = map[string]string{}
 			}
@@ -2654,3 +2674,177 @@ func TestProcessDetectedBaseImages(t *testing.T) {
 		})
 	}
 }
+
+func TestBuildBackendFor(t *testing.T) {
+	testCases := []struct {
+		name     string
+		config   api.BuildBackend
+		cluster  api.BuildBackend
+		expected api.BuildBackend
+	}{
+		{
+			name: "nothing set uses the default",
+		},
+		{
+			name:     "cluster default is used",
+			cluster:  api.BuildBackendBuildah,
+			expected: api.BuildBackendBuildah,
+		},
+		{
+			name:     "configuration overrides the cluster default",
+			config:   api.BuildBackendOpenShift,
+			cluster:  api.BuildBackendBuildah,
+			expected: api.BuildBackendOpenShift,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := &Config{CIConfig: &api.ReleaseBuildConfiguration{BuildBackend: tc.config}, BuildBackend: tc.cluster}
+			if actual := buildBackendFor(cfg); actual != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestPreviewsPromotion(t *testing.T) {
+	promotion := &api.PromotionConfiguration{Targets: []api.PromotionTarget{{Namespace: "ocp", Name: "4.20"}}}
+	testCases := []struct {
+		name      string
+		promotion *api.PromotionConfiguration
+		jobType   prowapi.ProwJobType
+		targets   []string
+		expected  bool
+	}{
+		{
+			name:      "presubmit building images of a promoting repository",
+			promotion: promotion,
+			jobType:   prowapi.PresubmitJob,
+			targets:   []string{"[images]"},
+			expected:  true,
+		},
+		{
+			name:    "no promotion configured",
+			jobType: prowapi.PresubmitJob,
+			targets: []string{"[images]"},
+		},
+		{
+			name:      "postsubmit",
+			promotion: promotion,
+			jobType:   prowapi.PostsubmitJob,
+			targets:   []string{"[images]"},
+		},
+		{
+			name:      "presubmit not building images",
+			promotion: promotion,
+			jobType:   prowapi.PresubmitJob,
+			targets:   []string{"unit"},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := &Config{
+				CIConfig:        &api.ReleaseBuildConfiguration{PromotionConfiguration: tc.promotion},
+				JobSpec:         &api.JobSpec{JobSpec: downwardapi.JobSpec{Type: tc.jobType}},
+				RequiredTargets: tc.targets,
+			}
+			if actual := previewsPromotion(cfg); actual != tc.expected {
+				t.Errorf("expected %t, got %t", tc.expected, actual)
+			}
+		})
+	}
+}
+
+func TestFromConfigDryRun(t *testing.T) {
+	config := api.ReleaseBuildConfiguration{
+		Metadata: api.Metadata{Org: "org", Repo: "repo", Branch: "branch"},
+		InputConfiguration: api.InputConfiguration{
+			BuildRootImage: &api.BuildRootImageConfiguration{
+				ImageStreamTagReference: &api.ImageStreamTagReference{Namespace: "ci", Name: "root", Tag: "latest"},
+			},
+		},
+		Images: []api.ProjectDirectoryImageBuildStepConfiguration{{
+			To:                               "bin",
+			ProjectDirectoryImageBuildInputs: api.ProjectDirectoryImageBuildInputs{DockerfilePath: "Dockerfile"},
+		}},
+		Resources: api.ResourceConfiguration{"*": {Requests: api.ResourceList{"cpu": "100m"}}},
+		Tests: []api.TestStepConfiguration{{
+			As:                         "unit",
+			Commands:                   "make test",
+			ContainerTestConfiguration: &api.ContainerTestConfiguration{From: "src", Clone: ptr.To(false)},
+		}, {
+			As: "e2e",
+			MultiStageTestConfigurationLiteral: &api.MultiStageTestConfigurationLiteral{
+				Test: []api.LiteralTestStep{{
+					As:           "e2e",
+					From:         "src",
+					Commands:     "make e2e",
+					Resources:    api.ResourceRequirements{Requests: api.ResourceList{"cpu": "100m"}},
+					Dependencies: []api.StepDependency{{Name: "bin", Env: "BIN"}},
+				}, {
+					As:        "skipped",
+					From:      "src",
+					Commands:  "make skipped",
+					Resources: api.ResourceRequirements{Requests: api.ResourceList{"cpu": "100m"}},
+					When:      []api.StepCondition{{Env: "MODE", Operator: api.StepConditionOperatorIn, Values: []string{"other"}}},
+				}},
+				Environment: api.TestEnvironment{"MODE": "default"},
+			},
+		}},
+	}
+	jobSpec := api.JobSpec{
+		JobSpec: downwardapi.JobSpec{
+			Job:       "job",
+			BuildID:   "1",
+			ProwJobID: "prowjob",
+			Type:      prowapi.PresubmitJob,
+			Refs: &prowapi.Refs{
+				Org: "org", Repo: "repo", BaseRef: "branch", BaseSHA: "sha",
+				Pulls: []prowapi.Pull{{Number: 1, SHA: "pull-sha"}},
+			},
+			DecorationConfig: &prowapi.DecorationConfig{
+				Timeout:       &prowapi.Duration{Duration: time.Hour},
+				GracePeriod:   &prowapi.Duration{Duration: time.Minute},
+				UtilityImages: &prowapi.UtilityImages{Sidecar: "sidecar", Entrypoint: "entrypoint"},
+			},
+		},
+	}
+	graphConf := FromConfigStatic(&config)
+	cfg := &Config{
+		CIConfig:          &config,
+		GraphConf:         &graphConf,
+		JobSpec:           &jobSpec,
+		IntegratedStreams: map[string]*configresolver.IntegratedStream{},
+		HTTPServerMux:     &http.ServeMux{},
+	}
+	buildSteps, _, err := FromConfigDryRun(context.Background(), cfg)
+	if err != nil {
+		t.Fatalf("failed to generate steps: %v", err)
+	}
+	jobSpec.SetNamespace("ci-op-dry-run")
+	nodes, err := api.BuildPartialGraph(buildSteps, []string{"unit", "e2e", "[images]"})
+	if err != nil {
+		t.Fatalf("failed to build graph: %v", err)
+	}
+	stepList, errs := nodes.TopologicalSort()
+	if len(errs) != 0 {
+		t.Fatalf("failed to sort graph: %v", errs)
+	}
+	dir := t.TempDir()
+	if err := dryrun.Run(stepList, dir); err != nil {
+		t.Fatalf("failed to render objects: %v", err)
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("failed to list output: %v", err)
+	}
+	for _, entry := range entries {
+		data, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			t.Fatalf("failed to read output: %v", err)
+		}
+		t.Run(strings.TrimSuffix(entry.Name(), filepath.Ext(entry.Name())), func(t *testing.T) {
+			testhelper.CompareWithFixture(t, string(data))
+		})
+	}
+}
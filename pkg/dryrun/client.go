@@ -0,0 +1,67 @@
+// Package dryrun implements the clients and output used by ci-operator to
+// build a graph of steps without a cluster and render the objects each step
+// would create.
+package dryrun
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	coreapi "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	fakerest "k8s.io/client-go/rest/fake"
+	"k8s.io/client-go/tools/remotecommand"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	fakectrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/openshift/ci-tools/pkg/kubernetes"
+	"github.com/openshift/ci-tools/pkg/steps/loggingclient"
+)
+
+// ErrDryRun is returned by all operations which cannot be simulated offline,
+// such as executing commands in pods or reaching external services.
+var ErrDryRun = errors.New("not available in a dry run")
+
+// NewClient returns a client backed by an in-memory object tracker, against
+// which the graph is built.
+func NewClient() ctrlruntimeclient.WithWatch {
+	return fakectrlruntimeclient.NewClientBuilder().WithScheme(scheme.Scheme).Build()
+}
+
+// NewRESTClient returns a REST client which fails every request.
+func NewRESTClient() rest.Interface {
+	return &fakerest.RESTClient{Err: ErrDryRun, NegotiatedSerializer: scheme.Codecs}
+}
+
+// NewPodClient returns a pod client on top of a dry-run client.
+func NewPodClient(client loggingclient.LoggingClient, pendingTimeout time.Duration) kubernetes.PodClient {
+	return &podClient{LoggingClient: client, pendingTimeout: pendingTimeout}
+}
+
+type podClient struct {
+	loggingclient.LoggingClient
+	pendingTimeout time.Duration
+}
+
+func (c *podClient) GetPendingTimeout() time.Duration { return c.pendingTimeout }
+
+func (c *podClient) WithNewLoggingClient() kubernetes.PodClient {
+	return &podClient{LoggingClient: c.New(), pendingTimeout: c.pendingTimeout}
+}
+
+func (*podClient) Exec(string, string, *coreapi.PodExecOptions) (remotecommand.Executor, error) {
+	return nil, ErrDryRun
+}
+
+func (*podClient) GetLogs(namespace, name string, opts *coreapi.PodLogOptions) *rest.Request {
+	return NewRESTClient().Get().Namespace(namespace).Name(name).Resource("pods").SubResource("log").VersionedParams(opts, scheme.ParameterCodec)
+}
+
+// HTTPClient fails every request.
+type HTTPClient struct{}
+
+func (HTTPClient) Do(*http.Request) (*http.Response, error) {
+	return nil, ErrDryRun
+}
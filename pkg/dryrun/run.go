@@ -0,0 +1,111 @@
+package dryrun
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/yaml"
+
+	"github.com/openshift/ci-tools/pkg/api"
+)
+
+// NotRenderedFile is the file in the output directory listing the steps whose
+// objects cannot be rendered without running them.
+const NotRenderedFile = "not-rendered.txt"
+
+// Run renders the objects each step would create, without running any of
+// them, and writes them to a file named after the step in `dir`.  Steps which
+// cannot render their objects without running are listed with their
+// description in NotRenderedFile, so the output is not mistaken as complete.
+func Run(nodes api.OrderedStepList, dir string) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+	var skipped []string
+	for _, node := range nodes {
+		name := node.Step.Name()
+		renderer, ok := node.Step.(api.ObjectRenderer)
+		if !ok {
+			skipped = append(skipped, fmt.Sprintf("%s: %s\n", name, node.Step.Description()))
+			continue
+		}
+		logrus.Infof("Rendering objects for step %s", name)
+		objects, err := renderer.RenderObjects()
+		if err != nil {
+			return fmt.Errorf("failed to render objects for step %s: %w", name, err)
+		}
+		if len(objects) == 0 {
+			continue
+		}
+		data, err := Marshal(objects)
+		if err != nil {
+			return fmt.Errorf("failed to serialize objects for step %s: %w", name, err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, fileName(name)), data, 0644); err != nil {
+			return fmt.Errorf("failed to write objects for step %s: %w", name, err)
+		}
+	}
+	if len(skipped) != 0 {
+		logrus.Warnf("Objects of %d steps cannot be rendered without running them, see %s.", len(skipped), filepath.Join(dir, NotRenderedFile))
+		if err := os.WriteFile(filepath.Join(dir, NotRenderedFile), []byte(strings.Join(skipped, "")), 0644); err != nil {
+			return fmt.Errorf("failed to write the steps which were not rendered: %w", err)
+		}
+	}
+	return nil
+}
+
+var fileNameReplacer = strings.NewReplacer("[", "", "]", "", ":", "-", "/", "-")
+
+func fileName(step string) string {
+	return fileNameReplacer.Replace(step) + ".yaml"
+}
+
+// Marshal serializes the objects into a multi-document YAML stream. Objects are
+// sorted and stripped of their status and of all server-populated metadata so
+// the output is stable across runs.
+func Marshal(objects []ctrlruntimeclient.Object) ([]byte, error) {
+	var docs []map[string]interface{}
+	for _, obj := range objects {
+		gvk, err := apiutil.GVKForObject(obj, scheme.Scheme)
+		if err != nil {
+			return nil, err
+		}
+		u, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+		if err != nil {
+			return nil, err
+		}
+		delete(u, "status")
+		for _, field := range []string{"resourceVersion", "creationTimestamp", "uid", "generation", "managedFields"} {
+			unstructured.RemoveNestedField(u, "metadata", field)
+		}
+		u["apiVersion"], u["kind"] = gvk.GroupVersion().String(), gvk.Kind
+		docs = append(docs, u)
+	}
+	key := func(u map[string]interface{}) string {
+		obj := unstructured.Unstructured{Object: u}
+		return strings.Join([]string{obj.GetKind(), obj.GetNamespace(), obj.GetName()}, "/")
+	}
+	sort.Slice(docs, func(i, j int) bool { return key(docs[i]) < key(docs[j]) })
+	var ret []byte
+	for i, doc := range docs {
+		raw, err := yaml.Marshal(doc)
+		if err != nil {
+			return nil, err
+		}
+		if i != 0 {
+			ret = append(ret, []byte("---\n")...)
+		}
+		ret = append(ret, raw...)
+	}
+	return ret, nil
+}
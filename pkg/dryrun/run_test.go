@@ -0,0 +1,82 @@
+package dryrun
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	coreapi "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/openshift/ci-tools/pkg/api"
+	"github.com/openshift/ci-tools/pkg/testhelper"
+)
+
+type step struct {
+	name string
+}
+
+func (s *step) Inputs() (api.InputDefinition, error) { return nil, nil }
+func (*step) Validate() error                        { return nil }
+func (s *step) Name() string                         { return s.name }
+func (s *step) Description() string                  { return "Description of " + s.name }
+func (*step) Requires() []api.StepLink               { return nil }
+func (*step) Creates() []api.StepLink                { return nil }
+func (*step) Provides() api.ParameterMap             { return nil }
+func (*step) Objects() []ctrlruntimeclient.Object    { return nil }
+
+func (s *step) Run(context.Context) error {
+	return errors.New("steps must not be run in a dry run")
+}
+
+type renderingStep struct {
+	step
+}
+
+func (s *renderingStep) RenderObjects() ([]ctrlruntimeclient.Object, error) {
+	return []ctrlruntimeclient.Object{
+		&coreapi.Pod{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: s.name, ResourceVersion: "1"},
+			Spec:       coreapi.PodSpec{Containers: []coreapi.Container{{Name: "test", Image: "image"}}},
+			Status:     coreapi.PodStatus{Phase: coreapi.PodSucceeded},
+		},
+		&coreapi.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: s.name},
+			Data:       map[string]string{"key": "value"},
+		},
+	}, nil
+}
+
+func TestRun(t *testing.T) {
+	nodes := api.OrderedStepList{
+		{Step: &renderingStep{step: step{name: "[input:first]"}}},
+		{Step: &step{name: "not-rendered"}},
+		{Step: &renderingStep{step: step{name: "second"}}},
+	}
+	dir := t.TempDir()
+	if err := Run(nodes, dir); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("failed to list output: %v", err)
+	}
+	var files []string
+	for _, entry := range entries {
+		files = append(files, entry.Name())
+	}
+	testhelper.Diff(t, "files", files, []string{"input-first.yaml", NotRenderedFile, "second.yaml"})
+	for _, name := range []string{"input-first.yaml", NotRenderedFile, "second.yaml"} {
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			t.Fatalf("failed to read output: %v", err)
+		}
+		t.Run(strings.TrimSuffix(name, filepath.Ext(name)), func(t *testing.T) {
+			testhelper.CompareWithFixture(t, string(data))
+		})
+	}
+}
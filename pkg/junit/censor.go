@@ -3,6 +3,10 @@ package junit
 import "sigs.k8s.io/prow/pkg/secretutil"
 
 // CensorTestSuite censors secret data in user-provided fields of a jUnit test suite.
+// Test output often holds secrets in an encoded form, so callers should pass a
+// censor which also redacts those, like secrets.DynamicCensor, and censor the
+// suite before it is marshalled, as XML escaping would hide secrets from the
+// censoring of the artifact itself.
 func CensorTestSuite(censor secretutil.Censorer, testSuite *TestSuite) {
 	if testSuite == nil {
 		return
@@ -14,6 +18,7 @@ func CensorTestSuite(censor secretutil.Censorer, testSuite *TestSuite) {
 	}
 	for i := range testSuite.TestCases {
 		testSuite.TestCases[i].Name = censored(censor, testSuite.TestCases[i].Name)
+		testSuite.TestCases[i].Classname = censored(censor, testSuite.TestCases[i].Classname)
 		if testSuite.TestCases[i].SkipMessage != nil {
 			testSuite.TestCases[i].SkipMessage.Message = censored(censor, testSuite.TestCases[i].SkipMessage.Message)
 		}
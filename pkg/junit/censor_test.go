@@ -1,10 +1,12 @@
 package junit
 
 import (
+	"encoding/base64"
 	"testing"
 
 	"sigs.k8s.io/prow/pkg/secretutil"
 
+	"github.com/openshift/ci-tools/pkg/secrets"
 	"github.com/openshift/ci-tools/pkg/testhelper"
 )
 
@@ -106,3 +108,22 @@ func TestCensorTestSuite(t *testing.T) {
 	CensorTestSuite(censorer, &input)
 	testhelper.CompareWithFixture(t, input)
 }
+
+func TestCensorTestSuiteEncodedSecrets(t *testing.T) {
+	censor := secrets.NewDynamicCensor()
+	censor.AddSecrets("pa$$word\"with/quotes")
+	input := TestSuite{
+		Name: "suite",
+		TestCases: []*TestCase{{
+			Name:      "login",
+			Classname: "login pa$$word\"with/quotes",
+			FailureOutput: &FailureOutput{
+				Message: `request body: {"password":"pa$$word\"with/quotes"}`,
+				Output:  "GET /login?password=pa%24%24word%22with%2Fquotes\nAuthorization: Basic " + base64.StdEncoding.EncodeToString([]byte("user:pa$$word\"with/quotes")),
+			},
+			SystemOut: "password: pa$$word\"wi\nth/quotes",
+		}},
+	}
+	CensorTestSuite(&censor, &input)
+	testhelper.CompareWithFixture(t, input)
+}
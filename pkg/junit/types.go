@@ -96,6 +96,10 @@ type FailureOutput struct {
 	// Message holds the failure message from the test
 	Message string `xml:"message,attr"`
 
+	// Type holds the classification of the failure, describing who
+	// is responsible for it
+	Type string `xml:"type,attr,omitempty"`
+
 	// Output holds verbose failure output from the test
 	Output string `xml:",chardata"`
 }
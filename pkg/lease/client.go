@@ -2,8 +2,13 @@ package lease
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"math/rand"
+	"net/http"
+	"net/url"
+	"sort"
 	"strconv"
 	"sync"
 	"time"
@@ -18,6 +23,12 @@ import (
 const (
 	freeState   = "free"
 	leasedState = "leased"
+
+	// queuePositionInterval is how often the position of queued requests is
+	// reported while waiting for a lease
+	queuePositionInterval = time.Minute
+	// queuePositionTimeout bounds each lookup of the position of a request
+	queuePositionTimeout = 10 * time.Second
 )
 
 type boskosClient interface {
@@ -42,6 +53,13 @@ type Metrics struct {
 
 type clientOptions struct {
 	randID func() string
+	now    func() time.Time
+	// prioritized requests carry their priority and organization, so lease
+	// servers which support it can queue them accordingly
+	prioritized   bool
+	priority      Priority
+	org           string
+	queuePosition func(rtype, requestID string) (QueuePosition, error)
 }
 
 type ClientOptions func(*clientOptions)
@@ -50,6 +68,26 @@ func WithRandID(randID func() string) ClientOptions {
 	return func(o *clientOptions) { o.randID = randID }
 }
 
+// WithClock sets the function the client gets the current time from.
+func WithClock(now func() time.Time) ClientOptions {
+	return func(o *clientOptions) { o.now = now }
+}
+
+// WithPriority makes the requests for leases with a priority, on behalf of an
+// organization, so the lease server can serve them fairly.
+func WithPriority(priority Priority, org string) ClientOptions {
+	return func(o *clientOptions) {
+		o.prioritized = true
+		o.priority = priority
+		o.org = org
+	}
+}
+
+// WithQueuePosition sets how the position of queued requests is looked up.
+func WithQueuePosition(queuePosition func(rtype, requestID string) (QueuePosition, error)) ClientOptions {
+	return func(o *clientOptions) { o.queuePosition = queuePosition }
+}
+
 // Client manages resource leases, acquiring, releasing, and keeping them
 // updated.
 type Client interface {
@@ -72,6 +110,18 @@ type Client interface {
 	// Metrics queries the states of a particular resource, for informational
 	// purposes.
 	Metrics(rtype string) (Metrics, error)
+	// QueuePosition looks up the position of a request in the queue of the
+	// lease server, for informational purposes.
+	QueuePosition(rtype, requestID string) (QueuePosition, error)
+	// Leases returns the leases currently held, sorted by name.
+	Leases() []HeldLease
+}
+
+// HeldLease describes a lease held by the client.
+type HeldLease struct {
+	Name     string
+	Type     string
+	Acquired time.Time
 }
 
 // NewClient creates a client that leases resources with the specified owner.
@@ -81,14 +131,56 @@ func NewClient(owner, url, username string, passwordGetter func() []byte, retrie
 		return nil, err
 	}
 	c.DistinguishNotFoundVsTypeNotFound = true
+	opts = append([]ClientOptions{WithQueuePosition(queuePositionFromServer(url, username, passwordGetter))}, opts...)
 	return newClient(c, retries, acquireTimeout, opts...), nil
 }
 
+// errQueueNotExposed is returned when the lease server does not queue requests
+// by priority and does not expose its queue, which is the case of Boskos.
+var errQueueNotExposed = errors.New("the lease server does not expose its queue")
+
+// queuePositionFromServer looks up the position of requests in the queue of
+// the lease server. Boskos does not expose it and serves requests in the order
+// they were made regardless of their priority, so lookups fail there with
+// errQueueNotExposed.
+func queuePositionFromServer(server, username string, passwordGetter func() []byte) func(rtype, requestID string) (QueuePosition, error) {
+	client := &http.Client{Timeout: queuePositionTimeout}
+	return func(rtype, requestID string) (QueuePosition, error) {
+		var position QueuePosition
+		u, err := url.Parse(server)
+		if err != nil {
+			return position, err
+		}
+		u.Path = "/queue"
+		u.RawQuery = url.Values{"type": {rtype}, "request_id": {requestID}}.Encode()
+		req, err := http.NewRequest(http.MethodGet, u.String(), nil)
+		if err != nil {
+			return position, err
+		}
+		if username != "" && passwordGetter != nil {
+			req.SetBasicAuth(username, string(passwordGetter()))
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			return position, err
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode == http.StatusNotFound {
+			return position, errQueueNotExposed
+		}
+		if resp.StatusCode != http.StatusOK {
+			return position, fmt.Errorf("status %s looking up the queue position", resp.Status)
+		}
+		return position, json.NewDecoder(resp.Body).Decode(&position)
+	}
+}
+
 func newClient(boskos boskosClient, retries int, acquireTimeout time.Duration, opts ...ClientOptions) Client {
 	defOpts := &clientOptions{
 		randID: func() string {
 			return strconv.Itoa(rand.Int())
 		},
+		now: time.Now,
 	}
 
 	for _, f := range opts {
@@ -114,6 +206,8 @@ type client struct {
 }
 
 type lease struct {
+	rtype          string
+	acquired       time.Time
 	updateFailures int
 	// cancel holds a cancellation function for steps that depend on leases
 	// being active; we must cancel this when we encounter errors to tie the
@@ -129,18 +223,55 @@ func (c *client) Acquire(rtype string, n uint, ctx context.Context, cancel conte
 	var ret []string
 	// TODO `m` processes may fight for the last `m * n` remaining leases
 	for i := uint(0); i < n; i++ {
-		r, err := c.boskos.AcquireWaitWithPriority(ctx, rtype, freeState, leasedState, c.opts.randID())
+		requestID := c.opts.randID()
+		if c.opts.prioritized {
+			requestID = RequestID(c.opts.priority, c.opts.org, requestID)
+		}
+		stopReporting := c.reportQueuePosition(rtype, requestID)
+		r, err := c.boskos.AcquireWaitWithPriority(ctx, rtype, freeState, leasedState, requestID)
+		stopReporting()
 		if err != nil {
 			return nil, err
 		}
 		c.Lock()
-		c.leases[r.Name] = &lease{cancel: cancel}
+		c.leases[r.Name] = &lease{rtype: rtype, acquired: c.opts.now(), cancel: cancel}
 		c.Unlock()
 		ret = append(ret, r.Name)
 	}
 	return ret, nil
 }
 
+// reportQueuePosition periodically logs the position of a prioritized request
+// in the queue until the returned function is called.
+func (c *client) reportQueuePosition(rtype, requestID string) func() {
+	if !c.opts.prioritized {
+		return func() {}
+	}
+	stop := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(queuePositionInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+				position, err := c.QueuePosition(rtype, requestID)
+				if errors.Is(err, errQueueNotExposed) {
+					logrus.Infof("The lease server does not queue requests by priority, the request for a %s lease is served in the order it was made.", rtype)
+					return
+				}
+				if err != nil {
+					logrus.WithError(err).Debugf("Could not look up the queue position of the request for a %s lease.", rtype)
+					continue
+				}
+				logrus.Infof("Waiting for a %s lease with %s priority: %d of %d queued requests are ahead.", rtype, c.opts.priority, position.Position, position.Length)
+			}
+		}
+	}()
+	return func() { close(stop) }
+}
+
 func (c *client) AcquireIfAvailableImmediately(rtype string, n uint, cancel context.CancelFunc) ([]string, error) {
 	var ret []string
 	for i := uint(0); i < n; i++ {
@@ -149,7 +280,7 @@ func (c *client) AcquireIfAvailableImmediately(rtype string, n uint, cancel cont
 			return nil, err
 		}
 		c.Lock()
-		c.leases[r.Name] = &lease{cancel: cancel}
+		c.leases[r.Name] = &lease{rtype: rtype, acquired: c.opts.now(), cancel: cancel}
 		c.Unlock()
 		ret = append(ret, r.Name)
 	}
@@ -214,3 +345,21 @@ func (c *client) Metrics(rtype string) (Metrics, error) {
 		Leased: metrics.Current[leasedState],
 	}, nil
 }
+
+func (c *client) QueuePosition(rtype, requestID string) (QueuePosition, error) {
+	if c.opts.queuePosition == nil {
+		return QueuePosition{}, errQueueNotExposed
+	}
+	return c.opts.queuePosition(rtype, requestID)
+}
+
+func (c *client) Leases() []HeldLease {
+	c.RLock()
+	defer c.RUnlock()
+	ret := make([]HeldLease, 0, len(c.leases))
+	for name, l := range c.leases {
+		ret = append(ret, HeldLease{Name: name, Type: l.rtype, Acquired: l.acquired})
+	}
+	sort.Slice(ret, func(i, j int) bool { return ret[i].Name < ret[j].Name })
+	return ret
+}
@@ -3,10 +3,18 @@ package lease
 import (
 	"context"
 	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"reflect"
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
+
 	"k8s.io/apimachinery/pkg/util/diff"
+	"sigs.k8s.io/boskos/common"
+
+	"github.com/openshift/ci-tools/pkg/testhelper"
 )
 
 func TestAcquire(t *testing.T) {
@@ -131,3 +139,64 @@ func TestHeartbeatRetries(t *testing.T) {
 		})
 	}
 }
+
+func TestAcquireWithPriority(t *testing.T) {
+	var calls []string
+	fake := &fakeClient{owner: "owner", calls: &calls, resources: map[string]*common.Resource{}}
+	client := newClient(fake, 0, 0, WithRandID(func() string { return "random" }), WithPriority(PriorityPostsubmit, "openshift"))
+	if _, err := client.Acquire("rtype", 1, context.Background(), nil); err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{"acquireWaitWithPriority owner rtype free leased 3_openshift_random"}
+	if !reflect.DeepEqual(calls, expected) {
+		t.Fatalf("wrong calls to the boskos client: %v", diff.ObjectDiff(calls, expected))
+	}
+}
+
+func TestQueuePositionFromServer(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		handler     http.HandlerFunc
+		expected    QueuePosition
+		expectedErr error
+	}{
+		{
+			name: "position",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				fmt.Fprint(w, `{"position":1,"length":3}`)
+			},
+			expected: QueuePosition{Position: 1, Length: 3},
+		},
+		{
+			name:        "Boskos does not expose its queue",
+			handler:     http.NotFound,
+			expectedErr: errQueueNotExposed,
+		},
+		{
+			name: "Boskos behind a proxy does not expose its queue",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				http.Error(w, "<html>Not Found</html>", http.StatusNotFound)
+			},
+			expectedErr: errQueueNotExposed,
+		},
+		{
+			name: "request is not queued",
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				http.Error(w, "request not queued: id", http.StatusConflict)
+			},
+			expectedErr: errors.New("status 409 Conflict looking up the queue position"),
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			server := httptest.NewServer(tc.handler)
+			defer server.Close()
+			position, err := queuePositionFromServer(server.URL, "", nil)("rtype", "id")
+			if diff := cmp.Diff(tc.expectedErr, err, testhelper.EquateErrorMessage); diff != "" {
+				t.Errorf("unexpected error: %s", diff)
+			}
+			if diff := cmp.Diff(tc.expected, position); diff != "" {
+				t.Errorf("unexpected position: %s", diff)
+			}
+		})
+	}
+}
@@ -10,6 +10,9 @@ import (
 	"sigs.k8s.io/boskos/common"
 )
 
+// fakeNow is the time leases of the fake client are acquired at.
+var fakeNow = time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
 type fakeClient struct {
 	owner     string
 	failures  map[string]error
@@ -29,7 +32,7 @@ func NewFakeClient(owner, url string, retries int, failures map[string]error, ca
 		failures:  failures,
 		calls:     calls,
 		resources: resources,
-	}, retries, time.Duration(0), WithRandID(func() string { return "random" }))
+	}, retries, time.Duration(0), WithRandID(func() string { return "random" }), WithClock(func() time.Time { return fakeNow }))
 }
 
 func (c *fakeClient) addCall(call string, args ...string) error {
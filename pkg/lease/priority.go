@@ -0,0 +1,110 @@
+package lease
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	prowapi "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+	"sigs.k8s.io/prow/pkg/kube"
+)
+
+// Priority orders the requests for leases queued by the lease server: under
+// contention, requests of a higher priority are served first.
+type Priority int
+
+const (
+	PriorityRehearsal Priority = iota
+	PriorityPresubmit
+	PriorityPeriodic
+	PriorityPostsubmit
+	PriorityReleaseBlocking
+)
+
+const (
+	// RehearsalLabel marks rehearsals of jobs, mirroring rehearse.Label.
+	RehearsalLabel = "ci.openshift.io/rehearse"
+	// JobReleaseLabel marks jobs providing the signal for an OCP release,
+	// mirroring jobconfig.JobReleaseKey, which prowgen sets from
+	// ocplifecycle.ProvidesSignalForVersion.
+	JobReleaseLabel = "job-release"
+)
+
+var priorityNames = map[Priority]string{
+	PriorityRehearsal:       "rehearsal",
+	PriorityPresubmit:       "presubmit",
+	PriorityPeriodic:        "periodic",
+	PriorityPostsubmit:      "postsubmit",
+	PriorityReleaseBlocking: "release-blocking",
+}
+
+func (p Priority) String() string {
+	if name, ok := priorityNames[p]; ok {
+		return name
+	}
+	return strconv.Itoa(int(p))
+}
+
+// ParsePriority parses the name of a priority.
+func ParsePriority(name string) (Priority, error) {
+	for priority, priorityName := range priorityNames {
+		if priorityName == name {
+			return priority, nil
+		}
+	}
+	return 0, fmt.Errorf("unknown lease priority %q", name)
+}
+
+// PriorityForLabels derives the priority of the lease requests of a job from
+// its labels: release-blocking periodics come first, then postsubmits, other
+// periodics, presubmits and finally rehearsals.
+func PriorityForLabels(labels map[string]string) Priority {
+	if _, ok := labels[RehearsalLabel]; ok {
+		return PriorityRehearsal
+	}
+	switch prowapi.ProwJobType(labels[kube.ProwJobTypeLabel]) {
+	case prowapi.PeriodicJob:
+		if labels[JobReleaseLabel] != "" {
+			return PriorityReleaseBlocking
+		}
+		return PriorityPeriodic
+	case prowapi.PostsubmitJob:
+		return PriorityPostsubmit
+	default:
+		return PriorityPresubmit
+	}
+}
+
+// requestIDSeparator separates the fields of request IDs. It cannot appear in
+// GitHub organization names.
+const requestIDSeparator = "_"
+
+// RequestID builds the ID of a request for a lease which carries its priority
+// and the organization it is made for.  Boskos treats request IDs as opaque
+// and serves the requests with the same ID in FIFO order, lease servers that
+// support priorities decode them with ParseRequestID.
+func RequestID(priority Priority, org, id string) string {
+	return strings.Join([]string{strconv.Itoa(int(priority)), org, id}, requestIDSeparator)
+}
+
+// ParseRequestID decodes the priority and the organization of a request ID
+// built by RequestID.
+func ParseRequestID(requestID string) (Priority, string, bool) {
+	parts := strings.SplitN(requestID, requestIDSeparator, 3)
+	if len(parts) != 3 {
+		return 0, "", false
+	}
+	priority, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, "", false
+	}
+	return Priority(priority), parts[1], true
+}
+
+// QueuePosition is the position of a request in the queue for a resource type.
+type QueuePosition struct {
+	// Position is zero-based; the request is served once as many resources
+	// are free as there are requests before it
+	Position int `json:"position"`
+	Length   int `json:"length"`
+}
@@ -0,0 +1,62 @@
+package lease
+
+import (
+	"testing"
+
+	"sigs.k8s.io/prow/pkg/kube"
+
+	"github.com/openshift/ci-tools/pkg/testhelper"
+)
+
+func TestPriorityForLabels(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		labels   map[string]string
+		expected Priority
+	}{
+		{
+			name:     "no labels",
+			expected: PriorityPresubmit,
+		},
+		{
+			name:     "presubmit",
+			labels:   map[string]string{kube.ProwJobTypeLabel: "presubmit"},
+			expected: PriorityPresubmit,
+		},
+		{
+			name:     "rehearsal",
+			labels:   map[string]string{kube.ProwJobTypeLabel: "presubmit", RehearsalLabel: "1234"},
+			expected: PriorityRehearsal,
+		},
+		{
+			name:     "postsubmit",
+			labels:   map[string]string{kube.ProwJobTypeLabel: "postsubmit"},
+			expected: PriorityPostsubmit,
+		},
+		{
+			name:     "periodic",
+			labels:   map[string]string{kube.ProwJobTypeLabel: "periodic"},
+			expected: PriorityPeriodic,
+		},
+		{
+			name:     "release-blocking periodic",
+			labels:   map[string]string{kube.ProwJobTypeLabel: "periodic", JobReleaseLabel: "4.18"},
+			expected: PriorityReleaseBlocking,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			testhelper.Diff(t, "priority", PriorityForLabels(tc.labels), tc.expected)
+		})
+	}
+}
+
+func TestRequestID(t *testing.T) {
+	id := RequestID(PriorityReleaseBlocking, "openshift", "a_b")
+	priority, org, ok := ParseRequestID(id)
+	if !ok || priority != PriorityReleaseBlocking || org != "openshift" {
+		t.Errorf("failed to parse %q: %v, %q, %t", id, priority, org, ok)
+	}
+	if _, _, ok := ParseRequestID("2b1f4d7e-uuid"); ok {
+		t.Error("expected a request ID without a priority not to be parsed")
+	}
+}
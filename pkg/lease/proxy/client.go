@@ -0,0 +1,103 @@
+package proxy
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Client uses the lease proxy on behalf of the steps of a job.
+type Client struct {
+	server string
+	token  string
+	client *http.Client
+}
+
+// NewClient creates a client for the lease proxy at the URL, authenticating
+// with the token of the job.
+func NewClient(server, token string) *Client {
+	return &Client{server: strings.TrimSuffix(server, "/"), token: token, client: &http.Client{}}
+}
+
+// Acquire leases resources of a type.  Leases acquired with a TTL are
+// released once it passes unless they are extended.
+func (c *Client) Acquire(rtype string, count uint, ttl time.Duration) ([]string, error) {
+	query := url.Values{"type": {rtype}, "count": {strconv.FormatUint(uint64(count), 10)}}
+	if ttl != 0 {
+		query.Set("ttl", ttl.String())
+	}
+	var names leaseNames
+	if err := c.do(http.MethodPost, acquireEndpoint, query, nil, &names); err != nil {
+		return nil, err
+	}
+	return names.Names, nil
+}
+
+// Release ends leases of the job before it finishes.
+func (c *Client) Release(names ...string) error {
+	body, err := json.Marshal(leaseNames{Names: names})
+	if err != nil {
+		return fmt.Errorf("marshal request body: %w", err)
+	}
+	return c.do(http.MethodPost, releaseEndpoint, nil, body, nil)
+}
+
+// List returns the leases held by the job.
+func (c *Client) List() ([]Lease, error) {
+	var list leaseList
+	if err := c.do(http.MethodGet, listEndpoint, nil, nil, &list); err != nil {
+		return nil, err
+	}
+	return list.Leases, nil
+}
+
+// Status returns a lease held by the job.
+func (c *Client) Status(name string) (Lease, error) {
+	var ret Lease
+	return ret, c.do(http.MethodGet, statusEndpoint, url.Values{"name": {name}}, nil, &ret)
+}
+
+// Extend moves the expiration of a lease acquired with a TTL to the TTL from now.
+func (c *Client) Extend(name string, ttl time.Duration) (Lease, error) {
+	var ret Lease
+	return ret, c.do(http.MethodPost, extendEndpoint, url.Values{"name": {name}, "ttl": {ttl.String()}}, nil, &ret)
+}
+
+func (c *Client) do(method, endpoint string, query url.Values, body []byte, response interface{}) error {
+	u := c.server + endpoint
+	if len(query) != 0 {
+		u += "?" + query.Encode()
+	}
+	req, err := http.NewRequest(method, u, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("create request: %w", err)
+	}
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("%s %s: %w", method, endpoint, err)
+	}
+	defer resp.Body.Close()
+	raw, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("read response body: %w", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("%s %s: %s: %s", method, endpoint, resp.Status, strings.TrimSpace(string(raw)))
+	}
+	if response == nil {
+		return nil
+	}
+	if err := json.Unmarshal(raw, response); err != nil {
+		return fmt.Errorf("unmarshal response body: %w", err)
+	}
+	return nil
+}
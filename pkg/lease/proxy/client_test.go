@@ -0,0 +1,66 @@
+package proxy
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sirupsen/logrus"
+
+	"github.com/openshift/ci-tools/pkg/lease"
+	"github.com/openshift/ci-tools/pkg/testhelper"
+)
+
+func TestClient(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	leaseClient := lease.NewFakeClient("owner", "", 1, nil, nil, nil)
+	srvMux := &http.ServeMux{}
+	New(logrus.NewEntry(&logrus.Logger{}), func() lease.Client { return leaseClient }, WithToken("token"), WithClock(func() time.Time { return now })).RegisterHandlers(srvMux)
+	server := httptest.NewServer(srvMux)
+	defer server.Close()
+	client := NewClient(server.URL+"/", "token")
+
+	names, err := client.Acquire("aws-1", 2, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testhelper.Diff(t, "names", names, []string{"aws-1_0", "aws-1_1"})
+	names, err = client.Acquire("gcp", 1, time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testhelper.Diff(t, "names", names, []string{"gcp_2"})
+
+	if err := client.Release("aws-1_0"); err != nil {
+		t.Fatal(err)
+	}
+	leases, err := client.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	acquired := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	expires := now.Add(time.Hour)
+	testhelper.Diff(t, "leases", leases, []Lease{
+		{Name: "aws-1_1", Type: "aws-1", Acquired: acquired},
+		{Name: "gcp_2", Type: "gcp", Acquired: acquired, Expires: &expires, Remaining: "1h0m0s"},
+	})
+
+	now = now.Add(30 * time.Minute)
+	status, err := client.Extend("gcp_2", time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expires = now.Add(time.Hour)
+	testhelper.Diff(t, "status", status, Lease{Name: "gcp_2", Type: "gcp", Acquired: acquired, Expires: &expires, Remaining: "1h0m0s"})
+
+	_, err = client.Status("aws-1_0")
+	if diff := cmp.Diff(errors.New("GET /lease/status: 404 Not Found: Lease aws-1_0 is not held by this job"), err, testhelper.EquateErrorMessage); diff != "" {
+		t.Errorf("unexpected error:\n%s", diff)
+	}
+	if _, err := NewClient(server.URL, "wrong").List(); err == nil {
+		t.Error("expected a client with a wrong token to fail")
+	}
+}
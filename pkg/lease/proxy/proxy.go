@@ -1,6 +1,7 @@
 package proxy
 
 import (
+	"crypto/subtle"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -8,6 +9,8 @@ import (
 	"net/http"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/sirupsen/logrus"
 
@@ -17,17 +20,48 @@ import (
 const (
 	acquireEndpoint = "/lease/acquire"
 	releaseEndpoint = "/lease/release"
+	listEndpoint    = "/lease/list"
+	statusEndpoint  = "/lease/status"
+	extendEndpoint  = "/lease/extend"
 )
 
 type acquireParams struct {
 	rtype string
 	count uint
+	ttl   time.Duration
 }
 
 type leaseNames struct {
 	Names []string `json:"names"`
 }
 
+// Lease describes a lease held by the job.  Leases acquired with a TTL expire
+// unless they are extended, the others are held until the job ends.
+type Lease struct {
+	Name     string     `json:"name"`
+	Type     string     `json:"type"`
+	Acquired time.Time  `json:"acquired"`
+	Expires  *time.Time `json:"expires,omitempty"`
+	// Remaining is the time left until the lease expires
+	Remaining string `json:"remaining,omitempty"`
+}
+
+type leaseList struct {
+	Leases []Lease `json:"leases"`
+}
+
+func parseTTL(r *http.Request) (time.Duration, error) {
+	raw := r.URL.Query().Get("ttl")
+	if raw == "" {
+		return 0, nil
+	}
+	ttl, err := time.ParseDuration(raw)
+	if err != nil || ttl <= 0 {
+		return 0, fmt.Errorf("parameter \"ttl\" is not valid: %s", raw)
+	}
+	return ttl, nil
+}
+
 func parseAcquireParams(r *http.Request) (acquireParams, error) {
 	params := acquireParams{}
 
@@ -48,6 +82,12 @@ func parseAcquireParams(r *http.Request) (acquireParams, error) {
 		params.count = uint(c)
 	}
 
+	ttl, err := parseTTL(r)
+	if err != nil {
+		return params, err
+	}
+	params.ttl = ttl
+
 	return params, nil
 }
 
@@ -70,17 +110,74 @@ type NewLeaseClientFunc func() lease.Client
 // Proxy is a proxy that forwards requests to the real lease server.
 // This serve as mean for the multistage steps to deals with leases.
 // It is supposed to be a quite dumb server, the actual work is delegated
-// to the lease client.
+// to the lease client.  Only the leases held by the lease client, that is
+// those of the job, are visible through the proxy.
 type Proxy struct {
 	logger          *logrus.Entry
 	leaseClientFunc NewLeaseClientFunc
+	token           string
+	now             func() time.Time
+
+	// expiries holds the deadlines of the leases acquired with a TTL
+	expiriesLock sync.Mutex
+	expiries     map[string]*expiry
 }
 
-func (p *Proxy) acquire(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodPost {
-		p.logger.Warnf("Lease acquire method %s is not allowed", r.Method)
-		msg := fmt.Sprintf("Method %v not allowed, POST requests only.", r.Method)
+type expiry struct {
+	deadline time.Time
+	timer    *time.Timer
+}
+
+type Option func(*Proxy)
+
+// WithToken requires all requests to carry the token as a bearer token, so
+// only the steps of the job can use the proxy.
+func WithToken(token string) Option {
+	return func(p *Proxy) { p.token = token }
+}
+
+// WithClock sets the function the proxy gets the current time from.
+func WithClock(now func() time.Time) Option {
+	return func(p *Proxy) { p.now = now }
+}
+
+// authorized checks the token of a request, writing an error if it is wrong.
+func (p *Proxy) authorized(w http.ResponseWriter, r *http.Request) bool {
+	if p.token == "" {
+		return true
+	}
+	token, found := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if found && subtle.ConstantTimeCompare([]byte(token), []byte(p.token)) == 1 {
+		return true
+	}
+	p.logger.Warnf("Unauthorized request to %s", r.URL.Path)
+	http.Error(w, "Unauthorized.", http.StatusUnauthorized)
+	return false
+}
+
+// allowed checks the method and the token of a request, writing an error if
+// either is wrong.
+func (p *Proxy) allowed(w http.ResponseWriter, r *http.Request, method string) bool {
+	if r.Method != method {
+		p.logger.Warnf("Lease %s method %s is not allowed", strings.TrimPrefix(r.URL.Path, "/lease/"), r.Method)
+		msg := fmt.Sprintf("Method %v not allowed, %s requests only.", r.Method, method)
 		http.Error(w, msg, http.StatusMethodNotAllowed)
+		return false
+	}
+	return p.authorized(w, r)
+}
+
+func (p *Proxy) client(w http.ResponseWriter) lease.Client {
+	c := p.leaseClientFunc()
+	if c == nil {
+		p.logger.Error("Failed to get lease client")
+		http.Error(w, "Failed to get lease client", http.StatusInternalServerError)
+	}
+	return c
+}
+
+func (p *Proxy) acquire(w http.ResponseWriter, r *http.Request) {
+	if !p.allowed(w, r, http.MethodPost) {
 		return
 	}
 
@@ -96,10 +193,8 @@ func (p *Proxy) acquire(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	c := p.leaseClientFunc()
+	c := p.client(w)
 	if c == nil {
-		p.logger.Error("Failed to get lease client")
-		http.Error(w, "Failed to get lease client", http.StatusInternalServerError)
 		return
 	}
 
@@ -115,12 +210,21 @@ func (p *Proxy) acquire(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, msg, code)
 		return
 	}
+	if params.ttl != 0 {
+		for _, name := range names {
+			p.expireAfter(name, params.ttl)
+		}
+	}
 
 	p.writeAcquireResponse(w, names)
 }
 
 func (p *Proxy) writeAcquireResponse(w http.ResponseWriter, names []string) {
-	namesBytes, err := json.Marshal(leaseNames{Names: names})
+	p.writeResponse(w, leaseNames{Names: names})
+}
+
+func (p *Proxy) writeResponse(w http.ResponseWriter, response interface{}) {
+	responseBytes, err := json.Marshal(response)
 	if err != nil {
 		p.logger.WithError(err).Warnf("Failed to marshal the response %s", err)
 		msg := fmt.Sprintf("Failed to marshal the response %s", err.Error())
@@ -129,7 +233,7 @@ func (p *Proxy) writeAcquireResponse(w http.ResponseWriter, names []string) {
 	}
 
 	w.Header().Set("Content-Type", "application/json")
-	if _, err := w.Write(namesBytes); err != nil {
+	if _, err := w.Write(responseBytes); err != nil {
 		p.logger.WithError(err).Warn("Failed to write leases response")
 		http.Error(w, "Failed to write response", http.StatusInternalServerError)
 		return
@@ -137,10 +241,7 @@ func (p *Proxy) writeAcquireResponse(w http.ResponseWriter, names []string) {
 }
 
 func (p *Proxy) release(w http.ResponseWriter, r *http.Request) {
-	if r.Method != http.MethodPost {
-		p.logger.Warnf("Lease release method %s is not allowed", r.Method)
-		msg := fmt.Sprintf("Method %v not allowed, POST requests only.", r.Method)
-		http.Error(w, msg, http.StatusMethodNotAllowed)
+	if !p.allowed(w, r, http.MethodPost) {
 		return
 	}
 
@@ -151,15 +252,21 @@ func (p *Proxy) release(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	c := p.leaseClientFunc()
+	c := p.client(w)
 	if c == nil {
-		p.logger.Error("Failed to get lease client")
-		http.Error(w, "Failed to get lease client", http.StatusInternalServerError)
 		return
 	}
 
 	released := make([]string, 0)
 	for _, name := range releaseReq.Names {
+		if _, held := p.held(c, name); !held {
+			leasesReleased := strings.Join(released, " ")
+			p.logger.WithField("Released", leasesReleased).Warnf("Lease %q is not held by this job", name)
+			msg := fmt.Sprintf("Lease %s is not held by this job\nReleased: %s", name, leasesReleased)
+			http.Error(w, msg, http.StatusNotFound)
+			return
+		}
+		p.clearExpiry(name)
 		if err := c.Release(name); err != nil {
 			leasesReleased := strings.Join(released, " ")
 			p.logger.WithError(err).WithField("Released", leasesReleased).Warnf("Failed to release lease %q", name)
@@ -173,15 +280,171 @@ func (p *Proxy) release(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNoContent)
 }
 
+func (p *Proxy) list(w http.ResponseWriter, r *http.Request) {
+	if !p.allowed(w, r, http.MethodGet) {
+		return
+	}
+	c := p.client(w)
+	if c == nil {
+		return
+	}
+	leases := make([]Lease, 0)
+	for _, held := range c.Leases() {
+		leases = append(leases, p.describe(held))
+	}
+	p.writeResponse(w, leaseList{Leases: leases})
+}
+
+func (p *Proxy) status(w http.ResponseWriter, r *http.Request) {
+	if !p.allowed(w, r, http.MethodGet) {
+		return
+	}
+	held, ok := p.heldFromParams(w, r)
+	if !ok {
+		return
+	}
+	p.writeResponse(w, p.describe(held))
+}
+
+func (p *Proxy) extend(w http.ResponseWriter, r *http.Request) {
+	if !p.allowed(w, r, http.MethodPost) {
+		return
+	}
+	ttl, err := parseTTL(r)
+	if err == nil && ttl == 0 {
+		err = errors.New("ttl is required")
+	}
+	if err != nil {
+		p.logger.WithError(err).Warn("Failed to parse lease extend params")
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	held, ok := p.heldFromParams(w, r)
+	if !ok {
+		return
+	}
+	if !p.extendExpiry(held.Name, ttl) {
+		http.Error(w, fmt.Sprintf("Lease %s was acquired without a TTL and is held until the job ends", held.Name), http.StatusConflict)
+		return
+	}
+	p.writeResponse(w, p.describe(held))
+}
+
+// heldFromParams looks up the lease named in the request among those of the
+// job, writing an error if it is not one of them.
+func (p *Proxy) heldFromParams(w http.ResponseWriter, r *http.Request) (lease.HeldLease, bool) {
+	name := r.URL.Query().Get("name")
+	if name == "" {
+		http.Error(w, "name is required", http.StatusBadRequest)
+		return lease.HeldLease{}, false
+	}
+	c := p.client(w)
+	if c == nil {
+		return lease.HeldLease{}, false
+	}
+	held, ok := p.held(c, name)
+	if !ok {
+		http.Error(w, fmt.Sprintf("Lease %s is not held by this job", name), http.StatusNotFound)
+	}
+	return held, ok
+}
+
+func (p *Proxy) held(c lease.Client, name string) (lease.HeldLease, bool) {
+	for _, held := range c.Leases() {
+		if held.Name == name {
+			return held, true
+		}
+	}
+	return lease.HeldLease{}, false
+}
+
+func (p *Proxy) describe(held lease.HeldLease) Lease {
+	ret := Lease{Name: held.Name, Type: held.Type, Acquired: held.Acquired}
+	p.expiriesLock.Lock()
+	defer p.expiriesLock.Unlock()
+	if e, ok := p.expiries[held.Name]; ok {
+		deadline := e.deadline
+		ret.Expires = &deadline
+		ret.Remaining = deadline.Sub(p.now()).Round(time.Second).String()
+	}
+	return ret
+}
+
+// expireAfter releases a lease once the TTL passed unless it is extended.
+func (p *Proxy) expireAfter(name string, ttl time.Duration) {
+	p.expiriesLock.Lock()
+	defer p.expiriesLock.Unlock()
+	p.expiries[name] = &expiry{
+		deadline: p.now().Add(ttl),
+		timer:    time.AfterFunc(ttl, func() { p.expire(name) }),
+	}
+}
+
+// extendExpiry moves the deadline of a lease acquired with a TTL, returning
+// false for leases without one.
+func (p *Proxy) extendExpiry(name string, ttl time.Duration) bool {
+	p.expiriesLock.Lock()
+	defer p.expiriesLock.Unlock()
+	e, ok := p.expiries[name]
+	if !ok {
+		return false
+	}
+	e.deadline = p.now().Add(ttl)
+	e.timer.Reset(ttl)
+	return true
+}
+
+func (p *Proxy) clearExpiry(name string) {
+	p.expiriesLock.Lock()
+	defer p.expiriesLock.Unlock()
+	if e, ok := p.expiries[name]; ok {
+		e.timer.Stop()
+		delete(p.expiries, name)
+	}
+}
+
+// expire releases a lease whose deadline passed.
+func (p *Proxy) expire(name string) {
+	p.expiriesLock.Lock()
+	e, ok := p.expiries[name]
+	if !ok || p.now().Before(e.deadline) {
+		p.expiriesLock.Unlock()
+		return
+	}
+	delete(p.expiries, name)
+	p.expiriesLock.Unlock()
+
+	c := p.leaseClientFunc()
+	if c == nil {
+		return
+	}
+	if _, held := p.held(c, name); !held {
+		return
+	}
+	p.logger.Infof("Lease %q was not extended within its TTL, releasing it", name)
+	if err := c.Release(name); err != nil {
+		p.logger.WithError(err).Warnf("Failed to release expired lease %q", name)
+	}
+}
+
 // RegisterHandlers adds to the multiplexer the HTTP endpoints served by this proxy.
 func (p *Proxy) RegisterHandlers(srvMux *http.ServeMux) {
 	srvMux.Handle(acquireEndpoint, http.HandlerFunc(p.acquire))
 	srvMux.Handle(releaseEndpoint, http.HandlerFunc(p.release))
+	srvMux.Handle(listEndpoint, http.HandlerFunc(p.list))
+	srvMux.Handle(statusEndpoint, http.HandlerFunc(p.status))
+	srvMux.Handle(extendEndpoint, http.HandlerFunc(p.extend))
 }
 
-func New(logger *logrus.Entry, leaseClientFunc NewLeaseClientFunc) *Proxy {
-	return &Proxy{
+func New(logger *logrus.Entry, leaseClientFunc NewLeaseClientFunc, opts ...Option) *Proxy {
+	p := &Proxy{
 		logger:          logger,
 		leaseClientFunc: leaseClientFunc,
+		now:             time.Now,
+		expiries:        map[string]*expiry{},
+	}
+	for _, opt := range opts {
+		opt(p)
 	}
+	return p
 }
@@ -7,10 +7,13 @@ import (
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/sirupsen/logrus"
 
+	"sigs.k8s.io/boskos/common"
+
 	"github.com/openshift/ci-tools/pkg/lease"
 )
 
@@ -20,6 +23,9 @@ func TestProxy(t *testing.T) {
 	for _, tc := range []struct {
 		name                    string
 		leaseClientFailingCalls map[string]error
+		held                    []string
+		token                   string
+		noToken                 bool
 		method                  string
 		url                     string
 		body                    string
@@ -81,6 +87,7 @@ func TestProxy(t *testing.T) {
 		},
 		{
 			name:      "Release: release 1 lease",
+			held:      []string{"foo"},
 			method:    http.MethodPost,
 			url:       "/lease/release",
 			body:      `{"names":["foo"]}`,
@@ -92,6 +99,7 @@ func TestProxy(t *testing.T) {
 			leaseClientFailingCalls: map[string]error{
 				"releaseone owner bar free": errors.New("injected"),
 			},
+			held:      []string{"foo", "bar"},
 			method:    http.MethodPost,
 			url:       "/lease/release",
 			body:      `{"names":["foo", "bar"]}`,
@@ -99,22 +107,165 @@ func TestProxy(t *testing.T) {
 			wantCalls: []string{"releaseone owner foo free", "releaseone owner bar free"},
 			wantBody:  "Failed to release lease bar: injected\nReleased: foo\n",
 		},
+		{
+			name:      "Release: leases of other jobs are not released",
+			held:      []string{"foo"},
+			method:    http.MethodPost,
+			url:       "/lease/release",
+			body:      `{"names":["foo", "bar"]}`,
+			wantCode:  http.StatusNotFound,
+			wantCalls: []string{"releaseone owner foo free"},
+			wantBody:  "Lease bar is not held by this job\nReleased: foo\n",
+		},
+		{
+			name:      "Unauthorized: missing token",
+			noToken:   true,
+			method:    http.MethodGet,
+			url:       "/lease/list",
+			wantCode:  http.StatusUnauthorized,
+			wantBody:  "Unauthorized.\n",
+			wantCalls: []string{},
+		},
+		{
+			name:      "Unauthorized: wrong token",
+			token:     "wrong",
+			held:      []string{"foo"},
+			method:    http.MethodPost,
+			url:       "/lease/extend?name=foo&ttl=1h",
+			wantCode:  http.StatusUnauthorized,
+			wantBody:  "Unauthorized.\n",
+			wantCalls: []string{},
+		},
+		{
+			name:      "Unauthorized: acquire without a token",
+			noToken:   true,
+			method:    http.MethodPost,
+			url:       "/lease/acquire?type=aws-1",
+			wantCode:  http.StatusUnauthorized,
+			wantBody:  "Unauthorized.\n",
+			wantCalls: []string{},
+		},
+		{
+			name:      "Unauthorized: release without a token",
+			noToken:   true,
+			held:      []string{"foo"},
+			method:    http.MethodPost,
+			url:       "/lease/release",
+			body:      `{"names":["foo"]}`,
+			wantCode:  http.StatusUnauthorized,
+			wantBody:  "Unauthorized.\n",
+			wantCalls: []string{},
+		},
+		{
+			name:      "List: no leases",
+			method:    http.MethodGet,
+			url:       "/lease/list",
+			wantCode:  http.StatusOK,
+			wantBody:  `{"leases":[]}`,
+			wantCalls: []string{},
+		},
+		{
+			name:      "List: leases of the job",
+			held:      []string{"foo", "bar"},
+			method:    http.MethodGet,
+			url:       "/lease/list",
+			wantCode:  http.StatusOK,
+			wantBody:  `{"leases":[{"name":"bar","type":"bar","acquired":"2026-01-01T00:00:00Z"},{"name":"foo","type":"foo","acquired":"2026-01-01T00:00:00Z"}]}`,
+			wantCalls: []string{},
+		},
+		{
+			name:      "List: wrong http method",
+			method:    http.MethodPost,
+			url:       "/lease/list",
+			wantCode:  http.StatusMethodNotAllowed,
+			wantBody:  "Method POST not allowed, GET requests only.\n",
+			wantCalls: []string{},
+		},
+		{
+			name:      "Status: lease of the job",
+			held:      []string{"foo"},
+			method:    http.MethodGet,
+			url:       "/lease/status?name=foo",
+			wantCode:  http.StatusOK,
+			wantBody:  `{"name":"foo","type":"foo","acquired":"2026-01-01T00:00:00Z"}`,
+			wantCalls: []string{},
+		},
+		{
+			name:      "Status: lease of another job",
+			held:      []string{"foo"},
+			method:    http.MethodGet,
+			url:       "/lease/status?name=bar",
+			wantCode:  http.StatusNotFound,
+			wantBody:  "Lease bar is not held by this job\n",
+			wantCalls: []string{},
+		},
+		{
+			name:      "Status: name is required",
+			method:    http.MethodGet,
+			url:       "/lease/status",
+			wantCode:  http.StatusBadRequest,
+			wantBody:  "name is required\n",
+			wantCalls: []string{},
+		},
+		{
+			name:      "Extend: lease without a TTL",
+			held:      []string{"foo"},
+			method:    http.MethodPost,
+			url:       "/lease/extend?name=foo&ttl=10m",
+			wantCode:  http.StatusConflict,
+			wantBody:  "Lease foo was acquired without a TTL and is held until the job ends\n",
+			wantCalls: []string{},
+		},
+		{
+			name:      "Extend: ttl is required",
+			held:      []string{"foo"},
+			method:    http.MethodPost,
+			url:       "/lease/extend?name=foo",
+			wantCode:  http.StatusBadRequest,
+			wantBody:  "ttl is required\n",
+			wantCalls: []string{},
+		},
+		{
+			name:      "Acquire: invalid ttl",
+			method:    http.MethodPost,
+			url:       "/lease/acquire?type=aws-1&ttl=-1m",
+			wantCode:  http.StatusBadRequest,
+			wantBody:  "parameter \"ttl\" is not valid: -1m\n",
+			wantCalls: []string{},
+		},
 	} {
 		t.Run(tc.name, func(t *testing.T) {
 			t.Parallel()
 
 			logger := logrus.NewEntry(&logrus.Logger{})
 			gotCalls := make([]string, 0)
-			leaseClient := lease.NewFakeClient("owner", "", 1, tc.leaseClientFailingCalls, &gotCalls, nil)
+			resources := map[string]*common.Resource{}
+			for _, name := range tc.held {
+				resources["acquire_"+name+"_free_leased"] = &common.Resource{Name: name}
+			}
+			leaseClient := lease.NewFakeClient("owner", "", 1, tc.leaseClientFailingCalls, &gotCalls, resources)
+			for _, name := range tc.held {
+				if _, err := leaseClient.AcquireIfAvailableImmediately(name, 1, func() {}); err != nil {
+					t.Fatal(err)
+				}
+			}
+			gotCalls = gotCalls[:0]
 
 			srvMux := &http.ServeMux{}
-			proxy := New(logger, func() lease.Client { return leaseClient })
+			proxy := New(logger, func() lease.Client { return leaseClient }, WithToken("token"))
 			proxy.RegisterHandlers(srvMux)
 
 			req, err := http.NewRequest(tc.method, tc.url, strings.NewReader(tc.body))
 			if err != nil {
 				t.Fatalf("unexpected failure when creating the http request: %s", err)
 			}
+			token := "token"
+			if tc.token != "" {
+				token = tc.token
+			}
+			if !tc.noToken {
+				req.Header.Set("Authorization", "Bearer "+token)
+			}
 
 			res := httptest.NewRecorder()
 			srvMux.ServeHTTP(res, req)
@@ -138,3 +289,54 @@ func TestProxy(t *testing.T) {
 		})
 	}
 }
+
+func TestProxyLeaseTTL(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	var calls []string
+	leaseClient := lease.NewFakeClient("owner", "", 1, nil, &calls, nil)
+	srvMux := &http.ServeMux{}
+	proxy := New(logrus.NewEntry(&logrus.Logger{}), func() lease.Client { return leaseClient }, WithToken("token"), WithClock(func() time.Time { return now }))
+	proxy.RegisterHandlers(srvMux)
+	do := func(method, url string) string {
+		t.Helper()
+		req := httptest.NewRequest(method, url, nil)
+		req.Header.Set("Authorization", "Bearer token")
+		res := httptest.NewRecorder()
+		srvMux.ServeHTTP(res, req)
+		if res.Code != http.StatusOK {
+			t.Fatalf("%s %s: unexpected code %d: %s", method, url, res.Code, res.Body.String())
+		}
+		return res.Body.String()
+	}
+
+	if diff := cmp.Diff(`{"names":["aws-1_0"]}`, do(http.MethodPost, "/lease/acquire?type=aws-1&ttl=1h")); diff != "" {
+		t.Errorf("unexpected acquire response:\n%s", diff)
+	}
+	// leases acquired through the proxy are listed with those of the test
+	if diff := cmp.Diff(`{"leases":[{"name":"aws-1_0","type":"aws-1","acquired":"2026-01-01T00:00:00Z","expires":"2026-01-01T01:00:00Z","remaining":"1h0m0s"}]}`, do(http.MethodGet, "/lease/list")); diff != "" {
+		t.Errorf("unexpected list response:\n%s", diff)
+	}
+	now = now.Add(15 * time.Minute)
+	if diff := cmp.Diff(`{"name":"aws-1_0","type":"aws-1","acquired":"2026-01-01T00:00:00Z","expires":"2026-01-01T01:00:00Z","remaining":"45m0s"}`, do(http.MethodGet, "/lease/status?name=aws-1_0")); diff != "" {
+		t.Errorf("unexpected status response:\n%s", diff)
+	}
+	if diff := cmp.Diff(`{"name":"aws-1_0","type":"aws-1","acquired":"2026-01-01T00:00:00Z","expires":"2026-01-01T00:45:00Z","remaining":"30m0s"}`, do(http.MethodPost, "/lease/extend?name=aws-1_0&ttl=30m")); diff != "" {
+		t.Errorf("unexpected extend response:\n%s", diff)
+	}
+
+	// the timer is not due before the extended deadline
+	now = now.Add(20 * time.Minute)
+	proxy.expire("aws-1_0")
+	if leases := leaseClient.Leases(); len(leases) != 1 {
+		t.Fatalf("expected the lease to be held before its deadline, got %v", leases)
+	}
+	now = now.Add(10 * time.Minute)
+	proxy.expire("aws-1_0")
+	if leases := leaseClient.Leases(); len(leases) != 0 {
+		t.Errorf("expected the lease to be released after its deadline, got %v", leases)
+	}
+	expected := []string{"acquireWaitWithPriority owner aws-1 free leased random", "releaseone owner aws-1_0 free"}
+	if diff := cmp.Diff(expected, calls); diff != "" {
+		t.Errorf("unexpected calls:\n%s", diff)
+	}
+}
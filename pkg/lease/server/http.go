@@ -0,0 +1,171 @@
+package server
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"sigs.k8s.io/boskos/common"
+)
+
+const (
+	acquireEndpoint = "/acquire"
+	releaseEndpoint = "/release"
+	updateEndpoint  = "/update"
+	resetEndpoint   = "/reset"
+	metricEndpoint  = "/metric"
+	queueEndpoint   = "/queue"
+)
+
+// Handler serves the subset of the Boskos API used by the Boskos client, and
+// the position of requests in the queue.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.Handle(acquireEndpoint, s.handle(http.MethodPost, s.acquire))
+	mux.Handle(releaseEndpoint, s.handle(http.MethodPost, s.release))
+	mux.Handle(updateEndpoint, s.handle(http.MethodPost, s.update))
+	mux.Handle(resetEndpoint, s.handle(http.MethodPost, s.reset))
+	mux.Handle(metricEndpoint, s.handle(http.MethodGet, s.metric))
+	mux.Handle(queueEndpoint, s.handle(http.MethodGet, s.queue))
+	return mux
+}
+
+// handle checks the method and the credentials of a request before passing it
+// to the handler, and writes the response or error the handler returns.
+func (s *Server) handle(method string, handler func(r *http.Request) (interface{}, error)) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != method {
+			http.Error(w, fmt.Sprintf("Method %v not allowed, %s requests only.", r.Method, method), http.StatusMethodNotAllowed)
+			return
+		}
+		if !s.authorized(r) {
+			http.Error(w, "Unauthorized.", http.StatusUnauthorized)
+			return
+		}
+		response, err := handler(r)
+		if err != nil {
+			s.logger.WithError(err).Debugf("Failed to handle request to %s.", r.URL.Path)
+			http.Error(w, err.Error(), statusFor(err))
+			return
+		}
+		if response == nil {
+			return
+		}
+		raw, err := json.Marshal(response)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("Failed to marshal the response: %v", err), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if _, err := w.Write(raw); err != nil {
+			s.logger.WithError(err).Warn("Failed to write response.")
+		}
+	})
+}
+
+func (s *Server) authorized(r *http.Request) bool {
+	if s.username == "" {
+		return true
+	}
+	username, password, ok := r.BasicAuth()
+	return ok && subtle.ConstantTimeCompare([]byte(username), []byte(s.username)) == 1 &&
+		subtle.ConstantTimeCompare([]byte(password), []byte(s.password)) == 1
+}
+
+// errBadRequest is returned for requests missing parameters.
+var errBadRequest = errors.New("bad request")
+
+// statusFor maps errors to the status codes Boskos returns for them, which
+// the Boskos client relies on.
+func statusFor(err error) int {
+	switch {
+	case errors.Is(err, errBadRequest):
+		return http.StatusBadRequest
+	case errors.Is(err, errNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, errNotQueued):
+		// not a 404, which clients take as the queue not being exposed
+		return http.StatusConflict
+	case errors.Is(err, errOwnerMismatch):
+		return http.StatusUnauthorized
+	case errors.Is(err, errStateMismatch):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// params returns the values of the query parameters, all of which are required.
+func params(r *http.Request, names ...string) ([]string, error) {
+	var values []string
+	for _, name := range names {
+		value := r.URL.Query().Get(name)
+		if value == "" {
+			return nil, fmt.Errorf("%w: parameter %q is required", errBadRequest, name)
+		}
+		values = append(values, value)
+	}
+	return values, nil
+}
+
+func (s *Server) acquire(r *http.Request) (interface{}, error) {
+	values, err := params(r, "type", "state", "dest", "owner")
+	if err != nil {
+		return nil, err
+	}
+	return s.Acquire(values[0], values[1], values[2], values[3], r.URL.Query().Get("request_id"))
+}
+
+func (s *Server) release(r *http.Request) (interface{}, error) {
+	values, err := params(r, "name", "dest", "owner")
+	if err != nil {
+		return nil, err
+	}
+	return nil, s.Release(values[0], values[1], values[2])
+}
+
+func (s *Server) update(r *http.Request) (interface{}, error) {
+	values, err := params(r, "name", "state", "owner")
+	if err != nil {
+		return nil, err
+	}
+	var userData *common.UserData
+	if r.ContentLength != 0 && r.Body != nil {
+		userData = &common.UserData{}
+		if err := json.NewDecoder(r.Body).Decode(userData); err != nil {
+			return nil, fmt.Errorf("%w: could not decode user data: %v", errBadRequest, err)
+		}
+	}
+	return nil, s.Update(values[0], values[1], values[2], userData)
+}
+
+func (s *Server) reset(r *http.Request) (interface{}, error) {
+	values, err := params(r, "type", "state", "expire", "dest")
+	if err != nil {
+		return nil, err
+	}
+	expire, err := time.ParseDuration(values[2])
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid expiration %q: %v", errBadRequest, values[2], err)
+	}
+	return s.Reset(values[0], values[1], expire, values[3])
+}
+
+func (s *Server) metric(r *http.Request) (interface{}, error) {
+	values, err := params(r, "type")
+	if err != nil {
+		return nil, err
+	}
+	return s.Metric(values[0])
+}
+
+func (s *Server) queue(r *http.Request) (interface{}, error) {
+	values, err := params(r, "type", "request_id")
+	if err != nil {
+		return nil, err
+	}
+	return s.QueuePosition(values[0], values[1])
+}
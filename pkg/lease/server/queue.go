@@ -0,0 +1,193 @@
+package server
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/openshift/ci-tools/pkg/lease"
+)
+
+// requestTTL is the time after which queued requests are dropped when their
+// clients stop retrying them. The Boskos client retries every few seconds.
+const requestTTL = 30 * time.Second
+
+var (
+	queuedRequestsMetric = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "lease_server_queued_requests",
+		Help: "Number of requests for leases waiting in the queue.",
+	}, []string{"type", "priority"})
+	queueWaitMetric = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "lease_server_queue_wait_seconds",
+		Help:    "Time requests for leases spent in the queue before being served.",
+		Buckets: prometheus.ExponentialBuckets(1, 2, 16),
+	}, []string{"type", "priority"})
+)
+
+// RegisterMetrics registers the metrics of the queues, which are only exposed
+// by the lease-server command and not by servers running within ci-operator.
+func RegisterMetrics(registerer prometheus.Registerer) error {
+	for _, c := range []prometheus.Collector{queuedRequestsMetric, queueWaitMetric} {
+		if err := registerer.Register(c); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// request is a request for a lease waiting in the queue of a resource type.
+type request struct {
+	id       string
+	priority lease.Priority
+	org      string
+	enqueued time.Time
+	lastSeen time.Time
+}
+
+// enqueue adds a request to the queue of the type, or refreshes it when it is
+// already queued.  Requests whose ID does not carry a priority, like those of
+// other Boskos clients, are queued with the priority of presubmits.
+func (s *Server) enqueue(rtype, requestID string) *request {
+	for _, r := range s.queues[rtype] {
+		if r.id == requestID {
+			r.lastSeen = s.now()
+			return r
+		}
+	}
+	priority, org, ok := lease.ParseRequestID(requestID)
+	if !ok {
+		priority, org = lease.PriorityPresubmit, ""
+	}
+	r := &request{id: requestID, priority: priority, org: org, enqueued: s.now(), lastSeen: s.now()}
+	s.queues[rtype] = append(s.queues[rtype], r)
+	return r
+}
+
+// dequeue removes a request from the queue of the type.
+func (s *Server) dequeue(rtype string, served *request) {
+	var queue []*request
+	for _, r := range s.queues[rtype] {
+		if r != served {
+			queue = append(queue, r)
+		}
+	}
+	s.queues[rtype] = queue
+}
+
+// dropStale removes the requests whose clients stopped retrying them.
+func (s *Server) dropStale(rtype string) {
+	var queue []*request
+	for _, r := range s.queues[rtype] {
+		if s.now().Sub(r.lastSeen) <= requestTTL {
+			queue = append(queue, r)
+		}
+	}
+	s.queues[rtype] = queue
+}
+
+// heldByOrg counts the leased resources of the type by organization.
+func (s *Server) heldByOrg(rtype string) map[string]int {
+	held := map[string]int{}
+	for name, org := range s.leaseOrgs {
+		if s.resources[name].Type == rtype {
+			held[org]++
+		}
+	}
+	return held
+}
+
+// ordered returns the queued requests of the type which may be served, in the
+// order in which they are served: by priority, then preferring organizations
+// which hold fewer resources of the type, then in the order they arrived.
+// Requests of organizations holding their share of the resources while other
+// organizations are waiting are left out.
+func (s *Server) ordered(rtype string) []*request {
+	held := s.heldByOrg(rtype)
+	limit := s.orgLimit(rtype)
+	orgs := map[string]bool{}
+	for _, r := range s.queues[rtype] {
+		orgs[r.org] = true
+	}
+	var eligible []*request
+	for _, r := range s.queues[rtype] {
+		if limit > 0 && held[r.org] >= limit && len(orgs) > 1 {
+			continue
+		}
+		eligible = append(eligible, r)
+	}
+	sort.SliceStable(eligible, func(i, j int) bool {
+		a, b := eligible[i], eligible[j]
+		if a.priority != b.priority {
+			return a.priority > b.priority
+		}
+		if held[a.org] != held[b.org] {
+			return held[a.org] < held[b.org]
+		}
+		return a.enqueued.Before(b.enqueued)
+	})
+	return eligible
+}
+
+// orgLimit is the number of resources of the type a single organization may
+// hold while others are waiting, or zero when there is no limit.
+func (s *Server) orgLimit(rtype string) int {
+	if s.orgShare <= 0 {
+		return 0
+	}
+	var total int
+	for _, resource := range s.resources {
+		if resource.Type == rtype {
+			total++
+		}
+	}
+	return int(math.Ceil(s.orgShare * float64(total)))
+}
+
+// position returns the number of requests served before the request, or -1
+// when it is not eligible to be served now.  When no request is given, all
+// eligible requests go first.
+func position(ordered []*request, r *request) int {
+	if r == nil {
+		return len(ordered)
+	}
+	for i := range ordered {
+		if ordered[i] == r {
+			return i
+		}
+	}
+	return -1
+}
+
+// updateQueueMetrics records the number of queued requests of the type.
+func (s *Server) updateQueueMetrics(rtype string) {
+	counts := map[lease.Priority]int{}
+	for _, r := range s.queues[rtype] {
+		counts[r.priority]++
+	}
+	for priority := lease.PriorityRehearsal; priority <= lease.PriorityReleaseBlocking; priority++ {
+		queuedRequestsMetric.WithLabelValues(rtype, priority.String()).Set(float64(counts[priority]))
+	}
+}
+
+// QueuePosition returns the position of a request in the queue of the type.
+func (s *Server) QueuePosition(rtype, requestID string) (lease.QueuePosition, error) {
+	s.Lock()
+	defer s.Unlock()
+	s.dropStale(rtype)
+	for _, r := range s.queues[rtype] {
+		if r.id != requestID {
+			continue
+		}
+		ordered := s.ordered(rtype)
+		p := position(ordered, r)
+		if p == -1 {
+			// waiting for its organization to release resources
+			p = len(ordered)
+		}
+		return lease.QueuePosition{Position: p, Length: len(s.queues[rtype])}, nil
+	}
+	return lease.QueuePosition{}, fmt.Errorf("%w: %s", errNotQueued, requestID)
+}
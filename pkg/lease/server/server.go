@@ -0,0 +1,368 @@
+// Package server implements a small lease server which is compatible with the
+// HTTP API of Boskos, so the lease client and the lease proxy can be used with
+// it where no Boskos is available, like on local clusters and in integration
+// tests.
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/boskos/common"
+)
+
+const (
+	// DefaultExpiry is the time after which leases which were not updated
+	// are returned to the pool.
+	DefaultExpiry = 10 * time.Minute
+
+	reapInterval = 30 * time.Second
+)
+
+var (
+	errNotFound      = errors.New("resource not found")
+	errNotQueued     = errors.New("request not queued")
+	errOwnerMismatch = errors.New("owner mismatch")
+	errStateMismatch = errors.New("state mismatch")
+)
+
+// Server holds the state of the leased resources and serves the Boskos API.
+// Access to the resources is internally synchronized.
+type Server struct {
+	sync.Mutex
+	logger    *logrus.Entry
+	resources map[string]*common.Resource
+	types     sets.Set[string]
+	// queues holds the requests waiting for a resource by type
+	queues map[string][]*request
+	// leaseOrgs holds the organizations leased resources were acquired for
+	leaseOrgs map[string]string
+	orgShare  float64
+	expiry    time.Duration
+	stateFile string
+	username  string
+	password  string
+	now       func() time.Time
+}
+
+type Option func(*Server)
+
+// WithStateFile persists the state of the resources in a file, from which it
+// is restored when the server starts.
+func WithStateFile(path string) Option {
+	return func(s *Server) { s.stateFile = path }
+}
+
+// WithExpiry sets the time after which leases which were not updated are
+// returned to the pool.
+func WithExpiry(expiry time.Duration) Option {
+	return func(s *Server) { s.expiry = expiry }
+}
+
+// WithCredentials requires clients to authenticate with basic auth.
+func WithCredentials(username, password string) Option {
+	return func(s *Server) { s.username, s.password = username, password }
+}
+
+// WithOrgShare limits the share of the resources of a type a single
+// organization may hold while other organizations are waiting for them.
+func WithOrgShare(share float64) Option {
+	return func(s *Server) { s.orgShare = share }
+}
+
+// WithClock sets the function the server gets the current time from.
+func WithClock(now func() time.Time) Option {
+	return func(s *Server) { s.now = now }
+}
+
+// New creates a server for the resources in a Boskos configuration.  Resource
+// types configured with a count rather than names get resources named after
+// the type.
+func New(logger *logrus.Entry, config *common.BoskosConfig, opts ...Option) (*Server, error) {
+	if err := common.ValidateConfig(config); err != nil {
+		return nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+	s := &Server{
+		logger:    logger,
+		resources: map[string]*common.Resource{},
+		types:     sets.New[string](),
+		queues:    map[string][]*request{},
+		leaseOrgs: map[string]string{},
+		expiry:    DefaultExpiry,
+		now:       time.Now,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	for _, entry := range config.Resources {
+		s.types.Insert(entry.Type)
+		names := entry.Names
+		for i := 0; i < entry.MaxCount; i++ {
+			names = append(names, fmt.Sprintf("%s-%d", entry.Type, i))
+		}
+		for _, name := range names {
+			resource := common.NewResource(name, entry.Type, entry.State, "", s.now())
+			s.resources[name] = &resource
+		}
+	}
+	if err := s.restore(); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// restore loads the state of the resources which are still configured from
+// the state file, if there is one.
+func (s *Server) restore() error {
+	if s.stateFile == "" {
+		return nil
+	}
+	raw, err := os.ReadFile(s.stateFile)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("could not read state file: %w", err)
+	}
+	var saved []common.Resource
+	if err := json.Unmarshal(raw, &saved); err != nil {
+		return fmt.Errorf("could not unmarshal state file: %w", err)
+	}
+	for i := range saved {
+		if current, ok := s.resources[saved[i].Name]; ok && current.Type == saved[i].Type {
+			s.resources[saved[i].Name] = &saved[i]
+		}
+	}
+	return nil
+}
+
+// persist saves the state of the resources to the state file. It must be
+// called with the lock held.
+func (s *Server) persist() error {
+	if s.stateFile == "" {
+		return nil
+	}
+	raw, err := json.MarshalIndent(s.sortedResources(), "", "  ")
+	if err != nil {
+		return fmt.Errorf("could not marshal state: %w", err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(s.stateFile), filepath.Base(s.stateFile))
+	if err != nil {
+		return fmt.Errorf("could not create temporary state file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(raw); err != nil {
+		tmp.Close()
+		return fmt.Errorf("could not write temporary state file: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("could not write temporary state file: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), s.stateFile); err != nil {
+		return fmt.Errorf("could not replace state file: %w", err)
+	}
+	return nil
+}
+
+func (s *Server) sortedResources() []common.Resource {
+	ret := make([]common.Resource, 0, len(s.resources))
+	for _, resource := range s.resources {
+		ret = append(ret, *resource)
+	}
+	sort.Sort(common.ResourceByName(ret))
+	return ret
+}
+
+// Acquire leases a resource of the type in the state to the owner, moving it
+// to the destination state.  The resource which was updated least recently is
+// chosen.  Requests with an ID are queued until it is their turn, so they are
+// served by priority and fairly between organizations; requests without one
+// are only served when no queued request is waiting for the free resources.
+func (s *Server) Acquire(rtype, state, dest, owner, requestID string) (common.Resource, error) {
+	s.Lock()
+	defer s.Unlock()
+	if !s.types.Has(rtype) {
+		return common.Resource{}, fmt.Errorf("%w: %s", errNotFound, common.ResourceTypeNotFoundMessage(rtype))
+	}
+	s.dropStale(rtype)
+	var queued *request
+	if requestID != "" {
+		queued = s.enqueue(rtype, requestID)
+	}
+	defer s.updateQueueMetrics(rtype)
+	var free []*common.Resource
+	for _, resource := range s.resources {
+		if resource.Type == rtype && resource.State == state && resource.Owner == "" {
+			free = append(free, resource)
+		}
+	}
+	ordered := s.ordered(rtype)
+	ahead := position(ordered, queued)
+	if ahead == -1 {
+		return common.Resource{}, fmt.Errorf("%w: organization %q holds its share of %s resources", errNotFound, queued.org, rtype)
+	}
+	if ahead >= len(free) {
+		return common.Resource{}, fmt.Errorf("%w: no resource of type %q is in state %q for this request, %d of %d queued requests are ahead", errNotFound, rtype, state, ahead, len(ordered))
+	}
+	sort.Slice(free, func(i, j int) bool {
+		if !free[i].LastUpdate.Equal(free[j].LastUpdate) {
+			return free[i].LastUpdate.Before(free[j].LastUpdate)
+		}
+		return free[i].Name < free[j].Name
+	})
+	resource := free[0]
+	resource.Owner = owner
+	resource.State = dest
+	resource.LastUpdate = s.now()
+	fields := logrus.Fields{"name": resource.Name, "owner": owner}
+	if queued != nil {
+		s.dequeue(rtype, queued)
+		s.leaseOrgs[resource.Name] = queued.org
+		queueWaitMetric.WithLabelValues(rtype, queued.priority.String()).Observe(s.now().Sub(queued.enqueued).Seconds())
+		fields["priority"], fields["org"] = queued.priority, queued.org
+	}
+	s.logger.WithFields(fields).Debug("Leased resource.")
+	return *resource, s.persist()
+}
+
+// Release ends the lease of the owner on a resource, moving it to the
+// destination state.
+func (s *Server) Release(name, dest, owner string) error {
+	s.Lock()
+	defer s.Unlock()
+	resource, err := s.owned(name, owner)
+	if err != nil {
+		return err
+	}
+	resource.Owner = ""
+	resource.State = dest
+	resource.LastUpdate = s.now()
+	delete(s.leaseOrgs, name)
+	s.logger.WithFields(logrus.Fields{"name": name, "owner": owner}).Debug("Released resource.")
+	return s.persist()
+}
+
+// Update records a heartbeat of the owner of a resource, merging the user
+// data into that of the resource.
+func (s *Server) Update(name, state, owner string, userData *common.UserData) error {
+	s.Lock()
+	defer s.Unlock()
+	resource, err := s.owned(name, owner)
+	if err != nil {
+		return err
+	}
+	if resource.State != state {
+		return fmt.Errorf("%w: resource %s is in state %q, not %q", errStateMismatch, name, resource.State, state)
+	}
+	if userData != nil {
+		if resource.UserData == nil {
+			resource.UserData = &common.UserData{}
+		}
+		resource.UserData.Update(userData)
+	}
+	resource.LastUpdate = s.now()
+	return s.persist()
+}
+
+func (s *Server) owned(name, owner string) (*common.Resource, error) {
+	resource, ok := s.resources[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", errNotFound, name)
+	}
+	if resource.Owner != owner {
+		return nil, fmt.Errorf("%w: resource %s is owned by %q, not %q", errOwnerMismatch, name, resource.Owner, owner)
+	}
+	return resource, nil
+}
+
+// Reset moves the owned resources of the type in the state which were not
+// updated within the expiration to the destination state, ending their lease.
+// It returns the owners of the resources by name.
+func (s *Server) Reset(rtype, state string, expire time.Duration, dest string) (map[string]string, error) {
+	s.Lock()
+	defer s.Unlock()
+	ret := map[string]string{}
+	for name, resource := range s.resources {
+		if resource.Type != rtype || resource.State != state || resource.Owner == "" {
+			continue
+		}
+		if s.now().Sub(resource.LastUpdate) > expire {
+			ret[name] = resource.Owner
+			delete(s.leaseOrgs, name)
+			resource.Owner = ""
+			resource.State = dest
+			resource.LastUpdate = s.now()
+		}
+	}
+	if len(ret) == 0 {
+		return ret, nil
+	}
+	return ret, s.persist()
+}
+
+// Metric counts the resources of a type by state and by owner.
+func (s *Server) Metric(rtype string) (common.Metric, error) {
+	s.Lock()
+	defer s.Unlock()
+	if !s.types.Has(rtype) {
+		return common.Metric{}, fmt.Errorf("%w: %s", errNotFound, common.ResourceTypeNotFoundMessage(rtype))
+	}
+	metric := common.NewMetric(rtype)
+	for _, resource := range s.resources {
+		if resource.Type != rtype {
+			continue
+		}
+		metric.Current[resource.State]++
+		metric.Owners[resource.Owner]++
+	}
+	return metric, nil
+}
+
+// Reap returns the resources whose leases were not updated within the expiry
+// to the free state, like the Boskos reaper does for crashed clients.
+func (s *Server) Reap() {
+	s.Lock()
+	defer s.Unlock()
+	var reaped bool
+	for name, resource := range s.resources {
+		if resource.Owner == "" || s.now().Sub(resource.LastUpdate) <= s.expiry {
+			continue
+		}
+		s.logger.WithFields(logrus.Fields{"name": name, "owner": resource.Owner}).Info("Lease expired, returning the resource to the pool.")
+		resource.Owner = ""
+		resource.State = common.Free
+		resource.LastUpdate = s.now()
+		delete(s.leaseOrgs, name)
+		reaped = true
+	}
+	if !reaped {
+		return
+	}
+	if err := s.persist(); err != nil {
+		s.logger.WithError(err).Warn("Failed to persist the state of the resources.")
+	}
+}
+
+// Run reaps expired leases until the context is cancelled.
+func (s *Server) Run(ctx context.Context) {
+	ticker := time.NewTicker(reapInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.Reap()
+		}
+	}
+}
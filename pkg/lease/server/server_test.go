@@ -0,0 +1,271 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/sirupsen/logrus"
+
+	"sigs.k8s.io/boskos/common"
+
+	"github.com/openshift/ci-tools/pkg/lease"
+	"github.com/openshift/ci-tools/pkg/testhelper"
+)
+
+func testConfig() *common.BoskosConfig {
+	return &common.BoskosConfig{Resources: []common.ResourceEntry{
+		{Type: "aws-quota-slice", State: common.Free, Names: []string{"us-east-1--aws-quota-slice-0", "us-east-1--aws-quota-slice-1"}},
+		{Type: "gcp-quota-slice", State: common.Free, MaxCount: 1},
+	}}
+}
+
+func TestServerWithLeaseClient(t *testing.T) {
+	s, err := New(logrus.NewEntry(logrus.StandardLogger()), testConfig(), WithCredentials("user", "pass"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	httpServer := httptest.NewServer(s.Handler())
+	defer httpServer.Close()
+	client, err := lease.NewClient("owner", httpServer.URL, "user", func() []byte { return []byte("pass") }, 0, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	names, err := client.Acquire("aws-quota-slice", 2, context.Background(), func() {})
+	if err != nil {
+		t.Fatalf("failed to acquire: %v", err)
+	}
+	testhelper.Diff(t, "leased names", names, []string{"us-east-1--aws-quota-slice-0", "us-east-1--aws-quota-slice-1"})
+	if _, err := client.AcquireIfAvailableImmediately("aws-quota-slice", 1, func() {}); !errors.Is(err, lease.ErrNotFound) {
+		t.Errorf("expected all resources to be leased, got %v", err)
+	}
+	if _, err := client.AcquireIfAvailableImmediately("missing", 1, func() {}); !errors.Is(err, lease.ErrTypeNotFound) {
+		t.Errorf("expected the type not to be found, got %v", err)
+	}
+	if err := client.Heartbeat(); err != nil {
+		t.Errorf("failed to heartbeat: %v", err)
+	}
+	metrics, err := client.Metrics("aws-quota-slice")
+	if err != nil {
+		t.Fatalf("failed to get metrics: %v", err)
+	}
+	testhelper.Diff(t, "metrics", metrics, lease.Metrics{Leased: 2})
+	if err := client.Release("us-east-1--aws-quota-slice-0"); err != nil {
+		t.Errorf("failed to release: %v", err)
+	}
+	if names, err := client.AcquireIfAvailableImmediately("gcp-quota-slice", 1, func() {}); err != nil {
+		t.Errorf("failed to acquire: %v", err)
+	} else {
+		testhelper.Diff(t, "leased names", names, []string{"gcp-quota-slice-0"})
+	}
+	released, err := client.ReleaseAll()
+	if err != nil {
+		t.Errorf("failed to release all: %v", err)
+	}
+	testhelper.Diff(t, "released names", len(released), 2)
+	metrics, err = client.Metrics("aws-quota-slice")
+	if err != nil {
+		t.Fatalf("failed to get metrics: %v", err)
+	}
+	testhelper.Diff(t, "metrics", metrics, lease.Metrics{Free: 2})
+
+	unauthorized, err := lease.NewClient("owner", httpServer.URL, "user", func() []byte { return []byte("wrong") }, 0, time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := unauthorized.AcquireIfAvailableImmediately("aws-quota-slice", 1, func() {}); err == nil {
+		t.Error("expected a client with wrong credentials to fail")
+	}
+}
+
+func TestServer(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := func() time.Time { return now }
+	stateFile := filepath.Join(t.TempDir(), "state.json")
+	logger := logrus.NewEntry(logrus.StandardLogger())
+	s, err := New(logger, testConfig(), WithStateFile(stateFile), WithExpiry(time.Minute), WithClock(clock))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	first, err := s.Acquire("aws-quota-slice", common.Free, common.Leased, "a", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	now = now.Add(time.Second)
+	second, err := s.Acquire("aws-quota-slice", common.Free, common.Leased, "b", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Release(first.Name, common.Free, "b"); err == nil || statusFor(err) != 401 {
+		t.Errorf("expected releasing a resource owned by another owner to be unauthorized, got %v", err)
+	}
+	if err := s.Update(second.Name, common.Free, "b", nil); err == nil || statusFor(err) != 409 {
+		t.Errorf("expected updating a resource in another state to conflict, got %v", err)
+	}
+	if err := s.Update("missing", common.Leased, "b", nil); err == nil || statusFor(err) != 404 {
+		t.Errorf("expected updating a missing resource to fail, got %v", err)
+	}
+
+	// b keeps its lease alive, a does not
+	now = now.Add(50 * time.Second)
+	if err := s.Update(second.Name, common.Leased, "b", common.UserDataFromMap(common.UserDataMap{"region": "us-east-1"})); err != nil {
+		t.Fatal(err)
+	}
+	now = now.Add(30 * time.Second)
+	s.Reap()
+
+	restored, err := New(logger, testConfig(), WithStateFile(stateFile), WithClock(clock))
+	if err != nil {
+		t.Fatal(err)
+	}
+	metric, err := restored.Metric("aws-quota-slice")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := common.Metric{
+		Type:    "aws-quota-slice",
+		Current: map[string]int{common.Free: 1, common.Leased: 1},
+		Owners:  map[string]int{"": 1, "b": 1},
+	}
+	if diff := cmp.Diff(expected, metric); diff != "" {
+		t.Errorf("unexpected metric after restoring the state: %s", diff)
+	}
+	var region string
+	if err := restored.resources[second.Name].UserData.Extract("region", &region); err != nil || region != "us-east-1" {
+		t.Errorf("expected the user data to be restored, got %q: %v", region, err)
+	}
+
+	now = now.Add(2 * time.Minute)
+	reset, err := restored.Reset("aws-quota-slice", common.Leased, time.Minute, common.Dirty)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(map[string]string{second.Name: "b"}, reset); diff != "" {
+		t.Errorf("unexpected reset resources: %s", diff)
+	}
+}
+
+func TestNewInvalidConfig(t *testing.T) {
+	config := &common.BoskosConfig{Resources: []common.ResourceEntry{{Type: "a", Names: []string{"x"}}, {Type: "b", Names: []string{"x"}}}}
+	_, err := New(logrus.NewEntry(logrus.StandardLogger()), config)
+	expected := errors.New("invalid configuration: .1.names.0(x) is a duplicate")
+	if diff := cmp.Diff(expected, err, testhelper.EquateErrorMessage); diff != "" {
+		t.Errorf("unexpected error: %s", diff)
+	}
+}
+
+func TestQueue(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := func() time.Time { return now }
+	config := &common.BoskosConfig{Resources: []common.ResourceEntry{{Type: "aws-quota-slice", State: common.Free, MaxCount: 4}}}
+	s, err := New(logrus.NewEntry(logrus.StandardLogger()), config, WithOrgShare(0.5), WithClock(clock))
+	if err != nil {
+		t.Fatal(err)
+	}
+	acquire := func(requestID string) error {
+		now = now.Add(time.Second)
+		_, err := s.Acquire("aws-quota-slice", common.Free, common.Leased, requestID, requestID)
+		return err
+	}
+	expectQueued := func(requestID string) {
+		t.Helper()
+		if err := acquire(requestID); !errors.Is(err, errNotFound) {
+			t.Errorf("expected %s to be queued, got %v", requestID, err)
+		}
+	}
+	expectServed := func(requestID string) {
+		t.Helper()
+		if err := acquire(requestID); err != nil {
+			t.Errorf("expected %s to be served, got %v", requestID, err)
+		}
+	}
+	expectPosition := func(requestID string, expected lease.QueuePosition) {
+		t.Helper()
+		position, err := s.QueuePosition("aws-quota-slice", requestID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		testhelper.Diff(t, "position of "+requestID, position, expected)
+	}
+	rehearsal := lease.RequestID(lease.PriorityRehearsal, "openshift", "rehearsal")
+	presubmit := lease.RequestID(lease.PriorityPresubmit, "openshift", "presubmit")
+	postsubmit := lease.RequestID(lease.PriorityPostsubmit, "openshift", "postsubmit")
+	other := lease.RequestID(lease.PriorityRehearsal, "other", "other")
+
+	// an organization may take all resources while nobody else waits
+	var held []string
+	for i := 0; i < 4; i++ {
+		requestID := lease.RequestID(lease.PriorityPresubmit, "openshift", strconv.Itoa(i))
+		expectServed(requestID)
+		held = append(held, requestID)
+	}
+	for _, requestID := range []string{rehearsal, presubmit, other, postsubmit} {
+		expectQueued(requestID)
+	}
+	// openshift holds more than its share while another organization waits, so
+	// the rehearsal of the other organization goes first
+	expectPosition(other, lease.QueuePosition{Position: 0, Length: 4})
+	if err := s.Release("aws-quota-slice-0", common.Free, held[0]); err != nil {
+		t.Fatal(err)
+	}
+	expectQueued(postsubmit)
+	expectPosition(postsubmit, lease.QueuePosition{Position: 1, Length: 4})
+	expectServed(other)
+
+	// requests of openshift are served by priority
+	for _, name := range []string{"aws-quota-slice-1", "aws-quota-slice-2"} {
+		if err := s.Release(name, common.Free, held[name[len(name)-1]-'0']); err != nil {
+			t.Fatal(err)
+		}
+	}
+	expectQueued(rehearsal)
+	expectPosition(rehearsal, lease.QueuePosition{Position: 2, Length: 3})
+	expectServed(presubmit)
+	expectServed(postsubmit)
+	expectQueued(rehearsal)
+
+	// requests whose clients stopped retrying are dropped
+	now = now.Add(requestTTL + time.Second)
+	if _, err := s.QueuePosition("aws-quota-slice", rehearsal); !errors.Is(err, errNotQueued) {
+		t.Errorf("expected the stale request to be dropped, got %v", err)
+	}
+}
+
+func TestQueueEndpoint(t *testing.T) {
+	config := &common.BoskosConfig{Resources: []common.ResourceEntry{{Type: "aws-quota-slice", State: common.Free, MaxCount: 1}}}
+	s, err := New(logrus.NewEntry(logrus.StandardLogger()), config, WithCredentials("user", "pass"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	httpServer := httptest.NewServer(s.Handler())
+	defer httpServer.Close()
+	client, err := lease.NewClient("owner", httpServer.URL, "user", func() []byte { return []byte("pass") }, 0, time.Minute, lease.WithPriority(lease.PriorityPeriodic, "openshift"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Acquire("aws-quota-slice", common.Free, common.Leased, "a", ""); err != nil {
+		t.Fatal(err)
+	}
+	first := lease.RequestID(lease.PriorityPeriodic, "openshift", "first")
+	second := lease.RequestID(lease.PriorityReleaseBlocking, "openshift", "second")
+	for _, requestID := range []string{first, second} {
+		if _, err := s.Acquire("aws-quota-slice", common.Free, common.Leased, "b", requestID); !errors.Is(err, errNotFound) {
+			t.Fatalf("expected %s to be queued, got %v", requestID, err)
+		}
+	}
+	position, err := client.QueuePosition("aws-quota-slice", first)
+	if err != nil {
+		t.Fatal(err)
+	}
+	testhelper.Diff(t, "position", position, lease.QueuePosition{Position: 1, Length: 2})
+	if _, err := client.QueuePosition("aws-quota-slice", "missing"); err == nil {
+		t.Error("expected looking up a request which is not queued to fail")
+	}
+}
@@ -23,6 +23,8 @@ type RegistryAgent interface {
 	GetGeneration() int
 	GetClusterProfiles() api.ClusterProfilesMap
 	GetClusterProfileDetails(profileName string) (*api.ClusterProfileDetails, error)
+	GetDeprecations() registry.Deprecations
+	ResolveConfigWithWarnings(config api.ReleaseBuildConfiguration) (api.ReleaseBuildConfiguration, []string, error)
 	registry.Resolver
 }
 
@@ -39,6 +41,7 @@ type registryAgent struct {
 	clusterProfiles api.ClusterProfilesMap
 	documentation   map[string]string
 	metadata        api.RegistryMetadata
+	deprecations    registry.Deprecations
 }
 
 var registryReloadTimeMetric = prometheus.NewHistogram(
@@ -112,11 +115,26 @@ func NewRegistryAgent(registryPath string, errCh chan error, opts ...RegistryAge
 	return a, startWatchers(registryPath, errCh, a.loadRegistry, a.errorMetrics, opt.UniversalSymlinkWatcher)
 }
 
-// ResolveConfig uses the registryAgent's resolver to resolve a provided ReleaseBuildConfiguration
+// ResolveConfig uses the registryAgent's resolver to resolve a provided ReleaseBuildConfiguration.
+// Configurations using registry components past their removal date fail to resolve.
 func (a *registryAgent) ResolveConfig(config api.ReleaseBuildConfiguration) (api.ReleaseBuildConfiguration, error) {
+	resolved, _, err := a.ResolveConfigWithWarnings(config)
+	return resolved, err
+}
+
+// ResolveConfigWithWarnings resolves a ReleaseBuildConfiguration like ResolveConfig and
+// also returns warnings for the deprecated registry components it uses
+func (a *registryAgent) ResolveConfigWithWarnings(config api.ReleaseBuildConfiguration) (api.ReleaseBuildConfiguration, []string, error) {
+	a.lock.RLock()
+	defer a.lock.RUnlock()
+	return registry.NewDeprecationChecker(a.chains, a.workflows, a.deprecations).ResolveConfig(a.resolver, config, time.Now())
+}
+
+// GetDeprecations returns the deprecation notices of the registry components
+func (a *registryAgent) GetDeprecations() registry.Deprecations {
 	a.lock.RLock()
 	defer a.lock.RUnlock()
-	return registry.ResolveConfig(a.resolver, config)
+	return a.deprecations
 }
 
 func (a *registryAgent) ResolveWorkflow(name string) (api.MultiStageTestConfigurationLiteral, error) {
@@ -161,18 +179,19 @@ func (a *registryAgent) loadRegistry() error {
 		a.lock.Lock()
 		defer a.lock.Unlock()
 		startTime := time.Now()
-		references, chains, workflows, clusterProfiles, documentation, metadata, observers, err := load.Registry(a.registryPath, a.flags)
+		contents, err := load.LoadRegistry(a.registryPath, a.flags)
 		if err != nil {
 			recordErrorForMetric(a.errorMetrics, "failed to load ci-operator registry")
 			return time.Duration(0), fmt.Errorf("failed to load ci-operator registry (%w)", err)
 		}
-		a.references = references
-		a.chains = chains
-		a.workflows = workflows
-		a.documentation = documentation
-		a.metadata = metadata
-		a.clusterProfiles = clusterProfiles
-		a.resolver = registry.NewResolver(references, chains, workflows, observers)
+		a.references = contents.References
+		a.chains = contents.Chains
+		a.workflows = contents.Workflows
+		a.documentation = contents.Documentation
+		a.metadata = contents.Metadata
+		a.deprecations = contents.Deprecations
+		a.clusterProfiles = contents.ClusterProfiles
+		a.resolver = registry.NewResolver(contents.References, contents.Chains, contents.Workflows, contents.Observers)
 		a.generation++
 		return time.Since(startTime), nil
 	}()
@@ -38,14 +38,39 @@ const (
 	RegistryDocumentation
 )
 
+// RegistryContents holds everything loaded from a registry config directory.
+type RegistryContents struct {
+	References      registry.ReferenceByName
+	Chains          registry.ChainByName
+	Workflows       registry.WorkflowByName
+	ClusterProfiles api.ClusterProfilesMap
+	// Documentation is only loaded with RegistryDocumentation
+	Documentation map[string]string
+	// Metadata is only loaded with RegistryMetadata
+	Metadata     api.RegistryMetadata
+	Observers    registry.ObserverByName
+	Deprecations registry.Deprecations
+}
+
 // Registry takes the path to a registry config directory and returns the full set of references, chains,
 // and workflows that the registry's Resolver needs to resolve a user's MultiStageTestConfiguration
 func Registry(root string, flags RegistryFlag) (registry.ReferenceByName, registry.ChainByName, registry.WorkflowByName, api.ClusterProfilesMap, map[string]string, api.RegistryMetadata, registry.ObserverByName, error) {
+	contents, err := LoadRegistry(root, flags)
+	if err != nil {
+		return nil, nil, nil, nil, nil, nil, nil, err
+	}
+	return contents.References, contents.Chains, contents.Workflows, contents.ClusterProfiles, contents.Documentation, contents.Metadata, contents.Observers, nil
+}
+
+// LoadRegistry takes the path to a registry config directory and returns its contents, including the
+// deprecation notices of the components which Registry does not return
+func LoadRegistry(root string, flags RegistryFlag) (*RegistryContents, error) {
 	flat := flags&RegistryFlat != 0
 	references := registry.ReferenceByName{}
 	chains := registry.ChainByName{}
 	workflows := registry.WorkflowByName{}
 	observers := registry.ObserverByName{}
+	deprecations := registry.NewDeprecations()
 	var profiles api.ClusterProfilesMap
 	var clusterProfilesConfigPath string
 	var documentation map[string]string
@@ -98,7 +123,7 @@ func Registry(root string, flags RegistryFlag) (registry.ReferenceByName, regist
 			}
 		}
 		if strings.HasSuffix(path, RefSuffix) {
-			name, doc, ref, err := loadReference(raw, dir, prefix, flat)
+			name, doc, deprecation, ref, err := loadReference(raw, dir, prefix, flat)
 			if err != nil {
 				return fmt.Errorf("failed to load registry file %s: %w", path, err)
 			}
@@ -112,6 +137,9 @@ func Registry(root string, flags RegistryFlag) (registry.ReferenceByName, regist
 			if documentation != nil {
 				documentation[name] = doc
 			}
+			if deprecation != nil {
+				deprecations.References[name] = *deprecation
+			}
 		} else if strings.HasSuffix(path, ChainSuffix) {
 			var chain api.RegistryChainConfig
 			err := yaml.UnmarshalStrict(raw, &chain)
@@ -128,9 +156,13 @@ func Registry(root string, flags RegistryFlag) (registry.ReferenceByName, regist
 				documentation[chain.Chain.As] = chain.Chain.Documentation
 			}
 			chain.Chain.Documentation = ""
+			if chain.Chain.Deprecation != nil {
+				deprecations.Chains[chain.Chain.As] = *chain.Chain.Deprecation
+			}
+			chain.Chain.Deprecation = nil
 			chains[chain.Chain.As] = chain.Chain
 		} else if strings.HasSuffix(path, WorkflowSuffix) {
-			name, doc, workflow, err := loadWorkflow(raw)
+			name, doc, deprecation, workflow, err := loadWorkflow(raw)
 			if err != nil {
 				return fmt.Errorf("failed to load registry file %s: %w", path, err)
 			}
@@ -144,6 +176,9 @@ func Registry(root string, flags RegistryFlag) (registry.ReferenceByName, regist
 			if documentation != nil {
 				documentation[name] = doc
 			}
+			if deprecation != nil {
+				deprecations.Workflows[name] = *deprecation
+			}
 		} else if strings.HasSuffix(path, MetadataSuffix) {
 			if metadata == nil {
 				return nil
@@ -191,19 +226,22 @@ func Registry(root string, flags RegistryFlag) (registry.ReferenceByName, regist
 		return nil
 	})
 	if err != nil {
-		return nil, nil, nil, nil, nil, nil, nil, err
+		return nil, err
 	}
 	// create graph to verify that there are no cycles
 	if _, err = registry.NewGraph(references, chains, workflows, observers); err != nil {
-		return nil, nil, nil, nil, nil, nil, nil, err
+		return nil, err
 	}
 	err = registry.Validate(references, chains, workflows, observers)
 	if err != nil {
-		return nil, nil, nil, nil, nil, nil, nil, err
+		return nil, err
+	}
+	if err := registry.ValidateDeprecations(references, chains, workflows, deprecations); err != nil {
+		return nil, err
 	}
 	profiles, err = ClusterProfilesConfig(clusterProfilesConfigPath)
 	if err != nil {
-		return nil, nil, nil, nil, nil, nil, nil, err
+		return nil, err
 	}
 	// validate the integrity of each reference
 	v := validation.NewValidator(nil, nil)
@@ -214,38 +252,47 @@ func Registry(root string, flags RegistryFlag) (registry.ReferenceByName, regist
 		}
 	}
 	if len(validationErrors) > 0 {
-		return nil, nil, nil, nil, nil, nil, nil, utilerrors.NewAggregate(validationErrors)
+		return nil, utilerrors.NewAggregate(validationErrors)
 	}
-	return references, chains, workflows, profiles, documentation, metadata, observers, nil
+	return &RegistryContents{
+		References:      references,
+		Chains:          chains,
+		Workflows:       workflows,
+		ClusterProfiles: profiles,
+		Documentation:   documentation,
+		Metadata:        metadata,
+		Observers:       observers,
+		Deprecations:    deprecations,
+	}, nil
 }
 
-func loadReference(bytes []byte, baseDir, prefix string, flat bool) (string, string, api.LiteralTestStep, error) {
+func loadReference(bytes []byte, baseDir, prefix string, flat bool) (string, string, *api.RegistryDeprecation, api.LiteralTestStep, error) {
 	step := api.RegistryReferenceConfig{}
 	err := yaml.UnmarshalStrict(bytes, &step)
 	if err != nil {
-		return "", "", api.LiteralTestStep{}, err
+		return "", "", nil, api.LiteralTestStep{}, err
 	}
 	if !flat && step.Reference.Commands != fmt.Sprintf("%s%s%s", prefix, CommandsSuffix, filepath.Ext(step.Reference.Commands)) {
-		return "", "", api.LiteralTestStep{}, fmt.Errorf("reference %s has invalid command file path; command should be set to %s (with an optional extension like .sh)", step.Reference.As, fmt.Sprintf("%s%s", prefix, CommandsSuffix))
+		return "", "", nil, api.LiteralTestStep{}, fmt.Errorf("reference %s has invalid command file path; command should be set to %s (with an optional extension like .sh)", step.Reference.As, fmt.Sprintf("%s%s", prefix, CommandsSuffix))
 	}
 	command, err := gzip.ReadFileMaybeGZIP(filepath.Join(baseDir, step.Reference.Commands))
 	if err != nil {
-		return "", "", api.LiteralTestStep{}, err
+		return "", "", nil, api.LiteralTestStep{}, err
 	}
 	step.Reference.Commands = string(command)
-	return step.Reference.As, step.Reference.Documentation, step.Reference.LiteralTestStep, nil
+	return step.Reference.As, step.Reference.Documentation, step.Reference.Deprecation, step.Reference.LiteralTestStep, nil
 }
 
-func loadWorkflow(bytes []byte) (string, string, api.MultiStageTestConfiguration, error) {
+func loadWorkflow(bytes []byte) (string, string, *api.RegistryDeprecation, api.MultiStageTestConfiguration, error) {
 	workflow := api.RegistryWorkflowConfig{}
 	err := yaml.UnmarshalStrict(bytes, &workflow)
 	if err != nil {
-		return "", "", api.MultiStageTestConfiguration{}, err
+		return "", "", nil, api.MultiStageTestConfiguration{}, err
 	}
 	if workflow.Workflow.Steps.Workflow != nil {
-		return "", "", api.MultiStageTestConfiguration{}, errors.New("workflows cannot contain other workflows")
+		return "", "", nil, api.MultiStageTestConfiguration{}, errors.New("workflows cannot contain other workflows")
 	}
-	return workflow.Workflow.As, workflow.Workflow.Documentation, workflow.Workflow.Steps, nil
+	return workflow.Workflow.As, workflow.Workflow.Documentation, workflow.Workflow.Deprecation, workflow.Workflow.Steps, nil
 }
 
 // ClusterProfilesConfig loads cluster profile information from its config in the release repository
@@ -17,6 +17,7 @@ import (
 
 func TestRegistry(t *testing.T) {
 	defaultStr := "test parameter default"
+	newStepRef, oldChain := "new-step", "old-chain"
 	var (
 		expectedReferences = registry.ReferenceByName{
 			"ipi-deprovision-deprovision": {
@@ -152,6 +153,7 @@ func TestRegistry(t *testing.T) {
 			chains        registry.ChainByName
 			workflows     registry.WorkflowByName
 			observers     registry.ObserverByName
+			deprecations  registry.Deprecations
 			expectedError bool
 		}{{
 			name:          "Read registry",
@@ -160,6 +162,7 @@ func TestRegistry(t *testing.T) {
 			chains:        expectedChains,
 			workflows:     expectedWorkflows,
 			observers:     expectedObservers,
+			deprecations:  registry.NewDeprecations(),
 			expectedError: false,
 		}, {
 			name:        "Read configmap style registry",
@@ -181,6 +184,7 @@ func TestRegistry(t *testing.T) {
 			chains:        registry.ChainByName{},
 			workflows:     registry.WorkflowByName{},
 			observers:     registry.ObserverByName{},
+			deprecations:  registry.NewDeprecations(),
 			expectedError: false,
 		}, {
 			name:          "Read registry with ref where name and filename don't match",
@@ -210,12 +214,75 @@ func TestRegistry(t *testing.T) {
 			chains:        nil,
 			workflows:     nil,
 			expectedError: true,
+		}, {
+			name:        "Read registry with deprecated components",
+			registryDir: "../../test/multistage-registry/deprecated",
+			references: registry.ReferenceByName{
+				"new-step": {
+					As:       "new-step",
+					From:     "installer",
+					Commands: "openshift-cluster install\n",
+					Resources: api.ResourceRequirements{
+						Requests: api.ResourceList{"cpu": "1000m", "memory": "2Gi"},
+					},
+				},
+				"old-step": {
+					As:       "old-step",
+					From:     "installer",
+					Commands: "openshift-cluster install\n",
+					Resources: api.ResourceRequirements{
+						Requests: api.ResourceList{"cpu": "1000m", "memory": "2Gi"},
+					},
+				},
+			},
+			chains: registry.ChainByName{
+				"old-chain": {
+					As:    "old-chain",
+					Steps: []api.TestStep{{Reference: &newStepRef}},
+				},
+			},
+			workflows: registry.WorkflowByName{
+				"old-workflow": {
+					Test: []api.TestStep{{Chain: &oldChain}},
+				},
+			},
+			observers: registry.ObserverByName{},
+			deprecations: registry.Deprecations{
+				References: map[string]api.RegistryDeprecation{
+					"old-step": {
+						Reason:      "The installer no longer needs a separate step.",
+						Replacement: "new-step",
+						RemovalDate: "2020-01-01",
+					},
+				},
+				Chains: map[string]api.RegistryDeprecation{
+					"old-chain": {Reason: "Use the step directly."},
+				},
+				Workflows: map[string]api.RegistryDeprecation{
+					"old-workflow": {
+						Reason:      "Nothing uses this workflow anymore.",
+						RemovalDate: "2030-01-01",
+					},
+				},
+			},
+			expectedError: false,
+		}, {
+			name:          "Read registry where a deprecation has an invalid removal date and replacement",
+			registryDir:   "../../test/multistage-registry/invalid-deprecation",
+			references:    nil,
+			chains:        nil,
+			workflows:     nil,
+			expectedError: true,
 		}}
 	)
 
 	for _, testCase := range testCases {
 		t.Run(testCase.name, func(t *testing.T) {
-			references, chains, workflows, _, _, _, observers, err := Registry(testCase.registryDir, testCase.flags)
+			contents, err := LoadRegistry(testCase.registryDir, testCase.flags)
+			if contents == nil {
+				contents = &RegistryContents{}
+			}
+			references, chains, workflows, observers, deprecations := contents.References, contents.Chains, contents.Workflows, contents.Observers, contents.Deprecations
 			if err == nil && testCase.expectedError == true {
 				t.Error("got no error when error was expected")
 			}
@@ -234,6 +301,9 @@ func TestRegistry(t *testing.T) {
 			if !reflect.DeepEqual(observers, testCase.observers) {
 				t.Errorf("output observers different from expected: %s", diff.ObjectReflectDiff(observers, testCase.observers))
 			}
+			if diff := cmp.Diff(testCase.deprecations, deprecations); diff != "" {
+				t.Errorf("output deprecations different from expected: %s", diff)
+			}
 		})
 	}
 	// set up a temporary directory registry with a broken component
@@ -22,6 +22,7 @@ import (
 
 	"github.com/openshift/ci-tools/pkg/api"
 	"github.com/openshift/ci-tools/pkg/lease"
+	"github.com/openshift/ci-tools/pkg/otlp"
 	"github.com/openshift/ci-tools/pkg/secrets"
 )
 
@@ -62,6 +63,7 @@ type MetricsAgent struct {
 	podPlugin      *PodLifecyclePlugin
 	machinesPlugin *MachinesPlugin
 	imagesPlugin   *imagesPlugin
+	tracesPlugin   *tracesPlugin
 
 	wg sync.WaitGroup
 	mu sync.Mutex
@@ -101,9 +103,28 @@ func NewMetricsAgent(ctx context.Context, clusterConfig *rest.Config, censor *se
 		podPlugin:      NewPodLifecyclePlugin(ctx, logger, client),
 		machinesPlugin: NewMachinesPlugin(ctx, logger, client, autoscalerList.Items),
 		imagesPlugin:   newImagesPlugin(ctx, logger, client),
+		tracesPlugin:   newTracesPlugin(logger),
 	}, nil
 }
 
+// NewTracingAgent returns an agent which only records the events needed to
+// export a trace of the execution, for when the metrics of the cluster cannot
+// be collected.
+func NewTracingAgent(ctx context.Context) *MetricsAgent {
+	logger := logrus.WithField("component", "metricsAgent")
+	return &MetricsAgent{
+		ctx:          ctx,
+		events:       make(chan MetricsEvent, 100),
+		logger:       logger,
+		tracesPlugin: newTracesPlugin(logger),
+	}
+}
+
+// tracingOnly determines whether the agent was created by NewTracingAgent.
+func (ma *MetricsAgent) tracingOnly() bool {
+	return ma.insightsPlugin == nil
+}
+
 // Run listens for events on the events channel until the channel is closed.
 func (ma *MetricsAgent) Run() {
 	ma.wg.Add(1)
@@ -126,14 +147,17 @@ func (ma *MetricsAgent) Run() {
 				return
 			}
 			// Record the event to all plugins
-			ma.insightsPlugin.Record(ev)
-			ma.eventsPlugin.Record(ev)
-			ma.buildPlugin.Record(ev)
-			ma.nodesPlugin.Record(ev)
-			ma.leasePlugin.Record(ev)
-			ma.podPlugin.Record(ev)
-			ma.machinesPlugin.Record(ev)
-			ma.imagesPlugin.Record(ev)
+			if !ma.tracingOnly() {
+				ma.insightsPlugin.Record(ev)
+				ma.eventsPlugin.Record(ev)
+				ma.buildPlugin.Record(ev)
+				ma.nodesPlugin.Record(ev)
+				ma.leasePlugin.Record(ev)
+				ma.podPlugin.Record(ev)
+				ma.machinesPlugin.Record(ev)
+				ma.imagesPlugin.Record(ev)
+			}
+			ma.tracesPlugin.Record(ev)
 			ma.logger.WithField("event_type", fmt.Sprintf("%T", ev)).Debug("Recorded metrics event")
 		}
 	}
@@ -152,12 +176,18 @@ func (ma *MetricsAgent) Record(ev MetricsEvent) {
 
 // Stop closes the events channel and blocks until flush completes.
 func (ma *MetricsAgent) Stop() {
+	if ma == nil {
+		return
+	}
 	ma.mu.Lock()
 	close(ma.events)
 	ma.mu.Unlock()
 
 	ma.wg.Wait()
-	ma.flush()
+	if !ma.tracingOnly() {
+		ma.flush()
+	}
+	ma.tracesPlugin.export(context.Background(), time.Now())
 }
 
 // flush writes the accumulated events to a JSON file in the artifacts directory.
@@ -184,9 +214,19 @@ func (ma *MetricsAgent) flush() {
 	}
 }
 
+// ExportTraces configures the agent to export a trace of the execution when it
+// is stopped. The trace is identified by `traceID` and the attributes describe
+// the job which produced it.
+func (ma *MetricsAgent) ExportTraces(traceID string, attributes map[string]string, exporters ...otlp.Exporter) {
+	if ma == nil || ma.tracesPlugin == nil {
+		return
+	}
+	ma.tracesPlugin.configure(traceID, attributes, exporters)
+}
+
 // AddNodeWorkload tracks a workload's pod and the node it runs on for metrics collection
 func (ma *MetricsAgent) AddNodeWorkload(ctx context.Context, namespace, podName, workloadName string, podClient ctrlruntimeclient.Client) {
-	if ma == nil {
+	if ma == nil || ma.nodesPlugin == nil {
 		return
 	}
 	go ma.nodesPlugin.ExtractPodNode(ctx, namespace, podName, workloadName, podClient)
@@ -194,7 +234,7 @@ func (ma *MetricsAgent) AddNodeWorkload(ctx context.Context, namespace, podName,
 
 // RemoveNodeWorkload removes a workload from any node it's running on
 func (ma *MetricsAgent) RemoveNodeWorkload(workloadName string) {
-	if ma == nil {
+	if ma == nil || ma.nodesPlugin == nil {
 		return
 	}
 	ma.nodesPlugin.RemoveWorkload(workloadName)
@@ -209,7 +249,7 @@ func (ma *MetricsAgent) RegisterLeaseClient(client lease.Client) {
 }
 
 func (ma *MetricsAgent) StorePodLifecycleMetrics(name, namespace string, phase corev1.PodPhase) {
-	if ma == nil || ma.podPlugin == nil {
+	if ma == nil || (ma.podPlugin == nil && ma.tracesPlugin == nil) {
 		return
 	}
 	event := PodLifecycleMetricsEvent{
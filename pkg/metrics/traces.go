@@ -0,0 +1,235 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+
+	buildapi "github.com/openshift/api/build/v1"
+
+	"github.com/openshift/ci-tools/pkg/otlp"
+)
+
+const (
+	TracesPluginName = "traces"
+	tracesScope      = "github.com/openshift/ci-tools/ci-operator"
+)
+
+// tracesPlugin builds a trace of the execution from the events recorded for
+// steps, pods, builds, leases and the test namespace, and exports it when the
+// agent is stopped.
+type tracesPlugin struct {
+	mu        sync.Mutex
+	logger    *logrus.Entry
+	events    []MetricsEvent
+	traceID   string
+	resource  []otlp.KeyValue
+	exporters []otlp.Exporter
+}
+
+func newTracesPlugin(logger *logrus.Entry) *tracesPlugin {
+	return &tracesPlugin{logger: logger.WithField("plugin", TracesPluginName)}
+}
+
+func (p *tracesPlugin) Name() string { return TracesPluginName }
+
+func (p *tracesPlugin) Record(ev MetricsEvent) {
+	switch e := ev.(type) {
+	case *Event:
+		if e.Locator.Type != "Step" {
+			return
+		}
+	case *InsightsEvent:
+		switch InsightEventName(e.Name) {
+		case InsightStarted, InsightNamespaceInitialized:
+		default:
+			return
+		}
+	case *PodLifecycleMetricsEvent, *BuildEvent, *LeaseAcquisitionMetricEvent, *LeaseReleaseMetricEvent:
+	default:
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.events = append(p.events, ev)
+}
+
+// configure sets where the trace is exported and the attributes identifying
+// the job which produced it.
+func (p *tracesPlugin) configure(traceID string, attributes map[string]string, exporters []otlp.Exporter) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.traceID = traceID
+	p.resource = otlp.Attributes(attributes)
+	p.exporters = exporters
+}
+
+// export sends the trace to all configured exporters.
+func (p *tracesPlugin) export(ctx context.Context, end time.Time) {
+	p.mu.Lock()
+	exporters := p.exporters
+	p.mu.Unlock()
+	if len(exporters) == 0 {
+		return
+	}
+	data := p.trace(end)
+	for _, exporter := range exporters {
+		if err := exporter.Export(ctx, data); err != nil {
+			p.logger.WithError(err).Warn("Failed to export trace")
+		}
+	}
+}
+
+type span struct {
+	kind          string
+	name, failure string
+	start, end    time.Time
+	attributes    []otlp.KeyValue
+}
+
+// trace creates a root span for the execution, a span for each step under it
+// and spans for pods and builds under the step which created them. Leases and
+// the initialization of the namespace are attributed to the execution.
+func (p *tracesPlugin) trace(end time.Time) *otlp.TracesData {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	var start time.Time
+	var steps, pods, builds, others []span
+	owners := map[string]string{}
+	acquisitions := map[string]span{}
+	var leases []string
+	for _, ev := range p.events {
+		switch e := ev.(type) {
+		case *InsightsEvent:
+			if InsightEventName(e.Name) == InsightStarted {
+				start = e.Timestamp
+				continue
+			}
+			duration, _ := e.AdditionalContext["duration_seconds"].(float64)
+			others = append(others, span{
+				name:  "initialize namespace",
+				start: e.Timestamp.Add(-time.Duration(duration * float64(time.Second))),
+				end:   e.Timestamp,
+			})
+		case *Event:
+			s := span{kind: "step", name: e.Locator.Name, start: e.From, end: e.To, failure: e.Message.Cause}
+			steps = append(steps, s)
+			refs, _ := e.Locator.Keys["objects"].([]ObjectRef)
+			for _, ref := range refs {
+				owners[ref.Kind+"/"+ref.Name] = s.name
+			}
+		case *PodLifecycleMetricsEvent:
+			if e.CreationTime == nil {
+				continue
+			}
+			s := span{kind: "pod", name: e.PodName, start: *e.CreationTime, end: e.Timestamp, attributes: []otlp.KeyValue{otlp.String("k8s.namespace.name", e.Namespace)}}
+			if e.CompletionTime != nil && !e.CompletionTime.IsZero() {
+				s.end = *e.CompletionTime
+			}
+			if e.PodPhase != "" {
+				s.attributes = append(s.attributes, otlp.String("k8s.pod.phase", string(e.PodPhase)))
+			}
+			if e.PodPhase == corev1.PodFailed {
+				s.failure = "pod failed"
+			}
+			pods = append(pods, s)
+		case *BuildEvent:
+			if e.StartTime.IsZero() {
+				continue
+			}
+			s := span{kind: "build", name: e.Name, start: e.StartTime, end: e.Timestamp, attributes: []otlp.KeyValue{otlp.String("k8s.namespace.name", e.Namespace), otlp.String("build.status", e.Status)}}
+			if !e.CompletionTime.IsZero() {
+				s.end = e.CompletionTime
+			}
+			if e.Status == string(buildapi.BuildPhaseFailed) || e.Status == string(buildapi.BuildPhaseError) {
+				s.failure = e.Reason
+			}
+			builds = append(builds, s)
+		case *LeaseAcquisitionMetricEvent:
+			s := span{
+				kind:       "lease",
+				name:       e.RawLeaseName,
+				start:      e.Timestamp.Add(-time.Duration(e.AcquisitionDurationSeconds * float64(time.Second))),
+				end:        e.Timestamp,
+				attributes: []otlp.KeyValue{otlp.String("lease.acquisition_seconds", fmt.Sprintf("%.0f", e.AcquisitionDurationSeconds))},
+			}
+			acquisitions[e.RawLeaseName] = s
+			leases = append(leases, e.RawLeaseName)
+		case *LeaseReleaseMetricEvent:
+			if s, ok := acquisitions[e.RawLeaseName]; ok {
+				s.end, s.failure = e.Timestamp, e.Error
+				acquisitions[e.RawLeaseName] = s
+			}
+		}
+	}
+	for _, name := range leases {
+		others = append(others, acquisitions[name])
+	}
+	for _, spans := range [][]span{steps, pods, builds, others} {
+		for _, s := range spans {
+			if start.IsZero() || s.start.Before(start) {
+				start = s.start
+			}
+			if s.end.After(end) {
+				end = s.end
+			}
+		}
+	}
+
+	trace := otlp.NewTrace(p.traceID)
+	root := trace.Add("", "ci-operator", start, end, "")
+	add := func(parent string, s span) string {
+		name := s.name
+		if s.kind != "" {
+			name = s.kind + " " + name
+		}
+		return trace.Add(parent, name, s.start, s.end, s.failure, s.attributes...)
+	}
+	stepIDs := map[string]string{}
+	for _, s := range steps {
+		stepIDs[s.name] = add(root, s)
+	}
+	// owner finds the step which created an object, either from the objects
+	// reported by the step or, for pods of multi-stage tests, from the prefix
+	// of the name.
+	owner := func(kind, name string) string {
+		if step, ok := owners[kind+"/"+name]; ok {
+			return stepIDs[step]
+		}
+		var longest string
+		for step := range stepIDs {
+			if strings.HasPrefix(name, step+"-") && len(step) > len(longest) {
+				longest = step
+			}
+		}
+		if longest != "" {
+			return stepIDs[longest]
+		}
+		return root
+	}
+	buildIDs := map[string]string{}
+	for _, s := range builds {
+		buildIDs[s.name] = add(owner("Build", s.name), s)
+	}
+	for _, s := range pods {
+		parent, ok := buildIDs[strings.TrimSuffix(s.name, "-build")]
+		if !ok {
+			parent = owner("Pod", s.name)
+		}
+		add(parent, s)
+	}
+	for _, s := range others {
+		add(root, s)
+	}
+	data := trace.Data(tracesScope, p.resource)
+	spans := data.ResourceSpans[0].ScopeSpans[0].Spans
+	sort.SliceStable(spans, func(i, j int) bool { return spans[i].StartTimeUnixNano < spans[j].StartTimeUnixNano })
+	return data
+}
@@ -0,0 +1,77 @@
+package metrics
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/openshift/ci-tools/pkg/otlp"
+	"github.com/openshift/ci-tools/pkg/testhelper"
+)
+
+func TestTracesPluginTrace(t *testing.T) {
+	start := time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+	at := func(minutes int) time.Time { return start.Add(time.Duration(minutes) * time.Minute) }
+	ptr := func(t time.Time) *time.Time { return &t }
+	plugin := newTracesPlugin(logrus.NewEntry(logrus.StandardLogger()))
+	plugin.configure("prow-job-id", map[string]string{"org": "org", "repo": "repo", "branch": "branch", "test": "e2e"}, nil)
+	for _, ev := range []MetricsEvent{
+		&InsightsEvent{Name: string(InsightStarted), Timestamp: at(0)},
+		&InsightsEvent{Name: string(InsightConfiguration), Timestamp: at(0)},
+		&InsightsEvent{Name: string(InsightNamespaceInitialized), AdditionalContext: Context{"duration_seconds": 60.0}, Timestamp: at(2)},
+		&BuildEvent{Namespace: "ns", Name: "src", StartTime: at(3), CompletionTime: at(8), Status: "Complete", Timestamp: at(8)},
+		&PodLifecycleMetricsEvent{PodName: "src-build", Namespace: "ns", CreationTime: ptr(at(3)), CompletionTime: ptr(at(8)), PodPhase: corev1.PodSucceeded, Timestamp: at(8)},
+		&Event{Locator: EventLocator{Type: "Step", Name: "src", Keys: map[string]any{"objects": []ObjectRef{{Kind: "Build", Name: "src"}}}}, From: at(2), To: at(9)},
+		&LeaseAcquisitionMetricEvent{RawLeaseName: "us-east-1--aws-quota-slice-01", AcquisitionDurationSeconds: 60, Timestamp: at(10)},
+		&PodLifecycleMetricsEvent{PodName: "e2e-pre", Namespace: "ns", CreationTime: ptr(at(10)), CompletionTime: ptr(at(20)), PodPhase: corev1.PodSucceeded, Timestamp: at(20)},
+		&PodLifecycleMetricsEvent{PodName: "e2e-test", Namespace: "ns", CreationTime: ptr(at(20)), CompletionTime: ptr(at(40)), PodPhase: corev1.PodFailed, Timestamp: at(40)},
+		&LeaseReleaseMetricEvent{RawLeaseName: "us-east-1--aws-quota-slice-01", Released: true, Timestamp: at(41)},
+		&Event{Locator: EventLocator{Type: "Step", Name: "e2e"}, Message: EventMessage{Cause: "pod failed"}, From: at(9), To: at(41)},
+		&Event{Locator: EventLocator{Type: "Node", Name: "node"}, From: at(0), To: at(41)},
+	} {
+		plugin.Record(ev)
+	}
+	raw, err := json.MarshalIndent(plugin.trace(at(42)), "", "  ")
+	if err != nil {
+		t.Fatalf("failed to marshal trace: %v", err)
+	}
+	testhelper.CompareWithFixture(t, raw)
+}
+
+type fakeExporter struct {
+	traces []*otlp.TracesData
+}
+
+func (e *fakeExporter) Export(_ context.Context, data *otlp.TracesData) error {
+	e.traces = append(e.traces, data)
+	return nil
+}
+
+func TestTracingAgent(t *testing.T) {
+	t.Setenv("ARTIFACT_DIR", t.TempDir())
+	agent := NewTracingAgent(context.Background())
+	exporter := &fakeExporter{}
+	agent.ExportTraces("prow-job-id", map[string]string{"test": "e2e"}, exporter)
+	done := make(chan struct{})
+	go func() {
+		agent.Run()
+		close(done)
+	}()
+	agent.Record(&InsightsEvent{Name: string(InsightStarted)})
+	agent.AddNodeWorkload(context.Background(), "ns", "e2e-test", "e2e", nil)
+	agent.StorePodLifecycleMetrics("e2e-test", "ns", corev1.PodSucceeded)
+	agent.Stop()
+	<-done
+	if len(exporter.traces) != 1 {
+		t.Fatalf("expected a single exported trace, got %d", len(exporter.traces))
+	}
+	if entries, err := os.ReadDir(os.Getenv("ARTIFACT_DIR")); err != nil || len(entries) != 0 {
+		t.Errorf("expected no metrics artifacts, got %v (%v)", entries, err)
+	}
+}
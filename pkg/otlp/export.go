@@ -0,0 +1,81 @@
+package otlp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+)
+
+// Exporter sends a trace to its destination.
+type Exporter interface {
+	Export(ctx context.Context, data *TracesData) error
+}
+
+// NewFileExporter creates an exporter which appends traces to a file, one
+// JSON-encoded export request per line, as expected by the file receivers of
+// OpenTelemetry collectors.
+func NewFileExporter(path string) Exporter {
+	return &fileExporter{path: path}
+}
+
+type fileExporter struct {
+	path string
+}
+
+func (e *fileExporter) Export(_ context.Context, data *TracesData) error {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("failed to marshal trace: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(e.path), 0755); err != nil {
+		return fmt.Errorf("failed to create directory for %s: %w", e.path, err)
+	}
+	f, err := os.OpenFile(e.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %w", e.path, err)
+	}
+	if _, err := f.Write(append(raw, '\n')); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to write %s: %w", e.path, err)
+	}
+	return f.Close()
+}
+
+// NewHTTPExporter creates an exporter which sends traces to a collector using
+// the JSON encoding of OTLP over HTTP. The endpoint is the full URL of the
+// traces resource, usually ending in `/v1/traces`.
+func NewHTTPExporter(endpoint string, client *http.Client) Exporter {
+	return &httpExporter{endpoint: endpoint, client: client}
+}
+
+type httpExporter struct {
+	endpoint string
+	client   *http.Client
+}
+
+func (e *httpExporter) Export(ctx context.Context, data *TracesData) error {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("failed to marshal trace: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.endpoint, bytes.NewReader(raw))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send trace to %s: %w", e.endpoint, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("collector at %s responded with %s: %s", e.endpoint, resp.Status, string(body))
+	}
+	return nil
+}
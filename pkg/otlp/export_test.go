@@ -0,0 +1,110 @@
+package otlp
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func testData() *TracesData {
+	start := time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+	trace := NewTrace("job")
+	root := trace.Add("", "root", start, start.Add(time.Hour), "")
+	trace.Add(root, "child", start, start.Add(time.Minute), "failed", String("key", "value"))
+	return trace.Data("scope", Attributes(map[string]string{"service.name": "test"}))
+}
+
+func TestTrace(t *testing.T) {
+	data := testData()
+	spans := data.ResourceSpans[0].ScopeSpans[0].Spans
+	if len(spans) != 2 {
+		t.Fatalf("expected two spans, got %d", len(spans))
+	}
+	root, child := spans[0], spans[1]
+	if root.TraceID != child.TraceID || len(root.TraceID) != 32 {
+		t.Errorf("invalid trace IDs %q, %q", root.TraceID, child.TraceID)
+	}
+	if root.ParentSpanID != "" || child.ParentSpanID != root.SpanID || len(child.SpanID) != 16 {
+		t.Errorf("invalid span IDs: %+v, %+v", root, child)
+	}
+	if diff := cmp.Diff(Status{Code: StatusCodeError, Message: "failed"}, child.Status); diff != "" {
+		t.Errorf("incorrect status: %s", diff)
+	}
+	if diff := cmp.Diff(testData(), data); diff != "" {
+		t.Errorf("trace is not stable: %s", diff)
+	}
+	raw, err := json.Marshal(child)
+	if err != nil {
+		t.Fatalf("failed to marshal span: %v", err)
+	}
+	if !strings.Contains(string(raw), `"startTimeUnixNano":"1735689600000000000"`) {
+		t.Errorf("timestamps must be encoded as strings: %s", raw)
+	}
+}
+
+func TestFileExporter(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "traces", "trace.jsonl")
+	exporter := NewFileExporter(path)
+	for i := 0; i < 2; i++ {
+		if err := exporter.Export(context.Background(), testData()); err != nil {
+			t.Fatalf("failed to export: %v", err)
+		}
+	}
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read trace: %v", err)
+	}
+	lines := strings.Split(strings.TrimSuffix(string(raw), "\n"), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected one line per export, got %d", len(lines))
+	}
+	var data TracesData
+	if err := json.Unmarshal([]byte(lines[1]), &data); err != nil {
+		t.Fatalf("failed to unmarshal trace: %v", err)
+	}
+	if diff := cmp.Diff(testData(), &data); diff != "" {
+		t.Errorf("incorrect trace: %s", diff)
+	}
+}
+
+func TestHTTPExporter(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		status      int
+		expectedErr bool
+	}{
+		{name: "accepted", status: http.StatusOK},
+		{name: "rejected", status: http.StatusBadRequest, expectedErr: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var received TracesData
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodPost || r.URL.Path != "/v1/traces" || r.Header.Get("Content-Type") != "application/json" {
+					t.Errorf("unexpected request: %s %s %s", r.Method, r.URL.Path, r.Header.Get("Content-Type"))
+				}
+				body, _ := io.ReadAll(r.Body)
+				if err := json.Unmarshal(body, &received); err != nil {
+					t.Errorf("failed to unmarshal request: %v", err)
+				}
+				w.WriteHeader(tc.status)
+			}))
+			defer server.Close()
+			err := NewHTTPExporter(server.URL+"/v1/traces", server.Client()).Export(context.Background(), testData())
+			if (err != nil) != tc.expectedErr {
+				t.Fatalf("expected error: %t, got: %v", tc.expectedErr, err)
+			}
+			if diff := cmp.Diff(testData(), &received); diff != "" {
+				t.Errorf("incorrect trace: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,148 @@
+// Package otlp implements the JSON encoding of traces defined by the
+// OpenTelemetry protocol and exporters which send traces to a collector or
+// write them to a file.
+package otlp
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"sort"
+	"time"
+)
+
+// TracesData is the payload of an OTLP trace export request.
+type TracesData struct {
+	ResourceSpans []ResourceSpans `json:"resourceSpans"`
+}
+
+// ResourceSpans holds the spans produced by a single resource.
+type ResourceSpans struct {
+	Resource   Resource     `json:"resource"`
+	ScopeSpans []ScopeSpans `json:"scopeSpans"`
+}
+
+// Resource describes the entity producing the spans.
+type Resource struct {
+	Attributes []KeyValue `json:"attributes,omitempty"`
+}
+
+// ScopeSpans holds the spans produced by a single instrumentation scope.
+type ScopeSpans struct {
+	Scope InstrumentationScope `json:"scope"`
+	Spans []Span               `json:"spans"`
+}
+
+// InstrumentationScope identifies the component producing the spans.
+type InstrumentationScope struct {
+	Name string `json:"name"`
+}
+
+// SpanKind is the type of a span.
+type SpanKind int
+
+const (
+	SpanKindInternal SpanKind = 1
+)
+
+// StatusCode is the outcome of the operation represented by a span.
+type StatusCode int
+
+const (
+	StatusCodeUnset StatusCode = 0
+	StatusCodeOk    StatusCode = 1
+	StatusCodeError StatusCode = 2
+)
+
+// Status of a span.
+type Status struct {
+	Code    StatusCode `json:"code,omitempty"`
+	Message string     `json:"message,omitempty"`
+}
+
+// Span is a single operation in a trace. Identifiers are hex-encoded, as
+// required by the JSON encoding of the protocol.
+type Span struct {
+	TraceID           string     `json:"traceId"`
+	SpanID            string     `json:"spanId"`
+	ParentSpanID      string     `json:"parentSpanId,omitempty"`
+	Name              string     `json:"name"`
+	Kind              SpanKind   `json:"kind"`
+	StartTimeUnixNano uint64     `json:"startTimeUnixNano,string"`
+	EndTimeUnixNano   uint64     `json:"endTimeUnixNano,string"`
+	Attributes        []KeyValue `json:"attributes,omitempty"`
+	Status            Status     `json:"status"`
+}
+
+// KeyValue is an attribute of a resource or a span.
+type KeyValue struct {
+	Key   string   `json:"key"`
+	Value AnyValue `json:"value"`
+}
+
+// AnyValue is the value of an attribute. Only strings are supported.
+type AnyValue struct {
+	StringValue string `json:"stringValue"`
+}
+
+// String creates a string attribute.
+func String(key, value string) KeyValue {
+	return KeyValue{Key: key, Value: AnyValue{StringValue: value}}
+}
+
+// Attributes converts a map into attributes sorted by key.
+func Attributes(m map[string]string) []KeyValue {
+	var ret []KeyValue
+	for k, v := range m {
+		ret = append(ret, String(k, v))
+	}
+	sort.Slice(ret, func(i, j int) bool { return ret[i].Key < ret[j].Key })
+	return ret
+}
+
+// Trace accumulates the spans of a single trace. Identifiers are derived from
+// the identifier of the trace and the name and start of each span, so
+// exporting the same execution twice produces the same trace.
+type Trace struct {
+	id    string
+	spans []Span
+}
+
+// NewTrace creates a trace identified by the hash of `id`.
+func NewTrace(id string) *Trace {
+	sum := sha256.Sum256([]byte(id))
+	return &Trace{id: hex.EncodeToString(sum[:16])}
+}
+
+// Add records a span and returns its identifier, to be used as the parent of
+// other spans. An empty parent creates a root span. A non-empty `failure`
+// marks the span as failed.
+func (t *Trace) Add(parent, name string, start, end time.Time, failure string, attributes ...KeyValue) string {
+	buf := make([]byte, 8)
+	binary.BigEndian.PutUint64(buf, uint64(start.UnixNano()))
+	sum := sha256.Sum256(append(append([]byte(t.id+parent+name), 0), buf...))
+	span := Span{
+		TraceID:           t.id,
+		SpanID:            hex.EncodeToString(sum[:8]),
+		ParentSpanID:      parent,
+		Name:              name,
+		Kind:              SpanKindInternal,
+		StartTimeUnixNano: uint64(start.UnixNano()),
+		EndTimeUnixNano:   uint64(end.UnixNano()),
+		Attributes:        attributes,
+		Status:            Status{Code: StatusCodeOk},
+	}
+	if failure != "" {
+		span.Status = Status{Code: StatusCodeError, Message: failure}
+	}
+	t.spans = append(t.spans, span)
+	return span.SpanID
+}
+
+// Data creates the export payload for the trace.
+func (t *Trace) Data(scope string, resource []KeyValue) *TracesData {
+	return &TracesData{ResourceSpans: []ResourceSpans{{
+		Resource:   Resource{Attributes: resource},
+		ScopeSpans: []ScopeSpans{{Scope: InstrumentationScope{Name: scope}, Spans: t.spans}},
+	}}}
+}
@@ -45,7 +45,7 @@ func GenerateJobs(configSpec *cioperatorapi.ReleaseBuildConfiguration, info *Pro
 	rehearsals := info.Config.Rehearsals
 	disabledRehearsals := sets.New[string](rehearsals.DisabledRehearsals...)
 
-	for _, element := range configSpec.Tests {
+	for _, element := range cioperatorapi.ExpandTestMatrices(configSpec.Tests) {
 		shardCount := 1
 		if element.ShardCount != nil {
 			shardCount = *element.ShardCount
@@ -811,6 +811,32 @@ func TestGenerateJobs(t *testing.T) {
 				Branch: "branch",
 			}},
 		},
+		{
+			id: "matrix presubmits",
+			config: &ciop.ReleaseBuildConfiguration{
+				Tests: []ciop.TestStepConfiguration{
+					{
+						As: "e2e",
+						Matrix: &ciop.TestMatrix{
+							Dimensions: []ciop.MatrixDimension{
+								{Env: "NETWORK_TYPE", Values: []ciop.MatrixValue{{Name: "ovn"}, {Name: "sdn"}}},
+								{Env: "IP_STACK", Values: []ciop.MatrixValue{{Name: "ipv4"}, {Name: "dualstack"}}},
+							},
+							Overrides: []ciop.MatrixOverride{
+								{Match: map[string]string{"NETWORK_TYPE": "sdn", "IP_STACK": "dualstack"}, Exclude: true},
+								{Match: map[string]string{"NETWORK_TYPE": "sdn"}, Optional: utilpointer.Bool(true)},
+							},
+						},
+						MultiStageTestConfiguration: &ciop.MultiStageTestConfiguration{Workflow: utilpointer.String("ipi-aws")},
+					},
+				},
+			},
+			repoInfo: &ProwgenInfo{Metadata: ciop.Metadata{
+				Org:    "organization",
+				Repo:   "repository",
+				Branch: "branch",
+			}},
+		},
 	}
 
 	for _, tc := range tests {
@@ -0,0 +1,224 @@
+package registry
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"github.com/openshift/ci-tools/pkg/api"
+)
+
+// DeprecationDateFormat is the format of the removal dates of deprecated
+// components.
+const DeprecationDateFormat = "2006-01-02"
+
+// Deprecations holds the deprecation notices of registry components by name.
+type Deprecations struct {
+	References map[string]api.RegistryDeprecation `json:"references,omitempty"`
+	Chains     map[string]api.RegistryDeprecation `json:"chains,omitempty"`
+	Workflows  map[string]api.RegistryDeprecation `json:"workflows,omitempty"`
+}
+
+func NewDeprecations() Deprecations {
+	return Deprecations{
+		References: map[string]api.RegistryDeprecation{},
+		Chains:     map[string]api.RegistryDeprecation{},
+		Workflows:  map[string]api.RegistryDeprecation{},
+	}
+}
+
+// byType returns the deprecation notices of the components of a type.
+func (d Deprecations) byType(t Type) map[string]api.RegistryDeprecation {
+	switch t {
+	case Reference:
+		return d.References
+	case Chain:
+		return d.Chains
+	case Workflow:
+		return d.Workflows
+	default:
+		return nil
+	}
+}
+
+// ValidateDeprecations checks that the deprecation notices give a reason, that
+// their removal dates are valid and that their replacements exist and are not
+// deprecated themselves.
+func ValidateDeprecations(refs ReferenceByName, chains ChainByName, workflows WorkflowByName, deprecations Deprecations) error {
+	var errs []error
+	exists := func(t Type, name string) bool {
+		switch t {
+		case Reference:
+			_, ok := refs[name]
+			return ok
+		case Chain:
+			_, ok := chains[name]
+			return ok
+		default:
+			_, ok := workflows[name]
+			return ok
+		}
+	}
+	for _, t := range []Type{Reference, Chain, Workflow} {
+		notices := deprecations.byType(t)
+		for _, name := range sets.List(sets.KeySet(notices)) {
+			deprecation := notices[name]
+			if deprecation.Reason == "" {
+				errs = append(errs, fmt.Errorf("%s %s: deprecation.reason must be set", nodeTypes[t], name))
+			}
+			if deprecation.RemovalDate != "" {
+				if _, err := time.Parse(DeprecationDateFormat, deprecation.RemovalDate); err != nil {
+					errs = append(errs, fmt.Errorf("%s %s: deprecation.removal_date %q is not in the YYYY-MM-DD format", nodeTypes[t], name, deprecation.RemovalDate))
+				}
+			}
+			if replacement := deprecation.Replacement; replacement != "" {
+				if !exists(t, replacement) {
+					errs = append(errs, fmt.Errorf("%s %s: deprecation.replacement %s does not exist", nodeTypes[t], name, replacement))
+				} else if _, deprecated := notices[replacement]; deprecated {
+					errs = append(errs, fmt.Errorf("%s %s: deprecation.replacement %s is deprecated as well", nodeTypes[t], name, replacement))
+				}
+			}
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+// Removed determines whether a deprecated component was removed at the time,
+// which happens at the start of its removal date (UTC).
+func Removed(deprecation api.RegistryDeprecation, now time.Time) bool {
+	if deprecation.RemovalDate == "" {
+		return false
+	}
+	removal, err := time.Parse(DeprecationDateFormat, deprecation.RemovalDate)
+	return err == nil && !now.Before(removal)
+}
+
+// DeprecatedUsage is the use of a deprecated component by a test.
+type DeprecatedUsage struct {
+	Test        string
+	Type        Type
+	Name        string
+	Deprecation api.RegistryDeprecation
+}
+
+// Removed determines whether the component was removed at the time.
+func (u DeprecatedUsage) Removed(now time.Time) bool {
+	return Removed(u.Deprecation, now)
+}
+
+func (u DeprecatedUsage) message(now time.Time) string {
+	var parts []string
+	if u.Removed(now) {
+		parts = append(parts, fmt.Sprintf("test %s uses %s %s, which was removed on %s: %s", u.Test, nodeTypes[u.Type], u.Name, u.Deprecation.RemovalDate, u.Deprecation.Reason))
+	} else {
+		parts = append(parts, fmt.Sprintf("test %s uses %s %s, which is deprecated: %s", u.Test, nodeTypes[u.Type], u.Name, u.Deprecation.Reason))
+	}
+	if u.Deprecation.Replacement != "" {
+		parts = append(parts, fmt.Sprintf("use %s %s instead", nodeTypes[u.Type], u.Deprecation.Replacement))
+	}
+	if !u.Removed(now) && u.Deprecation.RemovalDate != "" {
+		parts = append(parts, fmt.Sprintf("it will be removed on %s", u.Deprecation.RemovalDate))
+	}
+	return strings.Join(parts, "; ")
+}
+
+// DeprecationChecker finds the uses of deprecated registry components by the
+// multi-stage tests of configurations.
+type DeprecationChecker struct {
+	chains       ChainByName
+	workflows    WorkflowByName
+	deprecations Deprecations
+}
+
+func NewDeprecationChecker(chains ChainByName, workflows WorkflowByName, deprecations Deprecations) *DeprecationChecker {
+	return &DeprecationChecker{chains: chains, workflows: workflows, deprecations: deprecations}
+}
+
+// Usages lists the deprecated components the multi-stage tests of the
+// unresolved configuration use, directly or through their workflows and
+// chains, by test.
+func (c *DeprecationChecker) Usages(config api.ReleaseBuildConfiguration) []DeprecatedUsage {
+	var ret []DeprecatedUsage
+	for _, test := range config.Tests {
+		steps := test.MultiStageTestConfiguration
+		if steps == nil {
+			continue
+		}
+		used := map[Type]sets.Set[string]{Reference: sets.New[string](), Chain: sets.New[string](), Workflow: sets.New[string]()}
+		pre, testPhase, post := steps.Pre, steps.Test, steps.Post
+		if steps.Workflow != nil {
+			used[Workflow].Insert(*steps.Workflow)
+			if workflow, ok := c.workflows[*steps.Workflow]; ok {
+				if pre == nil {
+					pre = workflow.Pre
+				}
+				if testPhase == nil {
+					testPhase = workflow.Test
+				}
+				if post == nil {
+					post = workflow.Post
+				}
+			}
+		}
+		for _, phase := range [][]api.TestStep{pre, testPhase, post} {
+			c.collect(phase, used)
+		}
+		for _, t := range []Type{Workflow, Chain, Reference} {
+			notices := c.deprecations.byType(t)
+			for _, name := range sets.List(used[t]) {
+				if deprecation, ok := notices[name]; ok {
+					ret = append(ret, DeprecatedUsage{Test: test.As, Type: t, Name: name, Deprecation: deprecation})
+				}
+			}
+		}
+	}
+	return ret
+}
+
+// collect records the references and chains the steps consist of.
+func (c *DeprecationChecker) collect(steps []api.TestStep, used map[Type]sets.Set[string]) {
+	for _, step := range api.FlattenParallelSteps(steps) {
+		switch {
+		case step.Reference != nil:
+			used[Reference].Insert(*step.Reference)
+		case step.Chain != nil:
+			if used[Chain].Has(*step.Chain) {
+				continue
+			}
+			used[Chain].Insert(*step.Chain)
+			c.collect(c.chains[*step.Chain].Steps, used)
+		}
+	}
+}
+
+// Check returns warnings for the uses of deprecated components by the tests of
+// the configuration, and an error for the uses of components which were
+// removed at the time.
+func (c *DeprecationChecker) Check(config api.ReleaseBuildConfiguration, now time.Time) ([]string, error) {
+	var warnings []string
+	var errs []error
+	for _, usage := range c.Usages(config) {
+		if usage.Removed(now) {
+			errs = append(errs, fmt.Errorf("%s", usage.message(now)))
+		} else {
+			warnings = append(warnings, usage.message(now))
+		}
+	}
+	sort.Strings(warnings)
+	return warnings, utilerrors.NewAggregate(errs)
+}
+
+// ResolveConfig resolves the configuration unless it uses components which
+// were removed at the time, returning warnings for the deprecated ones.
+func (c *DeprecationChecker) ResolveConfig(resolver Resolver, config api.ReleaseBuildConfiguration, now time.Time) (api.ReleaseBuildConfiguration, []string, error) {
+	warnings, err := c.Check(config, now)
+	if err != nil {
+		return api.ReleaseBuildConfiguration{}, warnings, err
+	}
+	resolved, err := ResolveConfig(resolver, config)
+	return resolved, warnings, err
+}
@@ -0,0 +1,178 @@
+package registry
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/openshift/ci-tools/pkg/api"
+	"github.com/openshift/ci-tools/pkg/testhelper"
+)
+
+func TestValidateDeprecations(t *testing.T) {
+	for _, tc := range []struct {
+		name         string
+		deprecations Deprecations
+		expected     error
+	}{{
+		name:         "no deprecations",
+		deprecations: NewDeprecations(),
+	}, {
+		name: "valid deprecations",
+		deprecations: Deprecations{
+			References: map[string]api.RegistryDeprecation{
+				ipiConf: {Reason: "merged", Replacement: ipiConfAWS, RemovalDate: "2020-01-01"},
+			},
+			Chains: map[string]api.RegistryDeprecation{
+				nested: {Reason: "unused"},
+			},
+			Workflows: map[string]api.RegistryDeprecation{
+				ipi: {Reason: "unused", RemovalDate: "2030-12-31"},
+			},
+		},
+	}, {
+		name: "invalid deprecations",
+		deprecations: Deprecations{
+			References: map[string]api.RegistryDeprecation{
+				ipiConf:    {Reason: "merged", Replacement: ipiConfAWS},
+				ipiConfAWS: {Reason: "merged", Replacement: ipiInstall},
+			},
+			Chains: map[string]api.RegistryDeprecation{
+				nested: {Replacement: ipi, RemovalDate: "2020/01/01"},
+			},
+		},
+		expected: errors.New("[reference ipi-conf: deprecation.replacement ipi-conf-aws is deprecated as well, reference ipi-conf-aws: deprecation.replacement ipi-install does not exist, chain nested: deprecation.reason must be set, chain nested: deprecation.removal_date \"2020/01/01\" is not in the YYYY-MM-DD format, chain nested: deprecation.replacement ipi does not exist]"),
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateDeprecations(referenceMap, chainMap, workflowMap, tc.deprecations)
+			testhelper.Diff(t, "error", err, tc.expected, testhelper.EquateErrorMessage)
+		})
+	}
+}
+
+func TestDeprecationCheckerCheck(t *testing.T) {
+	now := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	deprecations := Deprecations{
+		References: map[string]api.RegistryDeprecation{
+			ipiInstallRBAC: {Reason: "RBAC is set up by the installer", RemovalDate: "2025-06-01"},
+		},
+		Chains: map[string]api.RegistryDeprecation{
+			ipiDeprovision: {Reason: "renamed", Replacement: nested},
+		},
+		Workflows: map[string]api.RegistryDeprecation{
+			ipi: {Reason: "unused", RemovalDate: "2025-06-02"},
+		},
+	}
+	for _, tc := range []struct {
+		name             string
+		tests            []api.TestStepConfiguration
+		expectedWarnings []string
+		expectedErr      error
+	}{{
+		name: "no multi-stage tests",
+		tests: []api.TestStepConfiguration{{
+			As:                         "unit",
+			ContainerTestConfiguration: &api.ContainerTestConfiguration{From: "src"},
+		}},
+	}, {
+		name: "deprecated workflow and the chains it uses",
+		tests: []api.TestStepConfiguration{{
+			As: "e2e",
+			MultiStageTestConfiguration: &api.MultiStageTestConfiguration{
+				Workflow: &ipi,
+			},
+		}},
+		expectedWarnings: []string{
+			"test e2e uses chain ipi-deprovision, which is deprecated: renamed; use chain nested instead",
+			"test e2e uses workflow ipi, which is deprecated: unused; it will be removed on 2025-06-02",
+		},
+		expectedErr: errors.New("test e2e uses reference ipi-install-rbac, which was removed on 2025-06-01: RBAC is set up by the installer"),
+	}, {
+		name: "overridden phases do not use the workflow's steps",
+		tests: []api.TestStepConfiguration{{
+			As: "e2e",
+			MultiStageTestConfiguration: &api.MultiStageTestConfiguration{
+				Workflow: &ipi,
+				Pre:      []api.TestStep{{Reference: &ipiConf}},
+				Post:     []api.TestStep{},
+			},
+		}},
+		expectedWarnings: []string{
+			"test e2e uses workflow ipi, which is deprecated: unused; it will be removed on 2025-06-02",
+		},
+	}, {
+		name: "deprecated references in nested chains",
+		tests: []api.TestStepConfiguration{{
+			As: "e2e",
+			MultiStageTestConfiguration: &api.MultiStageTestConfiguration{
+				Test: []api.TestStep{{Chain: &nested}},
+			},
+		}, {
+			As: "other",
+			MultiStageTestConfiguration: &api.MultiStageTestConfiguration{
+				Test: []api.TestStep{{Reference: &ipiConf}},
+			},
+		}},
+		expectedWarnings: []string{
+			"test e2e uses chain ipi-deprovision, which is deprecated: renamed; use chain nested instead",
+		},
+		expectedErr: errors.New("test e2e uses reference ipi-install-rbac, which was removed on 2025-06-01: RBAC is set up by the installer"),
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			checker := NewDeprecationChecker(chainMap, workflowMap, deprecations)
+			warnings, err := checker.Check(api.ReleaseBuildConfiguration{Tests: tc.tests}, now)
+			testhelper.Diff(t, "warnings", warnings, tc.expectedWarnings)
+			testhelper.Diff(t, "error", err, tc.expectedErr, testhelper.EquateErrorMessage)
+		})
+	}
+}
+
+type countingResolver struct {
+	Resolver
+	calls int
+}
+
+func (r *countingResolver) Resolve(string, api.MultiStageTestConfiguration) (api.MultiStageTestConfigurationLiteral, error) {
+	r.calls++
+	return api.MultiStageTestConfigurationLiteral{}, nil
+}
+
+func TestDeprecationCheckerResolveConfig(t *testing.T) {
+	now := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	checker := NewDeprecationChecker(chainMap, workflowMap, Deprecations{
+		References: map[string]api.RegistryDeprecation{
+			ipiInstallRBAC: {Reason: "RBAC is set up by the installer", RemovalDate: "2025-06-01"},
+		},
+		Chains: map[string]api.RegistryDeprecation{
+			ipiDeprovision: {Reason: "renamed", Replacement: nested},
+		},
+	})
+	for _, tc := range []struct {
+		name             string
+		test             api.TestStep
+		expectedWarnings []string
+		expectedErr      error
+		expectedCalls    int
+	}{{
+		name:             "deprecated components are resolved with warnings",
+		test:             api.TestStep{Chain: &ipiDeprovision},
+		expectedWarnings: []string{"test e2e uses chain ipi-deprovision, which is deprecated: renamed; use chain nested instead"},
+		expectedCalls:    1,
+	}, {
+		name:        "removed components are not resolved",
+		test:        api.TestStep{Reference: &ipiInstallRBAC},
+		expectedErr: errors.New("test e2e uses reference ipi-install-rbac, which was removed on 2025-06-01: RBAC is set up by the installer"),
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			resolver := &countingResolver{}
+			config := api.ReleaseBuildConfiguration{Tests: []api.TestStepConfiguration{{
+				As:                          "e2e",
+				MultiStageTestConfiguration: &api.MultiStageTestConfiguration{Test: []api.TestStep{tc.test}},
+			}}}
+			_, warnings, err := checker.ResolveConfig(resolver, config, now)
+			testhelper.Diff(t, "warnings", warnings, tc.expectedWarnings)
+			testhelper.Diff(t, "error", err, tc.expectedErr, testhelper.EquateErrorMessage)
+			testhelper.Diff(t, "calls", resolver.calls, tc.expectedCalls)
+		})
+	}
+}
@@ -121,6 +121,7 @@ func (r *registry) mergeWorkflow(config *api.MultiStageTestConfiguration) ([][]a
 	if config.AllowBestEffortPostSteps == nil {
 		config.AllowBestEffortPostSteps = workflow.AllowBestEffortPostSteps
 	}
+	config.Budgets = overwriteIfUnset(workflow.Budgets, config.Budgets)
 	return overridden, errs
 }
 
@@ -134,6 +135,7 @@ func (r *registry) resolveTest(
 		ClusterProfile:           config.ClusterProfile,
 		AllowSkipOnSuccess:       config.AllowSkipOnSuccess,
 		AllowBestEffortPostSteps: config.AllowBestEffortPostSteps,
+		Budgets:                  config.Budgets,
 		Leases:                   config.Leases,
 		DependencyOverrides:      config.DependencyOverrides,
 	}
@@ -266,21 +268,39 @@ func mergeLeases(dst, src []api.StepLease) ([]api.StepLease, error) {
 
 func (r *registry) process(steps []api.TestStep, seen sets.Set[string], stack stack) (ret []api.LiteralTestStep, errs []error) {
 	for _, step := range steps {
+		var processed []api.LiteralTestStep
 		if step.Chain != nil {
 			steps, err := r.processChain(*step.Chain, seen, stack)
 			errs = append(errs, err...)
-			ret = append(ret, steps...)
+			processed = steps
+		} else if step.Parallel != nil {
+			steps, err := r.processParallel(step.Parallel, seen, stack)
+			errs = append(errs, err...)
+			processed = steps
 		} else {
 			step, err := r.processStep(&step, seen, stack)
 			errs = append(errs, err...)
 			if err == nil {
-				ret = append(ret, step)
+				processed = []api.LiteralTestStep{step}
 			}
 		}
+		ret = append(ret, addConditions(processed, step.When)...)
 	}
 	return
 }
 
+// addConditions prepends the conditions of a step which references other
+// steps to the conditions of each of them.
+func addConditions(steps []api.LiteralTestStep, conditions []api.StepCondition) []api.LiteralTestStep {
+	if len(conditions) == 0 {
+		return steps
+	}
+	for i := range steps {
+		steps[i].When = append(append([]api.StepCondition(nil), conditions...), steps[i].When...)
+	}
+	return steps
+}
+
 func (r *registry) processChain(name string, seen sets.Set[string], stack stack) ([]api.LiteralTestStep, []error) {
 	chain, ok := r.chainsByName[name]
 	if !ok {
@@ -294,6 +314,26 @@ func (r *registry) processChain(name string, seen sets.Set[string], stack stack)
 	return ret, err
 }
 
+// processParallel resolves the members of a parallel group, marking them all
+// with the same group name so they are executed concurrently.
+func (r *registry) processParallel(steps []api.ParallelTestStep, seen sets.Set[string], stack stack) (ret []api.LiteralTestStep, errs []error) {
+	for _, member := range steps {
+		member := member.TestStep()
+		step, err := r.processStep(&member, seen, stack)
+		errs = append(errs, err...)
+		if err == nil {
+			ret = append(ret, step)
+		}
+	}
+	if len(ret) != 0 {
+		group := ret[0].As
+		for i := range ret {
+			ret[i].ParallelGroup = group
+		}
+	}
+	return
+}
+
 func (r *registry) processStep(step *api.TestStep, seen sets.Set[string], stack stack) (ret api.LiteralTestStep, err []error) {
 	if ref := step.Reference; ref != nil {
 		var ok bool
@@ -303,6 +343,9 @@ func (r *registry) processStep(step *api.TestStep, seen sets.Set[string], stack
 		}
 	} else if step.LiteralTestStep != nil {
 		ret = *step.LiteralTestStep
+		if ret.ParallelGroup != "" {
+			return api.LiteralTestStep{}, []error{stack.errorf("step/%s: `parallel_group` cannot be set by hand, use a `parallel` block instead", ret.As)}
+		}
 	} else {
 		return api.LiteralTestStep{}, []error{stack.errorf("encountered TestStep where both `Reference` and `LiteralTestStep` are nil")}
 	}
@@ -381,6 +424,12 @@ func (r *registry) iterateSteps(s api.TestStep, f func(*api.LiteralTestStep)) er
 				return err
 			}
 		}
+	case s.Parallel != nil:
+		for _, s := range s.Parallel {
+			if err := r.iterateSteps(s.TestStep(), f); err != nil {
+				return err
+			}
+		}
 	case s.Reference != nil:
 		r, ok := r.stepsByName[*s.Reference]
 		if !ok {
@@ -396,7 +445,7 @@ func (r *registry) iterateSteps(s api.TestStep, f func(*api.LiteralTestStep)) er
 // ResolveConfig uses a resolver to resolve an entire ci-operator config
 func ResolveConfig(resolver Resolver, config api.ReleaseBuildConfiguration) (api.ReleaseBuildConfiguration, error) {
 	var resolvedTests []api.TestStepConfiguration
-	for _, step := range config.Tests {
+	for _, step := range api.ExpandTestMatrices(config.Tests) {
 		// no changes if step is not multi-stage
 		if step.MultiStageTestConfiguration == nil {
 			resolvedTests = append(resolvedTests, step)
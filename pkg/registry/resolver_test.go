@@ -5,11 +5,13 @@ import (
 	"fmt"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 
 	"k8s.io/apimachinery/pkg/util/diff"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	prowv1 "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
 
 	"github.com/openshift/ci-tools/pkg/api"
 	"github.com/openshift/ci-tools/pkg/testhelper"
@@ -45,6 +47,7 @@ func TestResolve(t *testing.T) {
 			ClusterProfile:           api.ClusterProfileAWS,
 			AllowSkipOnSuccess:       &yes,
 			AllowBestEffortPostSteps: &yes,
+			Budgets:                  &api.PhaseBudgets{Post: &prowv1.Duration{Duration: 30 * time.Minute}},
 			Pre: []api.TestStep{{
 				LiteralTestStep: &api.LiteralTestStep{
 					As:       "ipi-install",
@@ -81,6 +84,7 @@ func TestResolve(t *testing.T) {
 			ClusterProfile:           api.ClusterProfileAWS,
 			AllowSkipOnSuccess:       &yes,
 			AllowBestEffortPostSteps: &yes,
+			Budgets:                  &api.PhaseBudgets{Post: &prowv1.Duration{Duration: 30 * time.Minute}},
 			Pre: []api.LiteralTestStep{{
 				As:       "ipi-install",
 				From:     "installer",
@@ -1572,6 +1576,81 @@ func TestResolve(t *testing.T) {
 				Post: []api.LiteralTestStep{{As: "ipi-teardown", NodeArchitecture: &nodeArchitectureARM64}},
 			},
 		},
+		{
+			name: "Parallel group in a chain is resolved with a group name",
+			config: api.MultiStageTestConfiguration{
+				Workflow: &awsWorkflow,
+			},
+			chainMap: ChainByName{
+				"gather": {
+					Steps: []api.TestStep{
+						{LiteralTestStep: &api.LiteralTestStep{As: "gather-begin"}},
+						{Parallel: []api.ParallelTestStep{
+							{LiteralTestStep: &api.LiteralTestStep{As: "gather-extra"}},
+							{Reference: &teardownRef},
+						}},
+					},
+				},
+			},
+			stepMap: ReferenceByName{teardownRef: {As: "ipi-teardown"}},
+			workflowMap: WorkflowByName{
+				awsWorkflow: {
+					ClusterProfile: api.ClusterProfileAWS,
+					Test:           []api.TestStep{{LiteralTestStep: &api.LiteralTestStep{As: "e2e"}}},
+					Post:           []api.TestStep{{Chain: strPtr("gather")}},
+				},
+			},
+			expectedRes: api.MultiStageTestConfigurationLiteral{
+				ClusterProfile: api.ClusterProfileAWS,
+				Test:           []api.LiteralTestStep{{As: "e2e"}},
+				Post: []api.LiteralTestStep{
+					{As: "gather-begin"},
+					{As: "gather-extra", ParallelGroup: "gather-extra"},
+					{As: "ipi-teardown", ParallelGroup: "gather-extra"},
+				},
+			},
+		},
+		{
+			name: "Literal step setting its parallel group by hand is rejected",
+			config: api.MultiStageTestConfiguration{
+				Test: []api.TestStep{{LiteralTestStep: &api.LiteralTestStep{As: "e2e", ParallelGroup: "e2e"}}},
+			},
+			expectedErr: errors.New("test/test: step/e2e: `parallel_group` cannot be set by hand, use a `parallel` block instead"),
+		},
+		{
+			name: "Conditions of chains and references are added to their steps",
+			config: api.MultiStageTestConfiguration{
+				Workflow: &awsWorkflow,
+			},
+			chainMap: ChainByName{
+				"gather": {
+					Steps: []api.TestStep{
+						{LiteralTestStep: &api.LiteralTestStep{As: "gather-begin"}},
+						{Reference: &teardownRef, When: []api.StepCondition{{Env: "TEARDOWN", Operator: api.StepConditionOperatorNotIn, Values: []string{"false"}}}},
+					},
+				},
+			},
+			stepMap: ReferenceByName{teardownRef: {As: "ipi-teardown", When: []api.StepCondition{{Env: "CLUSTER", Operator: api.StepConditionOperatorIn, Values: []string{"true"}}}}},
+			workflowMap: WorkflowByName{
+				awsWorkflow: {
+					ClusterProfile: api.ClusterProfileAWS,
+					Test:           []api.TestStep{{LiteralTestStep: &api.LiteralTestStep{As: "e2e"}}},
+					Post:           []api.TestStep{{Chain: strPtr("gather"), When: []api.StepCondition{{Env: "GATHER", Operator: api.StepConditionOperatorIn, Values: []string{"true"}}}}},
+				},
+			},
+			expectedRes: api.MultiStageTestConfigurationLiteral{
+				ClusterProfile: api.ClusterProfileAWS,
+				Test:           []api.LiteralTestStep{{As: "e2e"}},
+				Post: []api.LiteralTestStep{
+					{As: "gather-begin", When: []api.StepCondition{{Env: "GATHER", Operator: api.StepConditionOperatorIn, Values: []string{"true"}}}},
+					{As: "ipi-teardown", When: []api.StepCondition{
+						{Env: "GATHER", Operator: api.StepConditionOperatorIn, Values: []string{"true"}},
+						{Env: "TEARDOWN", Operator: api.StepConditionOperatorNotIn, Values: []string{"false"}},
+						{Env: "CLUSTER", Operator: api.StepConditionOperatorIn, Values: []string{"true"}},
+					}},
+				},
+			},
+		},
 	} {
 		t.Run(testCase.name, func(t *testing.T) {
 			err := Validate(testCase.stepMap, testCase.chainMap, testCase.workflowMap, testCase.observerMap)
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strconv"
 	"strings"
 
 	"github.com/hashicorp/go-retryablehttp"
@@ -163,9 +164,21 @@ func doRequest(req *http.Request) ([]byte, error) {
 		}
 		return nil, fmt.Errorf("got unexpected http %d status code from configresolver: %s", resp.StatusCode, responseBody)
 	}
+	for _, warning := range resp.Header.Values("Warning") {
+		logrus.Warn(warningMessage(warning))
+	}
 	return io.ReadAll(resp.Body)
 }
 
+// warningMessage extracts the message from the value of a Warning header
+// written by FormatWarning, falling back to the raw value.
+func warningMessage(value string) string {
+	if message, err := strconv.Unquote(strings.TrimPrefix(value, "299 - ")); err == nil {
+		return message
+	}
+	return value
+}
+
 // ClusterProfile gets the info about a desired cluster profile by creating a request
 // to config resolver
 func (r *resolverClient) ClusterProfile(profileName string) (*api.ClusterProfileDetails, error) {
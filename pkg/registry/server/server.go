@@ -6,6 +6,7 @@ import (
 	"io"
 	"net/http"
 	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/sirupsen/logrus"
@@ -40,6 +41,13 @@ type Resolver interface {
 	ResolveConfig(config api.ReleaseBuildConfiguration) (api.ReleaseBuildConfiguration, error)
 }
 
+// WarningResolver is implemented by resolvers which know about deprecated
+// registry components.  Resolved configurations using them are served with a
+// Warning header for each deprecated component.
+type WarningResolver interface {
+	ResolveConfigWithWarnings(config api.ReleaseBuildConfiguration) (api.ReleaseBuildConfiguration, []string, error)
+}
+
 type Getter interface {
 	// GetMatchingConfig loads a configuration that matches the metadata,
 	// allowing for regex matching on branch names.
@@ -80,7 +88,13 @@ func MissingQuery(w http.ResponseWriter, field string) {
 }
 
 func resolveAndRespond(resolver Resolver, config api.ReleaseBuildConfiguration, w http.ResponseWriter, logger *logrus.Entry, resolverMetrics *metrics.Metrics) {
-	config, err := resolver.ResolveConfig(config)
+	var warnings []string
+	var err error
+	if warningResolver, ok := resolver.(WarningResolver); ok {
+		config, warnings, err = warningResolver.ResolveConfigWithWarnings(config)
+	} else {
+		config, err = resolver.ResolveConfig(config)
+	}
 	if err != nil {
 		metrics.RecordError("failed to resolve config with registry", resolverMetrics.ErrorRate)
 		w.WriteHeader(http.StatusBadRequest)
@@ -99,12 +113,21 @@ func resolveAndRespond(resolver Resolver, config api.ReleaseBuildConfiguration,
 		logger.WithError(err).Errorf("failed to marshal config to JSON")
 		return
 	}
+	for _, warning := range warnings {
+		w.Header().Add("Warning", FormatWarning(warning))
+	}
 	w.WriteHeader(http.StatusOK)
 	if _, err := w.Write(jsonConfig); err != nil {
 		logrus.WithError(err).Error("Failed to write response")
 	}
 }
 
+// FormatWarning formats a message as the value of a Warning header with the
+// 299 (miscellaneous persistent warning) code.
+func FormatWarning(message string) string {
+	return fmt.Sprintf("299 - %s", strconv.Quote(message))
+}
+
 func getInjectTestFromQuery(w http.ResponseWriter, r *http.Request) (*api.MetadataWithTest, error) {
 	var ret api.MetadataWithTest
 
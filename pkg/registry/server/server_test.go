@@ -1,13 +1,17 @@
 package server
 
 import (
+	"errors"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
 
 	"sigs.k8s.io/prow/pkg/metrics"
+
+	"github.com/openshift/ci-tools/pkg/api"
 )
 
 var configresolverMetrics = metrics.NewMetrics("unittest")
@@ -52,3 +56,73 @@ func TestResolveAndMergeConfigsAndInjectTest(t *testing.T) {
 	}
 
 }
+
+type fakeDeprecationResolver struct {
+	warnings []string
+	err      error
+}
+
+func (r fakeDeprecationResolver) ResolveConfig(config api.ReleaseBuildConfiguration) (api.ReleaseBuildConfiguration, error) {
+	return config, r.err
+}
+
+func (r fakeDeprecationResolver) ResolveConfigWithWarnings(config api.ReleaseBuildConfiguration) (api.ReleaseBuildConfiguration, []string, error) {
+	return config, r.warnings, r.err
+}
+
+func TestResolveLiteralConfigDeprecations(t *testing.T) {
+	var testCases = []struct {
+		name             string
+		resolver         Resolver
+		expectedCode     int
+		expectedWarnings []string
+	}{
+		{
+			name:         "no deprecated components",
+			resolver:     fakeDeprecationResolver{},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "deprecated components",
+			resolver:     fakeDeprecationResolver{warnings: []string{"test e2e uses chain old, which is deprecated: \"old\" is old"}},
+			expectedCode: http.StatusOK,
+			expectedWarnings: []string{
+				`299 - "test e2e uses chain old, which is deprecated: \"old\" is old"`,
+			},
+		},
+		{
+			name: "removed components",
+			resolver: fakeDeprecationResolver{
+				warnings: []string{"test e2e uses chain old, which is deprecated: old"},
+				err:      errors.New("test e2e uses reference older, which was removed on 2020-01-01: older"),
+			},
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			req, err := http.NewRequest("POST", "https://config.ci.openshift.org/resolve", strings.NewReader("{}"))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			rr := httptest.NewRecorder()
+			handler := ResolveLiteralConfig(testCase.resolver, configresolverMetrics)
+
+			handler.ServeHTTP(rr, req)
+
+			if diff := cmp.Diff(testCase.expectedCode, rr.Code); diff != "" {
+				t.Errorf("code differs from expected:\n%s", diff)
+			}
+			if diff := cmp.Diff(testCase.expectedWarnings, rr.Header().Values("Warning")); diff != "" {
+				t.Errorf("warnings differ from expected:\n%s", diff)
+			}
+			for _, warning := range rr.Header().Values("Warning") {
+				if message := warningMessage(warning); !strings.HasPrefix(message, "test e2e") {
+					t.Errorf("failed to parse the warning back: %s", message)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,303 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/prow/pkg/metrics"
+
+	"github.com/openshift/ci-tools/pkg/api"
+	"github.com/openshift/ci-tools/pkg/config"
+	"github.com/openshift/ci-tools/pkg/jobconfig"
+	"github.com/openshift/ci-tools/pkg/load/agents"
+	"github.com/openshift/ci-tools/pkg/registry"
+)
+
+// TypeQuery is used for fetching the usage of a registry component by its type
+const TypeQuery = "type"
+
+// Types of registry components, as used in the URLs of the registry UI
+const (
+	ReferenceType = "reference"
+	ChainType     = "chain"
+	WorkflowType  = "workflow"
+)
+
+// Usage lists the consumers of a registry component: the chains and workflows
+// which include it, directly or through other chains, and the tests in the
+// ci-operator configuration which run it, with the names of their jobs.
+type Usage struct {
+	Type      string      `json:"type"`
+	Name      string      `json:"name"`
+	Chains    []string    `json:"chains"`
+	Workflows []string    `json:"workflows"`
+	Tests     []TestUsage `json:"tests"`
+}
+
+// TestUsage is a test in a ci-operator configuration which uses a registry
+// component, with the names of the jobs generated for it.
+type TestUsage struct {
+	api.Metadata `json:",inline"`
+	Test         string   `json:"test"`
+	Jobs         []string `json:"jobs"`
+}
+
+type component struct {
+	componentType, name string
+}
+
+type consumers struct {
+	chains, workflows sets.Set[string]
+	tests             []TestUsage
+}
+
+// UsageIndex is a reverse index from registry components to their consumers.
+type UsageIndex struct {
+	refs      registry.ReferenceByName
+	chains    registry.ChainByName
+	workflows registry.WorkflowByName
+	usage     map[component]*consumers
+}
+
+// NewUsageIndex indexes the consumers of the components in the registry.
+// Tests of the configurations which do not override a phase of their workflow
+// consume the steps of the workflow in that phase.
+func NewUsageIndex(refs registry.ReferenceByName, chains registry.ChainByName, workflows registry.WorkflowByName, configs config.ByOrgRepo) *UsageIndex {
+	index := &UsageIndex{refs: refs, chains: chains, workflows: workflows, usage: map[component]*consumers{}}
+	expanded := map[string]sets.Set[component]{}
+	for name := range chains {
+		for c := range index.expandChain(name, expanded, sets.New[string]()) {
+			index.consumers(c).chains.Insert(name)
+		}
+	}
+	for name, workflow := range workflows {
+		for c := range index.expandSteps(phases(workflow), expanded) {
+			index.consumers(c).workflows.Insert(name)
+		}
+	}
+	for _, repos := range configs {
+		for _, orgRepoConfigs := range repos {
+			for _, configuration := range orgRepoConfigs {
+				for _, test := range api.ExpandTestMatrices(configuration.Tests) {
+					steps := test.MultiStageTestConfiguration
+					if steps == nil {
+						continue
+					}
+					used := sets.New[component]()
+					workflow := api.MultiStageTestConfiguration{Pre: steps.Pre, Test: steps.Test, Post: steps.Post}
+					if steps.Workflow != nil {
+						used.Insert(component{componentType: WorkflowType, name: *steps.Workflow})
+						if fromWorkflow, ok := workflows[*steps.Workflow]; ok {
+							if workflow.Pre == nil {
+								workflow.Pre = fromWorkflow.Pre
+							}
+							if workflow.Test == nil {
+								workflow.Test = fromWorkflow.Test
+							}
+							if workflow.Post == nil {
+								workflow.Post = fromWorkflow.Post
+							}
+						}
+					}
+					used = used.Union(index.expandSteps(phases(workflow), expanded))
+					usage := TestUsage{Metadata: configuration.Metadata, Test: test.As, Jobs: jobNames(configuration.Metadata, test)}
+					for c := range used {
+						index.consumers(c).tests = append(index.consumers(c).tests, usage)
+					}
+				}
+			}
+		}
+	}
+	return index
+}
+
+func (i *UsageIndex) consumers(c component) *consumers {
+	if _, ok := i.usage[c]; !ok {
+		i.usage[c] = &consumers{chains: sets.New[string](), workflows: sets.New[string]()}
+	}
+	return i.usage[c]
+}
+
+func phases(workflow api.MultiStageTestConfiguration) []api.TestStep {
+	var steps []api.TestStep
+	steps = append(steps, workflow.Pre...)
+	steps = append(steps, workflow.Test...)
+	return append(steps, workflow.Post...)
+}
+
+// expandSteps returns the references and chains the steps consist of,
+// including those in nested chains.
+func (i *UsageIndex) expandSteps(steps []api.TestStep, expanded map[string]sets.Set[component]) sets.Set[component] {
+	ret := sets.New[component]()
+	for _, step := range api.FlattenParallelSteps(steps) {
+		switch {
+		case step.Reference != nil:
+			ret.Insert(component{componentType: ReferenceType, name: *step.Reference})
+		case step.Chain != nil:
+			ret.Insert(component{componentType: ChainType, name: *step.Chain})
+			ret = ret.Union(i.expandChain(*step.Chain, expanded, sets.New[string]()))
+		}
+	}
+	return ret
+}
+
+// expandChain returns the references and chains a chain consists of. Cycles,
+// which the registry loader rejects, are ignored.
+func (i *UsageIndex) expandChain(name string, expanded map[string]sets.Set[component], visiting sets.Set[string]) sets.Set[component] {
+	if ret, ok := expanded[name]; ok {
+		return ret
+	}
+	ret := sets.New[component]()
+	if visiting.Has(name) {
+		return ret
+	}
+	visiting.Insert(name)
+	for _, step := range api.FlattenParallelSteps(i.chains[name].Steps) {
+		switch {
+		case step.Reference != nil:
+			ret.Insert(component{componentType: ReferenceType, name: *step.Reference})
+		case step.Chain != nil:
+			ret.Insert(component{componentType: ChainType, name: *step.Chain})
+			ret = ret.Union(i.expandChain(*step.Chain, expanded, visiting))
+		}
+	}
+	expanded[name] = ret
+	return ret
+}
+
+// jobNames returns the names of the jobs generated for a test, one per shard.
+func jobNames(metadata api.Metadata, test api.TestStepConfiguration) []string {
+	var prefixes []string
+	switch {
+	case test.IsPeriodic():
+		prefixes = append(prefixes, jobconfig.PeriodicPrefix)
+		if test.Presubmit {
+			prefixes = append(prefixes, jobconfig.PresubmitPrefix)
+		}
+	case test.Postsubmit:
+		prefixes = append(prefixes, jobconfig.PostsubmitPrefix)
+	default:
+		prefixes = append(prefixes, jobconfig.PresubmitPrefix)
+	}
+	var names []string
+	for _, prefix := range prefixes {
+		name := metadata.JobName(prefix, test.As)
+		if test.ShardCount == nil || *test.ShardCount <= 1 {
+			names = append(names, name)
+			continue
+		}
+		for shard := 1; shard <= *test.ShardCount; shard++ {
+			names = append(names, fmt.Sprintf("%s-%dof%d", name, shard, *test.ShardCount))
+		}
+	}
+	return names
+}
+
+// Usage returns the consumers of a registry component, or false when the
+// registry has no component of the type with the name.
+func (i *UsageIndex) Usage(componentType, name string) (Usage, bool) {
+	var exists bool
+	switch componentType {
+	case ReferenceType:
+		_, exists = i.refs[name]
+	case ChainType:
+		_, exists = i.chains[name]
+	case WorkflowType:
+		_, exists = i.workflows[name]
+	}
+	if !exists {
+		return Usage{}, false
+	}
+	usage := Usage{Type: componentType, Name: name, Chains: []string{}, Workflows: []string{}, Tests: []TestUsage{}}
+	if c, ok := i.usage[component{componentType: componentType, name: name}]; ok {
+		usage.Chains = sets.List(c.chains)
+		usage.Workflows = sets.List(c.workflows)
+		usage.Tests = append(usage.Tests, c.tests...)
+		sort.Slice(usage.Tests, func(i, j int) bool {
+			a, b := usage.Tests[i], usage.Tests[j]
+			if a.Metadata.AsString() != b.Metadata.AsString() {
+				return a.Metadata.AsString() < b.Metadata.AsString()
+			}
+			return a.Test < b.Test
+		})
+	}
+	return usage, true
+}
+
+// UsageIndexer keeps a usage index up to date with the configurations and the
+// registry, rebuilding it when either of them was reloaded.
+type UsageIndexer struct {
+	lock               sync.Mutex
+	configs            agents.ConfigAgent
+	registry           agents.RegistryAgent
+	configGeneration   int
+	registryGeneration int
+	index              *UsageIndex
+}
+
+func NewUsageIndexer(configs agents.ConfigAgent, registry agents.RegistryAgent) *UsageIndexer {
+	return &UsageIndexer{configs: configs, registry: registry}
+}
+
+// Index returns the usage index for the current configurations and registry.
+func (i *UsageIndexer) Index() *UsageIndex {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+	configGeneration, registryGeneration := i.configs.GetGeneration(), i.registry.GetGeneration()
+	if i.index != nil && i.configGeneration == configGeneration && i.registryGeneration == registryGeneration {
+		return i.index
+	}
+	refs, chains, workflows, _, _ := i.registry.GetRegistryComponents()
+	i.index = NewUsageIndex(refs, chains, workflows, i.configs.GetAll())
+	i.configGeneration, i.registryGeneration = configGeneration, registryGeneration
+	return i.index
+}
+
+// ResolveUsage responds with the consumers of the registry component
+// identified by the type and name in the request query
+func ResolveUsage(indexer *UsageIndexer, resolverMetrics *metrics.Metrics) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" {
+			w.WriteHeader(http.StatusNotImplemented)
+			_, _ = w.Write([]byte(http.StatusText(http.StatusNotImplemented)))
+			return
+		}
+		componentType := r.URL.Query().Get(TypeQuery)
+		if componentType != ReferenceType && componentType != ChainType && componentType != WorkflowType {
+			metrics.RecordError("invalid usage query", resolverMetrics.ErrorRate)
+			MissingQuery(w, TypeQuery)
+			return
+		}
+		name := r.URL.Query().Get(NameQuery)
+		if name == "" {
+			metrics.RecordError("invalid usage query", resolverMetrics.ErrorRate)
+			MissingQuery(w, NameQuery)
+			return
+		}
+		usage, ok := indexer.Index().Usage(componentType, name)
+		if !ok {
+			metrics.RecordError("registry component not found", resolverMetrics.ErrorRate)
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprintf(w, "could not find %s %s", componentType, name)
+			return
+		}
+		jsonContent, err := json.MarshalIndent(usage, "", "  ")
+		if err != nil {
+			metrics.RecordError("failed to marshal usage to JSON", resolverMetrics.ErrorRate)
+			w.WriteHeader(http.StatusInternalServerError)
+			fmt.Fprintf(w, "failed to marshal usage of %s %s to JSON: %v", componentType, name, err)
+			logrus.WithError(err).Errorf("failed to marshal usage of %s %s to JSON", componentType, name)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if _, err := w.Write(jsonContent); err != nil {
+			logrus.WithError(err).Errorf("Failed to write response: %v", err)
+		}
+	}
+}
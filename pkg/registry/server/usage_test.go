@@ -0,0 +1,281 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"k8s.io/utils/ptr"
+
+	"github.com/openshift/ci-tools/pkg/api"
+	"github.com/openshift/ci-tools/pkg/config"
+	"github.com/openshift/ci-tools/pkg/load/agents"
+	"github.com/openshift/ci-tools/pkg/registry"
+	"github.com/openshift/ci-tools/pkg/testhelper"
+)
+
+func usageTestRegistry() (registry.ReferenceByName, registry.ChainByName, registry.WorkflowByName) {
+	refs := registry.ReferenceByName{
+		"install":     {As: "install"},
+		"gather":      {As: "gather"},
+		"e2e":         {As: "e2e"},
+		"unused":      {As: "unused"},
+		"deprovision": {As: "deprovision"},
+	}
+	chains := registry.ChainByName{
+		"teardown": {As: "teardown", Steps: []api.TestStep{
+			{Reference: ptr.To("gather")},
+			{Reference: ptr.To("deprovision")},
+		}},
+		"ipi-post": {As: "ipi-post", Steps: []api.TestStep{{Chain: ptr.To("teardown")}}},
+	}
+	workflows := registry.WorkflowByName{
+		"ipi": {
+			Pre:  []api.TestStep{{Reference: ptr.To("install")}},
+			Test: []api.TestStep{{Reference: ptr.To("e2e")}},
+			Post: []api.TestStep{{Chain: ptr.To("ipi-post")}},
+		},
+	}
+	return refs, chains, workflows
+}
+
+func usageTestConfigs() config.ByOrgRepo {
+	return config.ByOrgRepo{"org": {"repo": {
+		{
+			Metadata: api.Metadata{Org: "org", Repo: "repo", Branch: "main"},
+			Tests: []api.TestStepConfiguration{
+				{As: "e2e", MultiStageTestConfiguration: &api.MultiStageTestConfiguration{Workflow: ptr.To("ipi")}},
+				{As: "e2e-sharded", ShardCount: ptr.To(2), MultiStageTestConfiguration: &api.MultiStageTestConfiguration{Workflow: ptr.To("ipi")}},
+				{
+					As:                          "custom",
+					Cron:                        ptr.To("@daily"),
+					MultiStageTestConfiguration: &api.MultiStageTestConfiguration{Workflow: ptr.To("ipi"), Post: []api.TestStep{{Reference: ptr.To("gather")}}},
+				},
+				{As: "unit", ContainerTestConfiguration: &api.ContainerTestConfiguration{From: "src"}},
+			},
+		},
+		{
+			Metadata: api.Metadata{Org: "org", Repo: "repo", Branch: "main", Variant: "v2"},
+			Tests: []api.TestStepConfiguration{
+				{As: "deprovision", Postsubmit: true, MultiStageTestConfiguration: &api.MultiStageTestConfiguration{Test: []api.TestStep{{Chain: ptr.To("teardown")}}}},
+			},
+		},
+	}}}
+}
+
+func TestUsageIndex(t *testing.T) {
+	refs, chains, workflows := usageTestRegistry()
+	index := NewUsageIndex(refs, chains, workflows, usageTestConfigs())
+	main := api.Metadata{Org: "org", Repo: "repo", Branch: "main"}
+	v2 := api.Metadata{Org: "org", Repo: "repo", Branch: "main", Variant: "v2"}
+	e2e := TestUsage{Metadata: main, Test: "e2e", Jobs: []string{"pull-ci-org-repo-main-e2e"}}
+	sharded := TestUsage{Metadata: main, Test: "e2e-sharded", Jobs: []string{"pull-ci-org-repo-main-e2e-sharded-1of2", "pull-ci-org-repo-main-e2e-sharded-2of2"}}
+	custom := TestUsage{Metadata: main, Test: "custom", Jobs: []string{"periodic-ci-org-repo-main-custom"}}
+	deprovision := TestUsage{Metadata: v2, Test: "deprovision", Jobs: []string{"branch-ci-org-repo-main-v2-deprovision"}}
+
+	var testCases = []struct {
+		name          string
+		componentType string
+		componentName string
+		expected      Usage
+		expectedFound bool
+	}{
+		{
+			name:          "reference in a nested chain",
+			componentType: ReferenceType,
+			componentName: "deprovision",
+			expected: Usage{
+				Type: ReferenceType, Name: "deprovision",
+				Chains:    []string{"ipi-post", "teardown"},
+				Workflows: []string{"ipi"},
+				Tests:     []TestUsage{e2e, sharded, deprovision},
+			},
+			expectedFound: true,
+		},
+		{
+			name:          "reference also used directly by a test overriding a phase",
+			componentType: ReferenceType,
+			componentName: "gather",
+			expected: Usage{
+				Type: ReferenceType, Name: "gather",
+				Chains:    []string{"ipi-post", "teardown"},
+				Workflows: []string{"ipi"},
+				Tests:     []TestUsage{custom, e2e, sharded, deprovision},
+			},
+			expectedFound: true,
+		},
+		{
+			name:          "chain",
+			componentType: ChainType,
+			componentName: "teardown",
+			expected: Usage{
+				Type: ChainType, Name: "teardown",
+				Chains:    []string{"ipi-post"},
+				Workflows: []string{"ipi"},
+				Tests:     []TestUsage{e2e, sharded, deprovision},
+			},
+			expectedFound: true,
+		},
+		{
+			name:          "workflow",
+			componentType: WorkflowType,
+			componentName: "ipi",
+			expected: Usage{
+				Type: WorkflowType, Name: "ipi",
+				Chains:    []string{},
+				Workflows: []string{},
+				Tests:     []TestUsage{custom, e2e, sharded},
+			},
+			expectedFound: true,
+		},
+		{
+			name:          "unused reference",
+			componentType: ReferenceType,
+			componentName: "unused",
+			expected:      Usage{Type: ReferenceType, Name: "unused", Chains: []string{}, Workflows: []string{}, Tests: []TestUsage{}},
+			expectedFound: true,
+		},
+		{
+			name:          "missing component",
+			componentType: ChainType,
+			componentName: "install",
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			usage, found := index.Usage(testCase.componentType, testCase.componentName)
+			if found != testCase.expectedFound {
+				t.Errorf("expected found to be %t, got %t", testCase.expectedFound, found)
+			}
+			testhelper.Diff(t, "usage", usage, testCase.expected)
+		})
+	}
+}
+
+type fakeUsageRegistryAgent struct {
+	agents.RegistryAgent
+	generation int
+}
+
+func (a *fakeUsageRegistryAgent) GetGeneration() int { return a.generation }
+
+func (a *fakeUsageRegistryAgent) GetRegistryComponents() (registry.ReferenceByName, registry.ChainByName, registry.WorkflowByName, map[string]string, api.RegistryMetadata) {
+	refs, chains, workflows := usageTestRegistry()
+	return refs, chains, workflows, nil, nil
+}
+
+type fakeUsageConfigAgent struct {
+	agents.ConfigAgent
+	generation int
+	configs    config.ByOrgRepo
+}
+
+func (a *fakeUsageConfigAgent) GetGeneration() int { return a.generation }
+
+func (a *fakeUsageConfigAgent) GetAll() config.ByOrgRepo { return a.configs }
+
+func TestResolveUsage(t *testing.T) {
+	configAgent := &fakeUsageConfigAgent{configs: usageTestConfigs()}
+	indexer := NewUsageIndexer(configAgent, &fakeUsageRegistryAgent{})
+	handler := ResolveUsage(indexer, configresolverMetrics)
+
+	var testCases = []struct {
+		name         string
+		method       string
+		url          string
+		expectedCode int
+		expectedBody string
+	}{
+		{
+			name:         "not a GET",
+			method:       "POST",
+			url:          "/usage?type=chain&name=teardown",
+			expectedCode: http.StatusNotImplemented,
+			expectedBody: "Not Implemented",
+		},
+		{
+			name:         "invalid type",
+			method:       "GET",
+			url:          "/usage?type=observer&name=teardown",
+			expectedCode: http.StatusBadRequest,
+			expectedBody: "type query missing or incorrect",
+		},
+		{
+			name:         "missing name",
+			method:       "GET",
+			url:          "/usage?type=chain",
+			expectedCode: http.StatusBadRequest,
+			expectedBody: "name query missing or incorrect",
+		},
+		{
+			name:         "missing component",
+			method:       "GET",
+			url:          "/usage?type=chain&name=missing",
+			expectedCode: http.StatusNotFound,
+			expectedBody: "could not find chain missing",
+		},
+		{
+			name:         "usage",
+			method:       "GET",
+			url:          "/usage?type=chain&name=ipi-post",
+			expectedCode: http.StatusOK,
+			expectedBody: `{
+  "type": "chain",
+  "name": "ipi-post",
+  "chains": [],
+  "workflows": [
+    "ipi"
+  ],
+  "tests": [
+    {
+      "org": "org",
+      "repo": "repo",
+      "branch": "main",
+      "test": "e2e",
+      "jobs": [
+        "pull-ci-org-repo-main-e2e"
+      ]
+    },
+    {
+      "org": "org",
+      "repo": "repo",
+      "branch": "main",
+      "test": "e2e-sharded",
+      "jobs": [
+        "pull-ci-org-repo-main-e2e-sharded-1of2",
+        "pull-ci-org-repo-main-e2e-sharded-2of2"
+      ]
+    }
+  ]
+}`,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			req, err := http.NewRequest(testCase.method, testCase.url, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+			if diff := cmp.Diff(testCase.expectedCode, rr.Code); diff != "" {
+				t.Errorf("code differs from expected:\n%s", diff)
+			}
+			if diff := cmp.Diff(testCase.expectedBody, rr.Body.String()); diff != "" {
+				t.Errorf("body differs from expected:\n%s", diff)
+			}
+		})
+	}
+
+	// the index is rebuilt when the configuration is reloaded
+	configAgent.configs = config.ByOrgRepo{}
+	if usage, _ := indexer.Index().Usage(WorkflowType, "ipi"); len(usage.Tests) == 0 {
+		t.Error("expected the index not to be rebuilt while the generation is unchanged")
+	}
+	configAgent.generation++
+	if usage, _ := indexer.Index().Usage(WorkflowType, "ipi"); len(usage.Tests) != 0 {
+		t.Errorf("expected the index to be rebuilt for the new generation, got tests %v", usage.Tests)
+	}
+}
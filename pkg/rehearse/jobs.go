@@ -692,7 +692,7 @@ func selectJobsForRegistryStep(node registry.Node, configs []*config.DataWithInf
 				continue
 			}
 			testSteps := append(test.MultiStageTestConfiguration.Pre, append(test.MultiStageTestConfiguration.Test, test.MultiStageTestConfiguration.Post...)...)
-			for _, testStep := range testSteps {
+			for _, testStep := range api.FlattenParallelSteps(testSteps) {
 				hasRef := testStep.Reference != nil && node.Type() == registry.Reference && node.Name() == *testStep.Reference
 				hasChain := testStep.Chain != nil && node.Type() == registry.Chain && node.Name() == *testStep.Chain
 				if hasRef || hasChain {
@@ -1056,6 +1056,21 @@ func removeConfigResolverFlags(args []string) ([]string, api.Metadata) {
 	return newArgs, usedConfig
 }
 
+// AddDebugHold makes ci-operator hold failing steps of the rehearsals for
+// debugging for the given duration.
+func AddDebugHold(presubmits []*prowconfig.Presubmit, hold time.Duration) {
+	for _, job := range presubmits {
+		if job.Spec == nil || len(job.Spec.Containers) == 0 {
+			continue
+		}
+		container := &job.Spec.Containers[0]
+		if len(container.Command) == 0 || container.Command[0] != "ci-operator" {
+			continue
+		}
+		container.Args = append(container.Args, fmt.Sprintf("--debug-hold=%s", hold))
+	}
+}
+
 func (e *Executor) submitRehearsals() ([]*pjapi.ProwJob, error) {
 	var errs []error
 	var pjs []*pjapi.ProwJob
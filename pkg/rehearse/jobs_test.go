@@ -1340,6 +1340,25 @@ func TestRemoveConfigResolverFlags(t *testing.T) {
 	}
 }
 
+func TestAddDebugHold(t *testing.T) {
+	presubmits := []*prowconfig.Presubmit{
+		{JobBase: prowconfig.JobBase{Name: "ci-operator", Spec: &v1.PodSpec{Containers: []v1.Container{{Command: []string{"ci-operator"}, Args: []string{"--target=e2e"}}}}}},
+		{JobBase: prowconfig.JobBase{Name: "other", Spec: &v1.PodSpec{Containers: []v1.Container{{Command: []string{"make"}, Args: []string{"test"}}}}}},
+		{JobBase: prowconfig.JobBase{Name: "no-spec"}},
+	}
+	AddDebugHold(presubmits, 2*time.Hour)
+	expected := [][]string{{"--target=e2e", "--debug-hold=2h0m0s"}, {"test"}}
+	var args [][]string
+	for _, job := range presubmits {
+		if job.Spec != nil {
+			args = append(args, job.Spec.Containers[0].Args)
+		}
+	}
+	if diff := cmp.Diff(expected, args); diff != "" {
+		t.Errorf("unexpected args: %s", diff)
+	}
+}
+
 func TestGetTrimmedBranch(t *testing.T) {
 	testCases := []struct {
 		name     string
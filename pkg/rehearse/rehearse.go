@@ -55,6 +55,10 @@ type RehearsalConfig struct {
 	GCSCredentialsFile string
 	GCSBrowserPrefix   string
 
+	// DebugHold is how long failing steps of rehearsals requested for
+	// debugging are held.
+	DebugHold time.Duration
+
 	DryRun bool
 }
 
@@ -0,0 +1,196 @@
+package results
+
+import (
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Classification describes who is responsible for a failure, as opposed to
+// a Reason which describes where it happened.
+type Classification string
+
+const (
+	// ClassificationInfra is used for failures of the infrastructure a job
+	// depends on, like cloud quota, leases, cluster capacity or registries.
+	ClassificationInfra Classification = "infra"
+	// ClassificationUser is used for failures caused by the configuration of
+	// the job or the inputs it was given.
+	ClassificationUser Classification = "user"
+	// ClassificationTest is used for failures of the code or tests under test.
+	ClassificationTest Classification = "test"
+	// ClassificationPlatform is used for failures of the CI platform itself,
+	// like ci-operator or the test namespace on the build farm.
+	ClassificationPlatform Classification = "platform"
+	// ClassificationUnknown is used when a failure could not be classified.
+	ClassificationUnknown Classification = "unknown"
+)
+
+// reasonClassifications are the default classifications of failures with a
+// reason, used when no classification was determined where the error occurred.
+var reasonClassifications = map[Reason]Classification{
+	"pod_pending":              ClassificationInfra,
+	"acquiring_lease":          ClassificationInfra,
+	"releasing_lease":          ClassificationInfra,
+	"acquiring_ip_pool_lease":  ClassificationInfra,
+	"releasing_ip_pool_lease":  ClassificationInfra,
+	"acquiring_cluster_claim":  ClassificationInfra,
+	"releasing_cluster_claim":  ClassificationInfra,
+	"loading_args":             ClassificationUser,
+	"loading_config":           ClassificationUser,
+	"validating_config":        ClassificationUser,
+	"defaulting_config":        ClassificationUser,
+	"config_resolver":          ClassificationUser,
+	"config_resolver_literal":  ClassificationUser,
+	"building_graph":           ClassificationUser,
+	"missing_cluster_profile":  ClassificationUser,
+	"exceeding_phase_budget":   ClassificationTest,
+	"invalid_release":          ClassificationUser,
+	"initializing_namespace":   ClassificationPlatform,
+	"creating_service_account": ClassificationPlatform,
+	"creating_roles":           ClassificationPlatform,
+	"binding_roles":            ClassificationPlatform,
+	"create_dockercfg_secrets": ClassificationPlatform,
+	"executing_lease_proxy":    ClassificationPlatform,
+	"gsm_client":               ClassificationPlatform,
+	"writing_parameters":       ClassificationPlatform,
+}
+
+// Classify marks the error with the classification of the failure. When an
+// error is classified more than once, the innermost classification is used.
+func Classify(classification Classification, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &classifiedError{classification: classification, err: err}
+}
+
+type classifiedError struct {
+	classification Classification
+	err            error
+}
+
+func (e *classifiedError) Error() string { return e.err.Error() }
+func (e *classifiedError) Unwrap() error { return e.err }
+
+// Failure is a chain of reasons for an error together with its classification.
+type Failure struct {
+	// Reason is a single chain of reasons divided by colons.
+	Reason string
+	// Classification of the cause of the failure.
+	Classification Classification
+}
+
+// Failures provides the chains of error reasons, in the same way as Reasons,
+// along with the classification of each of them. Failures which were not
+// classified explicitly are classified by their innermost known reason. A
+// classified error without any reason is reported with the unknown reason.
+func Failures(errs ...error) []Failure {
+	ret := failures(errs...)
+	for i := range ret {
+		if ret[i].Reason == "" {
+			ret[i].Reason = string(ReasonUnknown)
+		}
+		if ret[i].Classification == "" {
+			ret[i].Classification = classificationForReason(ret[i].Reason)
+		}
+	}
+	return ret
+}
+
+func failures(errs ...error) (ret []Failure) {
+	for _, err := range errs {
+		switch err := err.(type) {
+		case *Error:
+			children := failures(err.Unwrap())
+			if len(children) == 0 {
+				ret = append(ret, Failure{Reason: string(err.reason)})
+				break
+			}
+			for _, child := range children {
+				reason := string(err.reason)
+				if child.Reason != "" {
+					reason += ":" + child.Reason
+				}
+				ret = append(ret, Failure{Reason: reason, Classification: child.Classification})
+			}
+		case *classifiedError:
+			children := failures(err.Unwrap())
+			if len(children) == 0 {
+				ret = append(ret, Failure{Classification: err.classification})
+				break
+			}
+			for _, child := range children {
+				if child.Classification == "" {
+					child.Classification = err.classification
+				}
+				ret = append(ret, child)
+			}
+		case interface{ Errors() []error }:
+			ret = append(ret, failures(err.Errors()...)...)
+		case interface{ Unwrap() error }:
+			ret = append(ret, failures(err.Unwrap())...)
+		}
+	}
+	return
+}
+
+func classificationForReason(chain string) Classification {
+	reasons := strings.Split(chain, ":")
+	for i := len(reasons) - 1; i >= 0; i-- {
+		if classification, ok := reasonClassifications[Reason(reasons[i])]; ok {
+			return classification
+		}
+	}
+	return ClassificationUnknown
+}
+
+// ClassificationFor determines the classification of an error. When the
+// error has multiple causes, the first classification which is not unknown
+// is used.
+func ClassificationFor(err error) Classification {
+	for _, failure := range Failures(err) {
+		if failure.Classification != ClassificationUnknown {
+			return failure.Classification
+		}
+	}
+	return ClassificationUnknown
+}
+
+// ciContainers are injected by the CI system into test pods.
+var ciContainers = map[string]bool{
+	"place-entrypoint":      true,
+	"sidecar":               true,
+	"initupload":            true,
+	"clonerefs":             true,
+	"cp-entrypoint-wrapper": true,
+}
+
+// ClassifyPod classifies the failure of a pod from its termination state.
+func ClassifyPod(pod *corev1.Pod) Classification {
+	switch pod.Status.Reason {
+	case "Evicted", "NodeLost", "NodeShutdown", "Terminated", "UnexpectedAdmissionError":
+		return ClassificationInfra
+	case "DeadlineExceeded":
+		return ClassificationTest
+	}
+	failedTest, failedCI := false, false
+	for _, status := range append(append([]corev1.ContainerStatus{}, pod.Status.InitContainerStatuses...), pod.Status.ContainerStatuses...) {
+		state := status.State.Terminated
+		if state == nil || state.ExitCode == 0 {
+			continue
+		}
+		switch {
+		case state.Reason == "OOMKilled":
+			return ClassificationUser
+		case ciContainers[status.Name]:
+			failedCI = true
+		default:
+			failedTest = true
+		}
+	}
+	if failedCI && !failedTest {
+		return ClassificationPlatform
+	}
+	return ClassificationTest
+}
@@ -0,0 +1,117 @@
+package results
+
+import (
+	"errors"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+
+	"github.com/openshift/ci-tools/pkg/testhelper"
+)
+
+func TestFailures(t *testing.T) {
+	base := errors.New("failure")
+	testCases := []struct {
+		name     string
+		err      error
+		expected []Failure
+	}{
+		{
+			name: "plain error has no failures",
+			err:  base,
+		},
+		{
+			name:     "reason without classification is unknown",
+			err:      ForReason("oops").ForError(base),
+			expected: []Failure{{Reason: "oops", Classification: ClassificationUnknown}},
+		},
+		{
+			name:     "known reason is classified by default",
+			err:      ForReason("running").ForError(ForReason("acquiring_lease").ForError(base)),
+			expected: []Failure{{Reason: "running:acquiring_lease", Classification: ClassificationInfra}},
+		},
+		{
+			name:     "explicit classification wins over the reason",
+			err:      ForReason("acquiring_lease").ForError(Classify(ClassificationUser, base)),
+			expected: []Failure{{Reason: "acquiring_lease", Classification: ClassificationUser}},
+		},
+		{
+			name:     "innermost classification wins",
+			err:      Classify(ClassificationInfra, ForReason("step_failed").ForError(Classify(ClassificationTest, base))),
+			expected: []Failure{{Reason: "step_failed", Classification: ClassificationTest}},
+		},
+		{
+			name:     "classified error without reason has the unknown reason",
+			err:      Classify(ClassificationPlatform, base),
+			expected: []Failure{{Reason: "unknown", Classification: ClassificationPlatform}},
+		},
+		{
+			name: "aggregate yields a failure per error",
+			err: utilerrors.NewAggregate([]error{
+				ForReason("pod_pending").ForError(base),
+				ForReason("step_failed").ForError(Classify(ClassificationTest, base)),
+			}),
+			expected: []Failure{
+				{Reason: "pod_pending", Classification: ClassificationInfra},
+				{Reason: "step_failed", Classification: ClassificationTest},
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			testhelper.Diff(t, "failures", Failures(testCase.err), testCase.expected)
+		})
+	}
+}
+
+func TestClassificationFor(t *testing.T) {
+	base := errors.New("failure")
+	testhelper.Diff(t, "plain error", ClassificationFor(base), ClassificationUnknown)
+	testhelper.Diff(t, "first known classification", ClassificationFor(utilerrors.NewAggregate([]error{
+		ForReason("oops").ForError(base),
+		ForReason("loading_config").ForError(base),
+	})), ClassificationUser)
+}
+
+func TestClassifyPod(t *testing.T) {
+	terminated := func(name string, exitCode int32, reason string) corev1.ContainerStatus {
+		return corev1.ContainerStatus{Name: name, State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: exitCode, Reason: reason}}}
+	}
+	testCases := []struct {
+		name     string
+		status   corev1.PodStatus
+		expected Classification
+	}{
+		{
+			name:     "evicted pod is an infrastructure failure",
+			status:   corev1.PodStatus{Reason: "Evicted"},
+			expected: ClassificationInfra,
+		},
+		{
+			name:     "pod over its deadline is a test failure",
+			status:   corev1.PodStatus{Reason: "DeadlineExceeded"},
+			expected: ClassificationTest,
+		},
+		{
+			name:     "failed test container is a test failure",
+			status:   corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{terminated("test", 1, "Error"), terminated("sidecar", 0, "Completed")}},
+			expected: ClassificationTest,
+		},
+		{
+			name:     "out of memory is a user failure",
+			status:   corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{terminated("test", 137, "OOMKilled")}},
+			expected: ClassificationUser,
+		},
+		{
+			name:     "only CI containers failing is a platform failure",
+			status:   corev1.PodStatus{InitContainerStatuses: []corev1.ContainerStatus{terminated("place-entrypoint", 1, "Error")}},
+			expected: ClassificationPlatform,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			testhelper.Diff(t, "classification", ClassifyPod(&corev1.Pod{Status: testCase.status}), testCase.expected)
+		})
+	}
+}
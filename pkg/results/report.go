@@ -94,6 +94,8 @@ type Request struct {
 	State string `json:"state"`
 	// Reason is a colon-delimited list of reasons for failure
 	Reason string `json:"reason"`
+	// Classification describes who is responsible for the failure
+	Classification string `json:"classification,omitempty"`
 }
 
 // PodScalerRequest holds the data from pod-scaler used to report a result to an aggregation server
@@ -137,17 +139,22 @@ func (r *reporter) Report(err error) {
 	if err != nil {
 		state = StateFailed
 	}
-	reasons := Reasons(err)
-	if len(reasons) == 0 {
-		reasons = []string{string(ReasonUnknown)}
+	failures := Failures(err)
+	if len(failures) == 0 {
+		failure := Failure{Reason: string(ReasonUnknown)}
+		if err != nil {
+			failure.Classification = ClassificationUnknown
+		}
+		failures = []Failure{failure}
 	}
-	for _, reason := range reasons {
+	for _, failure := range failures {
 		r.report(Request{
-			JobName: r.spec.Job,
-			Type:    string(r.spec.Type),
-			Cluster: r.consoleHost,
-			State:   state,
-			Reason:  reason,
+			JobName:        r.spec.Job,
+			Type:           string(r.spec.Type),
+			Cluster:        r.consoleHost,
+			State:          state,
+			Reason:         failure.Reason,
+			Classification: string(failure.Classification),
 		})
 	}
 }
@@ -161,7 +168,7 @@ func (r *reporter) report(request Request) {
 
 	reportMsg := fmt.Sprintf("Reporting job state '%s'", request.State)
 	if request.State != StateSucceeded {
-		reportMsg = fmt.Sprintf("Reporting job state '%s' with reason '%s'", request.State, request.Reason)
+		reportMsg = fmt.Sprintf("Reporting job state '%s' with reason '%s' (%s)", request.State, request.Reason, request.Classification)
 	}
 
 	logrus.Infof("%s", reportMsg)
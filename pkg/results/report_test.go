@@ -42,21 +42,28 @@ func TestReporter_Report(t *testing.T) {
 			spec:        &api.JobSpec{JobSpec: downwardapi.JobSpec{Job: "runme", Type: v1.PresubmitJob}},
 			consoleHost: "foo.com",
 			err:         errors.New("something"),
-			expected:    `{"job_name":"runme","type":"presubmit","cluster":"foo.com","state":"failed","reason":"unknown"}`,
+			expected:    `{"job_name":"runme","type":"presubmit","cluster":"foo.com","state":"failed","reason":"unknown","classification":"unknown"}`,
 		},
 		{
 			name:        "reasoned err reports failure with specific reason",
 			spec:        &api.JobSpec{JobSpec: downwardapi.JobSpec{Job: "runme", Type: v1.PresubmitJob}},
 			consoleHost: "foo.com",
 			err:         ForReason("because").ForError(errors.New("oops")),
-			expected:    `{"job_name":"runme","type":"presubmit","cluster":"foo.com","state":"failed","reason":"because"}`,
+			expected:    `{"job_name":"runme","type":"presubmit","cluster":"foo.com","state":"failed","reason":"because","classification":"unknown"}`,
 		},
 		{
 			name:        "nested reasoned err reports failure with specific reason",
 			spec:        &api.JobSpec{JobSpec: downwardapi.JobSpec{Job: "runme", Type: v1.PresubmitJob}},
 			consoleHost: "foo.com",
 			err:         ForReason("because").WithError(ForReason("something").ForError(errors.New("oops"))).Errorf("argh"),
-			expected:    `{"job_name":"runme","type":"presubmit","cluster":"foo.com","state":"failed","reason":"because:something"}`,
+			expected:    `{"job_name":"runme","type":"presubmit","cluster":"foo.com","state":"failed","reason":"because:something","classification":"unknown"}`,
+		},
+		{
+			name:        "classified err reports failure with its classification",
+			spec:        &api.JobSpec{JobSpec: downwardapi.JobSpec{Job: "runme", Type: v1.PresubmitJob}},
+			consoleHost: "foo.com",
+			err:         ForReason("acquiring_lease").ForError(errors.New("oops")),
+			expected:    `{"job_name":"runme","type":"presubmit","cluster":"foo.com","state":"failed","reason":"acquiring_lease","classification":"infra"}`,
 		},
 	}
 
@@ -1,7 +1,11 @@
 package secrets
 
 import (
+	"bytes"
+	"errors"
+	"io"
 	"os"
+	"sort"
 	"strings"
 	"sync"
 
@@ -9,29 +13,136 @@ import (
 	"sigs.k8s.io/prow/pkg/secretutil"
 )
 
+// streamChunkSize is the amount of data CensorStream censors at once.
+const streamChunkSize = 64 * 1024
+
 // DynamicCensor keeps a list of censored secrets that is dynamically updated.
 // Used when the list of secrets to censor is updated during the execution of
 // the program and cannot be determined in advance.  Access to the list of
 // secrets is internally synchronized.
+//
+// Besides the secrets themselves, the censor redacts their common encodings
+// (base64 in all its flavors and at any alignment, URL, JSON and HTML
+// escaping), secrets split across line wraps and the credentials embedded in
+// dockerconfig and kubeconfig secrets.
 type DynamicCensor struct {
 	sync.RWMutex
 	*secretutil.ReloadingCensorer
-	secrets sets.Set[string]
+	// joined censors the input with its line breaks removed, using only the
+	// variants which can be split across lines
+	joined *secretutil.ReloadingCensorer
+	// added holds the secrets whose variants were already computed
+	added          sets.Set[string]
+	secrets        sets.Set[string]
+	joinedVariants sets.Set[string]
 }
 
 func NewDynamicCensor() DynamicCensor {
 	return DynamicCensor{
 		ReloadingCensorer: secretutil.NewCensorer(),
+		joined:            secretutil.NewCensorer(),
+		added:             sets.New[string](),
 		secrets:           sets.New[string](),
+		joinedVariants:    sets.New[string](),
 	}
 }
 
 // AddSecrets adds the content of one or more secrets to the censor list.
+// The variants of each secret are computed once, when it is first added.
 func (c *DynamicCensor) AddSecrets(s ...string) {
 	c.Lock()
 	defer c.Unlock()
-	c.secrets.Insert(s...)
-	c.ReloadingCensorer.Refresh(sets.List(c.secrets)...)
+	var added bool
+	for _, secret := range s {
+		if c.added.Has(secret) {
+			continue
+		}
+		added = true
+		c.added.Insert(secret)
+		c.secrets.Insert(secret)
+		c.secrets.Insert(variants(secret)...)
+		c.joinedVariants.Insert(joinedVariants(secret)...)
+	}
+	if !added {
+		return
+	}
+	c.ReloadingCensorer.Refresh(longestFirst(c.secrets)...)
+	c.joined.Refresh(longestFirst(c.joinedVariants)...)
+}
+
+// longestFirst lists the secrets from the longest to the shortest.  When
+// secrets share a prefix, the first one registered is replaced, so longer
+// secrets go first to not leave the rest of them behind.
+func longestFirst(secrets sets.Set[string]) []string {
+	ret := sets.List(secrets)
+	sort.SliceStable(ret, func(i, j int) bool {
+		return len(ret[i]) > len(ret[j])
+	})
+	return ret
+}
+
+// Censor removes the secrets and their variants from the input.  It will
+// mutate the input but never change its size.
+func (c *DynamicCensor) Censor(input *[]byte) {
+	c.ReloadingCensorer.Censor(input)
+	c.censorWrapped(*input)
+}
+
+// censorWrapped censors secrets that are split across lines, by censoring
+// the input with its line breaks removed and mapping the redacted bytes back.
+func (c *DynamicCensor) censorWrapped(input []byte) {
+	if bytes.IndexAny(input, "\r\n") == -1 {
+		return
+	}
+	joined := make([]byte, 0, len(input))
+	var breaks []int
+	for i, b := range input {
+		if b == '\n' || b == '\r' {
+			breaks = append(breaks, i)
+			continue
+		}
+		joined = append(joined, b)
+	}
+	original := bytes.Clone(joined)
+	c.joined.Censor(&joined)
+	skipped := 0
+	for i := range joined {
+		for skipped < len(breaks) && breaks[skipped] <= i+skipped {
+			skipped++
+		}
+		if joined[i] != original[i] {
+			input[i+skipped] = joined[i]
+		}
+	}
+}
+
+// CensorStream copies src to dst, censoring it on the way without holding
+// all of it in memory.  Consecutive chunks overlap so secrets that straddle
+// a chunk boundary are censored as well.
+func (c *DynamicCensor) CensorStream(dst io.Writer, src io.Reader) error {
+	// secrets split across lines are longer than the secrets themselves
+	overlap := 2 * c.LargestSecret()
+	buf := make([]byte, 0, overlap+streamChunkSize)
+	for {
+		n, err := io.ReadFull(src, buf[len(buf):len(buf)+streamChunkSize])
+		buf = buf[:len(buf)+n]
+		done := errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF)
+		if err != nil && !done {
+			return err
+		}
+		c.Censor(&buf)
+		flush := len(buf)
+		if !done {
+			flush = max(len(buf)-overlap, 0)
+		}
+		if _, err := dst.Write(buf[:flush]); err != nil {
+			return err
+		}
+		if done {
+			return nil
+		}
+		buf = buf[:copy(buf, buf[flush:])]
+	}
 }
 
 // ReadFromEnv loads an environment variable and adds it to the censor list.
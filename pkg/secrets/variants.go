@@ -0,0 +1,213 @@
+package secrets
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"html"
+	"net/url"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+const (
+	// minFragmentLength is the length under which base64 fragments of a
+	// secret are not censored, as they would match unrelated content too often
+	minFragmentLength = 8
+	// minCredentialLength is the length under which credentials embedded in
+	// a secret are not censored on their own
+	minCredentialLength = 6
+	// mimeLineLength and pemLineLength are the widths base64 is commonly
+	// wrapped at, by base64(1) and by PEM encoders
+	mimeLineLength = 76
+	pemLineLength  = 64
+)
+
+// variants returns the secret and the forms it commonly takes when leaked,
+// including the credentials embedded in it when it is a dockerconfig or
+// a kubeconfig.  The plain base64 encoding is omitted, as the censorer
+// already handles it.
+func variants(secret string) []string {
+	secret = strings.TrimSpace(secret)
+	if secret == "" || strings.EqualFold(secret, "true") || strings.EqualFold(secret, "false") {
+		// the censorer does not censor booleans, so neither do we
+		return nil
+	}
+	ret := sets.New[string](secret)
+	ret.Insert(encodedVariants(secret)...)
+	for _, credential := range embeddedCredentials(secret) {
+		ret.Insert(credential)
+		ret.Insert(encodedVariants(credential)...)
+	}
+	return sets.List(ret)
+}
+
+// joinedVariants returns the forms of a secret which are censored in the
+// input with its line breaks removed: the secret, the credentials embedded in
+// it and their base64 fragments.  Escaped forms are rarely wrapped and
+// pre-wrapped forms cannot match once line breaks are removed, so they are
+// left to the censoring of the input itself.
+func joinedVariants(secret string) []string {
+	secret = strings.TrimSpace(secret)
+	if secret == "" || strings.EqualFold(secret, "true") || strings.EqualFold(secret, "false") {
+		return nil
+	}
+	ret := sets.New[string]()
+	for _, value := range append([]string{secret}, embeddedCredentials(secret)...) {
+		ret.Insert(value)
+		for offset := 0; offset < 3; offset++ {
+			if fragment := base64Fragment(value, offset); len(fragment) >= minFragmentLength {
+				ret.Insert(fragment)
+			}
+		}
+	}
+	return sets.List(ret)
+}
+
+// encodedVariants returns the escaped and encoded forms of a secret which
+// differ from the secret itself.
+func encodedVariants(secret string) []string {
+	candidates := []string{
+		url.QueryEscape(secret),
+		url.PathEscape(secret),
+		html.EscapeString(secret),
+		jsonEscaped(secret, true),
+		jsonEscaped(secret, false),
+		base64.URLEncoding.EncodeToString([]byte(secret)),
+		base64.RawStdEncoding.EncodeToString([]byte(secret)),
+		base64.RawURLEncoding.EncodeToString([]byte(secret)),
+	}
+	if strings.Contains(secret, "\n") {
+		candidates = append(candidates, strings.ReplaceAll(secret, "\n", "\r\n"))
+	}
+	encoded := base64.StdEncoding.EncodeToString([]byte(secret))
+	for _, width := range []int{mimeLineLength, pemLineLength} {
+		if len(encoded) > width {
+			candidates = append(candidates, wrapped(encoded, width))
+		}
+	}
+	for offset := 0; offset < 3; offset++ {
+		fragment := base64Fragment(secret, offset)
+		if len(fragment) >= minFragmentLength {
+			candidates = append(candidates, fragment, strings.NewReplacer("+", "-", "/", "_").Replace(fragment))
+		}
+	}
+
+	var ret []string
+	for _, candidate := range candidates {
+		if candidate != secret && candidate != encoded {
+			ret = append(ret, candidate)
+		}
+	}
+	return ret
+}
+
+// jsonEscaped returns the secret as it appears in a JSON string.
+func jsonEscaped(secret string, escapeHTML bool) string {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(escapeHTML)
+	if err := encoder.Encode(secret); err != nil {
+		return secret
+	}
+	return strings.TrimSuffix(strings.TrimPrefix(strings.TrimSuffix(buf.String(), "\n"), `"`), `"`)
+}
+
+// wrapped breaks the value into lines of the given width.
+func wrapped(value string, width int) string {
+	var b strings.Builder
+	for len(value) > width {
+		b.WriteString(value[:width])
+		b.WriteString("\n")
+		value = value[width:]
+	}
+	b.WriteString(value)
+	return b.String()
+}
+
+// base64Fragment returns the part of the base64 encoding of the secret which
+// does not depend on the surrounding data, when the secret starts at the
+// given offset modulo three of a larger encoded value.  This is how secrets
+// appear in dockerconfig auths, which encode "user:password".
+func base64Fragment(secret string, offset int) string {
+	start := (3 - offset) % 3
+	if start >= len(secret) {
+		return ""
+	}
+	groups := (len(secret) - start) / 3
+	return base64.RawStdEncoding.EncodeToString([]byte(secret[start : start+3*groups]))
+}
+
+// dockerConfigEntry holds the credentials for a registry in a dockerconfig.
+type dockerConfigEntry struct {
+	Auth          string `json:"auth,omitempty"`
+	Username      string `json:"username,omitempty"`
+	Password      string `json:"password,omitempty"`
+	IdentityToken string `json:"identitytoken,omitempty"`
+	RegistryToken string `json:"registrytoken,omitempty"`
+}
+
+// embeddedCredentials returns the credentials held in a dockerconfig or a
+// kubeconfig, which may leak on their own when the tools using them log them.
+func embeddedCredentials(secret string) []string {
+	var ret []string
+	switch {
+	case strings.HasPrefix(secret, "{"):
+		ret = dockerConfigCredentials(secret)
+	case strings.Contains(secret, "users:"):
+		ret = kubeConfigCredentials(secret)
+	}
+	var censored []string
+	for _, credential := range ret {
+		if credential = strings.TrimSpace(credential); len(credential) >= minCredentialLength {
+			censored = append(censored, credential)
+		}
+	}
+	return censored
+}
+
+func dockerConfigCredentials(secret string) []string {
+	var config struct {
+		Auths map[string]dockerConfigEntry `json:"auths"`
+	}
+	if err := json.Unmarshal([]byte(secret), &config); err != nil {
+		return nil
+	}
+	entries := config.Auths
+	if entries == nil {
+		// the legacy .dockercfg format has no auths key
+		if err := json.Unmarshal([]byte(secret), &entries); err != nil {
+			return nil
+		}
+	}
+	var ret []string
+	for _, entry := range entries {
+		ret = append(ret, entry.Auth, entry.Password, entry.IdentityToken, entry.RegistryToken)
+		if decoded, err := base64.StdEncoding.DecodeString(entry.Auth); err == nil {
+			ret = append(ret, string(decoded))
+			if _, password, found := strings.Cut(string(decoded), ":"); found {
+				ret = append(ret, password)
+			}
+		}
+	}
+	return ret
+}
+
+func kubeConfigCredentials(secret string) []string {
+	config, err := clientcmd.Load([]byte(secret))
+	if err != nil {
+		return nil
+	}
+	var ret []string
+	for _, authInfo := range config.AuthInfos {
+		ret = append(ret, authInfo.Token, authInfo.Password, string(authInfo.ClientKeyData))
+		if authInfo.AuthProvider != nil {
+			for _, key := range []string{"access-token", "client-secret", "id-token", "refresh-token"} {
+				ret = append(ret, authInfo.AuthProvider.Config[key])
+			}
+		}
+	}
+	return ret
+}
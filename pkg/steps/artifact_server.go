@@ -0,0 +1,91 @@
+package steps
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/openshift/ci-tools/pkg/api"
+	"github.com/openshift/ci-tools/pkg/results"
+	"github.com/openshift/ci-tools/pkg/steps/loggingclient"
+	"github.com/openshift/ci-tools/pkg/steps/utils"
+)
+
+// artifactServerStep serves a directory of an image over HTTP, like the RPM
+// server, so other steps can download build outputs from it.  The server runs
+// in the image itself, which needs to provide bash and python.
+type artifactServerStep struct {
+	config  api.ServedArtifactsConfiguration
+	client  loggingclient.LoggingClient
+	jobSpec *api.JobSpec
+}
+
+func (s *artifactServerStep) Inputs() (api.InputDefinition, error) {
+	return nil, nil
+}
+
+func (*artifactServerStep) Validate() error { return nil }
+
+func (s *artifactServerStep) Run(ctx context.Context) error {
+	return results.ForReason("serving_artifacts").ForError(s.run(ctx))
+}
+
+func (s *artifactServerStep) run(ctx context.Context) error {
+	return serveFromImage(ctx, s.client, s.jobSpec, fileServer{
+		name: s.serverName(),
+		from: s.config.From,
+		dir:  s.config.Path,
+		kind: fmt.Sprintf("%s artifacts", s.config.Name),
+	})
+}
+
+func (s *artifactServerStep) serverName() string {
+	return fmt.Sprintf("artifacts-%s", s.config.Name)
+}
+
+func (s *artifactServerStep) Requires() []api.StepLink {
+	return []api.StepLink{api.InternalImageLink(s.config.From)}
+}
+
+func (s *artifactServerStep) Creates() []api.StepLink {
+	return []api.StepLink{api.ServedArtifactsLink(s.config.Name)}
+}
+
+func (s *artifactServerStep) artifactsURL() (string, error) {
+	host, err := admittedHostForRoute(s.client, s.jobSpec.Namespace(), s.serverName(), time.Minute)
+	if err != nil {
+		return "", fmt.Errorf("unable to calculate the URL of the %s artifacts: %w", s.config.Name, err)
+	}
+	return fmt.Sprintf("http://%s", host), nil
+}
+
+func (s *artifactServerStep) Provides() api.ParameterMap {
+	return api.ParameterMap{
+		utils.ServedArtifactsEnv(s.config.Name): s.artifactsURL,
+	}
+}
+
+func (s *artifactServerStep) Name() string { return s.config.TargetName() }
+
+func (s *artifactServerStep) Description() string {
+	return fmt.Sprintf("Start a service that hosts the %s artifacts from %s", s.config.Name, s.config.Path)
+}
+
+func (s *artifactServerStep) Objects() []ctrlruntimeclient.Object {
+	return s.client.Objects()
+}
+
+// ArtifactServerStep serves a directory of an image in the pipeline image
+// stream to the other steps of the job.
+func ArtifactServerStep(
+	config api.ServedArtifactsConfiguration,
+	client loggingclient.LoggingClient,
+	jobSpec *api.JobSpec) api.Step {
+	return &artifactServerStep{
+		config:  config,
+		client:  client,
+		jobSpec: jobSpec,
+	}
+}
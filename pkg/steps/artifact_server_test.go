@@ -0,0 +1,59 @@
+package steps
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	fakectrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	routev1 "github.com/openshift/api/route/v1"
+
+	"github.com/openshift/ci-tools/pkg/api"
+	"github.com/openshift/ci-tools/pkg/steps/loggingclient"
+	"github.com/openshift/ci-tools/pkg/testhelper"
+)
+
+func TestArtifactServerStep(t *testing.T) {
+	ns := "ns"
+	if err := routev1.AddToScheme(scheme.Scheme); err != nil {
+		t.Error(err)
+	}
+	client := loggingclient.New(fakectrlruntimeclient.NewClientBuilder().WithRuntimeObjects(
+		&routev1.Route{
+			ObjectMeta: metav1.ObjectMeta{Namespace: ns, Name: "artifacts-docs-html"},
+			Status: routev1.RouteStatus{
+				Ingress: []routev1.RouteIngress{{
+					Host: "host",
+					Conditions: []routev1.RouteIngressCondition{{
+						Type:   routev1.RouteAdmitted,
+						Status: corev1.ConditionTrue,
+					}},
+				}},
+			},
+		},
+	).Build(), nil)
+	jobSpec := api.JobSpec{}
+	jobSpec.SetNamespace(ns)
+	config := api.ServedArtifactsConfiguration{Name: "docs-html", From: "bin", Path: "/go/docs"}
+	step := ArtifactServerStep(config, client, &jobSpec)
+
+	if name, expected := step.Name(), "[serve:artifacts-docs-html]"; name != expected {
+		t.Errorf("expected name %q, got %q", expected, name)
+	}
+	testhelper.Diff(t, "requires", step.Requires(), []api.StepLink{api.InternalImageLink("bin")}, api.Comparer())
+	testhelper.Diff(t, "creates", step.Creates(), []api.StepLink{api.ServedArtifactsLink("docs-html")}, api.Comparer())
+
+	provides := step.Provides()
+	if len(provides) != 1 {
+		t.Fatalf("expected a single parameter, got %d", len(provides))
+	}
+	url, err := provides["ARTIFACTS_URL_DOCS_HTML"]()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := "http://host"; url != expected {
+		t.Errorf("expected URL %q, got %q", expected, url)
+	}
+}
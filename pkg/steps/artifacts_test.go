@@ -2,6 +2,7 @@ package steps
 
 import (
 	"os"
+	"path/filepath"
 	"reflect"
 	"testing"
 	"time"
@@ -16,6 +17,7 @@ import (
 	prowv1 "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
 
 	"github.com/openshift/ci-tools/pkg/junit"
+	"github.com/openshift/ci-tools/pkg/secrets"
 	"github.com/openshift/ci-tools/pkg/steps/loggingclient"
 	"github.com/openshift/ci-tools/pkg/testhelper"
 	testhelper_kube "github.com/openshift/ci-tools/pkg/testhelper/kubernetes"
@@ -488,57 +490,76 @@ func TestTestCaseNotifier_SubTests(t *testing.T) {
 }
 
 func TestArtifactWorker(t *testing.T) {
-	tmp, err := os.MkdirTemp("", "")
-	if err != nil {
-		t.Fatal(err)
-	}
-	defer func() {
-		if err := os.RemoveAll(tmp); err != nil {
-			t.Errorf("couldn't clean up tmpdir: %v", err)
-		}
-	}()
-	pod := "pod"
-	podClient := &testhelper_kube.FakePodClient{
+	censor := secrets.NewDynamicCensor()
+	censor.AddSecrets("test")
+	for _, tc := range []struct {
+		name     string
+		censor   *secrets.DynamicCensor
+		expected string
+	}{
+		{
+			name:     "artifacts are copied",
+			expected: "test\n",
+		},
+		{
+			name:     "artifacts are censored",
+			censor:   &censor,
+			expected: "XXXX\n",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			tmp := t.TempDir()
+			pod := "pod"
+			podClient := &testhelper_kube.FakePodClient{
 
-		FakePodExecutor: &testhelper_kube.FakePodExecutor{LoggingClient: loggingclient.New(fakectrlruntimeclient.NewClientBuilder().WithRuntimeObjects(
-			&coreapi.Pod{
-				ObjectMeta: meta.ObjectMeta{
-					Name:      pod,
-					Namespace: "namespace",
-				},
-				Status: coreapi.PodStatus{
-					ContainerStatuses: []coreapi.ContainerStatus{
-						{
-							Name: "artifacts",
-							State: coreapi.ContainerState{
-								Running: &coreapi.ContainerStateRunning{},
+				FakePodExecutor: &testhelper_kube.FakePodExecutor{LoggingClient: loggingclient.New(fakectrlruntimeclient.NewClientBuilder().WithRuntimeObjects(
+					&coreapi.Pod{
+						ObjectMeta: meta.ObjectMeta{
+							Name:      pod,
+							Namespace: "namespace",
+						},
+						Status: coreapi.PodStatus{
+							ContainerStatuses: []coreapi.ContainerStatus{
+								{
+									Name: "artifacts",
+									State: coreapi.ContainerState{
+										Running: &coreapi.ContainerStateRunning{},
+									},
+								},
 							},
 						},
-					},
+					}).Build(), nil),
 				},
-			}).Build(), nil),
-		},
-		Namespace: "namespace",
-		Name:      pod,
-	}
-	w := NewArtifactWorker(podClient, tmp, "namespace")
-	w.CollectFromPod(pod, []string{"container"}, nil)
-	w.Complete(pod)
-	select {
-	case <-w.Done(pod):
-	case <-time.After(time.Second):
-		t.Fatal("timeout waiting for artifact worker to finish")
-	}
-	files, err := os.ReadDir(tmp)
-	if err != nil {
-		t.Fatal(err)
-	}
-	var names []string
-	for _, f := range files {
-		names = append(names, f.Name())
-	}
-	if diff := cmp.Diff(names, []string{"test.txt"}); diff != "" {
-		t.Fatalf("artifacts do not match expected: %s", diff)
+				Namespace: "namespace",
+				Name:      pod,
+			}
+			w := NewArtifactWorker(podClient, tc.censor, tmp, "namespace")
+			w.CollectFromPod(pod, []string{"container"}, nil)
+			w.Complete(pod)
+			select {
+			case <-w.Done(pod):
+			case <-time.After(time.Second):
+				t.Fatal("timeout waiting for artifact worker to finish")
+			}
+			files, err := os.ReadDir(tmp)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var names []string
+			for _, f := range files {
+				names = append(names, f.Name())
+			}
+			if diff := cmp.Diff(names, []string{"test.txt"}); diff != "" {
+				t.Fatalf("artifacts do not match expected: %s", diff)
+			}
+			content, err := os.ReadFile(filepath.Join(tmp, "test.txt"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tc.expected, string(content)); diff != "" {
+				t.Errorf("artifact content does not match expected: %s", diff)
+			}
+		})
 	}
 }
 
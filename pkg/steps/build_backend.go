@@ -0,0 +1,267 @@
+package steps
+
+import (
+	"context"
+	"fmt"
+	"path"
+
+	"github.com/sirupsen/logrus"
+
+	coreapi "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	buildapi "github.com/openshift/api/build/v1"
+
+	"github.com/openshift/ci-tools/pkg/api"
+	"github.com/openshift/ci-tools/pkg/kubernetes"
+)
+
+// BuildBackend executes a single image build, described as an OpenShift
+// Build, and waits for it to complete.  The image is pushed to the output of
+// the build.
+type BuildBackend interface {
+	Build(ctx context.Context, build buildapi.Build) error
+}
+
+// newBuildBackend returns the implementation of the given backend, defaulting
+// to OpenShift Builds.
+func newBuildBackend(backend api.BuildBackend, buildahImage string, client BuildClient, podClient kubernetes.PodClient, needsMultiArchWorkaround func() bool) BuildBackend {
+	switch backend {
+	case api.BuildBackendBuildah:
+		if buildahImage == "" {
+			buildahImage = DefaultBuildahImage
+		}
+		return &buildahBuildBackend{client: client, podClient: podClient, image: buildahImage}
+	default:
+		return &openShiftBuildBackend{client: client, podClient: podClient, needsMultiArchWorkaround: needsMultiArchWorkaround}
+	}
+}
+
+type openShiftBuildBackend struct {
+	client                   BuildClient
+	podClient                kubernetes.PodClient
+	needsMultiArchWorkaround func() bool
+}
+
+func (b *openShiftBuildBackend) Build(ctx context.Context, build buildapi.Build) error {
+	return handleBuild(ctx, b.client, b.podClient, build, b.needsMultiArchWorkaround)
+}
+
+const (
+	// DefaultBuildahImage is the image used to execute builds with buildah
+	// unless the build cluster configures another one.
+	DefaultBuildahImage = "quay.io/buildah/stable:v1.39"
+
+	buildahContainerName   = "build"
+	buildahWorkspaceVolume = "workspace"
+	buildahWorkspace       = "/workspace"
+	buildahHomeVolume      = "home"
+	buildahHome            = "/home/build"
+	buildahPullSecretMount = "/etc/pull-secret"
+	buildahTokenPath       = "/var/run/secrets/kubernetes.io/serviceaccount/token"
+	// buildahServiceAccount is allowed to push to the image streams of the
+	// test namespace, as it is for OpenShift Builds.
+	buildahServiceAccount = "builder"
+)
+
+// buildahScript prepares the Dockerfile and registry configuration, then
+// builds and pushes the image.  Like OpenShift Builds, the last FROM of the
+// Dockerfile is replaced when a base image is set.  Additional arguments to
+// the build are passed as positional parameters.
+const buildahScript = `set -o errexit -o nounset -o pipefail
+mkdir -p "${HOME}/.config/containers/registries.conf.d"
+cat > "${HOME}/.config/containers/registries.conf.d/99-local-registry.conf" <<EOF
+[[registry]]
+location = "${LOCAL_REGISTRY}"
+insecure = true
+EOF
+export REGISTRY_AUTH_FILE=/tmp/auth.json
+if [[ -f ` + buildahPullSecretMount + `/.dockerconfigjson ]]; then
+  cp ` + buildahPullSecretMount + `/.dockerconfigjson "${REGISTRY_AUTH_FILE}"
+else
+  echo '{}' > "${REGISTRY_AUTH_FILE}"
+fi
+buildah login --username serviceaccount --password-stdin "${LOCAL_REGISTRY}" < ` + buildahTokenPath + `
+cd "${BUILD_CONTEXT}"
+if [[ -n "${DOCKERFILE_LITERAL:-}" ]]; then
+  printf '%s\n' "${DOCKERFILE_LITERAL}" > "${DOCKERFILE}"
+fi
+if [[ -n "${FROM_IMAGE:-}" ]]; then
+  line="$(grep -n -i -E '^[[:space:]]*FROM[[:space:]]' "${DOCKERFILE}" | tail -n 1 | cut -d: -f1)"
+  sed -i -E "${line}s#^([[:space:]]*[Ff][Rr][Oo][Mm][[:space:]]+(--[^[:space:]]+[[:space:]]+)*)[^[:space:]]+#\1${FROM_IMAGE}#" "${DOCKERFILE}"
+fi
+buildah bud --format=docker --pull=always --no-cache --file "${DOCKERFILE}" --tag "${OUTPUT_IMAGE}" "$@" .
+buildah push "${OUTPUT_IMAGE}" "docker://${OUTPUT_IMAGE}"
+`
+
+// buildahBuildBackend runs builds with buildah in a plain Kubernetes pod, so
+// images can be built on clusters which do not serve OpenShift Builds.  The
+// image sources of the build are copied into the build context by init
+// containers running the source images, which need to provide a shell.
+//
+// Buildah runs rootless with chroot isolation and the vfs storage driver, so
+// the pod is admitted under the restricted security context constraints and
+// needs no privileges or capabilities.  Builds run as the user the cluster
+// assigns to the pod, ownership changes to other users in the image are
+// ignored.
+type buildahBuildBackend struct {
+	client    BuildClient
+	podClient kubernetes.PodClient
+	image     string
+}
+
+func (b *buildahBuildBackend) Build(ctx context.Context, build buildapi.Build) error {
+	pod, err := buildahPod(build, b.image, b.client.LocalRegistryDNS())
+	if err != nil {
+		return fmt.Errorf("could not create build pod for %s: %w", build.Name, err)
+	}
+	logrus.Infof("Building %s with buildah in pod %s", build.Name, pod.Name)
+	if _, err := RunPod(ctx, b.podClient, pod, false); err != nil {
+		return fmt.Errorf("build pod %s failed: %w", pod.Name, err)
+	}
+	return nil
+}
+
+// buildahPod translates a Docker strategy build into a pod running buildah.
+func buildahPod(build buildapi.Build, image, registry string) (*coreapi.Pod, error) {
+	strategy := build.Spec.Strategy.DockerStrategy
+	if build.Spec.Strategy.Type != buildapi.DockerBuildStrategyType || strategy == nil {
+		return nil, fmt.Errorf("only builds with the %s strategy are supported", buildapi.DockerBuildStrategyType)
+	}
+	if registry == "" {
+		return nil, fmt.Errorf("the local registry is not configured")
+	}
+	if build.Spec.Output.To == nil {
+		return nil, fmt.Errorf("the build has no output")
+	}
+	pullSpec := func(ref coreapi.ObjectReference) (string, error) {
+		switch ref.Kind {
+		case "DockerImage":
+			return ref.Name, nil
+		case "ImageStreamTag":
+			namespace := ref.Namespace
+			if namespace == "" {
+				namespace = build.Namespace
+			}
+			return fmt.Sprintf("%s/%s/%s", registry, namespace, ref.Name), nil
+		}
+		return "", fmt.Errorf("unsupported image reference kind %q for %s", ref.Kind, ref.Name)
+	}
+	output, err := pullSpec(*build.Spec.Output.To)
+	if err != nil {
+		return nil, err
+	}
+	contextDir := path.Join(buildahWorkspace, build.Spec.Source.ContextDir)
+	dockerfile := strategy.DockerfilePath
+	if dockerfile == "" || build.Spec.Source.Dockerfile != nil {
+		dockerfile = "Dockerfile"
+	}
+	env := []coreapi.EnvVar{
+		{Name: "HOME", Value: buildahHome},
+		{Name: "BUILDAH_ISOLATION", Value: "chroot"},
+		{Name: "STORAGE_DRIVER", Value: "vfs"},
+		{Name: "STORAGE_OPTS", Value: "vfs.ignore_chown_errors=true"},
+		{Name: "LOCAL_REGISTRY", Value: registry},
+		{Name: "BUILD_CONTEXT", Value: contextDir},
+		{Name: "DOCKERFILE", Value: dockerfile},
+		{Name: "OUTPUT_IMAGE", Value: output},
+	}
+	if build.Spec.Source.Dockerfile != nil {
+		env = append(env, coreapi.EnvVar{Name: "DOCKERFILE_LITERAL", Value: *build.Spec.Source.Dockerfile})
+	}
+	if strategy.From != nil {
+		from, err := pullSpec(*strategy.From)
+		if err != nil {
+			return nil, err
+		}
+		env = append(env, coreapi.EnvVar{Name: "FROM_IMAGE", Value: from})
+	}
+
+	var args []string
+	for _, arg := range strategy.BuildArgs {
+		args = append(args, fmt.Sprintf("--build-arg=%s=%s", arg.Name, arg.Value))
+	}
+	for _, label := range build.Spec.Output.ImageLabels {
+		args = append(args, fmt.Sprintf("--label=%s=%s", label.Name, label.Value))
+	}
+
+	workspaceMount := coreapi.VolumeMount{Name: buildahWorkspaceVolume, MountPath: buildahWorkspace}
+	var initContainers []coreapi.Container
+	for i, source := range build.Spec.Source.Images {
+		sourceImage, err := pullSpec(source.From)
+		if err != nil {
+			return nil, err
+		}
+		for _, as := range source.As {
+			args = append(args, fmt.Sprintf("--build-context=%s=docker-image://%s", as, sourceImage))
+		}
+		for j, p := range source.Paths {
+			initContainers = append(initContainers, coreapi.Container{
+				Name:    fmt.Sprintf("source-%d-%d", i, j),
+				Image:   sourceImage,
+				Command: []string{"/bin/sh", "-c", `mkdir -p "${DESTINATION_DIR}" && cp -a "${SOURCE_PATH}" "${DESTINATION_DIR}"`},
+				Env: []coreapi.EnvVar{
+					{Name: "SOURCE_PATH", Value: p.SourcePath},
+					{Name: "DESTINATION_DIR", Value: path.Join(contextDir, p.DestinationDir)},
+				},
+				VolumeMounts:             []coreapi.VolumeMount{workspaceMount},
+				TerminationMessagePolicy: coreapi.TerminationMessageFallbackToLogsOnError,
+			})
+		}
+	}
+
+	container := coreapi.Container{
+		Name:                     buildahContainerName,
+		Image:                    image,
+		Command:                  append([]string{"/bin/bash", "-c", buildahScript, "build"}, args...),
+		Env:                      env,
+		Resources:                build.Spec.Resources,
+		VolumeMounts:             []coreapi.VolumeMount{workspaceMount, {Name: buildahHomeVolume, MountPath: buildahHome}},
+		TerminationMessagePolicy: coreapi.TerminationMessageFallbackToLogsOnError,
+		SecurityContext: &coreapi.SecurityContext{
+			AllowPrivilegeEscalation: ptr.To(false),
+			Capabilities: &coreapi.Capabilities{
+				Drop: []coreapi.Capability{"ALL"},
+			},
+		},
+	}
+	volumes := []coreapi.Volume{
+		{
+			Name:         buildahWorkspaceVolume,
+			VolumeSource: coreapi.VolumeSource{EmptyDir: &coreapi.EmptyDirVolumeSource{}},
+		},
+		{
+			Name:         buildahHomeVolume,
+			VolumeSource: coreapi.VolumeSource{EmptyDir: &coreapi.EmptyDirVolumeSource{}},
+		},
+	}
+	var imagePullSecrets []coreapi.LocalObjectReference
+	if strategy.PullSecret != nil {
+		imagePullSecrets = append(imagePullSecrets, *strategy.PullSecret)
+		volumes = append(volumes, coreapi.Volume{
+			Name:         "pull-secret",
+			VolumeSource: coreapi.VolumeSource{Secret: &coreapi.SecretVolumeSource{SecretName: strategy.PullSecret.Name}},
+		})
+		container.VolumeMounts = append(container.VolumeMounts, coreapi.VolumeMount{Name: "pull-secret", MountPath: buildahPullSecretMount, ReadOnly: true})
+	}
+
+	return &coreapi.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            fmt.Sprintf("%s-build", build.Name),
+			Namespace:       build.Namespace,
+			Labels:          build.Labels,
+			Annotations:     build.Annotations,
+			OwnerReferences: build.OwnerReferences,
+		},
+		Spec: coreapi.PodSpec{
+			RestartPolicy:      coreapi.RestartPolicyNever,
+			ServiceAccountName: buildahServiceAccount,
+			NodeSelector:       build.Spec.NodeSelector,
+			ImagePullSecrets:   imagePullSecrets,
+			InitContainers:     initContainers,
+			Containers:         []coreapi.Container{container},
+			Volumes:            volumes,
+		},
+	}, nil
+}
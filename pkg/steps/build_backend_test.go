@@ -0,0 +1,98 @@
+package steps
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	coreapi "k8s.io/api/core/v1"
+	prowapi "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+	"sigs.k8s.io/prow/pkg/pod-utils/downwardapi"
+
+	buildapi "github.com/openshift/api/build/v1"
+
+	"github.com/openshift/ci-tools/pkg/api"
+	"github.com/openshift/ci-tools/pkg/testhelper"
+)
+
+func TestBuildahPod(t *testing.T) {
+	jobSpec := &api.JobSpec{
+		JobSpec: downwardapi.JobSpec{
+			Job:       "job",
+			BuildID:   "buildId",
+			ProwJobID: "prowJobId",
+			Refs: &prowapi.Refs{
+				Org:     "org",
+				Repo:    "repo",
+				BaseRef: "master",
+				BaseSHA: "masterSHA",
+			},
+		},
+	}
+	jobSpec.SetNamespace("test-namespace")
+	dockerfile := "FROM base\nRUN make\n"
+	testCases := []struct {
+		name     string
+		build    func() buildapi.Build
+		registry string
+		err      error
+	}{
+		{
+			name: "image sources, base image and build arguments",
+			build: func() buildapi.Build {
+				return *buildFromSource(
+					jobSpec, "base", "image",
+					buildapi.BuildSource{
+						Type: buildapi.BuildSourceImage,
+						Images: []buildapi.ImageSource{
+							{
+								From:  coreapi.ObjectReference{Kind: "ImageStreamTag", Name: "pipeline:src"},
+								Paths: []buildapi.ImageSourcePath{{SourcePath: "/go/src/github.com/org/repo/.", DestinationDir: "."}},
+							},
+							{
+								From: coreapi.ObjectReference{Kind: "ImageStreamTag", Name: "pipeline:cli"},
+								As:   []string{"registry.ci.openshift.org/ocp/4.20:cli"},
+							},
+						},
+					},
+					"digest", "images/Dockerfile", nil, &coreapi.Secret{}, []api.BuildArg{{Name: "TAGS", Value: "release"}}, "",
+				)
+			},
+			registry: "registry.local:5000",
+		},
+		{
+			name: "literal Dockerfile",
+			build: func() buildapi.Build {
+				return *buildFromSource(jobSpec, "", "image", buildapi.BuildSource{Type: buildapi.BuildSourceImage, Dockerfile: &dockerfile}, "", "ignored/Dockerfile", nil, nil, nil, "")
+			},
+			registry: "registry.local:5000",
+		},
+		{
+			name: "no local registry",
+			build: func() buildapi.Build {
+				return *buildFromSource(jobSpec, "", "image", buildapi.BuildSource{Type: buildapi.BuildSourceImage}, "", "", nil, nil, nil, "")
+			},
+			err: errors.New("the local registry is not configured"),
+		},
+		{
+			name: "source strategy",
+			build: func() buildapi.Build {
+				return buildapi.Build{Spec: buildapi.BuildSpec{CommonSpec: buildapi.CommonSpec{Strategy: buildapi.BuildStrategy{Type: buildapi.SourceBuildStrategyType}}}}
+			},
+			registry: "registry.local:5000",
+			err:      errors.New("only builds with the Docker strategy are supported"),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			pod, err := buildahPod(tc.build(), DefaultBuildahImage, tc.registry)
+			if diff := cmp.Diff(tc.err, err, testhelper.EquateErrorMessage); diff != "" {
+				t.Fatalf("unexpected error: %s", diff)
+			}
+			if err == nil {
+				testhelper.CompareWithFixture(t, pod)
+			}
+		})
+	}
+}
@@ -40,6 +40,11 @@ func (s *imagesReadyStep) Objects() []ctrlruntimeclient.Object {
 	return nil
 }
 
+// RenderObjects generates nothing, as the step does not create any object.
+func (s *imagesReadyStep) RenderObjects() ([]ctrlruntimeclient.Object, error) {
+	return nil, nil
+}
+
 func (s *imagesReadyStep) Description() string { return "All images are built and tagged into stable" }
 
 func ImagesReadyStep(links []api.StepLink) api.Step {
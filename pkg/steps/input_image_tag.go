@@ -80,41 +80,12 @@ func (s *inputImageTagStep) run(ctx context.Context) error {
 		return fmt.Errorf("could not resolve inputs for image tag step: %w", err)
 	}
 
-	var objectReferenceName string
+	ist, objectReferenceName := s.imageStreamTag(s.imageName)
+	from := ist.Tag.From
 	if s.config.ExternalImage != nil {
-		externalPullSpec := externalImageReference(s.config)
-		logrus.Infof("Tagging %s into %s:%s.", externalPullSpec, api.PipelineImageStream, s.config.To)
-		objectReferenceName = externalPullSpec
+		logrus.Infof("Tagging %s into %s:%s.", objectReferenceName, api.PipelineImageStream, s.config.To)
 	} else {
 		logrus.Infof("Tagging %s into %s:%s.", s.config.BaseImage.ISTagName(), api.PipelineImageStream, s.config.To)
-		objectReferenceName = api.QuayImageReference(s.config.BaseImage)
-	}
-	from := &coreapi.ObjectReference{
-		Kind: "DockerImage",
-		Name: objectReferenceName,
-	}
-	if api.IsCreatedForClusterBotJob(s.config.BaseImage.Namespace) {
-		from = &coreapi.ObjectReference{
-			Kind:      "ImageStreamImage",
-			Name:      fmt.Sprintf("%s@%s", s.config.BaseImage.Name, s.imageName),
-			Namespace: s.config.BaseImage.Namespace,
-		}
-	}
-
-	ist := &imagev1.ImageStreamTag{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      fmt.Sprintf("%s:%s", api.PipelineImageStream, s.config.To),
-			Namespace: s.jobSpec.Namespace(),
-		},
-		Tag: &imagev1.TagReference{
-			ReferencePolicy: imagev1.TagReferencePolicy{
-				Type: imagev1.SourceTagReferencePolicy,
-			},
-			From: from,
-			ImportPolicy: imagev1.TagImportPolicy{
-				ImportMode: imagev1.ImportModePreserveOriginal,
-			},
-		},
 	}
 
 	if err := s.client.Create(ctx, ist); err != nil && !kerrors.IsAlreadyExists(err) {
@@ -147,6 +118,52 @@ func (s *inputImageTagStep) run(ctx context.Context) error {
 	return nil
 }
 
+// RenderObjects generates the tag of the step without creating it.  Images
+// from streams created for cluster bot jobs are resolved when the step runs
+// and are rendered as placeholders.
+func (s *inputImageTagStep) RenderObjects() ([]ctrlruntimeclient.Object, error) {
+	ist, _ := s.imageStreamTag(api.DryRunPlaceholder(fmt.Sprintf("image of %s", s.config.BaseImage.ISTagName())))
+	return []ctrlruntimeclient.Object{ist}, nil
+}
+
+// imageStreamTag returns the tag in the pipeline image stream for the input
+// image and the pull spec it points to.  `imageName` is only used for images
+// from streams created for cluster bot jobs.
+func (s *inputImageTagStep) imageStreamTag(imageName string) (*imagev1.ImageStreamTag, string) {
+	var objectReferenceName string
+	if s.config.ExternalImage != nil {
+		objectReferenceName = externalImageReference(s.config)
+	} else {
+		objectReferenceName = api.QuayImageReference(s.config.BaseImage)
+	}
+	from := &coreapi.ObjectReference{
+		Kind: "DockerImage",
+		Name: objectReferenceName,
+	}
+	if api.IsCreatedForClusterBotJob(s.config.BaseImage.Namespace) {
+		from = &coreapi.ObjectReference{
+			Kind:      "ImageStreamImage",
+			Name:      fmt.Sprintf("%s@%s", s.config.BaseImage.Name, imageName),
+			Namespace: s.config.BaseImage.Namespace,
+		}
+	}
+	return &imagev1.ImageStreamTag{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("%s:%s", api.PipelineImageStream, s.config.To),
+			Namespace: s.jobSpec.Namespace(),
+		},
+		Tag: &imagev1.TagReference{
+			ReferencePolicy: imagev1.TagReferencePolicy{
+				Type: imagev1.SourceTagReferencePolicy,
+			},
+			From: from,
+			ImportPolicy: imagev1.TagImportPolicy{
+				ImportMode: imagev1.ImportModePreserveOriginal,
+			},
+		},
+	}, objectReferenceName
+}
+
 // waitForTagInSpec waits for the tag on the image stream are to show in spec
 func waitForTagInSpec(ctx context.Context, client ctrlruntimeclient.WithWatch, ns, name, tag string, timeout time.Duration) error {
 	obj := &imagev1.ImageStream{}
@@ -23,6 +23,7 @@ type stepLeaseProxyServer struct {
 	logger      *logrus.Entry
 	srvMux      *http.ServeMux
 	srvAddr     string
+	token       string
 	leaseClient *lease.Client
 }
 
@@ -44,6 +45,10 @@ func (s *stepLeaseProxyServer) Provides() api.ParameterMap {
 		api.LeaseProxyServerURLEnvVarName: func() (string, error) {
 			return s.srvAddr, nil
 		},
+		//nolint:unparam // Remove this as soon as this functions can return an error as well.
+		api.LeaseProxyTokenEnvVarName: func() (string, error) {
+			return s.token, nil
+		},
 	}
 }
 
@@ -65,16 +70,19 @@ func (s *stepLeaseProxyServer) Run(ctx context.Context) error {
 
 //nolint:unparam // Remove this as soon as this functions can return an error as well.
 func (s *stepLeaseProxyServer) run(context.Context) error {
-	proxy := leaseproxy.New(s.logger, func() lease.Client { return *s.leaseClient })
+	proxy := leaseproxy.New(s.logger, func() lease.Client { return *s.leaseClient }, leaseproxy.WithToken(s.token))
 	proxy.RegisterHandlers(s.srvMux)
 	return nil
 }
 
-func LeaseProxyStep(logger *logrus.Entry, srvAddr string, srvMux *http.ServeMux, leaseClient *lease.Client) api.Step {
+// LeaseProxyStep serves the lease proxy to the steps of the job, which must
+// authenticate with the token.
+func LeaseProxyStep(logger *logrus.Entry, srvAddr, token string, srvMux *http.ServeMux, leaseClient *lease.Client) api.Step {
 	return &stepLeaseProxyServer{
 		logger:      logger,
 		srvMux:      srvMux,
 		srvAddr:     srvAddr,
+		token:       token,
 		leaseClient: leaseClient,
 	}
 }
@@ -22,18 +22,18 @@ func TestLeaseProxyProvides(t *testing.T) {
 	}{
 		{
 			name:           "Empty HTTP server addr",
-			expectedParams: map[string]string{api.LeaseProxyServerURLEnvVarName: ""},
+			expectedParams: map[string]string{api.LeaseProxyServerURLEnvVarName: "", api.LeaseProxyTokenEnvVarName: "token"},
 		},
 		{
 			name:           "Non empty HTTP server addr",
 			httpSrvAddr:    "http://10.0.0.1:8080",
-			expectedParams: map[string]string{api.LeaseProxyServerURLEnvVarName: "http://10.0.0.1:8080"},
+			expectedParams: map[string]string{api.LeaseProxyServerURLEnvVarName: "http://10.0.0.1:8080", api.LeaseProxyTokenEnvVarName: "token"},
 		},
 	} {
 		t.Run(tc.name, func(t *testing.T) {
 			t.Parallel()
 
-			step := LeaseProxyStep(logrus.NewEntry(&logrus.Logger{}), tc.httpSrvAddr, &http.ServeMux{}, nil)
+			step := LeaseProxyStep(logrus.NewEntry(&logrus.Logger{}), tc.httpSrvAddr, "token", &http.ServeMux{}, nil)
 
 			gotParams := make(map[string]string)
 			for k, f := range step.Provides() {
@@ -63,13 +63,13 @@ func TestValidate(t *testing.T) {
 			name: "Validation passes",
 			newLeaseProxyStepFunc: func() api.Step {
 				leaseClient := lease.NewFakeClient("owner", "", 1, nil, nil, nil)
-				return LeaseProxyStep(nil, "x.y.w.z", &http.ServeMux{}, &leaseClient)
+				return LeaseProxyStep(nil, "x.y.w.z", "token", &http.ServeMux{}, &leaseClient)
 			},
 		},
 		{
 			name: "http mux is missing",
 			newLeaseProxyStepFunc: func() api.Step {
-				return LeaseProxyStep(nil, "x.y.w.z", nil, nil)
+				return LeaseProxyStep(nil, "x.y.w.z", "token", nil, nil)
 			},
 			wantErr: errors.New("lease proxy server requires an HTTP server mux"),
 		},
@@ -77,7 +77,7 @@ func TestValidate(t *testing.T) {
 			name: "http address is empty",
 			newLeaseProxyStepFunc: func() api.Step {
 				leaseClient := lease.NewFakeClient("owner", "", 1, nil, nil, nil)
-				return LeaseProxyStep(nil, "", &http.ServeMux{}, &leaseClient)
+				return LeaseProxyStep(nil, "", "token", &http.ServeMux{}, &leaseClient)
 			},
 			wantErr: errors.New("lease proxy server requires an HTTP server address"),
 		},
@@ -0,0 +1,181 @@
+package multi_stage
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	coreapi "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/openshift/ci-tools/pkg/api"
+	"github.com/openshift/ci-tools/pkg/api/nsttl"
+	base_steps "github.com/openshift/ci-tools/pkg/steps"
+)
+
+const (
+	// debugHoldSuffix is appended to the name of a failed pod to name its
+	// paused copy.
+	debugHoldSuffix = "-debug"
+	// debugHoldPollInterval is how often the paused copy is checked for
+	// deletion, which ends the hold early.
+	debugHoldPollInterval = 10 * time.Second
+)
+
+// debugHoldDroppedContainers are injected into step pods to run and upload the
+// results of the step and are not part of a paused copy.
+var debugHoldDroppedContainers = sets.New[string]("place-entrypoint", "initupload", "clonerefs", "sidecar", "cp-entrypoint-wrapper")
+
+// holdForDebugging replaces a failed step pod by a paused copy and waits until
+// the hold expires or the copy is deleted, so that the step can be inspected
+// with the same environment, mounts, shared directory and kubeconfig before
+// the test continues.  The copy is made from the failed pod as it exists in
+// the cluster, including the changes made to it on admission, and from the
+// generated pod if it cannot be retrieved.
+func (s *multiStageTestStep) holdForDebugging(ctx context.Context, generated *coreapi.Pod) {
+	hold := s.debugHold.Duration
+	failed := &coreapi.Pod{}
+	if err := s.client.Get(ctx, ctrlruntimeclient.ObjectKeyFromObject(generated), failed); err != nil {
+		logrus.WithError(err).Warnf("Could not get failed step %s, the paused copy is made from its generated pod.", generated.Name)
+		failed = generated
+	}
+	pod := debugHoldPod(failed, s.nodeName, hold)
+	if err := s.extendNamespaceTTL(ctx, hold); err != nil {
+		logrus.WithError(err).Warnf("Could not extend the time to live of namespace %s for the debug hold.", pod.Namespace)
+	}
+	if err := s.client.Create(ctx, pod); err != nil {
+		logrus.WithError(err).Warnf("Could not create paused copy %s of failed step %s.", pod.Name, failed.Name)
+		return
+	}
+	defer func() {
+		logrus.Infof("Deleting paused copy %s of failed step %s.", pod.Name, failed.Name)
+		deleteCtx, cancel := context.WithTimeout(base_steps.CleanupCtx, 30*time.Second)
+		defer cancel()
+		if err := s.client.Delete(deleteCtx, pod); err != nil && !kerrors.IsNotFound(err) {
+			logrus.WithError(err).Warnf("Failed to delete paused copy %s.", pod.Name)
+		}
+	}()
+	instructions := debugHoldInstructions(failed.Name, pod, hold)
+	logrus.Info(instructions)
+	if err := api.SaveArtifact(s.debugHold.Censor, filepath.Join("debug-hold", failed.Name+".txt"), []byte(instructions)); err != nil {
+		logrus.WithError(err).Warnf("Could not write the debug hold instructions for %s.", failed.Name)
+	}
+
+	holdCtx, cancel := context.WithTimeout(ctx, hold)
+	defer cancel()
+	// the poll only ends early when the copy is gone or has stopped, the end
+	// of the hold is reported as an error which is expected
+	_ = wait.PollUntilContextCancel(holdCtx, debugHoldPollInterval, true, func(ctx context.Context) (bool, error) {
+		current := &coreapi.Pod{}
+		if err := s.client.Get(ctx, ctrlruntimeclient.ObjectKeyFromObject(pod), current); err != nil {
+			if kerrors.IsNotFound(err) {
+				return true, nil
+			}
+			logrus.WithError(err).Debugf("Could not get paused copy %s.", pod.Name)
+			return false, nil
+		}
+		return current.DeletionTimestamp != nil || current.Status.Phase == coreapi.PodSucceeded || current.Status.Phase == coreapi.PodFailed, nil
+	})
+	logrus.Infof("Debug hold of failed step %s has ended.", failed.Name)
+}
+
+// debugHoldPod copies a step pod, replacing the command of the step by one
+// which waits for the hold to expire.  The containers which execute the step
+// and upload its results are removed.
+func debugHoldPod(failed *coreapi.Pod, nodeName string, hold time.Duration) *coreapi.Pod {
+	seconds := int64(hold.Seconds())
+	pod := &coreapi.Pod{
+		ObjectMeta: *failed.ObjectMeta.DeepCopy(),
+		Spec:       *failed.Spec.DeepCopy(),
+	}
+	pod.Name = failed.Name + debugHoldSuffix
+	pod.ResourceVersion = ""
+	pod.UID = ""
+	pod.CreationTimestamp = metav1.Time{}
+	pod.DeletionTimestamp = nil
+	pod.DeletionGracePeriodSeconds = nil
+	pod.ManagedFields = nil
+	pod.Finalizers = nil
+	pod.Spec.NodeName = nodeName
+	pod.Spec.ActiveDeadlineSeconds = &seconds
+	pod.Spec.InitContainers = dropDebugHoldContainers(pod.Spec.InitContainers)
+	pod.Spec.Containers = dropDebugHoldContainers(pod.Spec.Containers)
+	for i := range pod.Spec.Containers {
+		if c := &pod.Spec.Containers[i]; c.Name == containerName {
+			c.Command = []string{"/bin/sh", "-c", fmt.Sprintf("sleep %d", seconds)}
+			c.Args = nil
+		}
+	}
+	return pod
+}
+
+func dropDebugHoldContainers(containers []coreapi.Container) []coreapi.Container {
+	var ret []coreapi.Container
+	for _, c := range containers {
+		if !debugHoldDroppedContainers.Has(c.Name) {
+			ret = append(ret, c)
+		}
+	}
+	return ret
+}
+
+// extendNamespaceTTL makes sure the namespace is not deleted while a step is
+// held, even if the job ends before the hold does.  The idle time-to-live,
+// measured from the last activity in the namespace, is raised to the hold and
+// the hard time-to-live, measured from the creation of the namespace, to its
+// age plus the hold, rounded up to the minute.  Longer settings are kept.
+func (s *multiStageTestStep) extendNamespaceTTL(ctx context.Context, hold time.Duration) error {
+	ns := &coreapi.Namespace{}
+	if err := s.client.Get(ctx, ctrlruntimeclient.ObjectKey{Name: s.jobSpec.Namespace()}, ns); err != nil {
+		return fmt.Errorf("could not get namespace: %w", err)
+	}
+	original := ns.DeepCopy()
+	if ns.Annotations == nil {
+		ns.Annotations = map[string]string{}
+	}
+	hard := hold
+	if !ns.CreationTimestamp.IsZero() {
+		hard = (time.Since(ns.CreationTimestamp.Time) + hold).Truncate(time.Minute) + time.Minute
+	}
+	for annotation, required := range map[string]time.Duration{
+		nsttl.AnnotationIdleCleanupDurationTTL: hold,
+		nsttl.AnnotationCleanupDurationTTL:     hard,
+	} {
+		value, set := ns.Annotations[annotation]
+		if !set {
+			// the namespace is not deleted after any time
+			continue
+		}
+		if ttl, err := time.ParseDuration(value); err == nil && ttl >= required {
+			continue
+		}
+		ns.Annotations[annotation] = required.String()
+	}
+	ns.Annotations[nsttl.AnnotationNamespaceLastActive] = time.Now().Format(time.RFC3339)
+	if err := s.client.Patch(ctx, ns, ctrlruntimeclient.MergeFrom(original)); err != nil {
+		return fmt.Errorf("could not update namespace: %w", err)
+	}
+	return nil
+}
+
+// debugHoldInstructions explains how to connect to the paused copy of a step.
+func debugHoldInstructions(step string, pod *coreapi.Pod, hold time.Duration) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Step %s failed and is held for debugging for %s.\n\n", step, hold)
+	fmt.Fprintf(&b, "A paused copy of its pod is running as %s in namespace %s on the build cluster, with the same environment, mounts and kubeconfig. ", pod.Name, pod.Namespace)
+	fmt.Fprintf(&b, "$%s contains the shared directory as it was when the step finished; it is read-only, copy it to make changes. ", SecretMountEnv)
+	b.WriteString("The authors of the pull request under test have access to the namespace.\n\n")
+	b.WriteString("To open a shell in the copy:\n\n")
+	fmt.Fprintf(&b, "  oc --namespace %s rsh %s\n\n", pod.Namespace, pod.Name)
+	b.WriteString("The test continues when the hold expires. To end it earlier, delete the copy:\n\n")
+	fmt.Fprintf(&b, "  oc --namespace %s delete pod %s\n", pod.Namespace, pod.Name)
+	return b.String()
+}
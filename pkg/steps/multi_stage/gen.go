@@ -25,6 +25,9 @@ const (
 	profileVolumeName          = "cluster-profile"
 	vpnContainerName           = "vpn-client"
 	leaseProxyScriptsMountPath = "/opt/scripts/lease-proxy"
+	// leaseProxyClientPath is where the lease proxy client is copied to along
+	// with the entrypoint wrapper
+	leaseProxyClientPath = "/tmp/entrypoint-wrapper/lease-proxy-client"
 )
 
 func (s *multiStageTestStep) generateObservers(
@@ -54,6 +57,9 @@ func (s *multiStageTestStep) generateObservers(
 type generatePodOptions struct {
 	IsObserver                  bool
 	enableSecretsStoreCSIDriver bool
+	// placeholders renders the values which are only known once the
+	// dependencies of the test have run as placeholders instead of failing.
+	placeholders bool
 }
 
 func defaultGeneratePodOptions() *generatePodOptions {
@@ -185,7 +191,7 @@ func (s *multiStageTestStep) generatePods(
 			}
 		}
 
-		addSecretWrapper(pod, s.vpnConf, !needsKubeConfig, genPodOpts)
+		addSecretWrapper(pod, s.vpnConf, !needsKubeConfig, s.parallelStepGroups && step.ParallelGroup != "", genPodOpts)
 		if s.vpnConf != nil {
 			s.addVPNClient(pod)
 		}
@@ -198,7 +204,7 @@ func (s *multiStageTestStep) generatePods(
 		}...)
 		container.Env = append(container.Env, env...)
 		container.Env = append(container.Env, s.generateParams(step.Environment)...)
-		depEnv, depErrs := s.envForDependencies(step)
+		depEnv, depErrs := s.envForDependencies(step, genPodOpts.placeholders)
 		if len(depErrs) != 0 {
 			errs = append(errs, depErrs...)
 			continue
@@ -279,7 +285,7 @@ func isKubeconfigNeeded(step *api.LiteralTestStep, opts *generatePodOptions) boo
 	return needsKubeconfig || opts.IsObserver
 }
 
-func addSecretWrapper(pod *coreapi.Pod, vpnConf *vpnConf, skipKubeconfig bool, genPodOpts *generatePodOptions) {
+func addSecretWrapper(pod *coreapi.Pod, vpnConf *vpnConf, skipKubeconfig, mergeSharedDir bool, genPodOpts *generatePodOptions) {
 	volume := "entrypoint-wrapper"
 	dir := "/tmp/entrypoint-wrapper"
 	bin := filepath.Join(dir, "entrypoint-wrapper")
@@ -291,10 +297,12 @@ func addSecretWrapper(pod *coreapi.Pod, vpnConf *vpnConf, skipKubeconfig bool, g
 	})
 	mount := coreapi.VolumeMount{Name: volume, MountPath: dir}
 	pod.Spec.InitContainers = append(pod.Spec.InitContainers, coreapi.Container{
-		Image:                    fmt.Sprintf("%s/%s/ci:ci_entrypoint-wrapper_latest", api.QCIAPPCIDomain, "openshift"),
-		Name:                     "cp-entrypoint-wrapper",
-		Command:                  []string{"cp"},
-		Args:                     []string{"/bin/entrypoint-wrapper", bin},
+		Image:   fmt.Sprintf("%s/%s/ci:ci_entrypoint-wrapper_latest", api.QCIAPPCIDomain, "openshift"),
+		Name:    "cp-entrypoint-wrapper",
+		Command: []string{"/bin/sh", "-c"},
+		// the lease proxy client is missing from images published before it
+		// was added, in which case steps only have the shell client
+		Args:                     []string{fmt.Sprintf("cp /bin/entrypoint-wrapper %[1]s && if [ -e /bin/lease-proxy-client ]; then cp /bin/lease-proxy-client %[1]s; fi", dir)},
 		VolumeMounts:             []coreapi.VolumeMount{mount},
 		TerminationMessagePolicy: coreapi.TerminationMessageFallbackToLogsOnError,
 	})
@@ -312,6 +320,10 @@ func addSecretWrapper(pod *coreapi.Pod, vpnConf *vpnConf, skipKubeconfig bool, g
 	if genPodOpts.IsObserver {
 		container.Args = append(container.Args, "--mode=observer")
 	}
+	if mergeSharedDir {
+		// steps running in parallel must not overwrite each other's changes
+		container.Args = append(container.Args, "--merge-shared-dir")
+	}
 	container.Args = append(container.Args, container.Command...)
 	container.Args = append(container.Args, args...)
 	container.Command = []string{bin}
@@ -402,7 +414,7 @@ func (s *multiStageTestStep) generateParams(env []api.StepParameter) []coreapi.E
 	return ret
 }
 
-func (s *multiStageTestStep) envForDependencies(step api.LiteralTestStep) ([]coreapi.EnvVar, []error) {
+func (s *multiStageTestStep) envForDependencies(step api.LiteralTestStep, placeholders bool) ([]coreapi.EnvVar, []error) {
 	var env []coreapi.EnvVar
 	var errs []error
 	var claimRelease *api.ClaimRelease
@@ -418,6 +430,9 @@ func (s *multiStageTestStep) envForDependencies(step api.LiteralTestStep) ([]cor
 		} else {
 			imageStream, name, _ := s.config.DependencyParts(dependency, claimRelease)
 			depRef, err := utils.ImageDigestFor(s.client, s.jobSpec.Namespace, imageStream, name)()
+			if err != nil && placeholders {
+				depRef, err = api.DryRunPlaceholder(fmt.Sprintf("%s:%s", imageStream, name)), nil
+			}
 			if err != nil {
 				errs = append(errs, fmt.Errorf("could not determine image pull spec for image %s on step %s", dependency.Name, step.As))
 				continue
@@ -428,6 +443,23 @@ func (s *multiStageTestStep) envForDependencies(step api.LiteralTestStep) ([]cor
 			Name: dependency.Env, Value: ref,
 		})
 	}
+	for _, name := range step.ServedArtifacts {
+		envVar := utils.ServedArtifactsEnv(name)
+		if placeholders {
+			env = append(env, coreapi.EnvVar{Name: envVar, Value: s.placeholderParameter(envVar)})
+			continue
+		}
+		if s.params == nil {
+			errs = append(errs, fmt.Errorf("could not determine the URL of the %s artifacts on step %s", name, step.As))
+			continue
+		}
+		url, err := s.params.Get(envVar)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("could not determine the URL of the %s artifacts on step %s: %w", name, step.As, err))
+			continue
+		}
+		env = append(env, coreapi.EnvVar{Name: envVar, Value: url})
+	}
 	return env, errs
 }
 
@@ -651,5 +683,8 @@ func addLeaseProxyScripts(pod *coreapi.Pod, c *coreapi.Container) {
 	c.Env = append(c.Env, coreapi.EnvVar{
 		Name:  "LEASE_PROXY_CLIENT_SH",
 		Value: leaseProxyScriptsMountPath + "/client.sh",
+	}, coreapi.EnvVar{
+		Name:  api.LeaseProxyClientEnvVarName,
+		Value: leaseProxyClientPath,
 	})
 }
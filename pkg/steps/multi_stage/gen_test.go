@@ -71,6 +71,7 @@ func TestGeneratePods(t *testing.T) {
 		secretVolumes             []coreapi.Volume
 		secretVolumeMounts        []coreapi.VolumeMount
 		leaseProxyServerAvailable bool
+		parallelStepGroups        bool
 	}{
 		{
 			name: "generate pods",
@@ -153,12 +154,63 @@ func TestGeneratePods(t *testing.T) {
 			},
 			leaseProxyServerAvailable: true,
 		},
+		{
+			name: "lease proxy token is given to steps which acquire leases through the proxy only",
+			config: &api.ReleaseBuildConfiguration{
+				Tests: []api.TestStepConfiguration{{
+					As: "acquire-through-the-proxy",
+					MultiStageTestConfigurationLiteral: &api.MultiStageTestConfigurationLiteral{
+						Test: []api.LiteralTestStep{{
+							As:       "step0",
+							From:     "src",
+							Commands: "command0",
+						}},
+					},
+				}},
+			},
+			env: []coreapi.EnvVar{
+				{Name: api.LeaseProxyServerURLEnvVarName, Value: "http://10.0.0.1:8080"},
+				{Name: api.LeaseProxyTokenEnvVarName, Value: "token"},
+			},
+			leaseProxyServerAvailable: true,
+		},
+		{
+			name: "parallel group merges the shared directory",
+			config: &api.ReleaseBuildConfiguration{
+				Tests: []api.TestStepConfiguration{{
+					As: "test",
+					MultiStageTestConfigurationLiteral: &api.MultiStageTestConfigurationLiteral{
+						Test: []api.LiteralTestStep{{
+							As: "step0", From: "src", Commands: "command0", ParallelGroup: "step0",
+						}, {
+							As: "step1", From: "src", Commands: "command1", ParallelGroup: "step0",
+						}},
+					},
+				}},
+			},
+			parallelStepGroups: true,
+		},
+		{
+			name: "parallel group does not merge the shared directory when parallel step groups are disabled",
+			config: &api.ReleaseBuildConfiguration{
+				Tests: []api.TestStepConfiguration{{
+					As: "test",
+					MultiStageTestConfigurationLiteral: &api.MultiStageTestConfigurationLiteral{
+						Test: []api.LiteralTestStep{{
+							As: "step0", From: "src", Commands: "command0", ParallelGroup: "step0",
+						}, {
+							As: "step1", From: "src", Commands: "command1", ParallelGroup: "step0",
+						}},
+					},
+				}},
+			},
+		},
 	} {
 		t.Run(tc.name, func(t *testing.T) {
 			t.Parallel()
 
 			js := jobSpec()
-			step := newMultiStageTestStep(tc.config.Tests[0], tc.config, nil, nil, &js, nil, "node-name", "", nil, false, nil, tc.leaseProxyServerAvailable, wait.Backoff{})
+			step := newMultiStageTestStep(tc.config.Tests[0], tc.config, nil, nil, &js, nil, "node-name", "", nil, false, nil, tc.leaseProxyServerAvailable, wait.Backoff{}, nil, tc.parallelStepGroups)
 			step.test[0].Resources = resourceRequirements
 
 			ret, _, err := step.generatePods(tc.config.Tests[0].MultiStageTestConfigurationLiteral.Test, tc.env, tc.secretVolumes, tc.secretVolumeMounts, nil)
@@ -225,7 +277,7 @@ func TestGenerateObservers(t *testing.T) {
 		},
 	}
 	jobSpec.SetNamespace("namespace")
-	step := newMultiStageTestStep(config.Tests[0], &config, nil, nil, &jobSpec, nil, "node-name", "", nil, false, nil, false, wait.Backoff{})
+	step := newMultiStageTestStep(config.Tests[0], &config, nil, nil, &jobSpec, nil, "node-name", "", nil, false, nil, false, wait.Backoff{}, nil, false)
 	ret, err := step.generateObservers(observers, nil, nil, nil)
 	if err != nil {
 		t.Fatal(err)
@@ -237,10 +289,12 @@ func TestGenerateObservers(t *testing.T) {
 func TestGeneratePodsEnvironment(t *testing.T) {
 	value := "test"
 	defValue := "default"
+	url := "http://artifacts"
 	for _, tc := range []struct {
 		name     string
 		env      api.TestEnvironment
 		test     api.LiteralTestStep
+		variable string
 		expected *string
 	}{{
 		name: "test environment is propagated to the step",
@@ -274,8 +328,20 @@ func TestGeneratePodsEnvironment(t *testing.T) {
 			}},
 		},
 		expected: &defValue,
+	}, {
+		name: "URL of served artifacts is exposed",
+		test: api.LiteralTestStep{
+			ServedArtifacts: []string{"helm-charts"},
+		},
+		variable: "ARTIFACTS_URL_HELM_CHARTS",
+		expected: &url,
 	}} {
 		t.Run(tc.name, func(t *testing.T) {
+			params := api.NewDeferredParameters(nil)
+			params.Add("ARTIFACTS_URL_HELM_CHARTS", func() (string, error) { return url, nil })
+			if tc.variable == "" {
+				tc.variable = "TEST"
+			}
 			jobSpec := api.JobSpec{
 				JobSpec: prowdapi.JobSpec{
 					Job:       "job",
@@ -299,14 +365,14 @@ func TestGeneratePodsEnvironment(t *testing.T) {
 					Test:        test,
 					Environment: tc.env,
 				},
-			}, &api.ReleaseBuildConfiguration{}, nil, nil, &jobSpec, nil, "node-name", "", nil, false, nil, false, wait.Backoff{})
+			}, &api.ReleaseBuildConfiguration{}, params, nil, &jobSpec, nil, "node-name", "", nil, false, nil, false, wait.Backoff{}, nil, false)
 			pods, _, err := step.(*multiStageTestStep).generatePods(test, nil, nil, nil, nil)
 			if err != nil {
 				t.Fatal(err)
 			}
 			var env *string
 			for i, v := range pods[0].Spec.Containers[0].Env {
-				if v.Name == "TEST" {
+				if v.Name == tc.variable {
 					env = &pods[0].Spec.Containers[0].Env[i].Value
 				}
 			}
@@ -367,7 +433,7 @@ func TestGeneratePodBestEffort(t *testing.T) {
 		},
 	}
 	jobSpec.SetNamespace("namespace")
-	step := newMultiStageTestStep(config.Tests[0], &config, nil, nil, &jobSpec, nil, "node-name", "", nil, false, nil, false, wait.Backoff{})
+	step := newMultiStageTestStep(config.Tests[0], &config, nil, nil, &jobSpec, nil, "node-name", "", nil, false, nil, false, wait.Backoff{}, nil, false)
 	_, bestEffortSteps, err := step.generatePods(config.Tests[0].MultiStageTestConfigurationLiteral.Post, nil, nil, nil, nil)
 	if err != nil {
 		t.Fatal(err)
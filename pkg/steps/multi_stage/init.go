@@ -53,13 +53,17 @@ func (s *multiStageTestStep) getAllStepPtrs() []*api.LiteralTestStep {
 	return allStepPtrs
 }
 
-func (s *multiStageTestStep) createSharedDirSecret(ctx context.Context) error {
-	logrus.Debugf("Creating multi-stage test shared directory %q", s.name)
-	secret := &coreapi.Secret{ObjectMeta: meta.ObjectMeta{
+func (s *multiStageTestStep) sharedDirSecret() *coreapi.Secret {
+	return &coreapi.Secret{ObjectMeta: meta.ObjectMeta{
 		Namespace: s.jobSpec.Namespace(),
 		Name:      s.name,
 		Labels:    map[string]string{api.SkipCensoringLabel: "true"},
 	}}
+}
+
+func (s *multiStageTestStep) createSharedDirSecret(ctx context.Context) error {
+	logrus.Debugf("Creating multi-stage test shared directory %q", s.name)
+	secret := s.sharedDirSecret()
 	if err := s.client.Delete(ctx, secret); err != nil && !kerrors.IsNotFound(err) {
 		return fmt.Errorf("cannot delete shared directory %q: %w", s.name, err)
 	}
@@ -197,22 +201,26 @@ func (s *multiStageTestStep) createSPCs(ctx context.Context, credentials []api.C
 	return nil
 }
 
-func (s *multiStageTestStep) createCommandConfigMaps(ctx context.Context) error {
-	logrus.Debugf("Creating multi-stage test commands configmap for %q", s.name)
+func (s *multiStageTestStep) commandConfigMap() *coreapi.ConfigMap {
 	data := make(map[string]string)
 	for _, step := range append(s.pre, append(s.test, s.post...)...) {
 		data[step.As] = step.Commands
 	}
-	name := commandConfigMapForTest(s.name)
 	yes := true
-	commands := &coreapi.ConfigMap{
+	return &coreapi.ConfigMap{
 		ObjectMeta: meta.ObjectMeta{
-			Name:      name,
+			Name:      commandConfigMapForTest(s.name),
 			Namespace: s.jobSpec.Namespace(),
 		},
 		Data:      data,
 		Immutable: &yes,
 	}
+}
+
+func (s *multiStageTestStep) createCommandConfigMaps(ctx context.Context) error {
+	logrus.Debugf("Creating multi-stage test commands configmap for %q", s.name)
+	commands := s.commandConfigMap()
+	name := commands.Name
 	// delete old command configmap if it exists
 	if err := s.client.Delete(ctx, commands); err != nil && !kerrors.IsNotFound(err) {
 		return fmt.Errorf("could not delete command configmap %s: %w", name, err)
@@ -223,7 +231,7 @@ func (s *multiStageTestStep) createCommandConfigMaps(ctx context.Context) error
 	return nil
 }
 
-func (s *multiStageTestStep) setupRBAC(ctx context.Context) error {
+func (s *multiStageTestStep) rbacObjects() (*coreapi.ServiceAccount, *rbacapi.Role, []rbacapi.RoleBinding) {
 	labels := map[string]string{MultiStageTestLabel: s.name}
 	ns := s.jobSpec.Namespace()
 	m := meta.ObjectMeta{Namespace: ns, Name: s.name, Labels: labels}
@@ -289,12 +297,17 @@ func (s *multiStageTestStep) setupRBAC(ctx context.Context) error {
 		})
 	}
 
+	return sa, role, bindings
+}
+
+func (s *multiStageTestStep) setupRBAC(ctx context.Context) error {
+	sa, role, bindings := s.rbacObjects()
 	if err := util.CreateRBACs(ctx, sa, role, bindings, s.client, 1*time.Second, 1*time.Minute); err != nil {
 		return fmt.Errorf("create RBACs: %w", err)
 	}
 
 	if s.requireNestedPodman {
-		if err := util.WaitUntilNamespaceIsPrivileged(ctx, ns, s.client, 1*time.Second, 1*time.Minute); err != nil {
+		if err := util.WaitUntilNamespaceIsPrivileged(ctx, s.jobSpec.Namespace(), s.client, 1*time.Second, 1*time.Minute); err != nil {
 			return fmt.Errorf("wait test NS to become privileged: %w", err)
 		}
 	}
@@ -29,6 +29,7 @@ import (
 	"github.com/openshift/ci-tools/pkg/junit"
 	"github.com/openshift/ci-tools/pkg/kubernetes"
 	"github.com/openshift/ci-tools/pkg/results"
+	"github.com/openshift/ci-tools/pkg/secrets"
 	"github.com/openshift/ci-tools/pkg/steps/loggingclient"
 	"github.com/openshift/ci-tools/pkg/steps/utils"
 )
@@ -65,6 +66,15 @@ type GSMConfiguration struct {
 	ProjectConfig gsm.Config
 }
 
+// DebugHold configures the replacement of the pods of failing steps by paused
+// copies which can be inspected interactively.
+type DebugHold struct {
+	// Duration is how long a paused copy is kept before the test continues.
+	Duration time.Duration
+	// Censor is used to write the instructions to the artifacts of the job.
+	Censor *secrets.DynamicCensor
+}
+
 const (
 	// A test failure should terminate the current phase.
 	// Set for `pre` and `test`, unset for `post`.
@@ -132,6 +142,15 @@ type multiStageTestStep struct {
 	requireNestedPodman              bool
 	leaseProxyServerAvailable        bool
 	leaseProxyClientConfigMapBackoff wait.Backoff
+	budgets                          *api.PhaseBudgets
+	debugHold                        *DebugHold
+	// parallelStepGroups enables the concurrent execution of parallel groups,
+	// which needs an entrypoint wrapper supporting --merge-shared-dir
+	parallelStepGroups bool
+	// jobTimeout is the timeout of the job, if it has one
+	jobTimeout time.Duration
+	// started is when the test started running
+	started time.Time
 }
 
 func MultiStageTestStep(
@@ -148,9 +167,11 @@ func MultiStageTestStep(
 	gsmConfig *GSMConfiguration,
 	leaseProxyServerAvailable bool,
 	leaseProxyClientConfigMapBackoff wait.Backoff,
+	debugHold *DebugHold,
+	parallelStepGroups bool,
 ) api.Step {
 	return newMultiStageTestStep(testConfig, config, params, client, jobSpec, leases, nodeName, targetAdditionalSuffix,
-		cancelObservers, enableSecretsStoreCSIDriver, gsmConfig, leaseProxyServerAvailable, leaseProxyClientConfigMapBackoff)
+		cancelObservers, enableSecretsStoreCSIDriver, gsmConfig, leaseProxyServerAvailable, leaseProxyClientConfigMapBackoff, debugHold, parallelStepGroups)
 }
 
 func newMultiStageTestStep(
@@ -167,6 +188,8 @@ func newMultiStageTestStep(
 	gsmConfig *GSMConfiguration,
 	leaseProxyServerAvailable bool,
 	leaseProxyClientConfigMapBackoff wait.Backoff,
+	debugHold *DebugHold,
+	parallelStepGroups bool,
 ) *multiStageTestStep {
 	ms := testConfig.MultiStageTestConfigurationLiteral
 	var flags stepFlag
@@ -200,12 +223,33 @@ func newMultiStageTestStep(
 		gsm:                              gsmConfig,
 		leaseProxyServerAvailable:        leaseProxyServerAvailable,
 		leaseProxyClientConfigMapBackoff: leaseProxyClientConfigMapBackoff,
+		budgets:                          ms.Budgets,
+		debugHold:                        debugHold,
+		parallelStepGroups:               parallelStepGroups,
 	}
 	s.requireNestedPodman = stepRequiresNestedPodman(s)
+	// the timeout of the job is overwritten by the timeouts of steps when
+	// their pods are generated, so it has to be read at this point
+	if jobSpec != nil && jobSpec.DecorationConfig != nil && jobSpec.DecorationConfig.Timeout != nil {
+		s.jobTimeout = jobSpec.DecorationConfig.Timeout.Duration
+	}
 
 	return s
 }
 
+// jobDeadline is when the job times out, if it has a timeout.  The timeout
+// is measured from the start of the job or, if it is not known, of the test.
+func (s *multiStageTestStep) jobDeadline() time.Time {
+	if s.jobTimeout == 0 {
+		return time.Time{}
+	}
+	start := s.started
+	if s.jobSpec != nil && !s.jobSpec.StartTime().IsZero() {
+		start = s.jobSpec.StartTime()
+	}
+	return start.Add(s.jobTimeout)
+}
+
 func (s *multiStageTestStep) profileSecretName() string {
 	name := s.name
 	if s.additionalSuffix != "" {
@@ -226,6 +270,7 @@ func (s *multiStageTestStep) Run(ctx context.Context) error {
 
 func (s *multiStageTestStep) run(ctx context.Context) error {
 	logrus.Infof("Running multi-stage test %s", s.name)
+	s.started = time.Now()
 
 	clusterProfile, err := getClusterProfileFromParams(s.params)
 	if err != nil {
@@ -321,14 +366,18 @@ func (s *multiStageTestStep) run(ctx context.Context) error {
 	observerDone := make(chan struct{})
 	go s.runObservers(observerContext, ctx, observers, observerDone)
 	s.flags |= shortCircuit
-	if err := s.runSteps(ctx, "pre", s.pre, env, secretVolumes, secretVolumeMounts); err != nil {
+	var budgets api.PhaseBudgets
+	if s.budgets != nil {
+		budgets = *s.budgets
+	}
+	if err := s.runPhase(ctx, "pre", budgets.Pre, true, s.pre, env, secretVolumes, secretVolumeMounts); err != nil {
 		errs = append(errs, fmt.Errorf("%q pre steps failed: %w", s.name, err))
-	} else if err := s.runSteps(ctx, "test", s.test, env, secretVolumes, secretVolumeMounts); err != nil {
+	} else if err := s.runPhase(ctx, "test", budgets.Test, true, s.test, env, secretVolumes, secretVolumeMounts); err != nil {
 		errs = append(errs, fmt.Errorf("%q test steps failed: %w", s.name, err))
 	}
 	s.cancelObserversContext(cancel) // signal to observers that we're tearing down
 	s.flags &= ^shortCircuit
-	if err := s.runSteps(context.Background(), "post", s.post, env, secretVolumes, secretVolumeMounts); err != nil {
+	if err := s.runPhase(context.Background(), "post", budgets.Post, false, s.post, env, secretVolumes, secretVolumeMounts); err != nil {
 		errs = append(errs, fmt.Errorf("%q post steps failed: %w", s.name, err))
 	}
 	<-observerDone // wait for the observers to finish so we get their jUnit
@@ -387,6 +436,10 @@ func (s *multiStageTestStep) Requires() (ret []api.StepLink) {
 			imageStream, name, _ := s.config.DependencyParts(dependency, claimRelease)
 			ret = append(ret, api.LinkForImage(imageStream, name))
 		}
+
+		for _, name := range step.ServedArtifacts {
+			ret = append(ret, api.ServedArtifactsLink(name))
+		}
 	}
 	if s.profile != "" {
 		needsReleasePayload = true
@@ -463,20 +516,26 @@ func (s *multiStageTestStep) readVPNData(secret *coreapi.Secret) error {
 }
 
 func (s *multiStageTestStep) environment() ([]coreapi.EnvVar, error) {
-	var ret []coreapi.EnvVar
 	if s.params == nil {
-		return ret, nil
+		return nil, nil
 	}
+	return s.environmentFrom(s.params.Get)
+}
+
+// environmentFrom determines the environment of the test from the values
+// returned by `get` for the parameters it uses.
+func (s *multiStageTestStep) environmentFrom(get func(name string) (string, error)) ([]coreapi.EnvVar, error) {
+	var ret []coreapi.EnvVar
 	for _, l := range s.leases {
-		val, err := s.params.Get(l.Env)
+		val, err := get(l.Env)
 		if err != nil {
 			return nil, err
 		}
 		ret = append(ret, coreapi.EnvVar{Name: l.Env, Value: val})
 	}
 
-	for _, name := range []string{api.LeaseProxyServerURLEnvVarName, api.ClusterProfileSetEnv} {
-		val, err := s.params.Get(name)
+	for _, name := range []string{api.LeaseProxyServerURLEnvVarName, api.LeaseProxyTokenEnvVarName, api.ClusterProfileSetEnv} {
+		val, err := get(name)
 		if err != nil {
 			return nil, err
 		}
@@ -487,7 +546,7 @@ func (s *multiStageTestStep) environment() ([]coreapi.EnvVar, error) {
 
 	for _, name := range []string{api.InitialReleaseName, api.LatestReleaseName} {
 		envVar := fmt.Sprintf("ORIGINAL_%s", utils.ReleaseImageEnv(name))
-		pullspec, err := s.params.Get(envVar)
+		pullspec, err := get(envVar)
 		if err != nil {
 			return nil, err
 		} else if pullspec != "" {
@@ -497,14 +556,14 @@ func (s *multiStageTestStep) environment() ([]coreapi.EnvVar, error) {
 
 	if s.profile != "" {
 		for _, e := range envForProfile {
-			val, err := s.params.Get(e)
+			val, err := get(e)
 			if err != nil {
 				return nil, err
 			}
 			ret = append(ret, coreapi.EnvVar{Name: e, Value: val})
 		}
 		if s.profile == "aws" { //TODO(sgoeddel): only enabled for aws for now, later this will be configurable
-			val, err := s.params.Get(api.DefaultIPPoolLeaseEnv)
+			val, err := get(api.DefaultIPPoolLeaseEnv)
 			if err != nil {
 				return nil, err
 			}
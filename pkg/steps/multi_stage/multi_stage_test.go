@@ -82,13 +82,22 @@ func TestRequires(t *testing.T) {
 		name:                      "step needs the lease proxy server",
 		leaseProxyServerAvailable: true,
 		req:                       []api.StepLink{api.LeaseProxyServerLink()},
+	}, {
+		name: "step needs served artifacts",
+		steps: api.MultiStageTestConfigurationLiteral{
+			Test: []api.LiteralTestStep{{From: "src", ServedArtifacts: []string{"charts"}}},
+		},
+		req: []api.StepLink{
+			api.InternalImageLink(api.PipelineImageStreamTagReferenceSource),
+			api.ServedArtifactsLink("charts"),
+		},
 	}} {
 		t.Run(tc.name, func(t *testing.T) {
 			step := MultiStageTestStep(api.TestStepConfiguration{
 				As:                                 "some-e2e",
 				ClusterClaim:                       tc.clusterClaim,
 				MultiStageTestConfigurationLiteral: &tc.steps,
-			}, &tc.config, api.NewDeferredParameters(nil), nil, nil, nil, "node-name", "", nil, false, nil, tc.leaseProxyServerAvailable, wait.Backoff{})
+			}, &tc.config, api.NewDeferredParameters(nil), nil, nil, nil, "node-name", "", nil, false, nil, tc.leaseProxyServerAvailable, wait.Backoff{}, nil, false)
 			ret := step.Requires()
 			if len(ret) == len(tc.req) {
 				matches := true
@@ -357,9 +366,12 @@ func TestEnvironment(t *testing.T) {
 			expected: []coreapi.EnvVar{{Name: "LEASE_ONE", Value: "ONE"}, {Name: "LEASE_TWO", Value: "TWO"}},
 		},
 		{
-			name:     "leases proxy server URL is exposed in environment",
-			params:   fakeStepParams{api.LeaseProxyServerURLEnvVarName: "http://192.168.1.1"},
-			expected: []coreapi.EnvVar{{Name: api.LeaseProxyServerURLEnvVarName, Value: "http://192.168.1.1"}},
+			name:   "leases proxy server URL and token are exposed in environment",
+			params: fakeStepParams{api.LeaseProxyServerURLEnvVarName: "http://192.168.1.1", api.LeaseProxyTokenEnvVarName: "token"},
+			expected: []coreapi.EnvVar{
+				{Name: api.LeaseProxyServerURLEnvVarName, Value: "http://192.168.1.1"},
+				{Name: api.LeaseProxyTokenEnvVarName, Value: "token"},
+			},
 		},
 		{
 			name: "arbitrary variables are not exposed in environment",
@@ -0,0 +1,73 @@
+package multi_stage
+
+import (
+	"fmt"
+
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/openshift/ci-tools/pkg/api"
+)
+
+// RenderObjects generates the objects the test creates without running it:
+// the shared directory, the commands, the RBAC for the test and the pods of
+// the observers and of all phases, assuming every step succeeds.  Values only
+// known once the dependencies of the test have run are rendered as
+// placeholders.  Objects copied from the cluster, such as credentials and
+// cluster profiles, and the secret volumes mounted for censoring, which
+// depend on the contents of the namespace, are not rendered.
+func (s *multiStageTestStep) RenderObjects() ([]ctrlruntimeclient.Object, error) {
+	if clusterProfile, err := getClusterProfileFromParams(s.params); err == nil && clusterProfile != "" {
+		s.profile = clusterProfile
+	}
+	env, err := s.environmentFrom(func(name string) (string, error) {
+		return s.placeholderParameter(name), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sa, role, bindings := s.rbacObjects()
+	ret := []ctrlruntimeclient.Object{s.sharedDirSecret(), s.commandConfigMap(), sa, role}
+	for i := range bindings {
+		ret = append(ret, &bindings[i])
+	}
+	observers, err := s.generateObservers(s.observers, nil, nil, &generatePodOptions{
+		IsObserver:                  true,
+		enableSecretsStoreCSIDriver: s.enableSecretsStoreCSIDriver,
+		placeholders:                true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	pods := observers
+	for _, phase := range [][]api.LiteralTestStep{s.pre, s.test, s.post} {
+		var steps []api.LiteralTestStep
+		for _, step := range phase {
+			if s.unmetCondition(step) == "" {
+				steps = append(steps, step)
+			}
+		}
+		phasePods, _, err := s.generatePods(steps, env, nil, nil, &generatePodOptions{
+			enableSecretsStoreCSIDriver: s.enableSecretsStoreCSIDriver,
+			placeholders:                true,
+		})
+		if err != nil {
+			return nil, err
+		}
+		pods = append(pods, phasePods...)
+	}
+	for i := range pods {
+		ret = append(ret, &pods[i])
+	}
+	return ret, nil
+}
+
+// placeholderParameter returns the value of a parameter, or a placeholder if
+// it cannot be determined without running the steps which provide it.
+func (s *multiStageTestStep) placeholderParameter(name string) string {
+	if s.params != nil {
+		if value, err := s.params.Get(name); err == nil {
+			return value
+		}
+	}
+	return api.DryRunPlaceholder(fmt.Sprintf("parameter %s", name))
+}
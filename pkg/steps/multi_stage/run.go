@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 	"strings"
 	"sync"
 	"time"
@@ -16,13 +17,53 @@ import (
 	"k8s.io/apimachinery/pkg/util/sets"
 	utilpointer "k8s.io/utils/pointer"
 	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	prowapi "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
 
 	"github.com/openshift/ci-tools/pkg/api"
 	"github.com/openshift/ci-tools/pkg/junit"
+	"github.com/openshift/ci-tools/pkg/results"
 	base_steps "github.com/openshift/ci-tools/pkg/steps"
 	"github.com/openshift/ci-tools/pkg/util"
 )
 
+// runPhase runs the steps of a phase within its budget. When `reservePost`
+// is set, the phase is also interrupted once only the budget of the `post`
+// phase is left before the job times out.
+func (s *multiStageTestStep) runPhase(
+	ctx context.Context,
+	phase string,
+	budget *prowapi.Duration,
+	reservePost bool,
+	steps []api.LiteralTestStep,
+	env []coreapi.EnvVar,
+	secretVolumes []coreapi.Volume,
+	secretVolumeMounts []coreapi.VolumeMount,
+) error {
+	var deadline time.Time
+	var overrun string
+	if budget != nil {
+		deadline = time.Now().Add(budget.Duration)
+		overrun = fmt.Sprintf("exceeded its budget of %s", budget.Duration)
+	}
+	if jobDeadline := s.jobDeadline(); reservePost && s.budgets != nil && s.budgets.Post != nil && !jobDeadline.IsZero() {
+		if reserved := jobDeadline.Add(-s.budgets.Post.Duration); deadline.IsZero() || reserved.Before(deadline) {
+			deadline = reserved
+			overrun = fmt.Sprintf("was interrupted to leave %s for post steps before the job times out", s.budgets.Post.Duration)
+		}
+	}
+	if deadline.IsZero() {
+		return s.runSteps(ctx, phase, steps, env, secretVolumes, secretVolumeMounts)
+	}
+	phaseCtx, cancel := context.WithDeadline(ctx, deadline)
+	defer cancel()
+	err := s.runSteps(phaseCtx, phase, steps, env, secretVolumes, secretVolumeMounts)
+	if err != nil && ctx.Err() == nil && errors.Is(phaseCtx.Err(), context.DeadlineExceeded) {
+		logrus.Warnf("Multi-stage phase %s %s.", phase, overrun)
+		return results.ForReason("exceeding_phase_budget").WithError(err).Errorf("phase %s %s: %v", phase, overrun, err)
+	}
+	return err
+}
+
 func (s *multiStageTestStep) runSteps(
 	ctx context.Context,
 	phase string,
@@ -33,6 +74,7 @@ func (s *multiStageTestStep) runSteps(
 ) error {
 	start := time.Now()
 	logrus.Infof("Running multi-stage phase %s", phase)
+	steps = s.skipUnmetConditions(steps)
 	pods, bestEffortSteps, err := s.generatePods(steps, env, secretVolumes, secretVolumeMounts, &generatePodOptions{
 		enableSecretsStoreCSIDriver: s.enableSecretsStoreCSIDriver,
 	})
@@ -46,7 +88,7 @@ func (s *multiStageTestStep) runSteps(
 			s.flags |= hasPrevErrs
 		}
 	}()
-	if err := s.runPods(ctx, pods, bestEffortSteps); err != nil {
+	if err := s.runPods(ctx, pods, bestEffortSteps, s.parallelGroups(steps)); err != nil {
 		errs = append(errs, err)
 	}
 	select {
@@ -81,25 +123,140 @@ func (s *multiStageTestStep) runSteps(
 	return err
 }
 
-func (s *multiStageTestStep) runPods(ctx context.Context, pods []coreapi.Pod, bestEffortSteps sets.Set[string]) error {
-	var errs []error
-	for _, pod := range pods {
-		err := s.runPod(ctx, &pod, base_steps.NewTestCaseNotifier(util.NopNotifier), util.WaitForPodFlag(0))
-		if err == nil {
+// skipUnmetConditions removes the steps whose `when` conditions do not hold,
+// reporting each of them as a skipped test.
+func (s *multiStageTestStep) skipUnmetConditions(steps []api.LiteralTestStep) []api.LiteralTestStep {
+	var ret []api.LiteralTestStep
+	for _, step := range steps {
+		reason := s.unmetCondition(step)
+		if reason == "" {
+			ret = append(ret, step)
 			continue
 		}
-		if bestEffortSteps != nil && bestEffortSteps.Has(pod.Name) {
-			logrus.Infof("Pod %s is running in best-effort mode, ignoring the failure...", pod.Name)
-			continue
+		name := fmt.Sprintf("%s-%s", s.name, step.As)
+		logrus.Infof("Skipping step %s: %s", name, reason)
+		s.subLock.Lock()
+		s.subTests = append(s.subTests, &junit.TestCase{
+			Name:        fmt.Sprintf("%s - %s", s.Description(), name),
+			SkipMessage: &junit.SkipMessage{Message: reason},
+		})
+		s.subLock.Unlock()
+	}
+	return ret
+}
+
+// unmetCondition evaluates the conditions of a step against the values its
+// parameters would have in the pod, falling back to the environment of the
+// test for parameters the step does not declare.  It describes the first
+// condition which does not hold, if any.
+func (s *multiStageTestStep) unmetCondition(step api.LiteralTestStep) string {
+	if len(step.When) == 0 {
+		return ""
+	}
+	values := map[string]string{}
+	for name, value := range s.env {
+		values[name] = value
+	}
+	for _, param := range s.generateParams(step.Environment) {
+		values[param.Name] = param.Value
+	}
+	for _, condition := range step.When {
+		if value := values[condition.Env]; !condition.Holds(value) {
+			return fmt.Sprintf("condition %s does not hold for %q", condition, value)
 		}
-		errs = append(errs, err)
-		if s.flags&shortCircuit != 0 {
+	}
+	return ""
+}
+
+// parallelGroups maps the name of the pod of each step to the name of the
+// parallel group it belongs to, if any.  Nothing is mapped when parallel step
+// groups are disabled, in which case all steps are executed sequentially.
+func (s *multiStageTestStep) parallelGroups(steps []api.LiteralTestStep) map[string]string {
+	ret := map[string]string{}
+	if !s.parallelStepGroups {
+		return ret
+	}
+	for _, step := range steps {
+		if step.ParallelGroup != "" {
+			ret[fmt.Sprintf("%s-%s", s.name, step.As)] = step.ParallelGroup
+		}
+	}
+	return ret
+}
+
+// runPods executes pods in order.  Consecutive pods which are part of the same
+// parallel group are executed concurrently.  When short-circuiting, a failure
+// in a group does not interrupt the other members, but no pod after the group
+// is executed.
+func (s *multiStageTestStep) runPods(ctx context.Context, pods []coreapi.Pod, bestEffortSteps sets.Set[string], parallelGroups map[string]string) error {
+	var errs []error
+	for len(pods) != 0 {
+		n := 1
+		if group := parallelGroups[pods[0].Name]; group != "" {
+			for n < len(pods) && parallelGroups[pods[n].Name] == group {
+				n++
+			}
+		}
+		var groupErrs []error
+		if n == 1 {
+			if err := s.runStepPod(ctx, &pods[0], bestEffortSteps); err != nil {
+				groupErrs = append(groupErrs, err)
+			}
+		} else {
+			groupErrs = s.runParallelPods(ctx, pods[:n], bestEffortSteps)
+		}
+		pods = pods[n:]
+		errs = append(errs, groupErrs...)
+		if len(groupErrs) != 0 && s.flags&shortCircuit != 0 {
 			break
 		}
 	}
 	return utilerrors.NewAggregate(errs)
 }
 
+func (s *multiStageTestStep) runParallelPods(ctx context.Context, pods []coreapi.Pod, bestEffortSteps sets.Set[string]) []error {
+	names := make([]string, 0, len(pods))
+	for _, pod := range pods {
+		names = append(names, pod.Name)
+	}
+	logrus.Infof("Running steps %s in parallel.", strings.Join(names, ", "))
+	results := make([]error, len(pods))
+	var wg sync.WaitGroup
+	wg.Add(len(pods))
+	for i := range pods {
+		go func(i int) {
+			defer wg.Done()
+			results[i] = s.runStepPod(ctx, &pods[i], bestEffortSteps)
+		}(i)
+	}
+	wg.Wait()
+	var errs []error
+	for _, err := range results {
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errs
+}
+
+// runStepPod executes the pod for a single step, ignoring the failure if the
+// step is running in best-effort mode.  Other failures are held for debugging
+// if requested.
+func (s *multiStageTestStep) runStepPod(ctx context.Context, pod *coreapi.Pod, bestEffortSteps sets.Set[string]) error {
+	err := s.runPod(ctx, pod, base_steps.NewTestCaseNotifier(util.NopNotifier), util.WaitForPodFlag(0))
+	if err == nil {
+		return nil
+	}
+	if bestEffortSteps != nil && bestEffortSteps.Has(pod.Name) {
+		logrus.Infof("Pod %s is running in best-effort mode, ignoring the failure...", pod.Name)
+		return nil
+	}
+	if s.debugHold != nil && ctx.Err() == nil {
+		s.holdForDebugging(ctx, pod)
+	}
+	return err
+}
+
 func (s *multiStageTestStep) runObservers(ctx, textCtx context.Context, pods []coreapi.Pod, done chan<- struct{}) {
 	wg := sync.WaitGroup{}
 	wg.Add(len(pods))
@@ -134,7 +291,75 @@ func (s *multiStageTestStep) runObservers(ctx, textCtx context.Context, pods []c
 	done <- struct{}{}
 }
 
+// runPod executes the pod for a step.  If the step has a retry policy, a new
+// pod is created from the original one for each attempt allowed by the policy
+// and every failed attempt is recorded as a separate test case.
 func (s *multiStageTestStep) runPod(ctx context.Context, pod *coreapi.Pod, notifier *base_steps.TestCaseNotifier, flags util.WaitForPodFlag) error {
+	policy := s.retryPolicy(pod.Name)
+	if policy == nil {
+		_, err := s.runPodAttempt(ctx, pod, notifier, flags)
+		return err
+	}
+	for attempt := 1; ; attempt++ {
+		start := time.Now()
+		finished, err := s.runPodAttempt(ctx, pod.DeepCopy(), notifier, flags)
+		if err == nil || attempt >= policy.MaxAttempts || ctx.Err() != nil || !isRetryable(policy, finished, err) {
+			return err
+		}
+		s.subLock.Lock()
+		s.subTests = append(s.subTests, &junit.TestCase{
+			Name:          fmt.Sprintf("%s - %s attempt %d", s.Description(), pod.Name, attempt),
+			Duration:      time.Since(start).Seconds(),
+			FailureOutput: &junit.FailureOutput{Output: err.Error()},
+		})
+		s.subLock.Unlock()
+		logrus.Infof("Step %s failed on attempt %d of %d, retrying.", pod.Name, attempt, policy.MaxAttempts)
+		if policy.Backoff != nil {
+			select {
+			case <-ctx.Done():
+				return err
+			case <-time.After(policy.Backoff.Duration):
+			}
+		}
+	}
+}
+
+// retryPolicy returns the retry policy of the step executed by the pod, if any.
+func (s *multiStageTestStep) retryPolicy(podName string) *api.StepRetryPolicy {
+	for _, steps := range [][]api.LiteralTestStep{s.pre, s.test, s.post} {
+		for _, step := range steps {
+			if fmt.Sprintf("%s-%s", s.name, step.As) == podName {
+				return step.Retry
+			}
+		}
+	}
+	return nil
+}
+
+// isRetryable determines whether the failure of a pod matches the policy,
+// either by the exit code of the test container or by the reason of the error.
+func isRetryable(policy *api.StepRetryPolicy, pod *coreapi.Pod, err error) bool {
+	if len(policy.ExitCodes) == 0 && len(policy.Reasons) == 0 {
+		return true
+	}
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.Name == containerName && status.State.Terminated != nil && slices.Contains(policy.ExitCodes, status.State.Terminated.ExitCode) {
+			return true
+		}
+	}
+	for _, chain := range results.Reasons(err) {
+		for _, reason := range strings.Split(chain, ":") {
+			if slices.Contains(policy.Reasons, reason) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// runPodAttempt creates the pod and waits for it to finish, returning the
+// last observed state of the pod.
+func (s *multiStageTestStep) runPodAttempt(ctx context.Context, pod *coreapi.Pod, notifier *base_steps.TestCaseNotifier, flags util.WaitForPodFlag) (*coreapi.Pod, error) {
 	start := time.Now()
 	logrus.Infof("Running step %s.", pod.Name)
 	client := s.client.WithNewLoggingClient()
@@ -142,7 +367,7 @@ func (s *multiStageTestStep) runPod(ctx context.Context, pod *coreapi.Pod, notif
 	client.MetricsAgent().StoreMachinesSnapshot(pod)
 
 	if _, err := util.CreateOrRestartPod(ctx, client, pod); err != nil {
-		return fmt.Errorf("failed to create or restart %s pod: %w", pod.Name, err)
+		return pod, fmt.Errorf("failed to create or restart %s pod: %w", pod.Name, err)
 	}
 	newPod, err := util.WaitForPodCompletion(ctx, client, pod.Namespace, pod.Name, notifier, flags)
 	if newPod != nil {
@@ -196,7 +421,7 @@ func (s *multiStageTestStep) runPod(ctx context.Context, pod *coreapi.Pod, notif
 				status = fmt.Sprintf("%s activeDeadlineSeconds=%d", status, *pod.Spec.ActiveDeadlineSeconds)
 			}
 		}
-		return fmt.Errorf("%q pod %q %s: %w\n%s", s.name, pod.Name, status, err, linksText.String())
+		return pod, fmt.Errorf("%q pod %q %s: %w\n%s", s.name, pod.Name, status, err, linksText.String())
 	}
-	return nil
+	return pod, nil
 }
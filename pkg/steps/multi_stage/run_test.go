@@ -4,6 +4,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
 	"reflect"
 	"strings"
 	"sync/atomic"
@@ -28,6 +30,8 @@ import (
 	prowdapi "sigs.k8s.io/prow/pkg/pod-utils/downwardapi"
 
 	"github.com/openshift/ci-tools/pkg/api"
+	"github.com/openshift/ci-tools/pkg/results"
+	"github.com/openshift/ci-tools/pkg/secrets"
 	"github.com/openshift/ci-tools/pkg/steps"
 	"github.com/openshift/ci-tools/pkg/steps/loggingclient"
 	testhelper_kube "github.com/openshift/ci-tools/pkg/testhelper/kubernetes"
@@ -487,7 +491,7 @@ Link to job on registry info site: https://steps.ci.openshift.org/job?org=&repo=
 				PendingTimeout:  30 * time.Minute,
 				FakePodExecutor: crclient,
 			}
-			step := MultiStageTestStep(*tc.testConfig, &api.ReleaseBuildConfiguration{}, nil, client, &jobSpec, nil, "node-name", "", func(cf context.CancelFunc) {}, false, nil, false, tc.leaseProxyClientConfigMapBackoff)
+			step := MultiStageTestStep(*tc.testConfig, &api.ReleaseBuildConfiguration{}, nil, client, &jobSpec, nil, "node-name", "", func(cf context.CancelFunc) {}, false, nil, false, tc.leaseProxyClientConfigMapBackoff, nil, false)
 
 			gotErr := step.Run(context.Background())
 
@@ -672,7 +676,7 @@ func TestJUnit(t *testing.T) {
 					Test: []api.LiteralTestStep{{As: "test0"}, {As: "test1"}},
 					Post: []api.LiteralTestStep{{As: "post0"}, {As: "post1"}},
 				},
-			}, &api.ReleaseBuildConfiguration{}, nil, client, &jobSpec, nil, "node-name", "", nil, false, nil, false, wait.Backoff{})
+			}, &api.ReleaseBuildConfiguration{}, nil, client, &jobSpec, nil, "node-name", "", nil, false, nil, false, wait.Backoff{}, nil, false)
 			if err := step.Run(context.Background()); tc.failures == nil && err != nil {
 				t.Error(err)
 				return
@@ -748,7 +752,7 @@ func TestRunPodDeletesPendingPodsOnError(t *testing.T) {
 			Post:               []api.LiteralTestStep{{As: "post0"}},
 			AllowSkipOnSuccess: &yes,
 		},
-	}, &api.ReleaseBuildConfiguration{}, nil, client, &jobSpec, nil, "node-name", "", func(cf context.CancelFunc) {}, false, nil, false, wait.Backoff{})
+	}, &api.ReleaseBuildConfiguration{}, nil, client, &jobSpec, nil, "node-name", "", func(cf context.CancelFunc) {}, false, nil, false, wait.Backoff{}, nil, false)
 
 	// Use a context with timeout to ensure the test doesn't hang
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
@@ -775,3 +779,530 @@ func TestRunPodDeletesPendingPodsOnError(t *testing.T) {
 		}
 	}
 }
+
+func TestRunParallelGroups(t *testing.T) {
+	for _, tc := range []struct {
+		name         string
+		failures     sets.Set[string]
+		wantPodNames sets.Set[string]
+	}{{
+		name:         "all steps are executed",
+		wantPodNames: sets.New("test-pre0", "test-pre1", "test-pre2", "test-pre3", "test-test0", "test-post0", "test-post1"),
+	}, {
+		name:         "failure in a group does not stop other members, but stops the phase",
+		failures:     sets.New("test-pre1"),
+		wantPodNames: sets.New("test-pre0", "test-pre1", "test-pre2", "test-post0", "test-post1"),
+	}, {
+		name:         "failure in a post group does not stop the phase",
+		failures:     sets.New("test-post0"),
+		wantPodNames: sets.New("test-pre0", "test-pre1", "test-pre2", "test-pre3", "test-test0", "test-post0", "test-post1"),
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			sa := &v1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "ns", Labels: map[string]string{"ci.openshift.io/multi-stage-test": "test"}}}
+			crclient := &testhelper_kube.FakePodExecutor{
+				LoggingClient: loggingclient.New(
+					fakectrlruntimeclient.NewClientBuilder().
+						WithIndex(&v1.Pod{}, "metadata.name", fakePodNameIndexer).
+						WithObjects(sa).
+						Build(), nil),
+				Failures:     tc.failures,
+				AutoSchedule: true,
+			}
+			jobSpec := api.JobSpec{
+				JobSpec: prowdapi.JobSpec{
+					Job:       "job",
+					BuildID:   "build_id",
+					ProwJobID: "prow_job_id",
+					Type:      prowapi.PeriodicJob,
+					DecorationConfig: &prowapi.DecorationConfig{
+						Timeout:     &prowapi.Duration{Duration: time.Minute},
+						GracePeriod: &prowapi.Duration{Duration: time.Second},
+						UtilityImages: &prowapi.UtilityImages{
+							Sidecar:    "sidecar",
+							Entrypoint: "entrypoint",
+						},
+					},
+				},
+			}
+			jobSpec.SetNamespace("ns")
+			client := &testhelper_kube.FakePodClient{
+				FakePodExecutor: crclient,
+				PendingTimeout:  30 * time.Minute,
+			}
+			step := MultiStageTestStep(api.TestStepConfiguration{
+				As: "test",
+				MultiStageTestConfigurationLiteral: &api.MultiStageTestConfigurationLiteral{
+					Pre: []api.LiteralTestStep{
+						{As: "pre0"},
+						{As: "pre1", ParallelGroup: "pre1"},
+						{As: "pre2", ParallelGroup: "pre1"},
+						{As: "pre3"},
+					},
+					Test: []api.LiteralTestStep{{As: "test0"}},
+					Post: []api.LiteralTestStep{
+						{As: "post0", ParallelGroup: "post0"},
+						{As: "post1", ParallelGroup: "post0"},
+					},
+				},
+			}, &api.ReleaseBuildConfiguration{}, nil, client, &jobSpec, nil, "node-name", "", nil, false, nil, false, wait.Backoff{}, nil, true)
+			err := step.Run(context.Background())
+			if tc.failures.Len() == 0 && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tc.failures.Len() != 0 && err == nil {
+				t.Fatal("expected an error but got nil")
+			}
+			gotPodNames := sets.New[string]()
+			for _, pod := range crclient.CreatedPods {
+				gotPodNames.Insert(pod.Name)
+			}
+			if diff := cmp.Diff(sets.List(tc.wantPodNames), sets.List(gotPodNames)); diff != "" {
+				t.Errorf("did not execute correct pods: %s", diff)
+			}
+			var subTests []string
+			for _, t := range step.(steps.SubtestReporter).SubTests() {
+				subTests = append(subTests, t.Name)
+			}
+			for name := range tc.wantPodNames {
+				want := fmt.Sprintf("Run multi-stage test test - %s container test", name)
+				if !sets.New(subTests...).Has(want) {
+					t.Errorf("missing junit test case %q", want)
+				}
+			}
+		})
+	}
+}
+
+func TestRunRetries(t *testing.T) {
+	for _, tc := range []struct {
+		name         string
+		retry        *api.StepRetryPolicy
+		pending      bool
+		wantAttempts int
+		wantSubTests []string
+	}{{
+		name:         "no retry policy",
+		wantAttempts: 1,
+		wantSubTests: []string{"Run multi-stage test test - test-pre0 container test"},
+	}, {
+		name:         "any failure is retried",
+		retry:        &api.StepRetryPolicy{MaxAttempts: 3},
+		wantAttempts: 3,
+		wantSubTests: []string{
+			"Run multi-stage test test - test-pre0 container test",
+			"Run multi-stage test test - test-pre0 attempt 1",
+			"Run multi-stage test test - test-pre0 container test",
+			"Run multi-stage test test - test-pre0 attempt 2",
+			"Run multi-stage test test - test-pre0 container test",
+		},
+	}, {
+		name:         "matching exit code is retried",
+		retry:        &api.StepRetryPolicy{MaxAttempts: 2, ExitCodes: []int32{1}},
+		wantAttempts: 2,
+		wantSubTests: []string{
+			"Run multi-stage test test - test-pre0 container test",
+			"Run multi-stage test test - test-pre0 attempt 1",
+			"Run multi-stage test test - test-pre0 container test",
+		},
+	}, {
+		name:         "other exit code is not retried",
+		retry:        &api.StepRetryPolicy{MaxAttempts: 2, ExitCodes: []int32{2}},
+		wantAttempts: 1,
+		wantSubTests: []string{"Run multi-stage test test - test-pre0 container test"},
+	}, {
+		name:         "matching reason is retried",
+		retry:        &api.StepRetryPolicy{MaxAttempts: 2, Reasons: []string{api.ReasonPending}},
+		pending:      true,
+		wantAttempts: 2,
+		wantSubTests: []string{"Run multi-stage test test - test-pre0 attempt 1"},
+	}, {
+		name:         "other reason is not retried",
+		retry:        &api.StepRetryPolicy{MaxAttempts: 2, Reasons: []string{api.ReasonPending}},
+		wantAttempts: 1,
+		wantSubTests: []string{"Run multi-stage test test - test-pre0 container test"},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			sa := &v1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "ns", Labels: map[string]string{"ci.openshift.io/multi-stage-test": "test"}}}
+			crclient := &testhelper_kube.FakePodExecutor{
+				LoggingClient: loggingclient.New(
+					fakectrlruntimeclient.NewClientBuilder().
+						WithIndex(&v1.Pod{}, "metadata.name", fakePodNameIndexer).
+						WithObjects(sa).
+						Build(), nil),
+				Failures:     sets.New("test-pre0"),
+				AutoSchedule: true,
+			}
+			pendingTimeout := 30 * time.Minute
+			if tc.pending {
+				crclient.Pending = sets.New("test-pre0")
+				pendingTimeout = 100 * time.Millisecond
+			}
+			jobSpec := api.JobSpec{
+				JobSpec: prowdapi.JobSpec{
+					Job:       "job",
+					BuildID:   "build_id",
+					ProwJobID: "prow_job_id",
+					Type:      prowapi.PeriodicJob,
+					DecorationConfig: &prowapi.DecorationConfig{
+						Timeout:     &prowapi.Duration{Duration: time.Minute},
+						GracePeriod: &prowapi.Duration{Duration: time.Second},
+						UtilityImages: &prowapi.UtilityImages{
+							Sidecar:    "sidecar",
+							Entrypoint: "entrypoint",
+						},
+					},
+				},
+			}
+			jobSpec.SetNamespace("ns")
+			client := &testhelper_kube.FakePodClient{
+				FakePodExecutor: crclient,
+				PendingTimeout:  pendingTimeout,
+			}
+			step := MultiStageTestStep(api.TestStepConfiguration{
+				As: "test",
+				MultiStageTestConfigurationLiteral: &api.MultiStageTestConfigurationLiteral{
+					Pre: []api.LiteralTestStep{{As: "pre0", Retry: tc.retry}},
+				},
+			}, &api.ReleaseBuildConfiguration{}, nil, client, &jobSpec, nil, "node-name", "", nil, false, nil, false, wait.Backoff{}, nil, false)
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+			if err := step.Run(ctx); err == nil {
+				t.Fatal("expected an error but got nil")
+			}
+			var attempts int
+			for _, pod := range crclient.CreatedPods {
+				if pod.Name == "test-pre0" {
+					attempts++
+				}
+			}
+			if attempts != tc.wantAttempts {
+				t.Errorf("expected %d attempts, got %d", tc.wantAttempts, attempts)
+			}
+			var subTests []string
+			for _, t := range step.(steps.SubtestReporter).SubTests() {
+				if strings.Contains(t.Name, "test-pre0") {
+					subTests = append(subTests, t.Name)
+				}
+			}
+			if diff := cmp.Diff(tc.wantSubTests, subTests); diff != "" {
+				t.Errorf("incorrect junit test cases: %s", diff)
+			}
+		})
+	}
+}
+
+func TestRunSkipsUnmetConditions(t *testing.T) {
+	sa := &v1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "ns", Labels: map[string]string{"ci.openshift.io/multi-stage-test": "test"}}}
+	crclient := &testhelper_kube.FakePodExecutor{
+		LoggingClient: loggingclient.New(
+			fakectrlruntimeclient.NewClientBuilder().
+				WithIndex(&v1.Pod{}, "metadata.name", fakePodNameIndexer).
+				WithObjects(sa).
+				Build(), nil),
+		AutoSchedule: true,
+	}
+	jobSpec := api.JobSpec{
+		JobSpec: prowdapi.JobSpec{
+			Job:       "job",
+			BuildID:   "build_id",
+			ProwJobID: "prow_job_id",
+			Type:      prowapi.PeriodicJob,
+			DecorationConfig: &prowapi.DecorationConfig{
+				Timeout:     &prowapi.Duration{Duration: time.Minute},
+				GracePeriod: &prowapi.Duration{Duration: time.Second},
+				UtilityImages: &prowapi.UtilityImages{
+					Sidecar:    "sidecar",
+					Entrypoint: "entrypoint",
+				},
+			},
+		},
+	}
+	jobSpec.SetNamespace("ns")
+	client := &testhelper_kube.FakePodClient{FakePodExecutor: crclient, PendingTimeout: 30 * time.Minute}
+	enabled, disabled := "true", "false"
+	step := MultiStageTestStep(api.TestStepConfiguration{
+		As: "test",
+		MultiStageTestConfigurationLiteral: &api.MultiStageTestConfigurationLiteral{
+			Environment: api.TestEnvironment{"FROM_TEST": "yes"},
+			Test: []api.LiteralTestStep{
+				{As: "enabled", Environment: []api.StepParameter{{Name: "RUN", Default: &enabled}}, When: []api.StepCondition{{Env: "RUN", Operator: api.StepConditionOperatorIn, Values: []string{"true"}}}},
+				{As: "disabled", Environment: []api.StepParameter{{Name: "RUN", Default: &disabled}}, When: []api.StepCondition{{Env: "RUN", Operator: api.StepConditionOperatorIn, Values: []string{"true"}}}},
+				{As: "from-test", When: []api.StepCondition{{Env: "FROM_TEST", Operator: api.StepConditionOperatorNotIn, Values: []string{"yes"}}}},
+				{As: "unconditional"},
+			},
+		},
+	}, &api.ReleaseBuildConfiguration{}, nil, client, &jobSpec, nil, "node-name", "", nil, false, nil, false, wait.Backoff{}, nil, false)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := step.Run(ctx); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var created []string
+	for _, pod := range crclient.CreatedPods {
+		created = append(created, pod.Name)
+	}
+	if diff := cmp.Diff([]string{"test-enabled", "test-unconditional"}, created); diff != "" {
+		t.Errorf("incorrect pods created: %s", diff)
+	}
+	skipped := map[string]string{}
+	for _, t := range step.(steps.SubtestReporter).SubTests() {
+		if t.SkipMessage != nil {
+			skipped[t.Name] = t.SkipMessage.Message
+		}
+	}
+	expected := map[string]string{
+		"Run multi-stage test test - test-disabled":  `condition RUN in (true) does not hold for "false"`,
+		"Run multi-stage test test - test-from-test": `condition FROM_TEST notin (yes) does not hold for "yes"`,
+	}
+	if diff := cmp.Diff(expected, skipped); diff != "" {
+		t.Errorf("incorrect skipped tests: %s", diff)
+	}
+}
+
+func TestRunEnforcesPhaseBudgets(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		jobTimeout  time.Duration
+		jobAge      time.Duration
+		budgets     api.PhaseBudgets
+		wantPods    []string
+		wantErr     string
+		wantReasons []string
+	}{
+		{
+			name:        "test phase exceeding its budget is interrupted, post runs",
+			jobTimeout:  time.Hour,
+			budgets:     api.PhaseBudgets{Test: &prowapi.Duration{Duration: 100 * time.Millisecond}},
+			wantPods:    []string{"test-pre", "test-hang", "test-post"},
+			wantErr:     `phase test exceeded its budget of 100ms`,
+			wantReasons: []string{"executing_multi_stage_test:exceeding_phase_budget"},
+		},
+		{
+			name:        "post budget is reserved before the job times out",
+			jobTimeout:  time.Hour,
+			budgets:     api.PhaseBudgets{Post: &prowapi.Duration{Duration: time.Hour - 200*time.Millisecond}},
+			wantPods:    []string{"test-pre", "test-hang", "test-post"},
+			wantErr:     `phase test was interrupted to leave 59m59.8s for post steps before the job times out`,
+			wantReasons: []string{"executing_multi_stage_test:exceeding_phase_budget"},
+		},
+		{
+			name:        "job timeout is measured from the start of the job",
+			jobTimeout:  time.Hour,
+			jobAge:      30 * time.Minute,
+			budgets:     api.PhaseBudgets{Post: &prowapi.Duration{Duration: 30*time.Minute - 200*time.Millisecond}},
+			wantPods:    []string{"test-pre", "test-hang", "test-post"},
+			wantErr:     `phase test was interrupted to leave 29m59.8s for post steps before the job times out`,
+			wantReasons: []string{"executing_multi_stage_test:exceeding_phase_budget"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			sa := &v1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "ns", Labels: map[string]string{"ci.openshift.io/multi-stage-test": "test"}}}
+			crclient := &testhelper_kube.FakePodExecutor{
+				LoggingClient: loggingclient.New(
+					fakectrlruntimeclient.NewClientBuilder().
+						WithIndex(&v1.Pod{}, "metadata.name", fakePodNameIndexer).
+						WithObjects(sa).
+						Build(), nil),
+				Pending:      sets.New("test-hang"),
+				AutoSchedule: true,
+			}
+			jobSpec := api.JobSpec{
+				JobSpec: prowdapi.JobSpec{
+					Job:       "job",
+					BuildID:   "build_id",
+					ProwJobID: "prow_job_id",
+					Type:      prowapi.PeriodicJob,
+					DecorationConfig: &prowapi.DecorationConfig{
+						Timeout:     &prowapi.Duration{Duration: tc.jobTimeout},
+						GracePeriod: &prowapi.Duration{Duration: time.Second},
+						UtilityImages: &prowapi.UtilityImages{
+							Sidecar:    "sidecar",
+							Entrypoint: "entrypoint",
+						},
+					},
+				},
+			}
+			jobSpec.SetNamespace("ns")
+			jobSpec.SetStartTime(time.Now().Add(-tc.jobAge))
+			client := &testhelper_kube.FakePodClient{FakePodExecutor: crclient, PendingTimeout: 30 * time.Minute}
+			budgets := tc.budgets
+			step := MultiStageTestStep(api.TestStepConfiguration{
+				As: "test",
+				MultiStageTestConfigurationLiteral: &api.MultiStageTestConfigurationLiteral{
+					Pre:     []api.LiteralTestStep{{As: "pre"}},
+					Test:    []api.LiteralTestStep{{As: "hang"}, {As: "skipped"}},
+					Post:    []api.LiteralTestStep{{As: "post"}},
+					Budgets: &budgets,
+				},
+			}, &api.ReleaseBuildConfiguration{}, nil, client, &jobSpec, nil, "node-name", "", nil, false, nil, false, wait.Backoff{}, nil, false)
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+			err := step.Run(ctx)
+			if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Errorf("expected error containing %q, got %v", tc.wantErr, err)
+			}
+			if diff := cmp.Diff(tc.wantReasons, results.Reasons(err)); diff != "" {
+				t.Errorf("incorrect reasons: %s", diff)
+			}
+			var created []string
+			for _, pod := range crclient.CreatedPods {
+				created = append(created, pod.Name)
+			}
+			if diff := cmp.Diff(tc.wantPods, created); diff != "" {
+				t.Errorf("incorrect pods created: %s", diff)
+			}
+		})
+	}
+}
+
+func TestRunHoldsFailedStepsForDebugging(t *testing.T) {
+	for _, tc := range []struct {
+		name            string
+		hold            time.Duration
+		pending         sets.Set[string]
+		wantAnnotations map[string]string
+	}{
+		{
+			name: "hold ends when the copy stops",
+			hold: 2 * time.Hour,
+			wantAnnotations: map[string]string{
+				"ci.openshift.io/ttl.soft": "2h0m0s",
+				"ci.openshift.io/ttl.hard": "73h1m0s",
+			},
+		},
+		{
+			name:    "hold expires",
+			hold:    100 * time.Millisecond,
+			pending: sets.New("test-fail-debug"),
+			wantAnnotations: map[string]string{
+				"ci.openshift.io/ttl.soft": "1h0m0s",
+				"ci.openshift.io/ttl.hard": "72h0m0s",
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			artifacts := t.TempDir()
+			t.Setenv("ARTIFACTS", artifacts)
+			sa := &v1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "ns", Labels: map[string]string{"ci.openshift.io/multi-stage-test": "test"}}}
+			ns := &v1.Namespace{ObjectMeta: metav1.ObjectMeta{
+				Name:              "ns",
+				CreationTimestamp: metav1.NewTime(time.Now().Add(-71 * time.Hour)),
+				Annotations: map[string]string{
+					"ci.openshift.io/ttl.soft": "1h0m0s",
+					"ci.openshift.io/ttl.hard": "72h0m0s",
+				},
+			}}
+			crclient := &testhelper_kube.FakePodExecutor{
+				LoggingClient: loggingclient.New(
+					fakectrlruntimeclient.NewClientBuilder().
+						WithIndex(&v1.Pod{}, "metadata.name", fakePodNameIndexer).
+						WithObjects(sa, ns).
+						WithInterceptorFuncs(interceptor.Funcs{
+							// mutates the failed pod in the cluster only, as admission does
+							Create: func(ctx context.Context, client ctrlruntimeclient.WithWatch, obj ctrlruntimeclient.Object, opts ...ctrlruntimeclient.CreateOption) error {
+								pod, ok := obj.(*v1.Pod)
+								if !ok || pod.Name != "test-fail" {
+									return client.Create(ctx, obj, opts...)
+								}
+								admitted := pod.DeepCopy()
+								admitted.Annotations = map[string]string{"admitted": "true"}
+								if err := client.Create(ctx, admitted, opts...); err != nil {
+									return err
+								}
+								pod.ResourceVersion = admitted.ResourceVersion
+								pod.CreationTimestamp = admitted.CreationTimestamp
+								return nil
+							},
+						}).
+						Build(), nil),
+				Failures:     sets.New("test-fail"),
+				Pending:      tc.pending,
+				AutoSchedule: true,
+			}
+			jobSpec := api.JobSpec{
+				JobSpec: prowdapi.JobSpec{
+					Job:       "job",
+					BuildID:   "build_id",
+					ProwJobID: "prow_job_id",
+					Type:      prowapi.PeriodicJob,
+					DecorationConfig: &prowapi.DecorationConfig{
+						Timeout:     &prowapi.Duration{Duration: time.Minute},
+						GracePeriod: &prowapi.Duration{Duration: time.Second},
+						UtilityImages: &prowapi.UtilityImages{
+							Sidecar:    "sidecar",
+							Entrypoint: "entrypoint",
+						},
+					},
+				},
+			}
+			jobSpec.SetNamespace("ns")
+			client := &testhelper_kube.FakePodClient{FakePodExecutor: crclient, PendingTimeout: 30 * time.Minute}
+			censor := secrets.NewDynamicCensor()
+			step := MultiStageTestStep(api.TestStepConfiguration{
+				As: "test",
+				MultiStageTestConfigurationLiteral: &api.MultiStageTestConfigurationLiteral{
+					Test: []api.LiteralTestStep{{As: "fail"}, {As: "skipped"}},
+					Post: []api.LiteralTestStep{{As: "post"}},
+				},
+			}, &api.ReleaseBuildConfiguration{}, nil, client, &jobSpec, nil, "node-name", "", nil, false, nil, false, wait.Backoff{}, &DebugHold{Duration: tc.hold, Censor: &censor}, false)
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+			if err := step.Run(ctx); err == nil {
+				t.Fatal("expected an error but got nil")
+			}
+
+			var created []string
+			var debugPod *v1.Pod
+			for _, pod := range crclient.CreatedPods {
+				created = append(created, pod.Name)
+				if pod.Name == "test-fail-debug" {
+					debugPod = pod
+				}
+			}
+			if diff := cmp.Diff([]string{"test-fail", "test-fail-debug", "test-post"}, created); diff != "" {
+				t.Fatalf("incorrect pods created: %s", diff)
+			}
+			var containers []string
+			for _, c := range append(append([]v1.Container{}, debugPod.Spec.InitContainers...), debugPod.Spec.Containers...) {
+				containers = append(containers, c.Name)
+				if c.Name == "test" {
+					if diff := cmp.Diff([]string{"/bin/sh", "-c", fmt.Sprintf("sleep %d", int64(tc.hold.Seconds()))}, c.Command); diff != "" {
+						t.Errorf("incorrect command of the paused copy: %s", diff)
+					}
+				}
+			}
+			if diff := cmp.Diff([]string{"test"}, containers); diff != "" {
+				t.Errorf("incorrect containers in the paused copy: %s", diff)
+			}
+			if debugPod.Annotations["admitted"] != "true" {
+				t.Error("expected the paused copy to be made from the failed pod in the cluster")
+			}
+			var deleted bool
+			for _, pod := range crclient.DeletedPods {
+				deleted = deleted || pod.Name == "test-fail-debug"
+			}
+			if !deleted {
+				t.Error("expected the paused copy to be deleted")
+			}
+
+			updated := &v1.Namespace{}
+			if err := crclient.Get(ctx, ctrlruntimeclient.ObjectKey{Name: "ns"}, updated); err != nil {
+				t.Fatalf("failed to get namespace: %v", err)
+			}
+			if _, ok := updated.Annotations["ci.openshift.io/active"]; !ok {
+				t.Error("expected the namespace to be marked as active")
+			}
+			delete(updated.Annotations, "ci.openshift.io/active")
+			if diff := cmp.Diff(tc.wantAnnotations, updated.Annotations); diff != "" {
+				t.Errorf("incorrect namespace annotations: %s", diff)
+			}
+			instructions, err := os.ReadFile(filepath.Join(artifacts, "debug-hold", "test-fail.txt"))
+			if err != nil {
+				t.Fatalf("failed to read instructions: %v", err)
+			}
+			if !strings.Contains(string(instructions), "oc --namespace ns rsh test-fail-debug") {
+				t.Errorf("unexpected instructions: %s", instructions)
+			}
+		})
+	}
+}
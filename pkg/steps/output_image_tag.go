@@ -105,6 +105,13 @@ func (s *outputImageTagStep) run(ctx context.Context) error {
 	return nil
 }
 
+// RenderObjects generates the tag of the step without creating it.  The image
+// it points to is only known once it is built and is rendered as a
+// placeholder.
+func (s *outputImageTagStep) RenderObjects() ([]crclient.Object, error) {
+	return []crclient.Object{s.imageStreamTag(api.DryRunPlaceholder(fmt.Sprintf("image of %s:%s", api.PipelineImageStream, s.config.From)))}, nil
+}
+
 func (s *outputImageTagStep) Requires() []api.StepLink {
 	return []api.StepLink{
 		api.InternalImageLink(s.config.From),
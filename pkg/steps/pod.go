@@ -145,6 +145,29 @@ func (s *podStep) run(ctx context.Context) error {
 	return nil
 }
 
+// RenderObjects generates the pod of the step without creating it.  The
+// objects set up for nested podman are not rendered.
+func (s *podStep) RenderObjects() ([]ctrlruntimeclient.Object, error) {
+	containerResources, err := ResourcesFor(s.resources.RequirementsForStep(s.config.As))
+	if err != nil {
+		return nil, fmt.Errorf("unable to calculate %s pod resources for %s: %w", s.name, s.config.As, err)
+	}
+	if s.config.From.Namespace != "" {
+		return nil, errors.New("pod step does not support an image stream tag reference outside the namespace")
+	}
+	pod, err := s.generatePodForStep(fmt.Sprintf("%s:%s", s.config.From.Name, s.config.From.Tag), containerResources, s.config.Clone)
+	if err != nil {
+		return nil, fmt.Errorf("pod step was invalid: %w", err)
+	}
+	if s.config.NestedPodman {
+		podsutils.ConfigurePodForNestedPodman(pod, s.name, s.config.As)
+	}
+	if owner := s.jobSpec.Owner(); owner != nil {
+		pod.OwnerReferences = append(pod.OwnerReferences, *owner)
+	}
+	return []ctrlruntimeclient.Object{pod}, nil
+}
+
 func (s *podStep) SubTests() []*junit.TestCase {
 	return s.subTests
 }
@@ -435,6 +458,11 @@ func getSecretVolumeMountFromSecret(secretMountPath string, secretIndex int) []c
 // This pod will not be able to gather artifacts, nor will it report log messages
 // unless it fails.
 func RunPod(ctx context.Context, podClient kubernetes.PodClient, pod *coreapi.Pod, skipLogs bool) (*coreapi.Pod, error) {
+	return runPod(ctx, podClient, pod, nil, skipLogs)
+}
+
+// runPod runs a pod to completion, notifying the notifier of its containers.
+func runPod(ctx context.Context, podClient kubernetes.PodClient, pod *coreapi.Pod, notifier util.ContainerNotifier, skipLogs bool) (*coreapi.Pod, error) {
 	podClient.MetricsAgent().StoreMachinesSnapshot(pod)
 
 	pod, err := util.CreateOrRestartPod(ctx, podClient, pod)
@@ -445,5 +473,5 @@ func RunPod(ctx context.Context, podClient kubernetes.PodClient, pod *coreapi.Po
 	if !skipLogs {
 		flag = util.WaitForPodFlag(0)
 	}
-	return util.WaitForPodCompletion(ctx, podClient, pod.Namespace, pod.Name, nil, flag)
+	return util.WaitForPodCompletion(ctx, podClient, pod.Namespace, pod.Name, notifier, flag)
 }
@@ -32,6 +32,9 @@ type projectDirectoryImageBuildStep struct {
 	multiArch          bool
 	architectures      sets.Set[string]
 	metricsAgent       *metrics.MetricsAgent
+	buildBackend       api.BuildBackend
+	buildahImage       string
+	cosignImage        string
 }
 
 func (s *projectDirectoryImageBuildStep) Inputs() (api.InputDefinition, error) {
@@ -72,10 +75,55 @@ func (s *projectDirectoryImageBuildStep) run(ctx context.Context) error {
 
 	// Bundle images are non multi-arch by design. No manifest list is needed. Here we spawn a single build.
 	if s.config.IsBundleImage() {
-		return handleBuild(ctx, s.client, s.podClient, *build, func() bool { return false })
+		err = newBuildBackend(s.buildBackend, s.buildahImage, s.client, s.podClient, func() bool { return false }).Build(ctx, *build)
+	} else {
+		opts := newImageBuildOptions(s.architectures.UnsortedList())
+		opts.Backend = s.buildBackend
+		opts.BuildahImage = s.buildahImage
+		err = handleBuilds(ctx, s.client, s.podClient, *build, s.metricsAgent, opts)
+	}
+	if err != nil || s.config.SBOM == nil {
+		return err
 	}
+	return s.generateSBOM(ctx)
+}
 
-	return handleBuilds(ctx, s.client, s.podClient, *build, s.metricsAgent, newImageBuildOptions(s.architectures.UnsortedList()))
+// RenderObjects generates the build of the step without creating it.  The
+// working directory of the source image and the digest of the image the build
+// starts from are rendered as placeholders.
+func (s *projectDirectoryImageBuildStep) RenderObjects() ([]ctrlruntimeclient.Object, error) {
+	sourceTag, images, err := imagesFor(s.config, func(tag string) (string, error) {
+		return api.DryRunPlaceholder(tag + " working directory"), nil
+	}, s.releaseBuildConfig.IsBundleImage)
+	if err != nil {
+		return nil, err
+	}
+	build := buildFromSource(
+		s.jobSpec, s.config.From, s.config.To,
+		buildapi.BuildSource{
+			Type:       buildapi.BuildSourceImage,
+			Dockerfile: s.config.DockerfileLiteral,
+			Images:     images,
+		},
+		api.DryRunPlaceholder(string(sourceTag)),
+		s.config.DockerfilePath,
+		s.resources,
+		s.pullSecret,
+		s.config.BuildArgs,
+		s.config.Ref,
+	)
+	if s.buildBackend != api.BuildBackendBuildah {
+		return []ctrlruntimeclient.Object{build}, nil
+	}
+	image := s.buildahImage
+	if image == "" {
+		image = DefaultBuildahImage
+	}
+	pod, err := buildahPod(*build, image, s.client.LocalRegistryDNS())
+	if err != nil {
+		return nil, fmt.Errorf("could not create build pod for %s: %w", build.Name, err)
+	}
+	return []ctrlruntimeclient.Object{pod}, nil
 }
 
 type workingDir func(tag string) (string, error)
@@ -222,6 +270,9 @@ func ProjectDirectoryImageBuildStep(
 	jobSpec *api.JobSpec,
 	pullSecret *coreapi.Secret,
 	metricsAgent *metrics.MetricsAgent,
+	buildBackend api.BuildBackend,
+	buildahImage string,
+	cosignImage string,
 ) api.Step {
 	return &projectDirectoryImageBuildStep{
 		config:             config,
@@ -234,5 +285,8 @@ func ProjectDirectoryImageBuildStep(
 		multiArch:          config.MultiArch,
 		architectures:      sets.New[string](),
 		metricsAgent:       metricsAgent,
+		buildBackend:       buildBackend,
+		buildahImage:       buildahImage,
+		cosignImage:        cosignImage,
 	}
 }
@@ -18,6 +18,7 @@ import (
 
 	"github.com/openshift/ci-tools/pkg/api"
 	"github.com/openshift/ci-tools/pkg/api/configresolver"
+	"github.com/openshift/ci-tools/pkg/junit"
 	"github.com/openshift/ci-tools/pkg/kubernetes"
 	"github.com/openshift/ci-tools/pkg/metrics"
 	"github.com/openshift/ci-tools/pkg/results"
@@ -54,6 +55,8 @@ type assembleReleaseStep struct {
 	client    kubernetes.PodClient
 	jobSpec   *api.JobSpec
 	secret    *coreapi.Secret
+	differ    *ReleaseDiffer
+	subTests  []*junit.TestCase
 }
 
 func (s *assembleReleaseStep) Inputs() (api.InputDefinition, error) {
@@ -253,9 +256,22 @@ fi
 		return results.ForReason("creating_release").ForError(err)
 	}
 	logrus.Infof("Snapshot integration stream into release %s to tag %s:%s ", version, api.ReleaseImageStream, s.name)
+	if s.differ != nil {
+		test, err := s.differ.Diff(ctx)
+		if err != nil {
+			logrus.WithError(err).Warn("Failed to compare the initial and latest releases.")
+		}
+		if test != nil {
+			s.subTests = []*junit.TestCase{test}
+		}
+	}
 	return nil
 }
 
+func (s *assembleReleaseStep) SubTests() []*junit.TestCase {
+	return s.subTests
+}
+
 func (s *assembleReleaseStep) Requires() []api.StepLink {
 	if s.config.IncludeBuiltImages {
 		return []api.StepLink{api.ImagesReadyLink()}
@@ -284,9 +300,10 @@ func (s *assembleReleaseStep) Objects() []ctrlruntimeclient.Object {
 }
 
 // AssembleReleaseStep builds a new update payload image based on the cluster version operator
-// and the operators defined in the release configuration.
+// and the operators defined in the release configuration.  The differ, when set, compares the
+// releases once the steps assembling them are done.
 func AssembleReleaseStep(name, nodeName string, config *api.ReleaseTagConfiguration, resources api.ResourceConfiguration,
-	client kubernetes.PodClient, jobSpec *api.JobSpec, pullSecret *coreapi.Secret) api.Step {
+	client kubernetes.PodClient, jobSpec *api.JobSpec, pullSecret *coreapi.Secret, differ *ReleaseDiffer) api.Step {
 	return &assembleReleaseStep{
 		config:    config,
 		name:      name,
@@ -295,6 +312,7 @@ func AssembleReleaseStep(name, nodeName string, config *api.ReleaseTagConfigurat
 		client:    client,
 		jobSpec:   jobSpec,
 		secret:    pullSecret,
+		differ:    differ,
 	}
 }
 
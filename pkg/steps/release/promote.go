@@ -45,6 +45,7 @@ type promotionStep struct {
 	mirrorFunc        func(source, target string, tag api.ImageStreamTagReference, date string, imageMirror map[string]string)
 	targetNameFunc    func(string, api.PromotionTarget) string
 	nodeArchitectures []string
+	signing           *Signing
 }
 
 func (s *promotionStep) Inputs() (api.InputDefinition, error) {
@@ -99,6 +100,8 @@ func (s *promotionStep) run(ctx context.Context) error {
 		return nil
 	}
 
+	addSBOMMirrorTargets(imageMirrorTarget, tags, sbomImages(s.configuration), pipeline, s.registry, s.targetNameFunc)
+
 	// in some cases like when we are called by the ci-chat-bot we may need to create namespaces
 	// in general, we do not expect to be able to do this, so we only do it best-effort
 	if err := s.ensureNamespaces(ctx, namespaces); err != nil {
@@ -114,6 +117,9 @@ func (s *promotionStep) run(ctx context.Context) error {
 	if _, err := steps.RunPod(ctx, s.client, getPromotionPod(imageMirrorTarget, timeStr, s.jobSpec.Namespace(), s.name, version, s.nodeArchitectures), false); err != nil {
 		return fmt.Errorf("unable to run promotion pod: %w", err)
 	}
+	if s.signing != nil {
+		return s.sign(ctx, tags, pipeline)
+	}
 	return nil
 }
 
@@ -175,20 +181,7 @@ func getImageMirrorTarget(tags map[string][]api.ImageStreamTagReference, pipelin
 		}
 		dockerImageReference = getPublicImageReference(dockerImageReference, pipeline.Status.PublicDockerImageRepository)
 		for _, dst := range dsts {
-			var target string
-			if targetNameFunc != nil {
-				// Use targetNameFunc to generate template and substitute ${component} with actual component name
-				promotionTarget := api.PromotionTarget{
-					Namespace: dst.Namespace,
-					Name:      dst.Name,
-					Tag:       dst.Tag,
-				}
-				template := targetNameFunc(registry, promotionTarget)
-				target = strings.Replace(template, api.ComponentFormatReplacement, dst.Tag, -1)
-			} else {
-				// Fallback to direct target construction for backwards compatibility
-				target = fmt.Sprintf("%s/%s", registry, dst.ISTagName())
-			}
+			target := promotionTargetFor(registry, dst, targetNameFunc)
 			mirrorFunc(dockerImageReference, target, dst, time, imageMirror)
 			namespaces.Insert(dst.Namespace)
 		}
@@ -202,6 +195,58 @@ func getImageMirrorTarget(tags map[string][]api.ImageStreamTagReference, pipelin
 	return imageMirror, namespaces
 }
 
+func promotionTargetFor(registry string, dst api.ImageStreamTagReference, targetNameFunc func(string, api.PromotionTarget) string) string {
+	if targetNameFunc == nil {
+		// Fallback to direct target construction for backwards compatibility
+		return fmt.Sprintf("%s/%s", registry, dst.ISTagName())
+	}
+	// Use targetNameFunc to generate template and substitute ${component} with actual component name
+	promotionTarget := api.PromotionTarget{
+		Namespace: dst.Namespace,
+		Name:      dst.Name,
+		Tag:       dst.Tag,
+	}
+	template := targetNameFunc(registry, promotionTarget)
+	return strings.Replace(template, api.ComponentFormatReplacement, dst.Tag, -1)
+}
+
+// addSBOMMirrorTargets mirrors the SBOMs attached to promoted images into the
+// repositories the images are promoted to. The digest of the images does not
+// change when mirroring, so the SBOMs keep their tags.
+func addSBOMMirrorTargets(imageMirror map[string]string, tags map[string][]api.ImageStreamTagReference, sbomImages sets.Set[string], pipeline *imagev1.ImageStream, registry string, targetNameFunc func(string, api.PromotionTarget) string) {
+	for src, dsts := range tags {
+		if !sbomImages.Has(src) {
+			continue
+		}
+		digest := findImageDigest(pipeline, src)
+		if digest == "" {
+			continue
+		}
+		sbomTag := api.SBOMTag(digest)
+		sbomReference := findDockerImageReference(pipeline, sbomTag)
+		if sbomReference == "" {
+			logrus.Warnf("No SBOM is attached to image %s, it is promoted without it.", src)
+			continue
+		}
+		sbomReference = getPublicImageReference(sbomReference, pipeline.Status.PublicDockerImageRepository)
+		for _, dst := range dsts {
+			repository := repositoryOf(promotionTargetFor(registry, dst, targetNameFunc))
+			imageMirror[fmt.Sprintf("%s:%s", repository, sbomTag)] = sbomReference
+		}
+	}
+}
+
+// sbomImages returns the images of the configuration which have SBOMs attached
+func sbomImages(configuration *api.ReleaseBuildConfiguration) sets.Set[string] {
+	images := sets.New[string]()
+	for _, image := range configuration.Images {
+		if image.SBOM != nil {
+			images.Insert(string(image.To))
+		}
+	}
+	return images
+}
+
 func getPublicImageReference(dockerImageReference, publicDockerImageRepository string) string {
 	if !strings.Contains(dockerImageReference, ":5000") {
 		return dockerImageReference
@@ -380,6 +425,17 @@ func getPromotionPod(imageMirrorTarget map[string]string, timeStr string, namesp
 	}
 }
 
+// findImageDigest returns the digest of the image a tag in the ImageStream points to
+func findImageDigest(is *imagev1.ImageStream, tag string) string {
+	for _, t := range is.Status.Tags {
+		if t.Tag != tag || len(t.Items) == 0 {
+			continue
+		}
+		return t.Items[0].Image
+	}
+	return ""
+}
+
 // findDockerImageReference returns DockerImageReference, the string that can be used to pull this image,
 // to a tag if it exists in the ImageStream's Spec
 func findDockerImageReference(is *imagev1.ImageStream, tag string) string {
@@ -487,21 +543,7 @@ func PromotedTagsWithRequiredImages(configuration *api.ReleaseBuildConfiguration
 		tags, names := toPromote(target, configuration.Images, opts.requiredImages)
 		requiredImages.Insert(names.UnsortedList()...)
 		for dst, src := range tags {
-			var tag api.ImageStreamTagReference
-			if target.Name != "" {
-				tag = api.ImageStreamTagReference{
-					Namespace: target.Namespace,
-					Name:      target.Name,
-					Tag:       dst,
-				}
-			} else { // promotion.Tag must be set
-				tag = api.ImageStreamTagReference{
-					Namespace: target.Namespace,
-					Name:      dst,
-					Tag:       target.Tag,
-				}
-			}
-			promotedTags[src] = append(promotedTags[src], tag)
+			promotedTags[src] = append(promotedTags[src], promotedTagFor(target, dst))
 			if target.TagByCommit && opts.commitSha != "" {
 				promotedTags[src] = append(promotedTags[src], api.ImageStreamTagReference{
 					Namespace: target.Namespace,
@@ -523,6 +565,23 @@ func PromotedTagsWithRequiredImages(configuration *api.ReleaseBuildConfiguration
 	return promotedTags, requiredImages
 }
 
+// promotedTagFor returns the tag an image is promoted to for the target.
+func promotedTagFor(target api.PromotionTarget, dst string) api.ImageStreamTagReference {
+	if target.Name != "" {
+		return api.ImageStreamTagReference{
+			Namespace: target.Namespace,
+			Name:      target.Name,
+			Tag:       dst,
+		}
+	}
+	// promotion.Tag must be set
+	return api.ImageStreamTagReference{
+		Namespace: target.Namespace,
+		Name:      dst,
+		Tag:       target.Tag,
+	}
+}
+
 func (s *promotionStep) Requires() []api.StepLink {
 	return []api.StepLink{api.AllStepsLink()}
 }
@@ -554,7 +613,8 @@ func (s *promotionStep) Objects() []ctrlruntimeclient.Object {
 }
 
 // PromotionStep copies tags from the pipeline image stream to the destination defined in the promotion config.
-// If the source tag does not exist it is silently skipped.
+// If the source tag does not exist it is silently skipped. When signing is set, the promoted images are signed
+// and their provenance is attested once they are mirrored.
 func PromotionStep(
 	name string,
 	configuration *api.ReleaseBuildConfiguration,
@@ -566,6 +626,7 @@ func PromotionStep(
 	mirrorFunc func(source, target string, tag api.ImageStreamTagReference, date string, imageMirror map[string]string),
 	targetNameFunc func(string, api.PromotionTarget) string,
 	nodeArchitectures []string,
+	signing *Signing,
 ) api.Step {
 	return &promotionStep{
 		name:              name,
@@ -578,5 +639,6 @@ func PromotionStep(
 		mirrorFunc:        mirrorFunc,
 		targetNameFunc:    targetNameFunc,
 		nodeArchitectures: nodeArchitectures,
+		signing:           signing,
 	}
 }
@@ -2,8 +2,10 @@ package release
 
 import (
 	"fmt"
+	"path/filepath"
 	"reflect"
 	"sort"
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -1025,6 +1027,88 @@ func TestGetImageMirror(t *testing.T) {
 	}
 }
 
+func TestAddSBOMMirrorTargets(t *testing.T) {
+	pipeline := &imageapi.ImageStream{
+		Status: imageapi.ImageStreamStatus{
+			Tags: []imageapi.NamedTagEventList{
+				{
+					Tag:   "with-sbom",
+					Items: []imageapi.TagEvent{{Image: "sha256:aaa", DockerImageReference: "registry.svc.ci.openshift.org/ci-op-y2n8rsh3/pipeline@sha256:aaa"}},
+				},
+				{
+					Tag:   "sha256-aaa.sbom",
+					Items: []imageapi.TagEvent{{Image: "sha256:sss", DockerImageReference: "registry.svc.ci.openshift.org/ci-op-y2n8rsh3/pipeline@sha256:sss"}},
+				},
+				{
+					Tag:   "without-sbom",
+					Items: []imageapi.TagEvent{{Image: "sha256:bbb", DockerImageReference: "registry.svc.ci.openshift.org/ci-op-y2n8rsh3/pipeline@sha256:bbb"}},
+				},
+				{
+					Tag:   "sbom-missing",
+					Items: []imageapi.TagEvent{{Image: "sha256:ccc", DockerImageReference: "registry.svc.ci.openshift.org/ci-op-y2n8rsh3/pipeline@sha256:ccc"}},
+				},
+			},
+		},
+	}
+	tags := map[string][]api.ImageStreamTagReference{
+		"with-sbom": {
+			{Namespace: "ocp", Name: "4.20", Tag: "with-sbom"},
+			{Namespace: "ci", Name: "with-sbom", Tag: "latest"},
+		},
+		"without-sbom": {{Namespace: "ocp", Name: "4.20", Tag: "without-sbom"}},
+		"sbom-missing": {{Namespace: "ocp", Name: "4.20", Tag: "sbom-missing"}},
+	}
+	configuration := &api.ReleaseBuildConfiguration{
+		Images: []api.ProjectDirectoryImageBuildStepConfiguration{
+			{To: "with-sbom", SBOM: &api.SBOMConfiguration{}},
+			{To: "without-sbom"},
+			{To: "sbom-missing", SBOM: &api.SBOMConfiguration{Format: api.SBOMFormatCycloneDX}},
+		},
+	}
+	actual := map[string]string{}
+	addSBOMMirrorTargets(actual, tags, sbomImages(configuration), pipeline, "registry.ci.openshift.org", api.DefaultTargetNameFunc)
+	expected := map[string]string{
+		"registry.ci.openshift.org/ocp/4.20:sha256-aaa.sbom":     "registry.svc.ci.openshift.org/ci-op-y2n8rsh3/pipeline@sha256:sss",
+		"registry.ci.openshift.org/ci/with-sbom:sha256-aaa.sbom": "registry.svc.ci.openshift.org/ci-op-y2n8rsh3/pipeline@sha256:sss",
+	}
+	if diff := cmp.Diff(expected, actual); diff != "" {
+		t.Errorf("unexpected mirror targets: %s", diff)
+	}
+}
+
+func TestPromotionPodMirrorsSBOMs(t *testing.T) {
+	pipeline := &imageapi.ImageStream{
+		Status: imageapi.ImageStreamStatus{
+			PublicDockerImageRepository: "registry.svc.ci.openshift.org/ci-op-y2n8rsh3/pipeline",
+			Tags: []imageapi.NamedTagEventList{
+				{
+					Tag:   "with-sbom",
+					Items: []imageapi.TagEvent{{Image: "sha256:aaa", DockerImageReference: "image-registry.openshift-image-registry.svc:5000/ci-op-y2n8rsh3/pipeline@sha256:aaa"}},
+				},
+				{
+					Tag:   "sha256-aaa.sbom",
+					Items: []imageapi.TagEvent{{Image: "sha256:sss", DockerImageReference: "image-registry.openshift-image-registry.svc:5000/ci-op-y2n8rsh3/pipeline@sha256:sss"}},
+				},
+			},
+		},
+	}
+	tags := map[string][]api.ImageStreamTagReference{"with-sbom": {{Namespace: "ocp", Name: "4.20", Tag: "with-sbom"}}}
+	configuration := &api.ReleaseBuildConfiguration{
+		Images: []api.ProjectDirectoryImageBuildStepConfiguration{{To: "with-sbom", SBOM: &api.SBOMConfiguration{}}},
+	}
+	imageMirror, _ := getImageMirrorTarget(tags, pipeline, "registry.ci.openshift.org", "20240603235401", api.DefaultMirrorFunc, api.DefaultTargetNameFunc)
+	addSBOMMirrorTargets(imageMirror, tags, sbomImages(configuration), pipeline, "registry.ci.openshift.org", api.DefaultTargetNameFunc)
+	pod := getPromotionPod(imageMirror, "20240603235401", "ci-op-y2n8rsh3", api.PromotionStepName, "4.20", []string{"amd64"})
+
+	expected := getMirrorCommand(filepath.Join(api.RegistryPushCredentialsCICentralSecretMountPath, coreapi.DockerConfigJsonKey), []string{
+		"registry.svc.ci.openshift.org/ci-op-y2n8rsh3/pipeline@sha256:sss=registry.ci.openshift.org/ocp/4.20:sha256-aaa.sbom",
+		"registry.svc.ci.openshift.org/ci-op-y2n8rsh3/pipeline@sha256:aaa=registry.ci.openshift.org/ocp/4.20:with-sbom",
+	}, 2)
+	if args := strings.Join(pod.Spec.Containers[0].Args, "\n"); !strings.Contains(args, expected) {
+		t.Errorf("expected the promotion to run %q, got:\n%s", expected, args)
+	}
+}
+
 func TestGetPublicImageReference(t *testing.T) {
 	var testCases = []struct {
 		name                        string
@@ -0,0 +1,292 @@
+package release
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	imagev1 "github.com/openshift/api/image/v1"
+
+	"github.com/openshift/ci-tools/pkg/api"
+	"github.com/openshift/ci-tools/pkg/kubernetes"
+	"github.com/openshift/ci-tools/pkg/secrets"
+)
+
+const (
+	// PromotionPreviewJSON is the artifact holding the promotion preview.
+	PromotionPreviewJSON = "promotion-preview.json"
+	// PromotionPreviewSummary is the artifact holding the human-readable
+	// promotion preview.
+	PromotionPreviewSummary = "promotion-preview.txt"
+)
+
+// PromotionChange describes what promoting an image would do to a tag.
+type PromotionChange string
+
+const (
+	// PromotionChangeAdded means the tag does not exist yet.
+	PromotionChangeAdded PromotionChange = "added"
+	// PromotionChangeUpdated means the tag points to another image.
+	PromotionChangeUpdated PromotionChange = "updated"
+	// PromotionChangeUnchanged means the tag already points to the image.
+	PromotionChangeUnchanged PromotionChange = "unchanged"
+	// PromotionChangeNotBuilt means the image was not built by the job, so
+	// the preview cannot tell whether the tag would change.
+	PromotionChangeNotBuilt PromotionChange = "not-built"
+)
+
+// PromotionPreview describes the changes the promotion of the images built
+// by a job would make to the target image streams.
+type PromotionPreview struct {
+	Targets []PromotionTargetPreview `json:"targets"`
+}
+
+// PromotionTargetPreview describes the changes to a single promotion target.
+type PromotionTargetPreview struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name,omitempty"`
+	Tag       string `json:"tag,omitempty"`
+	// Disabled targets are not promoted to
+	Disabled bool `json:"disabled,omitempty"`
+	// Excluded holds the images which are not promoted to the target
+	Excluded []string              `json:"excluded,omitempty"`
+	Tags     []PromotionTagPreview `json:"tags,omitempty"`
+}
+
+func (t PromotionTargetPreview) String() string {
+	if t.Name != "" {
+		return fmt.Sprintf("%s/%s", t.Namespace, t.Name)
+	}
+	return fmt.Sprintf("%s/*:%s", t.Namespace, t.Tag)
+}
+
+// PromotionTagPreview describes the change to a single promoted tag.
+type PromotionTagPreview struct {
+	// Source is the tag in the pipeline image stream
+	Source string                      `json:"source"`
+	Target api.ImageStreamTagReference `json:"target"`
+	Change PromotionChange             `json:"change"`
+	// From is the digest the target tag currently points to
+	From string `json:"from,omitempty"`
+	// To is the digest the target tag would point to
+	To string `json:"to,omitempty"`
+}
+
+// Summary formats the preview for humans.
+func (p PromotionPreview) Summary() string {
+	if len(p.Targets) == 0 {
+		return "No promotion targets are configured.\n"
+	}
+	var b strings.Builder
+	for _, target := range p.Targets {
+		if target.Disabled {
+			fmt.Fprintf(&b, "Promotion to %s is disabled.\n", target)
+			continue
+		}
+		fmt.Fprintf(&b, "Promotion to %s:\n", target)
+		if len(target.Tags) == 0 {
+			b.WriteString("  nothing to promote\n")
+		}
+		for _, tag := range target.Tags {
+			switch tag.Change {
+			case PromotionChangeAdded:
+				fmt.Fprintf(&b, "  %-10s %s from %s: %s\n", tag.Change, tag.Target.ISTagName(), tag.Source, tag.To)
+			case PromotionChangeUpdated:
+				fmt.Fprintf(&b, "  %-10s %s from %s: %s -> %s\n", tag.Change, tag.Target.ISTagName(), tag.Source, tag.From, tag.To)
+			default:
+				fmt.Fprintf(&b, "  %-10s %s from %s\n", tag.Change, tag.Target.ISTagName(), tag.Source)
+			}
+		}
+		if len(target.Excluded) > 0 {
+			fmt.Fprintf(&b, "  excluded:  %s\n", strings.Join(target.Excluded, ", "))
+		}
+	}
+	return b.String()
+}
+
+// promotionPreview computes the changes promotion would make to the target
+// image streams, which streamFor returns, or nil when they do not exist.
+// Tags by commit are left out, as the commit is only known once merged.
+func promotionPreview(configuration *api.ReleaseBuildConfiguration, requiredImages sets.Set[string], pipeline *imagev1.ImageStream, streamFor func(namespace, name string) (*imagev1.ImageStream, error)) (PromotionPreview, error) {
+	preview := PromotionPreview{Targets: []PromotionTargetPreview{}}
+	for _, target := range api.PromotionTargets(configuration.PromotionConfiguration) {
+		targetPreview := PromotionTargetPreview{
+			Namespace: target.Namespace,
+			Name:      target.Name,
+			Tag:       target.Tag,
+			Disabled:  target.Disabled,
+		}
+		if target.Disabled {
+			preview.Targets = append(preview.Targets, targetPreview)
+			continue
+		}
+		targetPreview.Excluded = excludedImages(target, configuration.Images)
+		tags, _ := toPromote(target, configuration.Images, requiredImages)
+		destinations := make([]string, 0, len(tags))
+		for dst := range tags {
+			destinations = append(destinations, dst)
+		}
+		sort.Strings(destinations)
+		for _, dst := range destinations {
+			tag := PromotionTagPreview{Source: tags[dst], Target: promotedTagFor(target, dst)}
+			tag.To = findImageDigest(pipeline, tag.Source)
+			if tag.To == "" {
+				tag.Change = PromotionChangeNotBuilt
+				targetPreview.Tags = append(targetPreview.Tags, tag)
+				continue
+			}
+			stream, err := streamFor(tag.Target.Namespace, tag.Target.Name)
+			if err != nil {
+				return PromotionPreview{}, err
+			}
+			if stream != nil {
+				tag.From = findImageDigest(stream, tag.Target.Tag)
+			}
+			switch tag.From {
+			case "":
+				tag.Change = PromotionChangeAdded
+			case tag.To:
+				tag.Change = PromotionChangeUnchanged
+			default:
+				tag.Change = PromotionChangeUpdated
+			}
+			targetPreview.Tags = append(targetPreview.Tags, tag)
+		}
+		preview.Targets = append(preview.Targets, targetPreview)
+	}
+	return preview, nil
+}
+
+// excludedImages returns the images which are excluded from the target and
+// not promoted as additional images anyway.
+func excludedImages(target api.PromotionTarget, images []api.ProjectDirectoryImageBuildStepConfiguration) []string {
+	excluded := sets.New[string]()
+	for _, tag := range target.ExcludedImages {
+		if tag != api.PromotionExcludeImageWildcard {
+			excluded.Insert(tag)
+			continue
+		}
+		for _, image := range images {
+			excluded.Insert(string(image.To))
+		}
+	}
+	for dst := range target.AdditionalImages {
+		excluded.Delete(dst)
+	}
+	return sets.List(excluded)
+}
+
+// promotionPreviewStep reports the changes the promotion would make if the
+// tested changes merged, without promoting anything.
+type promotionPreviewStep struct {
+	configuration  *api.ReleaseBuildConfiguration
+	requiredImages sets.Set[string]
+	jobSpec        *api.JobSpec
+	client         kubernetes.PodClient
+	censor         *secrets.DynamicCensor
+}
+
+func (s *promotionPreviewStep) Inputs() (api.InputDefinition, error) {
+	return nil, nil
+}
+
+func (*promotionPreviewStep) Validate() error { return nil }
+
+// Run never fails, as the preview is only informative.
+func (s *promotionPreviewStep) Run(ctx context.Context) error {
+	if err := s.run(ctx); err != nil {
+		logrus.WithError(err).Warn("Failed to preview the promotion.")
+	}
+	return nil
+}
+
+func (s *promotionPreviewStep) run(ctx context.Context) error {
+	pipeline := &imagev1.ImageStream{}
+	if err := s.client.Get(ctx, ctrlruntimeclient.ObjectKey{
+		Namespace: s.jobSpec.Namespace(),
+		Name:      api.PipelineImageStream,
+	}, pipeline); err != nil {
+		return fmt.Errorf("could not resolve pipeline imagestream: %w", err)
+	}
+
+	streams := map[string]*imagev1.ImageStream{}
+	streamFor := func(namespace, name string) (*imagev1.ImageStream, error) {
+		key := fmt.Sprintf("%s/%s", namespace, name)
+		if stream, ok := streams[key]; ok {
+			return stream, nil
+		}
+		stream := &imagev1.ImageStream{}
+		if err := s.client.Get(ctx, ctrlruntimeclient.ObjectKey{Namespace: namespace, Name: name}, stream); kerrors.IsNotFound(err) {
+			stream = nil
+		} else if err != nil {
+			return nil, fmt.Errorf("could not get imagestream %s: %w", key, err)
+		}
+		streams[key] = stream
+		return stream, nil
+	}
+
+	preview, err := promotionPreview(s.configuration, s.requiredImages, pipeline, streamFor)
+	if err != nil {
+		return err
+	}
+	summary := preview.Summary()
+	logrus.Infof("Promotion would make the following changes:\n%s", summary)
+	raw, err := json.MarshalIndent(preview, "", "  ")
+	if err != nil {
+		return fmt.Errorf("could not marshal promotion preview: %w", err)
+	}
+	if err := api.SaveArtifact(s.censor, PromotionPreviewJSON, raw); err != nil {
+		return err
+	}
+	return api.SaveArtifact(s.censor, PromotionPreviewSummary, []byte(summary))
+}
+
+func (s *promotionPreviewStep) Requires() []api.StepLink {
+	return []api.StepLink{api.AllStepsLink()}
+}
+
+func (s *promotionPreviewStep) Creates() []api.StepLink {
+	return []api.StepLink{}
+}
+
+func (s *promotionPreviewStep) Provides() api.ParameterMap {
+	return nil
+}
+
+func (s *promotionPreviewStep) Name() string {
+	return fmt.Sprintf("[%s]", api.PromotionPreviewStepName)
+}
+
+func (s *promotionPreviewStep) Description() string {
+	return "Report the changes promotion would make to the target image streams"
+}
+
+func (s *promotionPreviewStep) Objects() []ctrlruntimeclient.Object {
+	return s.client.Objects()
+}
+
+// PromotionPreviewStep compares the images built by the job with the tags in the image streams they would be
+// promoted to and saves the changes the promotion would make as artifacts.
+func PromotionPreviewStep(
+	configuration *api.ReleaseBuildConfiguration,
+	requiredImages sets.Set[string],
+	jobSpec *api.JobSpec,
+	client kubernetes.PodClient,
+	censor *secrets.DynamicCensor,
+) api.Step {
+	return &promotionPreviewStep{
+		configuration:  configuration,
+		requiredImages: requiredImages,
+		jobSpec:        jobSpec,
+		client:         client,
+		censor:         censor,
+	}
+}
@@ -0,0 +1,103 @@
+package release
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	imageapi "github.com/openshift/api/image/v1"
+
+	"github.com/openshift/ci-tools/pkg/api"
+	"github.com/openshift/ci-tools/pkg/testhelper"
+)
+
+func TestPromotionPreview(t *testing.T) {
+	images := []api.ProjectDirectoryImageBuildStepConfiguration{
+		{To: "a"},
+		{To: "b"},
+		{To: "base"},
+		{To: "missing"},
+		{To: "optional", Optional: true},
+	}
+	current := map[string]*imageapi.ImageStream{
+		"ocp/4.20": {
+			Status: imageapi.ImageStreamStatus{
+				Tags: []imageapi.NamedTagEventList{
+					{Tag: "a", Items: []imageapi.TagEvent{{Image: "sha256:aaa"}}},
+					{Tag: "b", Items: []imageapi.TagEvent{{Image: "sha256:old"}}},
+				},
+			},
+		},
+		"ci/a": {
+			Status: imageapi.ImageStreamStatus{
+				Tags: []imageapi.NamedTagEventList{
+					{Tag: "latest", Items: []imageapi.TagEvent{{Image: "sha256:old"}}},
+				},
+			},
+		},
+	}
+	streamFor := func(namespace, name string) (*imageapi.ImageStream, error) {
+		return current[namespace+"/"+name], nil
+	}
+	testCases := []struct {
+		name      string
+		targets   []api.PromotionTarget
+		streamFor func(namespace, name string) (*imageapi.ImageStream, error)
+		err       error
+	}{
+		{
+			name:      "added, updated, unchanged and not built tags",
+			targets:   []api.PromotionTarget{{Namespace: "ocp", Name: "4.20"}},
+			streamFor: streamFor,
+		},
+		{
+			name: "excluded images and additional images",
+			targets: []api.PromotionTarget{{
+				Namespace:        "ocp",
+				Name:             "4.20",
+				ExcludedImages:   []string{"a", "base"},
+				AdditionalImages: map[string]string{"base": "a"},
+			}},
+			streamFor: streamFor,
+		},
+		{
+			name:      "all images excluded",
+			targets:   []api.PromotionTarget{{Namespace: "ocp", Name: "4.20", ExcludedImages: []string{api.PromotionExcludeImageWildcard}}},
+			streamFor: streamFor,
+		},
+		{
+			name: "disabled target and target by tag",
+			targets: []api.PromotionTarget{
+				{Namespace: "ocp", Name: "4.19", Disabled: true},
+				{Namespace: "ci", Tag: "latest", ExcludedImages: []string{"base", "missing"}},
+			},
+			streamFor: streamFor,
+		},
+		{
+			name:    "target image stream cannot be read",
+			targets: []api.PromotionTarget{{Namespace: "ocp", Name: "4.20"}},
+			streamFor: func(namespace, name string) (*imageapi.ImageStream, error) {
+				return nil, errors.New("forbidden")
+			},
+			err: errors.New("forbidden"),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			configuration := &api.ReleaseBuildConfiguration{
+				Images:                 images,
+				PromotionConfiguration: &api.PromotionConfiguration{Targets: tc.targets},
+			}
+			preview, err := promotionPreview(configuration, sets.New[string](), signingPipeline, tc.streamFor)
+			if diff := cmp.Diff(tc.err, err, testhelper.EquateErrorMessage); diff != "" {
+				t.Fatalf("unexpected error: %s", diff)
+			}
+			if err == nil {
+				testhelper.CompareWithFixture(t, preview.Summary(), testhelper.WithExtension(".txt"))
+			}
+		})
+	}
+}
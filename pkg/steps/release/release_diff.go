@@ -0,0 +1,221 @@
+package release
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	imagev1 "github.com/openshift/api/image/v1"
+
+	"github.com/openshift/ci-tools/pkg/api"
+	"github.com/openshift/ci-tools/pkg/api/helper"
+	"github.com/openshift/ci-tools/pkg/junit"
+	"github.com/openshift/ci-tools/pkg/secrets"
+)
+
+const (
+	// ReleaseDiffJSON is the artifact holding the difference between the
+	// initial and latest releases.
+	ReleaseDiffJSON = "release-payload-diff.json"
+	// ReleaseDiffSummary is the artifact holding the human-readable
+	// difference between the initial and latest releases.
+	ReleaseDiffSummary = "release-payload-diff.txt"
+
+	sourceCommitLabel   = "io.openshift.build.commit.id"
+	sourceLocationLabel = "io.openshift.build.source-location"
+)
+
+// ReleaseDiff describes how the components of two releases differ.
+type ReleaseDiff struct {
+	From      string                   `json:"from"`
+	To        string                   `json:"to"`
+	Added     []ReleaseComponent       `json:"added,omitempty"`
+	Removed   []ReleaseComponent       `json:"removed,omitempty"`
+	Changed   []ReleaseComponentChange `json:"changed,omitempty"`
+	Unchanged int                      `json:"unchanged"`
+}
+
+// ReleaseComponent is an image in a release.
+type ReleaseComponent struct {
+	Name           string `json:"name"`
+	Image          string `json:"image"`
+	SourceLocation string `json:"sourceLocation,omitempty"`
+	Commit         string `json:"commit,omitempty"`
+}
+
+// ReleaseComponentChange is a component whose image differs between the
+// releases.  The commits are taken from the labels of the images, so an
+// unchanged commit means the image was rebuilt from the same source.
+type ReleaseComponentChange struct {
+	Name           string `json:"name"`
+	FromImage      string `json:"fromImage"`
+	ToImage        string `json:"toImage"`
+	SourceLocation string `json:"sourceLocation,omitempty"`
+	FromCommit     string `json:"fromCommit,omitempty"`
+	ToCommit       string `json:"toCommit,omitempty"`
+}
+
+// Summary formats the difference for humans.
+func (d ReleaseDiff) Summary() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Release %s differs from release %s in %d components, %d are unchanged.\n", d.To, d.From, len(d.Added)+len(d.Removed)+len(d.Changed), d.Unchanged)
+	component := func(c ReleaseComponent) string {
+		if c.Commit == "" {
+			return fmt.Sprintf("%s %s", c.Name, c.Image)
+		}
+		return fmt.Sprintf("%s %s (%s@%s)", c.Name, c.Image, c.SourceLocation, c.Commit)
+	}
+	for _, c := range d.Added {
+		fmt.Fprintf(&b, "  added    %s\n", component(c))
+	}
+	for _, c := range d.Removed {
+		fmt.Fprintf(&b, "  removed  %s\n", component(c))
+	}
+	for _, c := range d.Changed {
+		fmt.Fprintf(&b, "  replaced %s %s -> %s", c.Name, c.FromImage, c.ToImage)
+		switch {
+		case c.FromCommit == "" || c.ToCommit == "":
+		case c.FromCommit == c.ToCommit:
+			fmt.Fprintf(&b, " (rebuilt from %s@%s)", c.SourceLocation, c.ToCommit)
+		default:
+			fmt.Fprintf(&b, " (%s %s -> %s)", c.SourceLocation, c.FromCommit, c.ToCommit)
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// releaseDiff compares the images tagged into the image streams two releases
+// are assembled from.  labelsFor returns the labels of the image behind a tag
+// of a stream, or nil when they are not known.
+func releaseDiff(from, to string, fromStream, toStream *imagev1.ImageStream, labelsFor func(stream *imagev1.ImageStream, tag string) map[string]string) ReleaseDiff {
+	diff := ReleaseDiff{From: from, To: to}
+	fromTags, toTags := taggedImages(fromStream), taggedImages(toStream)
+	component := func(stream *imagev1.ImageStream, tag, image string) ReleaseComponent {
+		labels := labelsFor(stream, tag)
+		return ReleaseComponent{Name: tag, Image: image, SourceLocation: labels[sourceLocationLabel], Commit: labels[sourceCommitLabel]}
+	}
+	for _, tag := range sets.List(sets.KeySet(toTags).Union(sets.KeySet(fromTags))) {
+		fromImage, inFrom := fromTags[tag]
+		toImage, inTo := toTags[tag]
+		switch {
+		case !inFrom:
+			diff.Added = append(diff.Added, component(toStream, tag, toImage))
+		case !inTo:
+			diff.Removed = append(diff.Removed, component(fromStream, tag, fromImage))
+		case fromImage == toImage:
+			diff.Unchanged++
+		default:
+			fromLabels, toLabels := labelsFor(fromStream, tag), labelsFor(toStream, tag)
+			sourceLocation := toLabels[sourceLocationLabel]
+			if sourceLocation == "" {
+				sourceLocation = fromLabels[sourceLocationLabel]
+			}
+			diff.Changed = append(diff.Changed, ReleaseComponentChange{
+				Name:           tag,
+				FromImage:      fromImage,
+				ToImage:        toImage,
+				SourceLocation: sourceLocation,
+				FromCommit:     fromLabels[sourceCommitLabel],
+				ToCommit:       toLabels[sourceCommitLabel],
+			})
+		}
+	}
+	return diff
+}
+
+// taggedImages maps the tags of the stream to the digests of their images.
+func taggedImages(stream *imagev1.ImageStream) map[string]string {
+	images := map[string]string{}
+	for _, tag := range stream.Status.Tags {
+		if digest := findImageDigest(stream, tag.Tag); digest != "" {
+			images[tag.Tag] = digest
+		}
+	}
+	return images
+}
+
+// ReleaseDiffer compares the initial and latest releases once both exist.
+// It is shared by the steps which assemble the releases, so that the
+// comparison runs once, after the last of them.
+type ReleaseDiffer struct {
+	client  ctrlruntimeclient.Client
+	jobSpec *api.JobSpec
+	censor  *secrets.DynamicCensor
+
+	lock sync.Mutex
+	done bool
+}
+
+func NewReleaseDiffer(client ctrlruntimeclient.Client, jobSpec *api.JobSpec, censor *secrets.DynamicCensor) *ReleaseDiffer {
+	return &ReleaseDiffer{client: client, jobSpec: jobSpec, censor: censor}
+}
+
+// Diff compares the releases if both exist and they were not compared yet,
+// returning the comparison as a test case.  Either release may be assembled
+// last, so every assembly calls it.
+func (d *ReleaseDiffer) Diff(ctx context.Context) (*junit.TestCase, error) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	if d.done {
+		return nil, nil
+	}
+	namespace := d.jobSpec.Namespace()
+	release := &imagev1.ImageStream{}
+	if err := d.client.Get(ctx, ctrlruntimeclient.ObjectKey{Namespace: namespace, Name: api.ReleaseImageStream}, release); err != nil {
+		return nil, fmt.Errorf("could not get the release imagestream: %w", err)
+	}
+	released := taggedImages(release)
+	for _, name := range []string{api.InitialReleaseName, api.LatestReleaseName} {
+		if _, ok := released[name]; !ok {
+			return nil, nil
+		}
+	}
+	d.done = true
+
+	streams := map[string]*imagev1.ImageStream{}
+	for _, name := range []string{api.InitialReleaseName, api.LatestReleaseName} {
+		stream := &imagev1.ImageStream{}
+		if err := d.client.Get(ctx, ctrlruntimeclient.ObjectKey{Namespace: namespace, Name: api.ReleaseStreamFor(name)}, stream); err != nil {
+			return nil, fmt.Errorf("could not get the imagestream of release %s: %w", name, err)
+		}
+		streams[name] = stream
+	}
+	labelsFor := func(stream *imagev1.ImageStream, tag string) map[string]string {
+		isTag := &imagev1.ImageStreamTag{}
+		if err := d.client.Get(ctx, ctrlruntimeclient.ObjectKey{Namespace: namespace, Name: fmt.Sprintf("%s:%s", stream.Name, tag)}, isTag); err != nil {
+			logrus.WithError(err).Debugf("Could not get the imagestreamtag %s:%s.", stream.Name, tag)
+			return nil
+		}
+		labels, err := helper.LabelsOnISTagImage(ctx, d.client, isTag, api.ReleaseArchitectureAMD64)
+		if err != nil {
+			logrus.WithError(err).Debugf("Could not get the labels of the imagestreamtag %s:%s.", stream.Name, tag)
+		}
+		return labels
+	}
+	diff := releaseDiff(api.InitialReleaseName, api.LatestReleaseName, streams[api.InitialReleaseName], streams[api.LatestReleaseName], labelsFor)
+	summary := diff.Summary()
+	logrus.Debugf("Releases differ:\n%s", summary)
+	test := &junit.TestCase{
+		Name:      fmt.Sprintf("Release %s differs from release %s", diff.To, diff.From),
+		SystemOut: summary,
+	}
+	raw, err := json.MarshalIndent(diff, "", "  ")
+	if err != nil {
+		return test, fmt.Errorf("could not marshal the release diff: %w", err)
+	}
+	if err := api.SaveArtifact(d.censor, ReleaseDiffJSON, raw); err != nil {
+		return test, fmt.Errorf("could not write %s: %w", ReleaseDiffJSON, err)
+	}
+	if err := api.SaveArtifact(d.censor, ReleaseDiffSummary, []byte(summary)); err != nil {
+		return test, fmt.Errorf("could not write %s: %w", ReleaseDiffSummary, err)
+	}
+	return test, nil
+}
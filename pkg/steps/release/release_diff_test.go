@@ -0,0 +1,120 @@
+package release
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	fakectrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	imageapi "github.com/openshift/api/image/v1"
+
+	"github.com/openshift/ci-tools/pkg/api"
+	"github.com/openshift/ci-tools/pkg/secrets"
+	"github.com/openshift/ci-tools/pkg/testhelper"
+)
+
+func TestReleaseDiff(t *testing.T) {
+	stream := func(name string, images map[string]string) *imageapi.ImageStream {
+		s := &imageapi.ImageStream{ObjectMeta: meta.ObjectMeta{Name: name}}
+		for _, tag := range []string{"cli", "installer", "machine-os-content", "operator", "tests"} {
+			if image, ok := images[tag]; ok {
+				s.Status.Tags = append(s.Status.Tags, imageapi.NamedTagEventList{Tag: tag, Items: []imageapi.TagEvent{{Image: image}}})
+			}
+		}
+		return s
+	}
+	labels := map[string]map[string]string{
+		"sha256:installer-old": {sourceLocationLabel: "https://github.com/openshift/installer", sourceCommitLabel: "1111"},
+		"sha256:installer-new": {sourceLocationLabel: "https://github.com/openshift/installer", sourceCommitLabel: "2222"},
+		"sha256:operator-old":  {sourceLocationLabel: "https://github.com/openshift/operator", sourceCommitLabel: "3333"},
+		"sha256:operator-new":  {sourceLocationLabel: "https://github.com/openshift/operator", sourceCommitLabel: "3333"},
+		"sha256:tests":         {sourceLocationLabel: "https://github.com/openshift/origin", sourceCommitLabel: "4444"},
+	}
+	labelsFor := func(stream *imageapi.ImageStream, tag string) map[string]string {
+		return labels[findImageDigest(stream, tag)]
+	}
+	testCases := []struct {
+		name          string
+		initial, late *imageapi.ImageStream
+	}{
+		{
+			name:    "identical releases",
+			initial: stream("stable-initial", map[string]string{"cli": "sha256:cli", "installer": "sha256:installer-old"}),
+			late:    stream("stable", map[string]string{"cli": "sha256:cli", "installer": "sha256:installer-old"}),
+		},
+		{
+			name: "added, removed and replaced components",
+			initial: stream("stable-initial", map[string]string{
+				"cli":                "sha256:cli",
+				"installer":          "sha256:installer-old",
+				"machine-os-content": "sha256:rhcos-old",
+				"operator":           "sha256:operator-old",
+			}),
+			late: stream("stable", map[string]string{
+				"cli":                "sha256:cli",
+				"installer":          "sha256:installer-new",
+				"machine-os-content": "sha256:rhcos-new",
+				"operator":           "sha256:operator-new",
+				"tests":              "sha256:tests",
+			}),
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			diff := releaseDiff("initial", "latest", tc.initial, tc.late, labelsFor)
+			testhelper.CompareWithFixture(t, diff)
+			testhelper.CompareWithFixture(t, diff.Summary(), testhelper.WithPrefix("summary_"), testhelper.WithExtension(".txt"))
+		})
+	}
+}
+
+func TestReleaseDifferDiffsOnce(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := imageapi.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to add imagev1 to scheme: %v", err)
+	}
+	stream := func(name string, images map[string]string) *imageapi.ImageStream {
+		s := &imageapi.ImageStream{ObjectMeta: meta.ObjectMeta{Namespace: "ns", Name: name}}
+		for _, tag := range []string{"initial", "latest", "cli"} {
+			if image, ok := images[tag]; ok {
+				s.Status.Tags = append(s.Status.Tags, imageapi.NamedTagEventList{Tag: tag, Items: []imageapi.TagEvent{{Image: image}}})
+			}
+		}
+		return s
+	}
+	release := stream(api.ReleaseImageStream, map[string]string{"initial": "sha256:initial"})
+	client := fakectrlruntimeclient.NewClientBuilder().WithScheme(scheme).WithObjects(
+		release,
+		stream("stable-initial", map[string]string{"cli": "sha256:cli-old"}),
+		stream("stable", map[string]string{"cli": "sha256:cli-new"}),
+	).Build()
+	jobSpec := &api.JobSpec{}
+	jobSpec.SetNamespace("ns")
+	artifacts := t.TempDir()
+	t.Setenv("ARTIFACTS", artifacts)
+	censor := secrets.NewDynamicCensor()
+	differ := NewReleaseDiffer(client, jobSpec, &censor)
+	ctx := context.Background()
+
+	test, err := differ.Diff(ctx)
+	if err != nil || test != nil {
+		t.Fatalf("expected no comparison before both releases exist, got %v, %v", test, err)
+	}
+	release.Status.Tags = stream(api.ReleaseImageStream, map[string]string{"initial": "sha256:initial", "latest": "sha256:latest"}).Status.Tags
+	if err := client.Update(ctx, release); err != nil {
+		t.Fatalf("failed to update the release stream: %v", err)
+	}
+	if test, err = differ.Diff(ctx); err != nil || test == nil {
+		t.Fatalf("expected a comparison once both releases exist, got %v, %v", test, err)
+	}
+	if _, err := os.Stat(filepath.Join(artifacts, ReleaseDiffJSON)); err != nil {
+		t.Errorf("expected the comparison to be saved: %v", err)
+	}
+	if test, err = differ.Diff(ctx); err != nil || test != nil {
+		t.Errorf("expected the releases to be compared once, got %v, %v", test, err)
+	}
+}
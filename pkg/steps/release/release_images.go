@@ -44,7 +44,20 @@ func (s *stableImagesTagStep) Run(ctx context.Context) error {
 func (s *stableImagesTagStep) run(ctx context.Context) error {
 	logrus.Infof("Will output images to %s:%s", api.StableImageStream, api.ComponentFormatReplacement)
 
-	newIS := &imagev1.ImageStream{
+	if _, err := util.CreateImageStreamWithMetrics(ctx, s.client, s.imageStream(), s.client.MetricsAgent()); err != nil {
+		return fmt.Errorf("could not create stable imagestream: %w", err)
+	}
+
+	return nil
+}
+
+// RenderObjects generates the stable image stream without creating it.
+func (s *stableImagesTagStep) RenderObjects() ([]ctrlruntimeclient.Object, error) {
+	return []ctrlruntimeclient.Object{s.imageStream()}, nil
+}
+
+func (s *stableImagesTagStep) imageStream() *imagev1.ImageStream {
+	return &imagev1.ImageStream{
 		ObjectMeta: meta.ObjectMeta{
 			Namespace: s.jobSpec.Namespace(),
 			Name:      api.StableImageStream,
@@ -55,11 +68,6 @@ func (s *stableImagesTagStep) run(ctx context.Context) error {
 			},
 		},
 	}
-	if _, err := util.CreateImageStreamWithMetrics(ctx, s.client, newIS, s.client.MetricsAgent()); err != nil {
-		return fmt.Errorf("could not create stable imagestream: %w", err)
-	}
-
-	return nil
 }
 
 func (s *stableImagesTagStep) Inputs() (api.InputDefinition, error) {
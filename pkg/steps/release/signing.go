@@ -0,0 +1,320 @@
+package release
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+
+	coreapi "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/utils/ptr"
+	prowapi "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+
+	imagev1 "github.com/openshift/api/image/v1"
+
+	"github.com/openshift/ci-tools/pkg/api"
+	"github.com/openshift/ci-tools/pkg/steps"
+)
+
+const (
+	// ProvenanceBuilderID identifies ci-operator as the builder in the
+	// provenance of promoted images.
+	ProvenanceBuilderID = "https://github.com/openshift/ci-tools/tree/main/cmd/ci-operator"
+	// ProvenanceBuildType describes how ci-operator builds images.
+	ProvenanceBuildType = "https://github.com/openshift/ci-tools/tree/main/pkg/steps/project_image.go"
+
+	signingKeyMountPath   = "/etc/signing-key"
+	provenanceMountPath   = "/etc/provenance"
+	dockerConfigMountPath = "/etc/docker"
+)
+
+// Signing configures signing of promoted images.  Images are signed with the
+// key in the api.PromotionSigningKeySecret secret unless LocalKey is set.
+type Signing struct {
+	// LocalKey signs with a key pair generated for the job, which is only
+	// useful to test the signing.  The public key is written to the logs.
+	LocalKey bool
+	// Image runs cosign, steps.DefaultCosignImage is used when unset.
+	Image string
+}
+
+// signingSubject is an image to sign in the repository it is promoted to.
+type signingSubject struct {
+	repository string
+	digest     string
+	// source is the tag in the pipeline image stream, which names the
+	// provenance of the image
+	source string
+}
+
+func (s signingSubject) reference() string {
+	return fmt.Sprintf("%s@%s", s.repository, s.digest)
+}
+
+// signingSubjects returns the images to sign for the promoted tags, once per
+// repository they are promoted to.
+func signingSubjects(tags map[string][]api.ImageStreamTagReference, pipeline *imagev1.ImageStream, registry string, targetNameFunc func(string, api.PromotionTarget) string) []signingSubject {
+	seen := sets.New[string]()
+	var subjects []signingSubject
+	for src, dsts := range tags {
+		digest := findImageDigest(pipeline, src)
+		if digest == "" {
+			continue
+		}
+		for _, dst := range dsts {
+			subject := signingSubject{repository: repositoryOf(promotionTargetFor(registry, dst, targetNameFunc)), digest: digest, source: src}
+			if seen.Has(subject.reference()) {
+				continue
+			}
+			seen.Insert(subject.reference())
+			subjects = append(subjects, subject)
+		}
+	}
+	sort.Slice(subjects, func(i, j int) bool {
+		return subjects[i].reference() < subjects[j].reference()
+	})
+	return subjects
+}
+
+// repositoryOf strips the tag from an image reference.
+func repositoryOf(target string) string {
+	if i := strings.LastIndex(target, ":"); i > strings.LastIndex(target, "/") {
+		return target[:i]
+	}
+	return target
+}
+
+// sign signs the promoted images and attests their provenance.
+func (s *promotionStep) sign(ctx context.Context, tags map[string][]api.ImageStreamTagReference, pipeline *imagev1.ImageStream) error {
+	subjects := signingSubjects(tags, pipeline, s.registry, s.targetNameFunc)
+	if len(subjects) == 0 {
+		return nil
+	}
+	provenance := &coreapi.ConfigMap{
+		ObjectMeta: meta.ObjectMeta{
+			Name:      fmt.Sprintf("%s-provenance", s.name),
+			Namespace: s.jobSpec.Namespace(),
+		},
+		Data: map[string]string{},
+	}
+	for _, subject := range subjects {
+		if _, ok := provenance.Data[subject.source]; ok {
+			continue
+		}
+		raw, err := json.MarshalIndent(provenanceFor(s.jobSpec, s.configuration, pipeline, subject.source), "", "  ")
+		if err != nil {
+			return fmt.Errorf("could not marshal provenance of %s: %w", subject.source, err)
+		}
+		provenance.Data[subject.source] = string(raw)
+	}
+	if err := s.client.Create(ctx, provenance); kerrors.IsAlreadyExists(err) {
+		err = s.client.Update(ctx, provenance)
+		if err != nil {
+			return fmt.Errorf("could not update provenance: %w", err)
+		}
+	} else if err != nil {
+		return fmt.Errorf("could not create provenance: %w", err)
+	}
+	logrus.WithField("name", s.name).Infof("Signing %d promoted images", len(subjects))
+	if _, err := steps.RunPod(ctx, s.client, getSigningPod(subjects, s.jobSpec.Namespace(), s.name, provenance.Name, s.signing), false); err != nil {
+		return fmt.Errorf("unable to run signing pod: %w", err)
+	}
+	return nil
+}
+
+// getSigningPod signs the images in an init container, once the key is in
+// place, and attests the provenance of each image in a container of its own.
+// The cosign image has no shell, so the containers run cosign directly.
+func getSigningPod(subjects []signingSubject, namespace, name, provenance string, signing *Signing) *coreapi.Pod {
+	image := signing.Image
+	if image == "" {
+		image = steps.DefaultCosignImage
+	}
+	volumes := []coreapi.Volume{
+		{
+			Name: "push-secret",
+			VolumeSource: coreapi.VolumeSource{
+				Secret: &coreapi.SecretVolumeSource{
+					SecretName: api.RegistryPushCredentialsCICentralSecret,
+					Items:      []coreapi.KeyToPath{{Key: coreapi.DockerConfigJsonKey, Path: "config.json"}},
+				},
+			},
+		},
+		{
+			Name: "provenance",
+			VolumeSource: coreapi.VolumeSource{
+				ConfigMap: &coreapi.ConfigMapVolumeSource{LocalObjectReference: coreapi.LocalObjectReference{Name: provenance}},
+			},
+		},
+	}
+	mounts := []coreapi.VolumeMount{
+		{Name: "push-secret", MountPath: dockerConfigMountPath, ReadOnly: true},
+		{Name: "provenance", MountPath: provenanceMountPath, ReadOnly: true},
+		{Name: "signing-key", MountPath: signingKeyMountPath},
+	}
+	env := []coreapi.EnvVar{{Name: "DOCKER_CONFIG", Value: dockerConfigMountPath}}
+	key := filepath.Join(signingKeyMountPath, api.PromotionSigningKeyKey)
+	container := func(name string, args ...string) coreapi.Container {
+		return coreapi.Container{
+			Name:                     name,
+			Image:                    image,
+			Command:                  []string{"cosign"},
+			Args:                     args,
+			Env:                      env,
+			VolumeMounts:             mounts,
+			TerminationMessagePolicy: coreapi.TerminationMessageFallbackToLogsOnError,
+		}
+	}
+	var initContainers []coreapi.Container
+	if signing.LocalKey {
+		env = append(env, coreapi.EnvVar{Name: "COSIGN_PASSWORD", Value: ""})
+		volumes = append(volumes, coreapi.Volume{
+			Name:         "signing-key",
+			VolumeSource: coreapi.VolumeSource{EmptyDir: &coreapi.EmptyDirVolumeSource{}},
+		})
+		// the public key is written to the logs of the public-key container
+		initContainers = append(initContainers,
+			container("generate-key", "generate-key-pair", "--output-key-prefix", strings.TrimSuffix(key, filepath.Ext(key))),
+			container("public-key", "public-key", "--key", key),
+		)
+	} else {
+		env = append(env, coreapi.EnvVar{Name: "COSIGN_PASSWORD", ValueFrom: &coreapi.EnvVarSource{
+			SecretKeyRef: &coreapi.SecretKeySelector{
+				LocalObjectReference: coreapi.LocalObjectReference{Name: api.PromotionSigningKeySecret},
+				Key:                  api.PromotionSigningKeyPasswordKey,
+				Optional:             ptr.To(true),
+			},
+		}})
+		volumes = append(volumes, coreapi.Volume{
+			Name: "signing-key",
+			VolumeSource: coreapi.VolumeSource{
+				Secret: &coreapi.SecretVolumeSource{SecretName: api.PromotionSigningKeySecret},
+			},
+		})
+	}
+	sign := []string{"sign", "--yes", "--tlog-upload=false", "--key", key}
+	var containers []coreapi.Container
+	for i, subject := range subjects {
+		sign = append(sign, subject.reference())
+		containers = append(containers, container(fmt.Sprintf("attest-%d", i), "attest", "--yes", "--tlog-upload=false", "--key", key, "--type", "slsaprovenance", "--predicate", filepath.Join(provenanceMountPath, subject.source), subject.reference()))
+	}
+	initContainers = append(initContainers, container("sign", sign...))
+
+	return &coreapi.Pod{
+		ObjectMeta: meta.ObjectMeta{
+			Name:      fmt.Sprintf("%s-signing", name),
+			Namespace: namespace,
+			Labels:    map[string]string{steps.AnnotationSaveContainerLogs: "true"},
+		},
+		Spec: coreapi.PodSpec{
+			RestartPolicy:  coreapi.RestartPolicyNever,
+			InitContainers: initContainers,
+			Containers:     containers,
+			Volumes:        volumes,
+		},
+	}
+}
+
+// provenance is a SLSA v0.2 provenance predicate, which cosign attests with
+// the slsaprovenance type.
+type provenance struct {
+	Builder    provenanceBuilder    `json:"builder"`
+	BuildType  string               `json:"buildType"`
+	Invocation provenanceInvocation `json:"invocation"`
+	Metadata   provenanceMetadata   `json:"metadata"`
+	Materials  []provenanceMaterial `json:"materials,omitempty"`
+}
+
+type provenanceBuilder struct {
+	ID string `json:"id"`
+}
+
+type provenanceInvocation struct {
+	ConfigSource provenanceMaterial `json:"configSource"`
+	Parameters   map[string]string  `json:"parameters,omitempty"`
+	Environment  map[string]string  `json:"environment,omitempty"`
+}
+
+type provenanceMetadata struct {
+	BuildInvocationID string `json:"buildInvocationId"`
+	Reproducible      bool   `json:"reproducible"`
+}
+
+type provenanceMaterial struct {
+	URI        string            `json:"uri"`
+	Digest     map[string]string `json:"digest,omitempty"`
+	EntryPoint string            `json:"entryPoint,omitempty"`
+}
+
+// provenanceFor describes how the image behind a tag of the pipeline image
+// stream was built: from which revisions of the repositories under test, by
+// which job and from which images.
+func provenanceFor(jobSpec *api.JobSpec, configuration *api.ReleaseBuildConfiguration, pipeline *imagev1.ImageStream, tag string) provenance {
+	p := provenance{
+		Builder:   provenanceBuilder{ID: ProvenanceBuilderID},
+		BuildType: ProvenanceBuildType,
+		Invocation: provenanceInvocation{
+			Parameters: map[string]string{"image": tag},
+			Environment: map[string]string{
+				"job":       jobSpec.Job,
+				"type":      string(jobSpec.Type),
+				"buildID":   jobSpec.BuildID,
+				"prowJobID": jobSpec.ProwJobID,
+			},
+		},
+		Metadata: provenanceMetadata{BuildInvocationID: jobSpec.ProwJobID},
+	}
+	var refs []prowapi.Refs
+	if jobSpec.Refs != nil {
+		refs = append(refs, *jobSpec.Refs)
+	}
+	refs = append(refs, jobSpec.ExtraRefs...)
+	for i, ref := range refs {
+		material := provenanceMaterial{
+			URI:    fmt.Sprintf("git+https://github.com/%s/%s@refs/heads/%s", ref.Org, ref.Repo, ref.BaseRef),
+			Digest: map[string]string{"sha1": ref.BaseSHA},
+		}
+		if i == 0 {
+			p.Invocation.ConfigSource = material
+			p.Invocation.ConfigSource.EntryPoint = jobSpec.Job
+		}
+		p.Materials = append(p.Materials, material)
+		for _, pull := range ref.Pulls {
+			p.Materials = append(p.Materials, provenanceMaterial{
+				URI:    fmt.Sprintf("git+https://github.com/%s/%s@refs/pull/%d/head", ref.Org, ref.Repo, pull.Number),
+				Digest: map[string]string{"sha1": pull.SHA},
+			})
+		}
+	}
+	inputs := sets.New[string]()
+	for _, image := range configuration.Images {
+		if string(image.To) != tag {
+			continue
+		}
+		if image.From != "" {
+			inputs.Insert(string(image.From))
+		}
+		for input := range image.Inputs {
+			inputs.Insert(input)
+		}
+	}
+	for _, input := range sets.List(inputs) {
+		digest := findImageDigest(pipeline, input)
+		if digest == "" {
+			continue
+		}
+		algorithm, hex, _ := strings.Cut(digest, ":")
+		p.Materials = append(p.Materials, provenanceMaterial{
+			URI:    getPublicImageReference(findDockerImageReference(pipeline, input), pipeline.Status.PublicDockerImageRepository),
+			Digest: map[string]string{algorithm: hex},
+		})
+	}
+	return p
+}
@@ -0,0 +1,108 @@
+package release
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	prowapi "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+	"sigs.k8s.io/prow/pkg/pod-utils/downwardapi"
+
+	imageapi "github.com/openshift/api/image/v1"
+
+	"github.com/openshift/ci-tools/pkg/api"
+	"github.com/openshift/ci-tools/pkg/testhelper"
+)
+
+var signingPipeline = &imageapi.ImageStream{
+	Status: imageapi.ImageStreamStatus{
+		PublicDockerImageRepository: "registry.build01.ci.openshift.org/ci-op-y2n8rsh3/pipeline",
+		Tags: []imageapi.NamedTagEventList{
+			{
+				Tag:   "a",
+				Items: []imageapi.TagEvent{{Image: "sha256:aaa", DockerImageReference: "image-registry.openshift-image-registry.svc:5000/ci-op-y2n8rsh3/pipeline@sha256:aaa"}},
+			},
+			{
+				Tag:   "b",
+				Items: []imageapi.TagEvent{{Image: "sha256:bbb", DockerImageReference: "image-registry.openshift-image-registry.svc:5000/ci-op-y2n8rsh3/pipeline@sha256:bbb"}},
+			},
+			{
+				Tag:   "base",
+				Items: []imageapi.TagEvent{{Image: "sha256:fff", DockerImageReference: "image-registry.openshift-image-registry.svc:5000/ci-op-y2n8rsh3/pipeline@sha256:fff"}},
+			},
+		},
+	},
+}
+
+func TestSigningSubjects(t *testing.T) {
+	tags := map[string][]api.ImageStreamTagReference{
+		"a": {
+			{Namespace: "ocp", Name: "4.20", Tag: "a"},
+			{Namespace: "ocp", Name: "4.20", Tag: "a-also"},
+			{Namespace: "ci", Name: "a", Tag: "latest"},
+		},
+		"b":       {{Namespace: "ocp", Name: "4.20", Tag: "b"}},
+		"missing": {{Namespace: "ocp", Name: "4.20", Tag: "missing"}},
+	}
+	expected := []signingSubject{
+		{repository: "registry.ci.openshift.org/ci/a", digest: "sha256:aaa", source: "a"},
+		{repository: "registry.ci.openshift.org/ocp/4.20", digest: "sha256:aaa", source: "a"},
+		{repository: "registry.ci.openshift.org/ocp/4.20", digest: "sha256:bbb", source: "b"},
+	}
+	actual := signingSubjects(tags, signingPipeline, "registry.ci.openshift.org", api.DefaultTargetNameFunc)
+	if diff := cmp.Diff(expected, actual, cmp.AllowUnexported(signingSubject{})); diff != "" {
+		t.Errorf("unexpected subjects: %s", diff)
+	}
+}
+
+func TestGetSigningPod(t *testing.T) {
+	subjects := []signingSubject{
+		{repository: "registry.ci.openshift.org/ocp/4.20", digest: "sha256:aaa", source: "a"},
+		{repository: "registry.ci.openshift.org/ocp/4.20", digest: "sha256:bbb", source: "b"},
+	}
+	for _, tc := range []struct {
+		name    string
+		signing *Signing
+	}{
+		{name: "key", signing: &Signing{}},
+		{name: "local key", signing: &Signing{LocalKey: true}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			testhelper.CompareWithFixture(t, getSigningPod(subjects, "ci-op-y2n8rsh3", "promotion", "promotion-provenance", tc.signing))
+		})
+	}
+}
+
+func TestProvenanceFor(t *testing.T) {
+	jobSpec := &api.JobSpec{
+		JobSpec: downwardapi.JobSpec{
+			Type:      prowapi.PostsubmitJob,
+			Job:       "branch-ci-org-repo-master-images",
+			BuildID:   "1234",
+			ProwJobID: "prowjob",
+			Refs: &prowapi.Refs{
+				Org:     "org",
+				Repo:    "repo",
+				BaseRef: "master",
+				BaseSHA: "e0f1",
+			},
+			ExtraRefs: []prowapi.Refs{{
+				Org:     "org",
+				Repo:    "other",
+				BaseRef: "main",
+				BaseSHA: "a2b3",
+				Pulls:   []prowapi.Pull{{Number: 12, SHA: "c4d5"}},
+			}},
+		},
+	}
+	configuration := &api.ReleaseBuildConfiguration{
+		Images: []api.ProjectDirectoryImageBuildStepConfiguration{{
+			From: "base",
+			To:   "a",
+			ProjectDirectoryImageBuildInputs: api.ProjectDirectoryImageBuildInputs{
+				Inputs: map[string]api.ImageBuildInputs{"b": {As: []string{"builder"}}},
+			},
+		}},
+	}
+	testhelper.CompareWithFixture(t, provenanceFor(jobSpec, configuration, signingPipeline, "a"))
+}
@@ -0,0 +1,298 @@
+package steps
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"path"
+	"sort"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	coreapi "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	prowapi "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+	"sigs.k8s.io/prow/pkg/gcsupload"
+
+	imagev1 "github.com/openshift/api/image/v1"
+
+	"github.com/openshift/ci-tools/pkg/api"
+	"github.com/openshift/ci-tools/pkg/junit"
+	"github.com/openshift/ci-tools/pkg/steps/utils"
+)
+
+const (
+	// ResultCacheLabel marks the config maps recording passing results.
+	ResultCacheLabel = "ci.openshift.io/test-result-cache"
+
+	resultCacheTestKey     = "test"
+	resultCacheJobURLKey   = "job-url"
+	resultCachePassedAtKey = "passed-at"
+)
+
+// cachedResult is a passing result recorded by a previous execution.
+type cachedResult struct {
+	jobURL   string
+	passedAt time.Time
+}
+
+// resultCacheStep wraps a test and reuses a recent passing result of a test
+// with the same configuration and images instead of running it again.
+type resultCacheStep struct {
+	config    api.TestStepConfiguration
+	release   *api.ReleaseBuildConfiguration
+	wrapped   api.Step
+	client    ctrlruntimeclient.Client
+	namespace string
+	jobSpec   *api.JobSpec
+	now       func() time.Time
+
+	cached *cachedResult
+}
+
+// ResultCacheStep wraps a test so that passing results are recorded in, and
+// reused from, config maps in the given namespace.
+func ResultCacheStep(config api.TestStepConfiguration, release *api.ReleaseBuildConfiguration, wrapped api.Step, client ctrlruntimeclient.Client, namespace string, jobSpec *api.JobSpec) api.Step {
+	return &resultCacheStep{
+		config:    config,
+		release:   release,
+		wrapped:   wrapped,
+		client:    client,
+		namespace: namespace,
+		jobSpec:   jobSpec,
+		now:       time.Now,
+	}
+}
+
+func (s *resultCacheStep) Inputs() (api.InputDefinition, error) {
+	return s.wrapped.Inputs()
+}
+
+func (s *resultCacheStep) Validate() error                     { return s.wrapped.Validate() }
+func (s *resultCacheStep) Name() string                        { return s.wrapped.Name() }
+func (s *resultCacheStep) Description() string                 { return s.wrapped.Description() }
+func (s *resultCacheStep) Requires() []api.StepLink            { return s.wrapped.Requires() }
+func (s *resultCacheStep) Creates() []api.StepLink             { return s.wrapped.Creates() }
+func (s *resultCacheStep) Provides() api.ParameterMap          { return s.wrapped.Provides() }
+func (s *resultCacheStep) Objects() []ctrlruntimeclient.Object { return s.wrapped.Objects() }
+
+func (s *resultCacheStep) SubTests() []*junit.TestCase {
+	if s.cached != nil {
+		return []*junit.TestCase{{
+			Name:      s.Description(),
+			SystemOut: fmt.Sprintf("Reused the result of %s, which passed at %s.", s.cached.jobURL, s.cached.passedAt.Format(time.RFC3339)),
+		}}
+	}
+	if subTests, ok := s.wrapped.(SubtestReporter); ok {
+		return subTests.SubTests()
+	}
+	return nil
+}
+
+// Run reuses a recorded result when one is found. Failing to use the cache
+// never fails the test, which is then executed as usual.
+func (s *resultCacheStep) Run(ctx context.Context) error {
+	key, err := s.key(ctx)
+	if err != nil {
+		logrus.WithError(err).Warnf("Could not determine the inputs of %s, it will not reuse results.", s.Name())
+		return s.wrapped.Run(ctx)
+	}
+	result, err := s.lookup(ctx, key)
+	if err != nil {
+		logrus.WithError(err).Warnf("Could not look up the results of %s.", s.Name())
+	}
+	if result != nil {
+		logrus.Infof("Reusing the result of %s, which passed at %s with identical inputs.", result.jobURL, result.passedAt.Format(time.RFC3339))
+		s.cached = result
+		return nil
+	}
+	if err := s.wrapped.Run(ctx); err != nil {
+		return err
+	}
+	if err := s.record(ctx, key); err != nil {
+		logrus.WithError(err).Warnf("Could not record the result of %s.", s.Name())
+	}
+	return nil
+}
+
+// key hashes the configuration of the test and the digests of the images it
+// uses. The refs under test are not part of it: changes which do not affect
+// any of the images do not affect the result.
+func (s *resultCacheStep) key(ctx context.Context) (string, error) {
+	config, err := json.Marshal(struct {
+		Metadata api.Metadata              `json:"metadata"`
+		Test     api.TestStepConfiguration `json:"test"`
+	}{Metadata: s.release.Metadata, Test: s.config})
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal the test: %w", err)
+	}
+	digests, err := s.digests(ctx)
+	if err != nil {
+		return "", err
+	}
+	hash := sha256.New()
+	hash.Write(config)
+	for _, digest := range digests {
+		hash.Write([]byte(digest))
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// digests resolves the images the test runs, sorted by name.
+func (s *resultCacheStep) digests(ctx context.Context) ([]string, error) {
+	streams := map[string]*imagev1.ImageStream{}
+	getStream := func(name string) (*imagev1.ImageStream, error) {
+		if stream, ok := streams[name]; ok {
+			return stream, nil
+		}
+		stream := &imagev1.ImageStream{}
+		if err := s.client.Get(ctx, ctrlruntimeclient.ObjectKey{Namespace: s.jobSpec.Namespace(), Name: name}, stream); err != nil {
+			if !kerrors.IsNotFound(err) {
+				return nil, fmt.Errorf("could not get image stream %s: %w", name, err)
+			}
+			stream = nil
+		}
+		streams[name] = stream
+		return stream, nil
+	}
+
+	var ret []string
+	for _, image := range s.images() {
+		stream, err := getStream(image.Name)
+		if err != nil {
+			return nil, err
+		}
+		var digest string
+		if stream != nil {
+			_, digest = utils.FindStatusTag(stream, image.Tag)
+		}
+		if digest == "" {
+			return nil, fmt.Errorf("could not resolve the digest of %s:%s", image.Name, image.Tag)
+		}
+		ret = append(ret, fmt.Sprintf("%s:%s@%s", image.Name, image.Tag, digest))
+	}
+	if s.config.MultiStageTestConfigurationLiteral != nil {
+		// release payloads are passed to steps as parameters, so they are
+		// inputs even when no step runs an image from them
+		releases, err := getStream(api.ReleaseImageStream)
+		if err != nil {
+			return nil, err
+		}
+		if releases != nil {
+			for _, tag := range releases.Status.Tags {
+				if len(tag.Items) != 0 {
+					ret = append(ret, fmt.Sprintf("%s:%s@%s", api.ReleaseImageStream, tag.Tag, tag.Items[0].Image))
+				}
+			}
+		}
+	}
+	sort.Strings(ret)
+	return ret, nil
+}
+
+// images lists the image stream tags the test runs.
+func (s *resultCacheStep) images() []api.ImageStreamTagReference {
+	var ret []api.ImageStreamTagReference
+	if test := s.config.ContainerTestConfiguration; test != nil {
+		ret = append(ret, api.ImageStreamTagReference{Name: api.PipelineImageStream, Tag: string(test.From)})
+	}
+	if test := s.config.MultiStageTestConfigurationLiteral; test != nil {
+		for _, step := range append(append(append([]api.LiteralTestStep{}, test.Pre...), test.Test...), test.Post...) {
+			if link, ok := step.FromImageTag(); ok {
+				ret = append(ret, api.ImageStreamTagReference{Name: api.PipelineImageStream, Tag: string(link)})
+			} else {
+				stream, tag, _ := s.release.DependencyParts(api.StepDependency{Name: step.From}, nil)
+				ret = append(ret, api.ImageStreamTagReference{Name: stream, Tag: tag})
+			}
+			for _, dependency := range step.Dependencies {
+				if dependency.PullSpec != "" {
+					continue
+				}
+				stream, tag, _ := s.release.DependencyParts(dependency, nil)
+				ret = append(ret, api.ImageStreamTagReference{Name: stream, Tag: tag})
+			}
+		}
+	}
+	return ret
+}
+
+func resultCacheName(key string) string {
+	return "test-result-" + key
+}
+
+// lookup returns the recorded result for the key, unless it is too old.
+func (s *resultCacheStep) lookup(ctx context.Context, key string) (*cachedResult, error) {
+	cm := &coreapi.ConfigMap{}
+	if err := s.client.Get(ctx, ctrlruntimeclient.ObjectKey{Namespace: s.namespace, Name: resultCacheName(key)}, cm); err != nil {
+		if kerrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("could not get the recorded result: %w", err)
+	}
+	passedAt, err := time.Parse(time.RFC3339, cm.Data[resultCachePassedAtKey])
+	if err != nil {
+		return nil, fmt.Errorf("recorded result has an invalid time: %w", err)
+	}
+	if s.now().Sub(passedAt) > s.config.ResultCache.MaxAgeOrDefault() {
+		return nil, nil
+	}
+	return &cachedResult{jobURL: cm.Data[resultCacheJobURLKey], passedAt: passedAt}, nil
+}
+
+// record stores the passing result of this execution for the key,
+// replacing any older one.
+func (s *resultCacheStep) record(ctx context.Context, key string) error {
+	data := map[string]string{
+		resultCacheTestKey:     s.config.As,
+		resultCacheJobURLKey:   jobURL(s.jobSpec),
+		resultCachePassedAtKey: s.now().UTC().Format(time.RFC3339),
+	}
+	cm := &coreapi.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: s.namespace,
+			Name:      resultCacheName(key),
+			Labels:    map[string]string{ResultCacheLabel: "true"},
+		},
+		Data: data,
+	}
+	err := s.client.Create(ctx, cm)
+	if !kerrors.IsAlreadyExists(err) {
+		return err
+	}
+	if err := s.client.Get(ctx, ctrlruntimeclient.ObjectKeyFromObject(cm), cm); err != nil {
+		return err
+	}
+	cm.Data = data
+	return s.client.Update(ctx, cm)
+}
+
+// jobURL links to the results of the job, in the same way Prow does when the
+// job has a URL prefix configured. Otherwise, the job is identified by its
+// name and build.
+func jobURL(jobSpec *api.JobSpec) string {
+	fallback := fmt.Sprintf("%s #%s", jobSpec.Job, jobSpec.BuildID)
+	if jobSpec.DecorationConfig == nil || jobSpec.DecorationConfig.GCSConfiguration == nil {
+		return fallback
+	}
+	gcsConfig := jobSpec.DecorationConfig.GCSConfiguration
+	if gcsConfig.JobURLPrefix == "" {
+		return fallback
+	}
+	prefix, err := url.Parse(gcsConfig.JobURLPrefix)
+	if err != nil {
+		return fallback
+	}
+	prowPath, err := prowapi.ParsePath(gcsConfig.Bucket)
+	if err != nil {
+		return fallback
+	}
+	_, gcsPath, _ := gcsupload.PathsForJob(gcsConfig, &jobSpec.JobSpec, "")
+	prefix.Path = path.Join(prefix.Path, prowPath.StorageProvider(), prowPath.FullPath(), gcsPath)
+	return prefix.String()
+}
@@ -0,0 +1,179 @@
+package steps
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	coreapi "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client"
+	fakectrlruntimeclient "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	prowapi "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+	"sigs.k8s.io/prow/pkg/pod-utils/downwardapi"
+
+	imagev1 "github.com/openshift/api/image/v1"
+
+	"github.com/openshift/ci-tools/pkg/api"
+)
+
+func TestResultCacheStep(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	pipeline := func(digest string) *imagev1.ImageStream {
+		return &imagev1.ImageStream{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ci-op-test", Name: api.PipelineImageStream},
+			Status: imagev1.ImageStreamStatus{Tags: []imagev1.NamedTagEventList{{
+				Tag:   "src",
+				Items: []imagev1.TagEvent{{Image: digest}},
+			}}},
+		}
+	}
+	recorded := func(passedAt time.Time) map[string]string {
+		return map[string]string{
+			resultCacheTestKey:     "unit",
+			resultCacheJobURLKey:   "https://prow.example.com/view/gs/bucket/logs/old/1",
+			resultCachePassedAtKey: passedAt.Format(time.RFC3339),
+		}
+	}
+	for _, tc := range []struct {
+		name         string
+		digest       string
+		recorded     map[string]string
+		fail         bool
+		expectedRan  bool
+		expectedData map[string]string
+		expectedOut  string
+	}{
+		{
+			name:        "no recorded result runs the test and records it",
+			digest:      "sha256:src",
+			expectedRan: true,
+			expectedData: map[string]string{
+				resultCacheTestKey:     "unit",
+				resultCacheJobURLKey:   "https://prow.example.com/view/gs/bucket/logs/job/2",
+				resultCachePassedAtKey: "2024-05-01T12:00:00Z",
+			},
+		},
+		{
+			name:         "recent recorded result is reused",
+			digest:       "sha256:src",
+			recorded:     recorded(now.Add(-time.Hour)),
+			expectedData: recorded(now.Add(-time.Hour)),
+			expectedOut:  "Reused the result of https://prow.example.com/view/gs/bucket/logs/old/1, which passed at 2024-05-01T11:00:00Z.",
+		},
+		{
+			name:        "expired result runs the test and replaces it",
+			digest:      "sha256:src",
+			recorded:    recorded(now.Add(-3 * time.Hour)),
+			expectedRan: true,
+			expectedData: map[string]string{
+				resultCacheTestKey:     "unit",
+				resultCacheJobURLKey:   "https://prow.example.com/view/gs/bucket/logs/job/2",
+				resultCachePassedAtKey: "2024-05-01T12:00:00Z",
+			},
+		},
+		{
+			name:         "failed test is not recorded",
+			digest:       "sha256:src",
+			fail:         true,
+			expectedRan:  true,
+			expectedData: nil,
+		},
+		{
+			name:        "unresolved image runs the test without recording it",
+			expectedRan: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			jobSpec := &api.JobSpec{JobSpec: downwardapi.JobSpec{
+				Job:     "job",
+				BuildID: "2",
+				Type:    prowapi.PeriodicJob,
+				DecorationConfig: &prowapi.DecorationConfig{GCSConfiguration: &prowapi.GCSConfiguration{
+					Bucket:       "bucket",
+					PathStrategy: prowapi.PathStrategyExplicit,
+					JobURLPrefix: "https://prow.example.com/view/",
+				}},
+			}}
+			jobSpec.SetNamespace("ci-op-test")
+			config := api.TestStepConfiguration{
+				As:                         "unit",
+				ResultCache:                &api.TestResultCache{MaxAge: &prowapi.Duration{Duration: 2 * time.Hour}},
+				ContainerTestConfiguration: &api.ContainerTestConfiguration{From: "src"},
+			}
+			release := &api.ReleaseBuildConfiguration{Metadata: api.Metadata{Org: "org", Repo: "repo", Branch: "main"}}
+			client := fakectrlruntimeclient.NewClientBuilder().WithObjects(pipeline(tc.digest)).Build()
+			wrapped := &stepNeedsLease{fail: tc.fail}
+			step := ResultCacheStep(config, release, wrapped, client, "ci", jobSpec).(*resultCacheStep)
+			step.now = func() time.Time { return now }
+
+			var name string
+			if tc.digest != "" {
+				key, err := step.key(context.Background())
+				if err != nil {
+					t.Fatalf("failed to determine key: %v", err)
+				}
+				name = resultCacheName(key)
+			}
+			if tc.recorded != nil {
+				if err := client.Create(context.Background(), &coreapi.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "ci", Name: name}, Data: tc.recorded}); err != nil {
+					t.Fatalf("failed to record result: %v", err)
+				}
+			}
+
+			err := step.Run(context.Background())
+			if tc.fail != (err != nil) {
+				t.Errorf("expected failure: %t, got error: %v", tc.fail, err)
+			}
+			if wrapped.ran != tc.expectedRan {
+				t.Errorf("expected the test to run: %t, ran: %t", tc.expectedRan, wrapped.ran)
+			}
+			var data map[string]string
+			if name != "" {
+				cm := &coreapi.ConfigMap{}
+				if err := client.Get(context.Background(), ctrlruntimeclient.ObjectKey{Namespace: "ci", Name: name}, cm); err == nil {
+					data = cm.Data
+				}
+			}
+			if diff := cmp.Diff(tc.expectedData, data); diff != "" {
+				t.Errorf("unexpected recorded result: %s", diff)
+			}
+			var out string
+			if subTests := step.SubTests(); len(subTests) == 1 {
+				out = subTests[0].SystemOut
+			}
+			if out != tc.expectedOut {
+				t.Errorf("expected output %q, got %q", tc.expectedOut, out)
+			}
+		})
+	}
+}
+
+func TestResultCacheStepImages(t *testing.T) {
+	release := &api.ReleaseBuildConfiguration{
+		InputConfiguration: api.InputConfiguration{
+			BaseImages: map[string]api.ImageStreamTagReference{"os": {Namespace: "ocp", Name: "builder", Tag: "latest"}},
+		},
+		Images: []api.ProjectDirectoryImageBuildStepConfiguration{{To: "component"}},
+	}
+	config := api.TestStepConfiguration{
+		As: "e2e",
+		MultiStageTestConfigurationLiteral: &api.MultiStageTestConfigurationLiteral{
+			Pre:  []api.LiteralTestStep{{As: "setup", From: "os"}},
+			Test: []api.LiteralTestStep{{As: "test", From: "cli", Dependencies: []api.StepDependency{{Name: "component", Env: "IMAGE"}, {Name: "quay.io/org/image", PullSpec: "quay.io/org/image", Env: "EXTERNAL"}}}},
+			Post: []api.LiteralTestStep{{As: "teardown", From: "stable:tests"}},
+		},
+	}
+	step := ResultCacheStep(config, release, nil, nil, "ci", &api.JobSpec{}).(*resultCacheStep)
+	expected := []api.ImageStreamTagReference{
+		{Name: api.PipelineImageStream, Tag: "os"},
+		{Name: api.StableImageStream, Tag: "cli"},
+		{Name: api.PipelineImageStream, Tag: "component"},
+		{Name: api.StableImageStream, Tag: "tests"},
+	}
+	if diff := cmp.Diff(expected, step.images()); diff != "" {
+		t.Errorf("unexpected images: %s", diff)
+	}
+}
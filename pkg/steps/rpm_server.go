@@ -52,21 +52,45 @@ func (s *rpmServerStep) Run(ctx context.Context) error {
 }
 
 func (s *rpmServerStep) run(ctx context.Context) error {
+	return serveFromImage(ctx, s.client, s.jobSpec, fileServer{
+		name: RPMRepoName,
+		from: s.config.From,
+		dir:  api.RPMServeLocation,
+		ref:  s.config.Ref,
+		kind: "RPM repo",
+	})
+}
+
+// fileServer describes a directory of an image in the pipeline image stream
+// which is served over HTTP.
+type fileServer struct {
+	// name of the deployment, service and route
+	name string
+	from api.PipelineImageStreamTagReference
+	dir  string
+	ref  string
+	// kind describes what is served in messages
+	kind string
+}
+
+// serveFromImage deploys a server for the directory of the image and waits
+// for it to be reachable through its route.
+func serveFromImage(ctx context.Context, client loggingclient.LoggingClient, jobSpec *api.JobSpec, server fileServer) error {
 	ist := &imagev1.ImageStreamTag{}
-	if err := s.client.Get(ctx, ctrlruntimeclient.ObjectKey{
-		Namespace: s.jobSpec.Namespace(),
-		Name:      fmt.Sprintf("%s:%s", api.PipelineImageStream, s.config.From)},
+	if err := client.Get(ctx, ctrlruntimeclient.ObjectKey{
+		Namespace: jobSpec.Namespace(),
+		Name:      fmt.Sprintf("%s:%s", api.PipelineImageStream, server.from)},
 		ist); err != nil {
-		return fmt.Errorf("could not find source ImageStreamTag for RPM repo deployment: %w", err)
+		return fmt.Errorf("could not find source ImageStreamTag for %s deployment: %w", server.kind, err)
 	}
 
-	labelSet := LabelsFor(s.jobSpec, map[string]string{AppLabel: RPMRepoName, TTLIgnoreLabel: "true"}, s.config.Ref)
+	labelSet := LabelsFor(jobSpec, map[string]string{AppLabel: server.name, TTLIgnoreLabel: "true"}, server.ref)
 	selectorSet := map[string]string{
-		AppLabel: RPMRepoName,
+		AppLabel: server.name,
 	}
 	commonMeta := meta.ObjectMeta{
-		Name:      RPMRepoName,
-		Namespace: s.jobSpec.Namespace(),
+		Name:      server.name,
+		Namespace: jobSpec.Namespace(),
 		Labels:    labelSet,
 	}
 
@@ -100,7 +124,7 @@ func (s *rpmServerStep) run(ctx context.Context) error {
 				},
 				Spec: coreapi.PodSpec{
 					Containers: []coreapi.Container{{
-						Name:            RPMRepoName,
+						Name:            server.name,
 						Image:           ist.Image.DockerImageReference,
 						ImagePullPolicy: coreapi.PullAlways,
 
@@ -173,7 +197,7 @@ END
 fi
 							`,
 						},
-						WorkingDir: api.RPMServeLocation,
+						WorkingDir: server.dir,
 						Ports: []coreapi.ContainerPort{{
 							ContainerPort: 8080,
 							Protocol:      coreapi.ProtocolTCP,
@@ -192,12 +216,12 @@ fi
 			},
 		},
 	}
-	if owner := s.jobSpec.Owner(); owner != nil {
+	if owner := jobSpec.Owner(); owner != nil {
 		deployment.OwnerReferences = append(deployment.OwnerReferences, *owner)
 	}
 
-	if err := s.client.Create(ctx, deployment); err != nil && !kerrors.IsAlreadyExists(err) {
-		return fmt.Errorf("could not create RPM repo server deployment: %w", err)
+	if err := client.Create(ctx, deployment); err != nil && !kerrors.IsAlreadyExists(err) {
+		return fmt.Errorf("could not create %s server deployment: %w", server.kind, err)
 	}
 
 	service := &coreapi.Service{
@@ -211,35 +235,35 @@ fi
 			Selector: selectorSet,
 		},
 	}
-	if owner := s.jobSpec.Owner(); owner != nil {
+	if owner := jobSpec.Owner(); owner != nil {
 		service.OwnerReferences = append(service.OwnerReferences, *owner)
 	}
 
-	if err := s.client.Create(ctx, service); err != nil && !kerrors.IsAlreadyExists(err) {
-		return fmt.Errorf("could not create RPM repo server service: %w", err)
+	if err := client.Create(ctx, service); err != nil && !kerrors.IsAlreadyExists(err) {
+		return fmt.Errorf("could not create %s server service: %w", server.kind, err)
 	}
 	route := &routev1.Route{
 		ObjectMeta: commonMeta,
 		Spec: routev1.RouteSpec{
 			To: routev1.RouteTargetReference{
-				Name: RPMRepoName,
+				Name: server.name,
 			},
 			Port: &routev1.RoutePort{
 				TargetPort: intstr.FromInt(8080),
 			},
 		},
 	}
-	if owner := s.jobSpec.Owner(); owner != nil {
+	if owner := jobSpec.Owner(); owner != nil {
 		route.OwnerReferences = append(route.OwnerReferences, *owner)
 	}
 
-	if err := s.client.Create(ctx, route); err != nil && !kerrors.IsAlreadyExists(err) {
-		return fmt.Errorf("could not create RPM repo server route: %w", err)
+	if err := client.Create(ctx, route); err != nil && !kerrors.IsAlreadyExists(err) {
+		return fmt.Errorf("could not create %s server route: %w", server.kind, err)
 	}
-	if err := waitForDeployment(ctx, ctrlruntimeclient.NewNamespacedClient(s.client, s.jobSpec.Namespace()), deployment.Name); err != nil {
-		return fmt.Errorf("could not wait for RPM repo server to deploy: %w", err)
+	if err := waitForDeployment(ctx, ctrlruntimeclient.NewNamespacedClient(client, jobSpec.Namespace()), deployment.Name); err != nil {
+		return fmt.Errorf("could not wait for %s server to deploy: %w", server.kind, err)
 	}
-	return waitForRouteReachable(ctx, s.client, s.jobSpec.Namespace(), route.Name, "http")
+	return waitForRouteReachable(ctx, client, jobSpec.Namespace(), route.Name, "http")
 }
 
 func waitForDeployment(ctx context.Context, client ctrlruntimeclient.Client, name string) error {
@@ -353,7 +377,7 @@ func waitForRouteReachable(ctx context.Context, client ctrlruntimeclient.Client,
 				continue
 			}
 		}
-		logrus.Infof("RPMs being served at %s", u)
+		logrus.Infof("Files of %s being served at %s", name, u)
 		return nil
 	}
 }
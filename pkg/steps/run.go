@@ -58,7 +58,7 @@ func Run(ctx context.Context, graph api.StepGraph, agent *metrics.MetricsAgent)
 			testCase := &junit.TestCase{Name: out.node.Step.Description(), Duration: out.duration.Seconds()}
 			stepDetails = append(stepDetails, out.stepDetails)
 			if out.err != nil {
-				testCase.FailureOutput = &junit.FailureOutput{Output: out.err.Error()}
+				testCase.FailureOutput = &junit.FailureOutput{Type: string(results.ClassificationFor(out.err)), Output: out.err.Error()}
 				executionErrors = append(executionErrors, results.ForReason("step_failed").WithError(out.err).Errorf("step %s failed: %v", out.node.Step.Name(), out.err))
 			} else {
 				seen = append(seen, out.node.Step.Creates()...)
@@ -81,6 +81,13 @@ func Run(ctx context.Context, graph api.StepGraph, agent *metrics.MetricsAgent)
 			var testCases []*junit.TestCase
 			if len(out.additionalTests) > 0 {
 				testCases = out.additionalTests
+				if out.err != nil {
+					for _, test := range testCases {
+						if test.FailureOutput != nil && test.FailureOutput.Type == "" {
+							test.FailureOutput.Type = testCase.FailureOutput.Type
+						}
+					}
+				}
 			} else {
 				testCases = []*junit.TestCase{testCase}
 			}
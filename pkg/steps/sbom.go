@@ -0,0 +1,143 @@
+package steps
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+
+	coreapi "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/openshift/ci-tools/pkg/api"
+	"github.com/openshift/ci-tools/pkg/util"
+)
+
+const (
+	// SyftImage generates SBOMs. The debug variant provides a shell.
+	SyftImage = "docker.io/anchore/syft:v1.18.1-debug"
+	// DefaultCosignImage attaches SBOMs to and signs images unless the build
+	// cluster configures another image.  It runs cosign without a shell.
+	DefaultCosignImage = "gcr.io/projectsigstore/cosign:v2.4.1"
+
+	sbomContainerName    = "sbom"
+	sbomAttachContainer  = "attach"
+	sbomArtifactsVolume  = "artifacts"
+	sbomArtifactsPath    = "/tmp/artifacts"
+	sbomDockerConfig     = "docker-config"
+	sbomDockerConfigPath = "/tmp/docker"
+)
+
+// sbomScript scans the image, writing the SBOM into the artifacts of the pod,
+// and writes the registry credentials for the attach container, which has no
+// shell to read the token of the service account.
+const sbomScript = `token="$(cat ` + buildahTokenPath + `)"
+export SYFT_REGISTRY_AUTH_PASSWORD="${token}"
+syft scan --quiet --source-name "${SOURCE_NAME}" --source-version "${SOURCE_VERSION}" --output "${SYFT_FORMAT}=` + sbomArtifactsPath + `/${SBOM_FILE}" "registry:${IMAGE}"
+auth="$(printf 'serviceaccount:%s' "${token}" | base64 -w 0)"
+printf '{"auths":{"%s":{"auth":"%s"}}}' "${SYFT_REGISTRY_AUTH_AUTHORITY}" "${auth}" > ` + sbomDockerConfigPath + `/config.json
+`
+
+var syftFormats = map[api.SBOMFormat]string{
+	api.SBOMFormatSPDX:      "spdx-json",
+	api.SBOMFormatCycloneDX: "cyclonedx-json",
+}
+
+// generateSBOM generates the SBOM of the built image from the package manager
+// metadata and binaries it contains and attaches it to the image.  The SBOM
+// is copied out of the pod into the artifacts of the job.
+func (s *projectDirectoryImageBuildStep) generateSBOM(ctx context.Context) error {
+	digest, err := resolvePipelineImageStreamTagReference(ctx, s.client, s.config.To, s.jobSpec)
+	if err != nil {
+		return err
+	}
+	format := s.config.SBOM.FormatOrDefault()
+	image := s.cosignImage
+	if image == "" {
+		image = DefaultCosignImage
+	}
+	pod := sbomPod(s.jobSpec, s.config.To, digest, format, s.client.LocalRegistryDNS(), image)
+	var notifier util.ContainerNotifier = util.NopNotifier
+	if artifactDir, set := api.Artifacts(); set {
+		addArtifactsToPod(pod)
+		// the SBOM lists the contents of the image, there is nothing to censor
+		worker := NewArtifactWorker(s.podClient, nil, filepath.Join(artifactDir, "sbom"), pod.Namespace)
+		addArtifactContainersFromPod(pod, worker)
+		notifier = worker
+	}
+	logrus.Infof("Generating %s SBOM for %s", format, s.config.To)
+	if _, err := runPod(ctx, s.podClient, pod, notifier, false); err != nil {
+		return fmt.Errorf("could not generate SBOM for %s: %w", s.config.To, err)
+	}
+	return nil
+}
+
+// sbomPod scans an image of the pipeline image stream and attaches the SBOM
+// to it under the tag given by api.SBOMTag.
+func sbomPod(jobSpec *api.JobSpec, to api.PipelineImageStreamTagReference, digest string, format api.SBOMFormat, registry, cosignImage string) *coreapi.Pod {
+	image := fmt.Sprintf("%s/%s/%s@%s", registry, jobSpec.Namespace(), api.PipelineImageStream, digest)
+	var sourceName, sourceVersion string
+	if refs := jobSpec.Refs; refs != nil {
+		sourceName = fmt.Sprintf("github.com/%s/%s", refs.Org, refs.Repo)
+		sourceVersion = refs.BaseSHA
+		if len(refs.Pulls) > 0 {
+			sourceVersion = refs.Pulls[0].SHA
+		}
+	}
+	file := fmt.Sprintf("%s.%s.json", to, format)
+	mounts := []coreapi.VolumeMount{
+		{Name: sbomArtifactsVolume, MountPath: sbomArtifactsPath},
+		{Name: sbomDockerConfig, MountPath: sbomDockerConfigPath},
+	}
+	return &coreapi.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      strings.ReplaceAll(fmt.Sprintf("%s-sbom", to), "_", "-"),
+			Namespace: jobSpec.Namespace(),
+			Labels:    LabelsFor(jobSpec, map[string]string{}, ""),
+		},
+		Spec: coreapi.PodSpec{
+			RestartPolicy:      coreapi.RestartPolicyNever,
+			ServiceAccountName: buildahServiceAccount,
+			InitContainers: []coreapi.Container{{
+				Name:    sbomContainerName,
+				Image:   SyftImage,
+				Command: []string{"/busybox/sh", "-c", sbomScript},
+				Env: []coreapi.EnvVar{
+					{Name: "IMAGE", Value: image},
+					{Name: "SYFT_FORMAT", Value: syftFormats[format]},
+					{Name: "SBOM_FILE", Value: file},
+					{Name: "SOURCE_NAME", Value: sourceName},
+					{Name: "SOURCE_VERSION", Value: sourceVersion},
+					{Name: "SYFT_REGISTRY_AUTH_AUTHORITY", Value: registry},
+					{Name: "SYFT_REGISTRY_AUTH_USERNAME", Value: "serviceaccount"},
+					{Name: "SYFT_REGISTRY_INSECURE_SKIP_TLS_VERIFY", Value: "true"},
+					{Name: "SYFT_CHECK_FOR_APP_UPDATE", Value: "false"},
+				},
+				VolumeMounts:             mounts,
+				TerminationMessagePolicy: coreapi.TerminationMessageFallbackToLogsOnError,
+			}},
+			Containers: []coreapi.Container{{
+				Name:                     sbomAttachContainer,
+				Image:                    cosignImage,
+				Command:                  []string{"cosign"},
+				Args:                     []string{"attach", "sbom", "--allow-insecure-registry", "--type", string(format), "--sbom", path.Join(sbomArtifactsPath, file), image},
+				Env:                      []coreapi.EnvVar{{Name: "DOCKER_CONFIG", Value: sbomDockerConfigPath}},
+				VolumeMounts:             mounts,
+				TerminationMessagePolicy: coreapi.TerminationMessageFallbackToLogsOnError,
+			}},
+			Volumes: []coreapi.Volume{
+				{
+					Name:         sbomArtifactsVolume,
+					VolumeSource: coreapi.VolumeSource{EmptyDir: &coreapi.EmptyDirVolumeSource{}},
+				},
+				{
+					Name:         sbomDockerConfig,
+					VolumeSource: coreapi.VolumeSource{EmptyDir: &coreapi.EmptyDirVolumeSource{}},
+				},
+			},
+		},
+	}
+}
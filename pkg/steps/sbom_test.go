@@ -0,0 +1,34 @@
+package steps
+
+import (
+	"testing"
+
+	prowapi "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
+	"sigs.k8s.io/prow/pkg/pod-utils/downwardapi"
+
+	"github.com/openshift/ci-tools/pkg/api"
+	"github.com/openshift/ci-tools/pkg/testhelper"
+)
+
+func TestSBOMPod(t *testing.T) {
+	jobSpec := &api.JobSpec{
+		JobSpec: downwardapi.JobSpec{
+			Job:       "job",
+			BuildID:   "buildId",
+			ProwJobID: "prowJobId",
+			Refs: &prowapi.Refs{
+				Org:     "org",
+				Repo:    "repo",
+				BaseRef: "master",
+				BaseSHA: "masterSHA",
+				Pulls:   []prowapi.Pull{{Number: 1, SHA: "pullSHA"}},
+			},
+		},
+	}
+	jobSpec.SetNamespace("test-namespace")
+	for _, format := range []api.SBOMFormat{api.SBOMFormatSPDX, api.SBOMFormatCycloneDX} {
+		t.Run(string(format), func(t *testing.T) {
+			testhelper.CompareWithFixture(t, sbomPod(jobSpec, "my_image", "sha256:abc", format, "registry.local:5000", DefaultCosignImage))
+		})
+	}
+}
@@ -200,6 +200,14 @@ func (s *sourceStep) run(ctx context.Context) error {
 	)
 }
 
+// RenderObjects generates the build of the step without creating it.  The
+// digest of the image it builds from is rendered as a placeholder.
+func (s *sourceStep) RenderObjects() ([]ctrlruntimeclient.Object, error) {
+	clonerefsRef := corev1.ObjectReference{Kind: "DockerImage", Name: s.config.ClonerefsPullSpec}
+	fromDigest := api.DryRunPlaceholder(string(s.config.From))
+	return []ctrlruntimeclient.Object{createBuild(s.config, s.jobSpec, clonerefsRef, s.resources, s.cloneAuthConfig, s.pullSecret, fromDigest)}, nil
+}
+
 func createBuild(config api.SourceStepConfiguration, jobSpec *api.JobSpec, clonerefsRef corev1.ObjectReference, resources api.ResourceConfiguration, cloneAuthConfig *CloneAuthConfig, pullSecret *corev1.Secret, fromDigest string) *buildapi.Build {
 	var refs []prowv1.Refs
 	if jobSpec.Refs != nil {
@@ -432,7 +440,7 @@ func handleFailedBuild(ctx context.Context, client BuildClient, ns, name string,
 
 	if !(isInfraReason(b.Status.Reason) || hintsAtInfraReason(b.Status.LogSnippet)) {
 		logrus.Debugf("Build %q (created at %v) classified as legitimate failure, will not be retried", name, b.CreationTimestamp)
-		return err
+		return results.Classify(results.ClassificationTest, err)
 	}
 
 	logrus.Infof("Build %s previously failed from an infrastructure error (%s), retrying...", name, b.Status.Reason)
@@ -469,6 +477,12 @@ func isBuildPhaseTerminated(phase buildapi.BuildPhase) bool {
 type ImageBuildOptions struct {
 	Architectures            []string
 	NeedsMultiArchWorkaround func() bool
+	// Backend executes the build for each architecture, OpenShift Builds
+	// are used when unset.
+	Backend api.BuildBackend
+	// BuildahImage runs the builds of the buildah backend, the default image
+	// is used when unset.
+	BuildahImage string
 }
 
 func newImageBuildOptions(archs []string) ImageBuildOptions {
@@ -511,6 +525,7 @@ func handleBuilds(ctx context.Context, buildClient BuildClient, podClient kubern
 		}
 	}
 
+	backend := newBuildBackend(o.Backend, o.BuildahImage, buildClient, podClient, needsMultiArchWorkaround)
 	builds := constructMultiArchBuilds(build, o.Architectures)
 	errChan := make(chan error, len(builds))
 
@@ -519,7 +534,7 @@ func handleBuilds(ctx context.Context, buildClient BuildClient, podClient kubern
 		go func(b buildapi.Build) {
 			defer wg.Done()
 			metricsAgent.AddNodeWorkload(ctx, b.Namespace, fmt.Sprintf("%s-build", b.Name), b.Name, podClient)
-			if err := handleBuild(ctx, buildClient, podClient, b, needsMultiArchWorkaround); err != nil {
+			if err := backend.Build(ctx, b); err != nil {
 				errChan <- fmt.Errorf("error occurred handling build %s: %w", b.Name, err)
 			}
 			metricsAgent.RemoveNodeWorkload(b.Name)
@@ -529,8 +544,11 @@ func handleBuilds(ctx context.Context, buildClient BuildClient, podClient kubern
 	wg.Wait()
 	close(errChan)
 
-	for _, b := range builds {
-		metricsAgent.Record(metrics.NewBuildEvent(b.Name, b.Namespace, build.Spec.Output.To.Name))
+	// the buildah backend runs pods, there are no Build objects to report
+	if o.Backend != api.BuildBackendBuildah {
+		for _, b := range builds {
+			metricsAgent.Record(metrics.NewBuildEvent(b.Name, b.Namespace, build.Spec.Output.To.Name))
+		}
 	}
 
 	var errs []error
@@ -609,7 +627,8 @@ func handleBuild(ctx context.Context, client BuildClient, podClient kubernetes.P
 		return true, nil
 	}); err != nil {
 		if err == wait.ErrWaitTimeout {
-			return fmt.Errorf("build not successful after %d attempts: %w", attempts, utilerrors.NewAggregate(errs))
+			// every attempt failed from an infrastructure error
+			return results.Classify(results.ClassificationInfra, fmt.Errorf("build not successful after %d attempts: %w", attempts, utilerrors.NewAggregate(errs)))
 		}
 		return err
 	}
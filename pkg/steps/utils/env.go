@@ -18,6 +18,8 @@ const (
 	ImageFormatEnv = "IMAGE_FORMAT"
 
 	OverrideImageEnvPrefix = "OVERRIDE_IMAGE_"
+
+	servedArtifactsEnvPrefix = "ARTIFACTS_URL_"
 )
 
 var knownPrefixes = map[string]string{
@@ -70,11 +72,19 @@ func LinkForEnv(envVar string) (api.StepLink, bool) {
 		return api.ReleaseImagesLink(api.InitialReleaseName), true
 	case IsReleaseImageEnv(envVar):
 		return api.ReleasePayloadImageLink(ReleaseNameFrom(envVar)), true
+	case strings.HasPrefix(envVar, servedArtifactsEnvPrefix):
+		return api.ServedArtifactsLink(unescapedImageName(strings.TrimPrefix(envVar, servedArtifactsEnvPrefix))), true
 	default:
 		return nil, false
 	}
 }
 
+// ServedArtifactsEnv determines the environment variable used to expose the
+// URL of served artifacts to test workloads.
+func ServedArtifactsEnv(name string) string {
+	return servedArtifactsEnvPrefix + escapedImageName(name)
+}
+
 // EnvVarFor determines the environment variable used to
 // expose a pull spec for an ImageStreamTag in the test
 // namespace to test workloads.
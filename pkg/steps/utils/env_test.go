@@ -95,6 +95,11 @@ func TestLinkForEnv(t *testing.T) {
 			output: api.ReleasePayloadImageLink("foobar"),
 			valid:  true,
 		},
+		{
+			input:  "ARTIFACTS_URL_HELM_CHARTS",
+			output: api.ServedArtifactsLink("helm-charts"),
+			valid:  true,
+		},
 	}
 
 	for _, testCase := range testCases {
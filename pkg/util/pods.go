@@ -224,7 +224,8 @@ func processPodEvent(
 	if podJobIsFailed(pod) {
 		podClient.MetricsAgent().StorePodLifecycleMetrics(pod.Name, pod.Namespace, corev1.PodFailed)
 		podClient.MetricsAgent().StoreMachinesSnapshot(pod)
-		return true, AppendLogToError(fmt.Errorf("the pod %s/%s failed after %s (failed containers: %s): %s", pod.Namespace, pod.Name, podDuration(pod).Truncate(time.Second), strings.Join(failedContainerNames(pod), ", "), podReason(pod)), podMessages(pod))
+		err := AppendLogToError(fmt.Errorf("the pod %s/%s failed after %s (failed containers: %s): %s", pod.Namespace, pod.Name, podDuration(pod).Truncate(time.Second), strings.Join(failedContainerNames(pod), ", "), podReason(pod)), podMessages(pod))
+		return true, results.Classify(results.ClassifyPod(pod), err)
 	}
 	return false, nil
 }
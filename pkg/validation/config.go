@@ -189,6 +189,12 @@ func (v *Validator) validateConfiguration(ctx *configContext, config *api.Releas
 
 	validationErrors = append(validationErrors, validateReleases("releases", config.Releases, config.ReleaseTagConfiguration != nil)...)
 	validationErrors = append(validationErrors, ValidateImages(ctx.AddField("images"), config.Images)...)
+	validationErrors = append(validationErrors, validateServedArtifacts(ctx.AddField("served_artifacts"), config.ServedArtifacts)...)
+	if config.BuildBackend != "" {
+		if err := config.BuildBackend.Validate(); err != nil {
+			validationErrors = append(validationErrors, ctx.AddField("build_backend").errorf("%v", err))
+		}
+	}
 	validationErrors = append(validationErrors, v.ValidateTestStepConfiguration(ctx, config, resolved)...)
 	// this validation brings together a large amount of data from separate
 	// parts of the configuration, so it's written as a standalone method
@@ -332,11 +338,41 @@ func ValidateImages(ctx *configContext, images []api.ProjectDirectoryImageBuildS
 				validationErrors = append(validationErrors, ctxN.errorf("invalid architecture: %s. Use one of %s", arch, strings.Join(archList, ", ")))
 			}
 		}
+		if image.SBOM != nil {
+			switch image.SBOM.Format {
+			case "", api.SBOMFormatSPDX, api.SBOMFormatCycloneDX:
+			default:
+				validationErrors = append(validationErrors, ctxN.AddField("sbom.format").errorf("invalid format: %s. Use one of %s, %s", image.SBOM.Format, api.SBOMFormatSPDX, api.SBOMFormatCycloneDX))
+			}
+		}
 
 	}
 	return validationErrors
 }
 
+func validateServedArtifacts(ctx *configContext, artifacts []api.ServedArtifactsConfiguration) []error {
+	var validationErrors []error
+	names := sets.New[string]()
+	for num, served := range artifacts {
+		ctxN := ctx.addIndex(num)
+		if served.Name == "" {
+			validationErrors = append(validationErrors, ctxN.errorf("`name` must be set"))
+		} else if errs := validation.IsDNS1123Label(fmt.Sprintf("artifacts-%s", served.Name)); len(errs) > 0 {
+			validationErrors = append(validationErrors, ctxN.AddField("name").errorf("invalid name %q: %s", served.Name, strings.Join(errs, ", ")))
+		} else if names.Has(served.Name) {
+			validationErrors = append(validationErrors, ctxN.AddField("name").errorf("duplicate name %q", served.Name))
+		}
+		names.Insert(served.Name)
+		if served.From == "" {
+			validationErrors = append(validationErrors, ctxN.errorf("`from` must be set"))
+		}
+		if !strings.HasPrefix(served.Path, "/") {
+			validationErrors = append(validationErrors, ctxN.AddField("path").errorf("must be an absolute path"))
+		}
+	}
+	return validationErrors
+}
+
 func ValidateOperator(ctx *configContext, config *api.ReleaseBuildConfiguration) []error {
 	// validateOperator needs a method that maps `substitute.with` values to image links
 	// to validate the value is meaningful in the context of the configuration
@@ -662,5 +698,6 @@ func Observer(observer api.Observer) []error {
 	// to refer to an image from a release payload for an observer, but this should be
 	// not of any real issue and will at least be obvious to the user on presubmit.
 	errs = append(errs, validateFromAndFromImage(newContext("", nil, nil, nil), observer.From, observer.FromImage, nil, nil)...)
+	errs = append(errs, validateParameterTypes(&context{field: fieldPath(observer.Name)}, observer.Environment)...)
 	return errs
 }
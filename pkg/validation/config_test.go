@@ -571,6 +571,16 @@ func TestValidateImages(t *testing.T) {
 				errors.New("images[0]: invalid architecture: foo. Use one of amd64, arm64, ppc64le, s390x"),
 			},
 		},
+		{
+			name: "invalid SBOM format",
+			input: []api.ProjectDirectoryImageBuildStepConfiguration{
+				{To: "amsterdam", SBOM: &api.SBOMConfiguration{Format: api.SBOMFormatCycloneDX}},
+				{To: "rotterdam", SBOM: &api.SBOMConfiguration{Format: "swid"}},
+			},
+			output: []error{
+				errors.New("images[1].sbom.format: invalid format: swid. Use one of spdx, cyclonedx"),
+			},
+		},
 	}
 	for _, testCase := range testCases {
 		t.Run(testCase.name, func(t *testing.T) {
@@ -586,6 +596,44 @@ func TestValidateImages(t *testing.T) {
 	}
 }
 
+func TestValidateServedArtifacts(t *testing.T) {
+	var testCases = []struct {
+		name   string
+		input  []api.ServedArtifactsConfiguration
+		output []error
+	}{
+		{
+			name:  "valid artifacts",
+			input: []api.ServedArtifactsConfiguration{{Name: "charts", From: "bin", Path: "/go/src/charts"}, {Name: "bundles", From: "bin", Path: "/bundles"}},
+		},
+		{
+			name:  "missing fields",
+			input: []api.ServedArtifactsConfiguration{{}},
+			output: []error{
+				errors.New("served_artifacts[0]: `name` must be set"),
+				errors.New("served_artifacts[0]: `from` must be set"),
+				errors.New("served_artifacts[0].path: must be an absolute path"),
+			},
+		},
+		{
+			name:  "invalid and duplicate names",
+			input: []api.ServedArtifactsConfiguration{{Name: "Charts", From: "bin", Path: "/charts"}, {Name: "charts", From: "bin", Path: "/charts"}, {Name: "charts", From: "bin", Path: "/charts"}},
+			output: []error{
+				errors.New("served_artifacts[0].name: invalid name \"Charts\": a lowercase RFC 1123 label must consist of lower case alphanumeric characters or '-', and must start and end with an alphanumeric character (e.g. 'my-name',  or '123-abc', regex used for validation is '[a-z0-9]([-a-z0-9]*[a-z0-9])?')"),
+				errors.New("served_artifacts[2].name: duplicate name \"charts\""),
+			},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			actual := validateServedArtifacts(NewConfigContext().AddField("served_artifacts"), testCase.input)
+			if diff := cmp.Diff(testCase.output, actual, testhelper.EquateErrorMessage); diff != "" {
+				t.Errorf("got incorrect errors: %s", diff)
+			}
+		})
+	}
+}
+
 func TestValidateOperator(t *testing.T) {
 	var goodStepLink = api.AllStepsLink()
 	var badStepLink api.StepLink
@@ -21,6 +21,7 @@ func IsValidGraphConfiguration(rawSteps []api.StepConfiguration) error {
 	var ret []error
 	var containerTests, multiStageTests []*api.TestStepConfiguration
 	names := sets.New[string]()
+	servedArtifacts := sets.New[string]()
 	pipelineImages := pipelineImageSet{
 		// `src` can only be validated at runtime
 		api.PipelineImageStreamTagReferenceSource: {},
@@ -57,6 +58,9 @@ func IsValidGraphConfiguration(rawSteps []api.StepConfiguration) error {
 			pipelineImages[c.To] = sets.Empty{}
 		} else if c := s.RPMServeStepConfiguration; c != nil {
 			addName(c.TargetName())
+		} else if c := s.ArtifactServeStepConfiguration; c != nil {
+			addName(c.TargetName())
+			servedArtifacts.Insert(c.Name)
 		} else if c := s.OutputImageTagStepConfiguration; c != nil {
 			addName(c.TargetName())
 		} else if c := s.ReleaseImagesTagStepConfiguration; c != nil {
@@ -91,7 +95,7 @@ func IsValidGraphConfiguration(rawSteps []api.StepConfiguration) error {
 		ret = append(ret, validateContainerTest(pipelineImages, t)...)
 	}
 	for _, t := range multiStageTests {
-		ret = append(ret, validateMultiStageTest(pipelineImages, t)...)
+		ret = append(ret, validateMultiStageTest(pipelineImages, servedArtifacts, t)...)
 	}
 	return utilerrors.NewAggregate(ret)
 }
@@ -113,6 +117,7 @@ func validateContainerTest(
 
 func validateMultiStageTest(
 	pipelineImages pipelineImageSet,
+	servedArtifacts sets.Set[string],
 	s *api.TestStepConfiguration,
 ) (ret []error) {
 	f := func(phase string, i int, step api.LiteralTestStep) (ret []error) {
@@ -124,6 +129,11 @@ func validateMultiStageTest(
 				ret = append(ret, fmt.Errorf("tests[%s].steps.%s[%d].from: unknown image %q (configuration is missing `%s`)", s.As, phase, i, from, msg))
 			}
 		}
+		for j, name := range step.ServedArtifacts {
+			if !servedArtifacts.Has(name) {
+				ret = append(ret, fmt.Errorf("tests[%s].steps.%s[%d].served_artifacts[%d]: unknown artifacts %q (configuration is missing them in `served_artifacts`)", s.As, phase, i, j, name))
+			}
+		}
 		return
 	}
 	ms := s.MultiStageTestConfigurationLiteral
@@ -12,6 +12,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation"
+	prowv1 "sigs.k8s.io/prow/pkg/apis/prowjobs/v1"
 
 	"github.com/openshift/ci-tools/pkg/api"
 	"github.com/openshift/ci-tools/pkg/util"
@@ -112,7 +113,12 @@ var trapPattern = regexp.MustCompile(`(^|\W)\s*trap\s*['"]?\w*['"]?\s*\w*`)
 // component, the image references exist in the test configuration, etc.) are
 // not performed.
 func (v *Validator) IsValidReference(step api.LiteralTestStep) []error {
-	return v.validateLiteralTestStep(&context{field: fieldPath(step.As)}, testStageUnknown, step, nil)
+	context := &context{field: fieldPath(step.As)}
+	ret := v.validateLiteralTestStep(context, testStageUnknown, step, nil)
+	if step.ParallelGroup != "" {
+		ret = append(ret, context.errorf("`parallel_group` cannot be set in a reference, use a `parallel` block in the chain, workflow or test instead"))
+	}
+	return ret
 }
 
 func (v *Validator) validateTestStepConfiguration(
@@ -126,11 +132,14 @@ func (v *Validator) validateTestStepConfiguration(
 ) []error {
 	var validationErrors []error
 
+	// tests generated from a matrix are validated like any other test
+	tests, fields, matrixErrors := expandTestMatrices(fieldRoot, input)
+	validationErrors = append(validationErrors, matrixErrors...)
 	// check for test.As duplicates
-	validationErrors = append(validationErrors, searchForTestDuplicates(input)...)
+	validationErrors = append(validationErrors, searchForTestDuplicates(tests)...)
 	inputImagesSeen := make(testInputImages)
-	for num, test := range input {
-		fieldRootN := fmt.Sprintf("%s[%d]", fieldRoot, num)
+	for num, test := range tests {
+		fieldRootN := fields[num]
 		if len(test.As) == 0 {
 			validationErrors = append(validationErrors, fmt.Errorf("%s.as: is required", fieldRootN))
 		} else if l := len(test.As); l > maxTestNameLength {
@@ -217,6 +226,10 @@ func (v *Validator) validateTestStepConfiguration(
 			validationErrors = append(validationErrors, fmt.Errorf("%s: job timeout is limited to %s", fieldRootN, maxJobTimeout))
 		}
 
+		if test.ResultCache != nil {
+			validationErrors = append(validationErrors, validateResultCache(fieldRootN+".result_cache", test)...)
+		}
+
 		// Validate Secret/Secrets
 		if test.Secret != nil && test.Secrets != nil {
 			validationErrors = append(validationErrors, fmt.Errorf("test.Secret and test.Secrets cannot both be set"))
@@ -486,6 +499,93 @@ func verifyClusterClaimOwnership(claim api.ClusterClaimDetails, m *api.Metadata)
 	return fmt.Errorf("%s/%s is not an owner of the cluster claim: %q", m.Org, m.Repo, claim.Claim)
 }
 
+// validateResultCache ensures results are only reused for tests whose inputs
+// are fully known to ci-operator; claimed clusters are not.
+func validateResultCache(fieldRoot string, test api.TestStepConfiguration) (ret []error) {
+	if test.ContainerTestConfiguration == nil && test.MultiStageTestConfiguration == nil && test.MultiStageTestConfigurationLiteral == nil {
+		ret = append(ret, fmt.Errorf("%s: only container and multi-stage tests can reuse results", fieldRoot))
+	}
+	if test.ClusterClaim != nil {
+		ret = append(ret, fmt.Errorf("%s: tests with a cluster claim cannot reuse results", fieldRoot))
+	}
+	if maxAge := test.ResultCache.MaxAge; maxAge != nil && maxAge.Duration <= 0 {
+		ret = append(ret, fmt.Errorf("%s.max_age: must be positive, got %s", fieldRoot, maxAge.Duration))
+	}
+	return ret
+}
+
+// expandTestMatrices validates the matrices of the tests and replaces them
+// by the tests they generate, returning the field path of each test.
+func expandTestMatrices(fieldRoot string, input []api.TestStepConfiguration) (tests []api.TestStepConfiguration, fields []string, errs []error) {
+	for num, test := range input {
+		fieldRootN := fmt.Sprintf("%s[%d]", fieldRoot, num)
+		if test.Matrix == nil {
+			tests = append(tests, test)
+			fields = append(fields, fieldRootN)
+			continue
+		}
+		matrixErrs := validateTestMatrix(fieldRootN, test)
+		errs = append(errs, matrixErrs...)
+		if len(matrixErrs) != 0 {
+			continue
+		}
+		for _, expanded := range test.ExpandMatrix() {
+			tests = append(tests, expanded)
+			fields = append(fields, fmt.Sprintf("%s(%s)", fieldRootN, expanded.As))
+		}
+	}
+	return tests, fields, errs
+}
+
+func validateTestMatrix(fieldRoot string, test api.TestStepConfiguration) (ret []error) {
+	if test.MultiStageTestConfiguration == nil && test.MultiStageTestConfigurationLiteral == nil {
+		ret = append(ret, fmt.Errorf("%s.matrix: only multi-stage tests can have a matrix", fieldRoot))
+	}
+	matrix := test.Matrix
+	if len(matrix.Dimensions) == 0 {
+		ret = append(ret, fmt.Errorf("%s.matrix.dimensions: at least one dimension is required", fieldRoot))
+	}
+	values := map[string]sets.Set[string]{}
+	for i, dimension := range matrix.Dimensions {
+		fieldRootI := fmt.Sprintf("%s.matrix.dimensions[%d]", fieldRoot, i)
+		if dimension.Env == "" {
+			ret = append(ret, fmt.Errorf("%s.env: must not be empty", fieldRootI))
+		} else if _, seen := values[dimension.Env]; seen {
+			ret = append(ret, fmt.Errorf("%s.env: duplicated dimension %q", fieldRootI, dimension.Env))
+		}
+		if len(dimension.Values) == 0 {
+			ret = append(ret, fmt.Errorf("%s.values: at least one value is required", fieldRootI))
+		}
+		names := sets.New[string]()
+		for j, value := range dimension.Values {
+			if errs := validation.IsDNS1123Label(value.Name); len(errs) != 0 {
+				ret = append(ret, fmt.Errorf("%s.values[%d].name: %q cannot be used in the name of a test: %s", fieldRootI, j, value.Name, strings.Join(errs, ", ")))
+			} else if names.Has(value.Name) {
+				ret = append(ret, fmt.Errorf("%s.values[%d].name: duplicated value %q", fieldRootI, j, value.Name))
+			}
+			names.Insert(value.Name)
+		}
+		values[dimension.Env] = names
+	}
+	for i, override := range matrix.Overrides {
+		fieldRootI := fmt.Sprintf("%s.matrix.overrides[%d]", fieldRoot, i)
+		if len(override.Match) == 0 {
+			ret = append(ret, fmt.Errorf("%s.match: must not be empty", fieldRootI))
+		}
+		for _, env := range sets.List(sets.KeySet(override.Match)) {
+			if names, ok := values[env]; !ok {
+				ret = append(ret, fmt.Errorf("%s.match: %q is not a dimension of the matrix", fieldRootI, env))
+			} else if !names.Has(override.Match[env]) {
+				ret = append(ret, fmt.Errorf("%s.match: %q is not a value of dimension %q", fieldRootI, override.Match[env], env))
+			}
+		}
+		if override.Exclude && (override.Env != nil || override.Optional != nil || override.AlwaysRun != nil) {
+			ret = append(ret, fmt.Errorf("%s: `exclude` cannot be combined with other overrides", fieldRootI))
+		}
+	}
+	return ret
+}
+
 func searchForTestDuplicates(tests []api.TestStepConfiguration) []error {
 	duplicates := make(map[string]bool, len(tests))
 	var testNames []string
@@ -601,6 +701,7 @@ func (v *Validator) validateTestConfigurationType(
 		}
 		context := newContext(fieldPath(fieldRoot), testConfig.Environment, releases, inputImagesSeen)
 		validationErrors = append(validationErrors, validateLeases(context.addField("leases"), testConfig.Leases)...)
+		validationErrors = append(validationErrors, validatePhaseBudgets(context.addField("budgets"), testConfig.Budgets, test.Timeout)...)
 		if testConfig.NodeArchitecture != nil {
 			validationErrors = append(validationErrors, validateNodeArchitecture(fieldRoot, *testConfig.NodeArchitecture))
 		}
@@ -616,6 +717,11 @@ func (v *Validator) validateTestConfigurationType(
 			validationErrors = append(validationErrors, v.validateClusterProfile(fieldRoot, testConfig.ClusterProfile, metadata)...)
 		}
 		validationErrors = append(validationErrors, validateLeases(context.addField("leases"), testConfig.Leases)...)
+		timeout := test.Timeout
+		if timeout == nil {
+			timeout = testConfig.Timeout
+		}
+		validationErrors = append(validationErrors, validatePhaseBudgets(context.addField("budgets"), testConfig.Budgets, timeout)...)
 		for i, s := range testConfig.Pre {
 			validationErrors = append(validationErrors, v.validateLiteralTestStep(context.addField("pre").addIndex(i), testStagePre, s, claimRelease)...)
 		}
@@ -625,6 +731,9 @@ func (v *Validator) validateTestConfigurationType(
 		for i, s := range testConfig.Post {
 			validationErrors = append(validationErrors, v.validateLiteralTestStep(context.addField("post").addIndex(i), testStagePost, s, claimRelease)...)
 		}
+		validationErrors = append(validationErrors, validateParallelGroups(context.addField("pre"), testConfig.Pre)...)
+		validationErrors = append(validationErrors, validateParallelGroups(context.addField("test"), testConfig.Test)...)
+		validationErrors = append(validationErrors, validateParallelGroups(context.addField("post"), testConfig.Post)...)
 	}
 	if typeCount == 0 {
 		validationErrors = append(validationErrors, fmt.Errorf("%s has no type, you may want to specify 'container' for a container based test", fieldRoot))
@@ -649,21 +758,61 @@ func (v *Validator) validateTestSteps(context *context, stage testStage, steps [
 		if s.LiteralTestStep != nil {
 			ret = append(ret, v.validateLiteralTestStep(contextI, stage, *s.LiteralTestStep, claimRelease)...)
 		}
+		if s.Parallel != nil {
+			ret = append(ret, v.validateParallelTestSteps(contextI.addField("parallel"), stage, s.Parallel, claimRelease)...)
+		}
+	}
+	return
+}
+
+func (v *Validator) validateParallelTestSteps(context *context, stage testStage, steps []api.ParallelTestStep, claimRelease *api.ClaimRelease) (ret []error) {
+	if len(steps) == 0 {
+		return []error{context.errorf("a parallel group must contain at least one step")}
+	}
+	for i, s := range steps {
+		contextI := context.addIndex(i)
+		ret = append(ret, validateTestStep(contextI, s.TestStep())...)
+		if s.LiteralTestStep != nil {
+			ret = append(ret, v.validateLiteralTestStep(contextI, stage, *s.LiteralTestStep, claimRelease)...)
+		}
+	}
+	return
+}
+
+// validateParallelGroups verifies that all members of a parallel group are
+// adjacent, since only consecutive steps can be executed concurrently.
+func validateParallelGroups(context *context, steps []api.LiteralTestStep) (ret []error) {
+	seen := sets.New[string]()
+	for i, s := range steps {
+		if s.ParallelGroup == "" || (i != 0 && steps[i-1].ParallelGroup == s.ParallelGroup) {
+			continue
+		}
+		if seen.Has(s.ParallelGroup) {
+			ret = append(ret, context.addIndex(i).errorf("steps in parallel group %q must be consecutive", s.ParallelGroup))
+		}
+		seen.Insert(s.ParallelGroup)
 	}
 	return
 }
 
 func validateTestStep(context *context, step api.TestStep) (ret []error) {
-	if (step.LiteralTestStep != nil && step.Reference != nil) ||
-		(step.LiteralTestStep != nil && step.Chain != nil) ||
-		(step.Reference != nil && step.Chain != nil) {
-		ret = append(ret, context.errorf("only one of `ref`, `chain`, or a literal test step can be set"))
+	var set int
+	for _, isSet := range []bool{step.LiteralTestStep != nil, step.Reference != nil, step.Chain != nil, step.Parallel != nil} {
+		if isSet {
+			set++
+		}
+	}
+	if set > 1 {
+		ret = append(ret, context.errorf("only one of `ref`, `chain`, `parallel`, or a literal test step can be set"))
 		return
 	}
-	if step.LiteralTestStep == nil && step.Reference == nil && step.Chain == nil {
-		ret = append(ret, context.errorf("a reference, chain, or literal test step is required"))
+	if set == 0 {
+		ret = append(ret, context.errorf("a reference, chain, parallel group, or literal test step is required"))
 		return
 	}
+	if step.LiteralTestStep != nil && step.LiteralTestStep.ParallelGroup != "" {
+		ret = append(ret, context.addField("parallel_group").errorf("cannot be set by hand, use a `parallel` block instead"))
+	}
 	if step.Reference != nil {
 		if len(*step.Reference) == 0 {
 			ret = append(ret, context.addField("ref").errorf("length cannot be 0"))
@@ -682,6 +831,7 @@ func validateTestStep(context *context, step api.TestStep) (ret []error) {
 			context.namesSeen.Insert(*step.Chain)
 		}
 	}
+	ret = append(ret, validateConditions(context.addField("when"), step.When)...)
 	return
 }
 
@@ -717,6 +867,7 @@ func (v *Validator) validateLiteralTestStep(context *context, stage testStage, s
 			ret = append(ret, err)
 		}
 	}
+	ret = append(ret, validateParameterTypes(context, step.Environment)...)
 	ret = append(ret, validateDependencies(string(context.field), step.Dependencies)...)
 	ret = append(ret, validateLeases(context.addField("leases"), step.Leases)...)
 	if step.NodeArchitecture != nil {
@@ -724,6 +875,25 @@ func (v *Validator) validateLiteralTestStep(context *context, stage testStage, s
 			ret = append(ret, err)
 		}
 	}
+	if step.Retry != nil {
+		ret = append(ret, validateRetryPolicy(context.addField("retry"), *step.Retry)...)
+	}
+	ret = append(ret, validateConditions(context.addField("when"), step.When)...)
+	// registry components are validated without the environment of a test
+	if context.env != nil {
+		declared := sets.New[string]()
+		for _, param := range step.Environment {
+			declared.Insert(param.Name)
+		}
+		for i, condition := range step.When {
+			if condition.Env == "" || declared.Has(condition.Env) {
+				continue
+			}
+			if _, ok := context.env[condition.Env]; !ok {
+				ret = append(ret, context.addField("when").addIndex(i).addField("env").errorf("parameter %q is neither declared by the step nor set in the environment of the test", condition.Env))
+			}
+		}
+	}
 	switch stage {
 	case testStagePre, testStageTest:
 		if step.OptionalOnSuccess != nil {
@@ -733,6 +903,44 @@ func (v *Validator) validateLiteralTestStep(context *context, stage testStage, s
 	return ret
 }
 
+func validateRetryPolicy(context *context, policy api.StepRetryPolicy) (ret []error) {
+	if policy.MaxAttempts < 1 {
+		ret = append(ret, context.addField("max_attempts").errorf("must be at least 1"))
+	}
+	if policy.Backoff != nil && policy.Backoff.Duration < 0 {
+		ret = append(ret, context.addField("backoff").errorf("must not be negative"))
+	}
+	for i, code := range policy.ExitCodes {
+		if code == 0 {
+			ret = append(ret, context.addField("exit_codes").addIndex(i).errorf("a successful exit code cannot be retried"))
+		}
+	}
+	for i, reason := range policy.Reasons {
+		if reason == "" {
+			ret = append(ret, context.addField("reasons").addIndex(i).errorf("must not be empty"))
+		}
+	}
+	return ret
+}
+
+func validateConditions(context *context, conditions []api.StepCondition) (ret []error) {
+	for i, condition := range conditions {
+		contextI := context.addIndex(i)
+		if condition.Env == "" {
+			ret = append(ret, contextI.addField("env").errorf("must not be empty"))
+		}
+		switch condition.Operator {
+		case api.StepConditionOperatorIn, api.StepConditionOperatorNotIn:
+		default:
+			ret = append(ret, contextI.addField("operator").errorf("must be one of %q or %q", api.StepConditionOperatorIn, api.StepConditionOperatorNotIn))
+		}
+		if len(condition.Values) == 0 {
+			ret = append(ret, contextI.addField("values").errorf("must not be empty"))
+		}
+	}
+	return ret
+}
+
 func validateFromAndFromImage(
 	context *context,
 	from string,
@@ -897,6 +1105,29 @@ func validateParameters(context *context, params []api.StepParameter) error {
 	return nil
 }
 
+// validateParameterTypes validates the type information of the parameters and
+// that the values set in the environment of the test, or the defaults when
+// none is set, are of the declared types.
+func validateParameterTypes(context *context, params []api.StepParameter) (ret []error) {
+	for i, param := range params {
+		contextI := context.addField("env").addIndex(i)
+		if err := param.ValidateType(); err != nil {
+			ret = append(ret, contextI.errorf("parameter %s: %v", param.Name, err))
+			continue
+		}
+		if value, ok := context.env[param.Name]; ok {
+			if err := param.ValidateValue(value); err != nil {
+				ret = append(ret, contextI.errorf("invalid value for parameter %s set in the environment of the test: %v", param.Name, err))
+			}
+		} else if param.Default != nil {
+			if err := param.ValidateValue(*param.Default); err != nil {
+				ret = append(ret, contextI.addField("default").errorf("invalid value for parameter %s: %v", param.Name, err))
+			}
+		}
+	}
+	return ret
+}
+
 func validateDependencies(fieldRoot string, dependencies []api.StepDependency) []error {
 	var errs []error
 	env := sets.New[string]()
@@ -953,6 +1184,26 @@ func validateLeases(context *context, leases []api.StepLease) (ret []error) {
 	return
 }
 
+// validatePhaseBudgets ensures the budgets are positive and that reserving
+// the `post` budget leaves time for the other phases within the job timeout.
+func validatePhaseBudgets(context *context, budgets *api.PhaseBudgets, timeout *prowv1.Duration) (ret []error) {
+	if budgets == nil {
+		return nil
+	}
+	for _, phase := range []struct {
+		name   string
+		budget *prowv1.Duration
+	}{{"pre", budgets.Pre}, {"test", budgets.Test}, {"post", budgets.Post}} {
+		if phase.budget != nil && phase.budget.Duration <= 0 {
+			ret = append(ret, context.addField(phase.name).errorf("must be positive, got %s", phase.budget.Duration))
+		}
+	}
+	if budgets.Post != nil && timeout != nil && budgets.Post.Duration >= timeout.Duration {
+		ret = append(ret, context.addField("post").errorf("must be shorter than the job timeout of %s", timeout.Duration))
+	}
+	return ret
+}
+
 func validateNodeArchitectureOverrides(fieldRoot string, nodeArchitectureOverrides api.NodeArchitectureOverrides) error {
 	for index, arch := range nodeArchitectureOverrides {
 		if err := arch.Validate(); err != nil {
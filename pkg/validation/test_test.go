@@ -19,6 +19,7 @@ import (
 
 func TestValidateTests(t *testing.T) {
 	cronString := "0 0 * * 1"
+	workflow := "workflow"
 	invalidCronString := "r 0 * * 1"
 	intervalString := "6h"
 	invalidIntervalString := "6t"
@@ -773,6 +774,120 @@ func TestValidateTests(t *testing.T) {
 				},
 			},
 		},
+		{
+			id: `matrix generates valid tests`,
+			tests: []api.TestStepConfiguration{
+				{
+					As: "e2e",
+					Matrix: &api.TestMatrix{Dimensions: []api.MatrixDimension{
+						{Env: "NETWORK", Values: []api.MatrixValue{{Name: "ovn"}, {Name: "sdn"}}},
+					}},
+					MultiStageTestConfiguration: &api.MultiStageTestConfiguration{Workflow: &workflow},
+				},
+			},
+		},
+		{
+			id: `matrix cannot generate a test with the name of another test`,
+			tests: []api.TestStepConfiguration{
+				{
+					As: "e2e",
+					Matrix: &api.TestMatrix{Dimensions: []api.MatrixDimension{
+						{Env: "NETWORK", Values: []api.MatrixValue{{Name: "ovn"}, {Name: "sdn"}}},
+					}},
+					MultiStageTestConfiguration: &api.MultiStageTestConfiguration{Workflow: &workflow},
+				},
+				{
+					As:                          "e2e-ovn",
+					MultiStageTestConfiguration: &api.MultiStageTestConfiguration{Workflow: &workflow},
+				},
+			},
+			expectedError: errors.New("tests: found duplicated test: (e2e-ovn)"),
+		},
+		{
+			id: `matrix generated tests are validated`,
+			tests: []api.TestStepConfiguration{
+				{
+					As: "e2e",
+					Matrix: &api.TestMatrix{Dimensions: []api.MatrixDimension{
+						{Env: "NETWORK", Values: []api.MatrixValue{{Name: "ovn"}}},
+					}},
+					MultiStageTestConfiguration: &api.MultiStageTestConfiguration{Workflow: &workflow},
+					Postsubmit:                  true,
+					Optional:                    true,
+				},
+			},
+			expectedError: errors.New("tests[0](e2e-ovn): `optional` and `postsubmit` are mututally exclusive"),
+		},
+		{
+			id: `matrix only applies to multi-stage tests`,
+			tests: []api.TestStepConfiguration{
+				{
+					As:                         "unit",
+					Matrix:                     &api.TestMatrix{Dimensions: []api.MatrixDimension{{Env: "NETWORK", Values: []api.MatrixValue{{Name: "ovn"}}}}},
+					ContainerTestConfiguration: &api.ContainerTestConfiguration{From: "ignored"},
+				},
+			},
+			expectedError: errors.New("tests[0].matrix: only multi-stage tests can have a matrix"),
+		},
+		{
+			id: `matrix value names must be usable in test names`,
+			tests: []api.TestStepConfiguration{
+				{
+					As:                          "e2e",
+					Matrix:                      &api.TestMatrix{Dimensions: []api.MatrixDimension{{Env: "NETWORK", Values: []api.MatrixValue{{Name: "OVN"}}}}},
+					MultiStageTestConfiguration: &api.MultiStageTestConfiguration{Workflow: &workflow},
+				},
+			},
+			expectedError: errors.New(`tests[0].matrix.dimensions[0].values[0].name: "OVN" cannot be used in the name of a test: a lowercase RFC 1123 label must consist of lower case alphanumeric characters or '-', and must start and end with an alphanumeric character (e.g. 'my-name',  or '123-abc', regex used for validation is '[a-z0-9]([-a-z0-9]*[a-z0-9])?')`),
+		},
+		{
+			id: `matrix overrides must match dimensions`,
+			tests: []api.TestStepConfiguration{
+				{
+					As: "e2e",
+					Matrix: &api.TestMatrix{
+						Dimensions: []api.MatrixDimension{{Env: "NETWORK", Values: []api.MatrixValue{{Name: "ovn"}}}},
+						Overrides:  []api.MatrixOverride{{Match: map[string]string{"NETWORK": "sdn"}, Exclude: true}},
+					},
+					MultiStageTestConfiguration: &api.MultiStageTestConfiguration{Workflow: &workflow},
+				},
+			},
+			expectedError: errors.New(`tests[0].matrix.overrides[0].match: "sdn" is not a value of dimension "NETWORK"`),
+		},
+		{
+			id: `result cache on a container test is valid`,
+			tests: []api.TestStepConfiguration{
+				{
+					As:                         "unit",
+					Commands:                   "make test",
+					ResultCache:                &api.TestResultCache{MaxAge: &prowv1.Duration{Duration: time.Hour}},
+					ContainerTestConfiguration: &api.ContainerTestConfiguration{From: "ignored"},
+				},
+			},
+		},
+		{
+			id: `result cache cannot be used with a cluster claim`,
+			tests: []api.TestStepConfiguration{
+				{
+					As:                          "e2e",
+					ResultCache:                 &api.TestResultCache{},
+					ClusterClaim:                &api.ClusterClaim{Product: api.ReleaseProductOCP, Version: "4.16", Architecture: api.ReleaseArchitectureAMD64, Cloud: api.CloudAWS, Owner: "dpp", Timeout: &prowv1.Duration{Duration: time.Hour}},
+					MultiStageTestConfiguration: &api.MultiStageTestConfiguration{Workflow: &workflow},
+				},
+			},
+			expectedError: errors.New("tests[0].result_cache: tests with a cluster claim cannot reuse results"),
+		},
+		{
+			id: `result cache max age must be positive`,
+			tests: []api.TestStepConfiguration{
+				{
+					As:                         "unit",
+					ResultCache:                &api.TestResultCache{MaxAge: &prowv1.Duration{}},
+					ContainerTestConfiguration: &api.ContainerTestConfiguration{From: "ignored"},
+				},
+			},
+			expectedError: errors.New("tests[0].result_cache.max_age: must be positive, got 0s"),
+		},
 	} {
 		t.Run(tc.id, func(t *testing.T) {
 			v := newSingleUseValidator()
@@ -1038,7 +1153,73 @@ func TestValidateTestSteps(t *testing.T) {
 			Reference: &myReference,
 		}},
 		errs: []error{
-			errors.New("test[0]: only one of `ref`, `chain`, or a literal test step can be set"),
+			errors.New("test[0]: only one of `ref`, `chain`, `parallel`, or a literal test step can be set"),
+		},
+	}, {
+		name: "parallel group",
+		steps: []api.TestStep{{
+			Parallel: []api.ParallelTestStep{{
+				LiteralTestStep: &api.LiteralTestStep{
+					As:        "as",
+					From:      "from",
+					Commands:  "commands",
+					Resources: resources},
+			}, {
+				Reference: &myReference,
+			}},
+		}},
+	}, {
+		name: "empty parallel group",
+		steps: []api.TestStep{{
+			Parallel: []api.ParallelTestStep{},
+		}},
+		errs: []error{
+			errors.New("test[0].parallel: a parallel group must contain at least one step"),
+		},
+	}, {
+		name: "reference and literal in a parallel group member",
+		steps: []api.TestStep{{
+			Parallel: []api.ParallelTestStep{{
+				Reference: &myReference,
+			}, {
+				LiteralTestStep: &api.LiteralTestStep{
+					As:        "as",
+					From:      "from",
+					Commands:  "commands",
+					Resources: resources},
+				Reference: &myReference,
+			}},
+		}},
+		errs: []error{
+			errors.New("test[0].parallel[1]: only one of `ref`, `chain`, `parallel`, or a literal test step can be set"),
+		},
+	}, {
+		name: "literal step setting its parallel group by hand",
+		steps: []api.TestStep{{
+			LiteralTestStep: &api.LiteralTestStep{
+				As:            "as",
+				From:          "from",
+				Commands:      "commands",
+				Resources:     resources,
+				ParallelGroup: "as"},
+		}},
+		errs: []error{
+			errors.New("test[0].parallel_group: cannot be set by hand, use a `parallel` block instead"),
+		},
+	}, {
+		name: "parallel group member setting its parallel group by hand",
+		steps: []api.TestStep{{
+			Parallel: []api.ParallelTestStep{{
+				LiteralTestStep: &api.LiteralTestStep{
+					As:            "as",
+					From:          "from",
+					Commands:      "commands",
+					Resources:     resources,
+					ParallelGroup: "other"},
+			}},
+		}},
+		errs: []error{
+			errors.New("test[0].parallel[0].parallel_group: cannot be set by hand, use a `parallel` block instead"),
 		},
 	}, {
 		name: "Step with same name as reference",
@@ -1211,7 +1392,7 @@ func TestValidatePostSteps(t *testing.T) {
 }
 
 func TestValidateParameters(t *testing.T) {
-	defaultStr := "default"
+	defaultStr, falseStr, calicoStr := "default", "false", "calico"
 	for _, tc := range []struct {
 		name     string
 		params   []api.StepParameter
@@ -1232,6 +1413,25 @@ func TestValidateParameters(t *testing.T) {
 		params: []api.StepParameter{{Name: "TEST0"}, {Name: "TEST1"}},
 		env:    api.TestEnvironment{"TEST0": "test0"},
 		err:    []error{errors.New("test: unresolved parameter(s): [TEST1]")},
+	}, {
+		name:   "typed parameter, valid value provided",
+		params: []api.StepParameter{{Name: "FIPS_ENABLED", Type: api.StepParameterTypeBool, Default: &falseStr}},
+		env:    api.TestEnvironment{"FIPS_ENABLED": "true"},
+	}, {
+		name:   "typed parameter, invalid value provided",
+		params: []api.StepParameter{{Name: "FIPS_ENABLED", Type: api.StepParameterTypeBool, Default: &falseStr}},
+		env:    api.TestEnvironment{"FIPS_ENABLED": "ture"},
+		err:    []error{errors.New(`test.env[0]: invalid value for parameter FIPS_ENABLED set in the environment of the test: "ture" is not a bool, expected true or false`)},
+	}, {
+		name:   "typed parameter, invalid default",
+		params: []api.StepParameter{{Name: "NETWORK", Type: api.StepParameterTypeEnum, AllowedValues: []string{"ovn", "sdn"}, Default: &calicoStr}},
+		env:    api.TestEnvironment{},
+		err:    []error{errors.New(`test.env[0].default: invalid value for parameter NETWORK: "calico" is not one of the allowed values: ovn, sdn`)},
+	}, {
+		name:   "invalid type",
+		params: []api.StepParameter{{Name: "VERSION", Type: api.StepParameterTypeRegex}},
+		env:    api.TestEnvironment{"VERSION": "4.21"},
+		err:    []error{errors.New("test.env[0]: parameter VERSION: parameters of type regex must set a pattern")},
 	}} {
 		t.Run(tc.name, func(t *testing.T) {
 			v := NewValidator(nil, nil)
@@ -1252,6 +1452,100 @@ func TestValidateParameters(t *testing.T) {
 	}
 }
 
+func TestValidateRetryPolicy(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		policy api.StepRetryPolicy
+		errs   []error
+	}{{
+		name:   "valid policy",
+		policy: api.StepRetryPolicy{MaxAttempts: 3, Backoff: &prowv1.Duration{Duration: time.Minute}, ExitCodes: []int32{1}, Reasons: []string{api.ReasonPending}},
+	}, {
+		name:   "no attempts",
+		policy: api.StepRetryPolicy{},
+		errs:   []error{errors.New("test.retry.max_attempts: must be at least 1")},
+	}, {
+		name:   "negative backoff",
+		policy: api.StepRetryPolicy{MaxAttempts: 2, Backoff: &prowv1.Duration{Duration: -time.Minute}},
+		errs:   []error{errors.New("test.retry.backoff: must not be negative")},
+	}, {
+		name:   "invalid exit codes and reasons",
+		policy: api.StepRetryPolicy{MaxAttempts: 2, ExitCodes: []int32{1, 0}, Reasons: []string{""}},
+		errs: []error{
+			errors.New("test.retry.exit_codes[1]: a successful exit code cannot be retried"),
+			errors.New("test.retry.reasons[0]: must not be empty"),
+		},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			v := NewValidator(nil, nil)
+			errs := v.validateLiteralTestStep(newContext("test", nil, nil, make(testInputImages)), testStageTest, api.LiteralTestStep{
+				As:       "as",
+				From:     "from",
+				Commands: "commands",
+				Resources: api.ResourceRequirements{
+					Requests: api.ResourceList{"cpu": "1"},
+					Limits:   api.ResourceList{"memory": "1m"},
+				},
+				Retry: &tc.policy,
+			}, nil)
+			if !errListMessagesEqual(errs, tc.errs) {
+				t.Error(diff.ObjectReflectDiff(errs, tc.errs))
+			}
+		})
+	}
+}
+
+func TestValidateConditions(t *testing.T) {
+	for _, tc := range []struct {
+		name       string
+		conditions []api.StepCondition
+		testEnv    api.TestEnvironment
+		errs       []error
+	}{{
+		name:       "condition on a parameter of the step",
+		conditions: []api.StepCondition{{Env: "STEP_PARAM", Operator: api.StepConditionOperatorIn, Values: []string{"true"}}},
+	}, {
+		name:       "condition on a parameter of the test",
+		conditions: []api.StepCondition{{Env: "TEST_PARAM", Operator: api.StepConditionOperatorNotIn, Values: []string{""}}},
+		testEnv:    api.TestEnvironment{"TEST_PARAM": "value"},
+	}, {
+		name:       "condition on an undeclared parameter",
+		conditions: []api.StepCondition{{Env: "UNKNOWN", Operator: api.StepConditionOperatorIn, Values: []string{"true"}}},
+		testEnv:    api.TestEnvironment{"TEST_PARAM": "value"},
+		errs:       []error{errors.New(`test.when[0].env: parameter "UNKNOWN" is neither declared by the step nor set in the environment of the test`)},
+	}, {
+		name:       "condition on a parameter set by a parent component, validated without a test environment",
+		conditions: []api.StepCondition{{Env: "UNKNOWN", Operator: api.StepConditionOperatorIn, Values: []string{"true"}}},
+	}, {
+		name:       "invalid condition",
+		conditions: []api.StepCondition{{Operator: "equals"}},
+		errs: []error{
+			errors.New("test.when[0].env: must not be empty"),
+			errors.New(`test.when[0].operator: must be one of "in" or "notin"`),
+			errors.New("test.when[0].values: must not be empty"),
+		},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			defaultValue := "false"
+			v := NewValidator(nil, nil)
+			errs := v.validateLiteralTestStep(newContext("test", tc.testEnv, nil, make(testInputImages)), testStageTest, api.LiteralTestStep{
+				As:       "as",
+				From:     "from",
+				Commands: "commands",
+				Resources: api.ResourceRequirements{
+					Requests: api.ResourceList{"cpu": "1"},
+					Limits:   api.ResourceList{"memory": "1m"},
+				},
+				Environment: []api.StepParameter{{Name: "STEP_PARAM", Default: &defaultValue}},
+				When:        tc.conditions,
+			}, nil)
+			if !errListMessagesEqual(errs, tc.errs) {
+				t.Error(diff.ObjectReflectDiff(errs, tc.errs))
+			}
+		})
+	}
+}
+
 func TestValidateCredentials(t *testing.T) {
 	var testCases = []struct {
 		name   string
@@ -1796,6 +2090,89 @@ func TestValidateLeases(t *testing.T) {
 	}
 }
 
+func TestValidatePhaseBudgets(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		timeout *prowv1.Duration
+		budgets *api.PhaseBudgets
+		err     []error
+	}{{
+		name: "no budgets",
+	}, {
+		name:    "valid budgets",
+		timeout: &prowv1.Duration{Duration: 4 * time.Hour},
+		budgets: &api.PhaseBudgets{
+			Pre:  &prowv1.Duration{Duration: time.Hour},
+			Test: &prowv1.Duration{Duration: 2 * time.Hour},
+			Post: &prowv1.Duration{Duration: time.Hour},
+		},
+	}, {
+		name: "budgets must be positive",
+		budgets: &api.PhaseBudgets{
+			Pre:  &prowv1.Duration{},
+			Post: &prowv1.Duration{Duration: -time.Hour},
+		},
+		err: []error{
+			errors.New("tests[0].steps.budgets.pre: must be positive, got 0s"),
+			errors.New("tests[0].steps.budgets.post: must be positive, got -1h0m0s"),
+		},
+	}, {
+		name:    "post budget must leave time for the other phases",
+		timeout: &prowv1.Duration{Duration: time.Hour},
+		budgets: &api.PhaseBudgets{Post: &prowv1.Duration{Duration: time.Hour}},
+		err: []error{
+			errors.New("tests[0].steps.budgets.post: must be shorter than the job timeout of 1h0m0s"),
+		},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			test := api.TestStepConfiguration{
+				Timeout:                            tc.timeout,
+				MultiStageTestConfigurationLiteral: &api.MultiStageTestConfigurationLiteral{Budgets: tc.budgets},
+			}
+			v := NewValidator(nil, nil)
+			err := v.validateTestConfigurationType("tests[0]", test, nil, nil, nil, make(testInputImages), true)
+			if diff := diff.ObjectReflectDiff(tc.err, err); diff != "<no diffs>" {
+				t.Errorf("unexpected error: %s", diff)
+			}
+		})
+	}
+}
+
+func TestValidateParallelGroups(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		steps []api.LiteralTestStep
+		err   []error
+	}{{
+		name:  "no groups",
+		steps: []api.LiteralTestStep{{As: "a"}, {As: "b"}},
+	}, {
+		name: "consecutive groups",
+		steps: []api.LiteralTestStep{
+			{As: "a", ParallelGroup: "a"},
+			{As: "b", ParallelGroup: "a"},
+			{As: "c"},
+			{As: "d", ParallelGroup: "d"},
+			{As: "e", ParallelGroup: "d"},
+		},
+	}, {
+		name: "group is split",
+		steps: []api.LiteralTestStep{
+			{As: "a", ParallelGroup: "a"},
+			{As: "b"},
+			{As: "c", ParallelGroup: "a"},
+		},
+		err: []error{errors.New("test[2]: steps in parallel group \"a\" must be consecutive")},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateParallelGroups(newContext("test", nil, nil, nil), tc.steps)
+			if diff := cmp.Diff(tc.err, err, testhelper.EquateErrorMessage); diff != "" {
+				t.Errorf("unexpected error: %s", diff)
+			}
+		})
+	}
+}
+
 func TestValidateTestConfigurationType(t *testing.T) {
 	for _, tc := range []struct {
 		name     string
@@ -23,11 +23,13 @@ type node struct {
 
 // subgraph is a group of sequential steps and/or other subgraphs
 // Used to render both registry chains and the pre/test/post steps of a
-// workflow.
+// workflow.  Parallel groups are also rendered as subgraphs, but their nodes
+// are not connected to each other.
 type subgraph struct {
 	label     string
 	nodes     []int
 	subgraphs []int
+	parallel  bool
 }
 
 // edge connects node objects in the final drawing
@@ -156,6 +158,9 @@ func (b *graphBuilder) addSubgraph(label string, steps []api.TestStep) int {
 		} else if step.Chain != nil {
 			i := b.addSubgraph(*step.Chain, b.chains[*step.Chain].Steps)
 			sg.subgraphs = append(sg.subgraphs, i)
+		} else if step.Parallel != nil {
+			i := b.addParallel(step.Parallel)
+			sg.subgraphs = append(sg.subgraphs, i)
 		}
 	}
 	i := len(b.graph.subgraphs)
@@ -173,6 +178,28 @@ func (b *graphBuilder) addSubgraph(label string, steps []api.TestStep) int {
 	return i
 }
 
+// addParallel creates a sub-graph for a group of concurrent steps
+// Each step gets its own incoming edge from the previous node, while the
+// outgoing edge is clipped to the bounding box of the group.
+func (b *graphBuilder) addParallel(steps []api.ParallelTestStep) int {
+	sg := subgraph{label: "parallel", parallel: true}
+	in := b.edge
+	in.dstType = nodeType
+	for _, step := range steps {
+		b.edge = in
+		if step.LiteralTestStep != nil {
+			b.addNode(&sg, node{label: step.As})
+		} else if step.Reference != nil {
+			b.addNode(&sg, node{label: *step.Reference, linkable: true})
+		}
+	}
+	i := len(b.graph.subgraphs)
+	b.graph.subgraphs = append(b.graph.subgraphs, sg)
+	b.edge.srcType = subgraphType
+	b.edge.srcGraph = i
+	return i
+}
+
 // addNode creates a single leaf node and, if necessary, an edge
 func (b *graphBuilder) addNode(sg *subgraph, n node) {
 	i := len(b.graph.nodes)
@@ -250,7 +277,7 @@ func writeSubgraph(g graph, tmpl *template.Template, b *strings.Builder, i int,
 		panic(fmt.Errorf("subgraph template rendering failed: %w", err))
 	}
 	for _, i := range g.subgraphs[i].subgraphs {
-		writeSubgraph(g, tmpl, b, i, prefix+"\t", true)
+		writeSubgraph(g, tmpl, b, i, prefix+"\t", !g.subgraphs[i].parallel)
 	}
 	b.WriteString(prefix)
 	b.WriteString("}\n")
@@ -135,6 +135,18 @@ func TestWorkflowDotFile(t *testing.T) {
 				{Chain: &installChain},
 			},
 		},
+	}, {
+		name: "parallel",
+		workflow: api.MultiStageTestConfiguration{
+			Pre: []api.TestStep{{Chain: &installChain}},
+			Post: []api.TestStep{
+				{Parallel: []api.ParallelTestStep{
+					{Reference: &gather},
+					{LiteralTestStep: &api.LiteralTestStep{As: "gather-extra"}},
+				}},
+				{Reference: &deprovision},
+			},
+		},
 	}, {
 		name: "chain-to-step",
 		workflow: api.MultiStageTestConfiguration{
@@ -70,6 +70,9 @@ const bodyStart = `
       <li class="nav-item">
         <a class="nav-link" href="/search">Jobs</a>
       </li>
+      <li class="nav-item">
+        <a class="nav-link" href="/deprecated">Deprecated</a>
+      </li>
       <li class="nav-item">
         <a class="nav-link" href="http://docs.ci.openshift.org">Help</a>
       </li>
@@ -101,7 +104,8 @@ const mainPage = `
 `
 
 const referencePage = `
-<h2 id="title"><a href="#title">Step:</a> <nobr style="font-family:monospace">{{ .Reference.As }}</nobr></h2>
+<h2 id="title"><a href="#title">Step:</a> <nobr style="font-family:monospace">{{ .Reference.As }}</nobr> {{ template "deprecationBadge" .Deprecation }}</h2>
+{{ template "deprecationNotice" .Deprecation }}
 <p id="documentation">{{ .Reference.Documentation }}</p>
 <h3 id="image"><a href="#image">Container image used for this step:</a> <span style="font-family:monospace">{{ fromImage .Reference.From .Reference.FromImage }}</span></h3>
 <p id="image">{{ fromImageDescription .Reference.From .Reference.FromImage }}<d/p>
@@ -111,12 +115,15 @@ const referencePage = `
 {{ syntaxedSource .Reference.Commands }}
 <h3 id="properties"><a href="#properties">Properties</a></h3>
 {{ template "referenceProperties" .Reference }}
+<h3 id="usage" title="Chains, workflows and tests which run this step"><a href="#usage">Used By</a></h3>
+{{ template "usageTable" .Usage }}
 <h3 id="github"><p><a href="#github">GitHub Link:</a></h3></p>{{ githubLink .Metadata.Path }}
 {{ ownersBlock .Metadata.Owners }}
 `
 
 const chainPage = `
-<h2 id="title"><a href="#title">Chain:</a> <nobr style="font-family:monospace">{{ .Chain.As }}</nobr></h2>
+<h2 id="title"><a href="#title">Chain:</a> <nobr style="font-family:monospace">{{ .Chain.As }}</nobr> {{ template "deprecationBadge" .Deprecation }}</h2>
+{{ template "deprecationNotice" .Deprecation }}
 <p id="documentation">{{ .Chain.Documentation }}</p>
 <h3 id="steps" title="Step run by the chain, in runtime order"><a href="#steps">Steps</a></h3>
 {{ template "stepTable" .Chain.Steps}}
@@ -127,6 +134,8 @@ const chainPage = `
 {{ template "refEnvironment" .Chain.As }}
 <h3 id="graph" title="Visual representation of steps run by this chain"><a href="#graph">Step Graph</a></h3>
 {{ chainGraph .Chain.As }}
+<h3 id="usage" title="Chains, workflows and tests which run this chain"><a href="#usage">Used By</a></h3>
+{{ template "usageTable" .Usage }}
 <h3 id="github"><a href="#github">GitHub Link:</a></h3>{{ githubLink .Metadata.Path }}
 {{ ownersBlock .Metadata.Owners }}
 `
@@ -134,7 +143,10 @@ const chainPage = `
 // workflowJobPage defines the template for both jobs and workflows
 const workflowJobPage = `
 {{ $type := .Workflow.Type }}
-<h2 id="title"><a href="#title">{{ $type }}:</a> <nobr style="font-family:monospace">{{ .Workflow.As }}</nobr></h2>
+<h2 id="title"><a href="#title">{{ $type }}:</a> <nobr style="font-family:monospace">{{ .Workflow.As }}</nobr>{{ if eq $type "Workflow" }} {{ template "deprecationBadge" .Deprecation }}{{ end }}</h2>
+{{ if eq $type "Workflow" }}
+	{{ template "deprecationNotice" .Deprecation }}
+{{ end }}
 {{ if .Workflow.Documentation }}
 	<p id="documentation">{{ .Workflow.Documentation }}</p>
 {{ end }}
@@ -155,6 +167,8 @@ const workflowJobPage = `
 <h3 id="graph" title="Visual representation of steps run by this {{ toLower $type }}"><a href="#graph">Step Graph</a></h3>
 {{ workflowGraph .Workflow.As .Workflow.Type }}
 {{ if eq $type "Workflow" }}
+<h3 id="usage" title="Chains, workflows and tests which run this workflow"><a href="#usage">Used By</a></h3>
+{{ template "usageTable" .Usage }}
 <h3 id="github"><a href="#github">GitHub Link:</a></h3>{{ githubLink .Metadata.Path }}
 {{ ownersBlock .Metadata.Owners }}
 {{ end }}
@@ -164,11 +178,72 @@ const jobSearchPage = `
 {{ template "jobTable" . }}
 `
 
+const deprecatedPage = `
+<h2 id="deprecated"><a href="#deprecated">Deprecated Components</a></h2>
+<p>Tests using deprecated registry components get a warning until the removal date of the component, after which they fail.</p>
+{{ if not . }}
+	<p>No registry component is deprecated.</p>
+{{ else }}
+	<table class="table">
+		<thead>
+			<tr>
+				<th title="The deprecated registry component" class="info">Component</th>
+				<th title="Why the component is deprecated" class="info">Reason</th>
+				<th title="The component to use instead" class="info">Replacement</th>
+				<th title="The date from which tests using the component fail" class="info">Removal Date</th>
+				<th title="The tests which use the component" class="info">Used By Tests</th>
+			</tr>
+		</thead>
+		<tbody>
+			{{ range $index, $notice := . }}
+				<tr>
+					<td>{{ $notice.Type }} {{ template "nameWithLink" $notice }} {{ template "deprecationBadge" $notice }}</td>
+					<td>{{ $notice.Reason }}</td>
+					<td>{{ if $notice.Replacement }}<nobr><a href="/{{ $notice.Type }}/{{ $notice.Replacement }}" style="font-family:monospace">{{ $notice.Replacement }}</a></nobr>{{ end }}</td>
+					<td>{{ $notice.RemovalDate }}</td>
+					<td>
+						<ul>
+						{{ range $index, $test := $notice.Tests }}
+							<li><nobr><a href="/job?org={{$test.Org}}&repo={{$test.Repo}}&branch={{$test.Branch}}&test={{$test.Test}}{{ if $test.Variant }}&variant={{$test.Variant}}{{ end }}" style="font-family:monospace">{{ $test.Org }}/{{ $test.Repo }}@{{ $test.Branch }}{{ if $test.Variant }}__{{ $test.Variant }}{{ end }}: {{ $test.Test }}</a></nobr></li>
+						{{ end }}
+						</ul>
+					</td>
+				</tr>
+			{{ end }}
+		</tbody>
+	</table>
+{{ end }}
+`
+
 const templateDefinitions = `
 {{ define "nameWithLink" }}
 	<nobr><a href="/{{ .Type }}/{{ .Name }}" style="font-family:monospace">{{ .Name }}</a></nobr>
 {{ end }}
 
+{{ define "deprecationBadge" }}
+	{{- if . }}
+		{{- if .Removed }}
+	<span class="badge badge-danger" title="{{ .Reason }}">removed</span>
+		{{- else }}
+	<span class="badge badge-warning" title="{{ .Reason }}">deprecated</span>
+		{{- end }}
+	{{- end }}
+{{ end }}
+
+{{ define "deprecationNotice" }}
+	{{ if . }}
+	<div id="deprecation" class="alert {{ if .Removed }}alert-danger{{ else }}alert-warning{{ end }}" role="alert">
+		<p><b>This {{ .Type }} is deprecated:</b> {{ .Reason }}</p>
+		{{ if .Replacement }}
+		<p>Use <nobr><a href="/{{ .Type }}/{{ .Replacement }}" style="font-family:monospace">{{ .Replacement }}</a></nobr> instead.</p>
+		{{ end }}
+		{{ if .RemovalDate }}
+		<p>{{ if .Removed }}Tests using it fail since {{ .RemovalDate }}.{{ else }}Tests using it will fail from {{ .RemovalDate }}.{{ end }}</p>
+		{{ end }}
+	</div>
+	{{ end }}
+{{ end }}
+
 {{ define "nameWithLinkReference" }}
 	<nobr><a href="/reference/{{ . }}" style="font-family:monospace">{{ . }}</a></nobr>
 {{ end }}
@@ -314,6 +389,7 @@ const templateDefinitions = `
 			 (default: <span style="font-family:monospace">{{ $env.Default }}</span>)
 		   {{ end }}
 		   {{ end }}
+		   {{ template "parameterType" $env }}
 		 </td>
 		 <td>
              {{ range $i, $step := $env.Steps }}
@@ -327,6 +403,14 @@ const templateDefinitions = `
     {{ end }}
 {{ end }}
 
+{{ define "parameterType" }}
+	{{ if .Type }}
+		<br>Type: <span style="font-family:monospace">{{ .Type }}</span>
+		{{- if .AllowedValues }}, allowed values: {{ range $i, $value := .AllowedValues }}{{ if $i }}, {{ end }}<span style="font-family:monospace">{{ $value }}</span>{{ end }}{{ end }}
+		{{- if .Pattern }}, matching <span style="font-family:monospace">{{ .Pattern }}</span>{{ end }}
+	{{ end }}
+{{ end }}
+
 {{ define "stepEnvironment" }}
 {{ if and (eq (len .Dependencies) 0) (eq (len .Environment) 0) (eq (len .Leases) 0) }}
   <p>Step exposes no environmental variables except the <a href="https://docs.ci.openshift.org/architecture/step-registry/#available-environment-variables">defaults</a>.</p>
@@ -359,6 +443,7 @@ const templateDefinitions = `
          (default: <span style="font-family:monospace">{{ $env.Default }}</span>)
        {{ end }}
        {{ end }}
+       {{ template "parameterType" $env }}
      </td>
    </tr>
    {{ end }}
@@ -392,7 +477,7 @@ const templateDefinitions = `
 		</tr>
 	</thead>
 	<tbody>
-		{{ range $index, $step := . }}
+		{{ range $index, $step := flattenParallelSteps . }}
 			<tr>
 				{{ $nameAndType := testStepNameAndType $step }}
 				{{ $doc := docsForName $nameAndType.Name }}
@@ -411,7 +496,7 @@ const templateDefinitions = `
 
 {{ define "stepList" }}
 	<ul>
-	{{ range $index, $step := .}}
+	{{ range $index, $step := flattenParallelSteps .}}
 		{{ $nameAndType := testStepNameAndType $step }}
 		<li>{{ template "nameWithLink" $nameAndType }}</li>
 	{{ end }}
@@ -431,7 +516,7 @@ const templateDefinitions = `
 		<tbody>
 			{{ range $name, $config := . }}
 				<tr>
-					<td><b>Name:</b> {{ template "nameWithLinkWorkflow" $name }}<p>
+					<td><b>Name:</b> {{ template "nameWithLinkWorkflow" $name }} {{ template "deprecationBadge" (deprecationFor "workflow" $name) }}<p>
 						<b>Description:</b><br>{{ docsForName $name }}
 					</td>
 					<td>{{ if gt (len $config.Pre) 0 }}<b>Pre:</b>{{ template "stepList" $config.Pre }}{{ end }}
@@ -458,7 +543,7 @@ const templateDefinitions = `
 		<tbody>
 			{{ range $name, $config := . }}
 				<tr>
-					<td>{{ template "nameWithLinkChain" $name }}</td>
+					<td>{{ template "nameWithLinkChain" $name }} {{ template "deprecationBadge" (deprecationFor "chain" $name) }}</td>
 					<td>{{ docsForName $name }}</td>
 					<td>{{ template "stepList" $config.Steps }}</td>
 				</tr>
@@ -480,7 +565,7 @@ const templateDefinitions = `
 		<tbody>
 			{{ range $name, $config := . }}
 				<tr>
-					<td>{{ template "nameWithLinkReference" $name }}</td>
+					<td>{{ template "nameWithLinkReference" $name }} {{ template "deprecationBadge" (deprecationFor "reference" $name) }}</td>
 					<td>{{ docsForName $name }}</td>
 				</tr>
 			{{ end }}
@@ -555,11 +640,105 @@ const templateDefinitions = `
 		</tbody>
 	</table>
 {{ end }}
+{{ define "usageTable" }}
+	{{ if .Chains }}
+	<h4 id="usage-chains"><a href="#usage-chains">Chains</a></h4>
+	<ul>
+	{{ range $index, $name := .Chains }}
+		<li>{{ template "nameWithLinkChain" $name }}</li>
+	{{ end }}
+	</ul>
+	{{ end }}
+	{{ if .Workflows }}
+	<h4 id="usage-workflows"><a href="#usage-workflows">Workflows</a></h4>
+	<ul>
+	{{ range $index, $name := .Workflows }}
+		<li>{{ template "nameWithLinkWorkflow" $name }}</li>
+	{{ end }}
+	</ul>
+	{{ end }}
+	{{ if .Tests }}
+	<h4 id="usage-tests"><a href="#usage-tests">Tests</a></h4>
+	<table class="table">
+		<thead>
+			<tr>
+				<th title="The ci-operator configuration of the test" class="info">Configuration</th>
+				<th title="The name of the test" class="info">Test</th>
+				<th title="The jobs generated for the test" class="info">Jobs</th>
+			</tr>
+		</thead>
+		<tbody>
+			{{ range $index, $test := .Tests }}
+				<tr>
+					<td style="font-family:monospace">{{ $test.Org }}/{{ $test.Repo }}@{{ $test.Branch }}{{ if $test.Variant }}__{{ $test.Variant }}{{ end }}</td>
+					<td><nobr><a href="/job?org={{$test.Org}}&repo={{$test.Repo}}&branch={{$test.Branch}}&test={{$test.Test}}{{ if $test.Variant }}&variant={{$test.Variant}}{{ end }}" style="font-family:monospace">{{ $test.Test }}</a></nobr></td>
+					<td>
+						<ul>
+						{{ range $index, $job := $test.Jobs }}
+							<li style="font-family:monospace">{{ $job }}</li>
+						{{ end }}
+						</ul>
+					</td>
+				</tr>
+			{{ end }}
+		</tbody>
+	</table>
+	{{ end }}
+	{{ if not (or .Chains .Workflows .Tests) }}
+	<p>This component is not used by any chain, workflow or test.</p>
+	{{ end }}
+{{ end }}
 `
 
 const workflowType = "Workflow"
 const jobType = "Job"
 
+// deprecationNotice describes a deprecated registry component
+type deprecationNotice struct {
+	api.RegistryDeprecation
+	Type    string
+	Name    string
+	Removed bool
+	Tests   []registryserver.TestUsage
+}
+
+// newDeprecationNotice returns the notice for a component, nil if it is not deprecated
+func newDeprecationNotice(deprecations map[string]api.RegistryDeprecation, componentType, name string, now time.Time) *deprecationNotice {
+	deprecation, ok := deprecations[name]
+	if !ok {
+		return nil
+	}
+	return &deprecationNotice{
+		RegistryDeprecation: deprecation,
+		Type:                componentType,
+		Name:                name,
+		Removed:             registry.Removed(deprecation, now),
+	}
+}
+
+// deprecationNotices lists the notices of all deprecated components with the
+// tests which use them, by component type and name
+func deprecationNotices(deprecations registry.Deprecations, index *registryserver.UsageIndex, now time.Time) []deprecationNotice {
+	var notices []deprecationNotice
+	for _, item := range []struct {
+		componentType string
+		deprecations  map[string]api.RegistryDeprecation
+	}{
+		{componentType: registryserver.WorkflowType, deprecations: deprecations.Workflows},
+		{componentType: registryserver.ChainType, deprecations: deprecations.Chains},
+		{componentType: registryserver.ReferenceType, deprecations: deprecations.References},
+	} {
+		for _, name := range sets.List(sets.KeySet(item.deprecations)) {
+			notice := newDeprecationNotice(item.deprecations, item.componentType, name, now)
+			if usage, ok := index.Usage(item.componentType, name); ok {
+				notice.Tests = usage.Tests
+			}
+			notices = append(notices, *notice)
+		}
+	}
+	return notices
+}
+
 // workflowJob is a struct that can define either a workflow or a job
 type workflowJob struct {
 	api.RegistryWorkflow
@@ -665,8 +844,10 @@ func getBaseTemplate() (*template.Template, error) {
 			"getEnvironment": func(string) environmentData {
 				return environmentData{}
 			},
+			"deprecationFor": func(_, _ string) *deprecationNotice { return nil },
 
-			"testStepNameAndType": getTestStepNameAndType,
+			"testStepNameAndType":  getTestStepNameAndType,
+			"flattenParallelSteps": api.FlattenParallelSteps,
 			"noescape": func(str string) template.HTML {
 				return template.HTML(str)
 			},
@@ -712,7 +893,7 @@ func jobToWorkflow(name string, config api.MultiStageTestConfiguration, workflow
 	// If there are literal test steps, we need to add the command to the docs, without changing the original map
 	// check if there are literal test steps
 	literalExists := false
-	for _, step := range append(append(config.Pre, config.Test...), config.Post...) {
+	for _, step := range api.FlattenParallelSteps(append(append(config.Pre, config.Test...), config.Post...)) {
 		if step.LiteralTestStep != nil {
 			literalExists = true
 			break
@@ -724,7 +905,7 @@ func jobToWorkflow(name string, config api.MultiStageTestConfiguration, workflow
 			newDocs[k] = v
 		}
 		docs = newDocs
-		for _, step := range append(append(config.Pre, config.Test...), config.Post...) {
+		for _, step := range api.FlattenParallelSteps(append(append(config.Pre, config.Test...), config.Post...)) {
 			if step.LiteralTestStep != nil {
 				baseDoc := fmt.Sprintf(`Container image: <span style="font-family:monospace">%s</span>`, step.From)
 				if highlighted, err := syntaxBash(step.Commands); err == nil {
@@ -785,6 +966,23 @@ func setDocs(t *template.Template, docs map[string]string) *template.Template {
 		})
 }
 
+func setDeprecations(t *template.Template, deprecations registry.Deprecations, now time.Time) *template.Template {
+	return t.Funcs(
+		template.FuncMap{
+			"deprecationFor": func(componentType, name string) *deprecationNotice {
+				switch componentType {
+				case registryserver.ReferenceType:
+					return newDeprecationNotice(deprecations.References, componentType, name, now)
+				case registryserver.ChainType:
+					return newDeprecationNotice(deprecations.Chains, componentType, name, now)
+				case registryserver.WorkflowType:
+					return newDeprecationNotice(deprecations.Workflows, componentType, name, now)
+				}
+				return nil
+			},
+		})
+}
+
 func setWorkflowGraph(t *template.Template, chains registry.ChainByName, workflows registry.WorkflowByName) *template.Template {
 	return t.Funcs(
 		template.FuncMap{
@@ -801,6 +999,9 @@ func setWorkflowGraph(t *template.Template, chains registry.ChainByName, workflo
 type environmentLine struct {
 	Documentation string
 	Default       *string
+	Type          api.StepParameterType
+	AllowedValues []string
+	Pattern       string
 	Steps         []string
 }
 
@@ -812,11 +1013,15 @@ type environmentData struct {
 func getEnvironmentDataItems(worklist []api.TestStep, registryRefs registry.ReferenceByName, registryChains registry.ChainByName) map[string]environmentLine {
 	data := map[string]environmentLine{}
 
-	add := func(name, documentation, step string, defaultVal *string) {
+	add := func(param api.StepParameter, step string) {
+		name := param.Name
 		if _, ok := data[name]; !ok {
 			data[name] = environmentLine{
-				Documentation: documentation,
-				Default:       defaultVal,
+				Documentation: param.Documentation,
+				Default:       param.Default,
+				Type:          param.Type,
+				AllowedValues: param.AllowedValues,
+				Pattern:       param.Pattern,
 			}
 		}
 
@@ -838,7 +1043,7 @@ func getEnvironmentDataItems(worklist []api.TestStep, registryRefs registry.Refe
 				continue
 			}
 			for _, env := range ref.Environment {
-				add(env.Name, env.Documentation, ref.As, env.Default)
+				add(env, ref.As)
 			}
 		case step.Chain != nil:
 			chainName := *step.Chain
@@ -850,9 +1055,11 @@ func getEnvironmentDataItems(worklist []api.TestStep, registryRefs registry.Refe
 				}
 				worklist = append(worklist, chain.Steps...)
 			}
+		case step.Parallel != nil:
+			worklist = append(worklist, api.FlattenParallelSteps([]api.TestStep{step})...)
 		case step.LiteralTestStep != nil:
 			for _, env := range step.Environment {
-				add(env.Name, env.Documentation, step.As, env.Default)
+				add(env, step.As)
 			}
 		}
 	}
@@ -921,6 +1128,8 @@ func getDependencyDataItems(worklist []api.TestStep, registryRefs registry.Refer
 				}
 				worklist = append(worklist, chain.Steps...)
 			}
+		case step.Parallel != nil:
+			worklist = append(worklist, api.FlattenParallelSteps([]api.TestStep{step})...)
 		case step.LiteralTestStep != nil:
 			for _, dep := range step.Dependencies {
 				add(dep.Name, dep.Env, step.As)
@@ -1046,6 +1255,7 @@ func mainPageHandler(agent agents.RegistryAgent, templateString string, w http.R
 		return
 	}
 	page = setDocs(page, docs)
+	page = setDeprecations(page, agent.GetDeprecations(), time.Now())
 	page = setWorkflowGraph(page, chains, workflows)
 	page = setChainGraph(page, chains)
 	if page, err = page.Parse(templateString); err != nil {
@@ -1065,6 +1275,7 @@ func mainPageHandler(agent agents.RegistryAgent, templateString string, w http.R
 }
 
 func WebRegHandler(regAgent agents.RegistryAgent, confAgent agents.ConfigAgent) http.HandlerFunc {
+	usageIndexer := registryserver.NewUsageIndexer(confAgent, regAgent)
 	return func(w http.ResponseWriter, req *http.Request) {
 		trimmedPath := strings.TrimPrefix(req.URL.Path, req.URL.Host)
 		// remove leading slash
@@ -1082,6 +1293,8 @@ func WebRegHandler(regAgent agents.RegistryAgent, confAgent agents.ConfigAgent)
 				jobHandler(regAgent, confAgent, w, req)
 			case "ci-operator-reference":
 				ciOpConfigRefHandler(w)
+			case "deprecated":
+				deprecatedHandler(regAgent, usageIndexer, w, req)
 			default:
 				writeErrorPage(w, errors.New("Invalid path"), http.StatusNotImplemented)
 			}
@@ -1089,13 +1302,13 @@ func WebRegHandler(regAgent agents.RegistryAgent, confAgent agents.ConfigAgent)
 		} else if len(splitURI) == 2 {
 			switch splitURI[0] {
 			case "reference":
-				referenceHandler(regAgent, w, req)
+				referenceHandler(regAgent, usageIndexer, w, req)
 				return
 			case "chain":
-				chainHandler(regAgent, w, req)
+				chainHandler(regAgent, usageIndexer, w, req)
 				return
 			case "workflow":
-				workflowHandler(regAgent, w, req)
+				workflowHandler(regAgent, usageIndexer, w, req)
 				return
 			default:
 				writeErrorPage(w, fmt.Errorf("Component type %s not found", splitURI[0]), http.StatusNotFound)
@@ -1258,7 +1471,7 @@ func fromImageDescription(name string, reference *api.ImageStreamTagReference) t
 	return template.HTML(fmt.Sprintf("%s image built or imported by the ci-operator configuration (<a href=\"%s\">documentation</a>).", prefix, fromDocumentation))
 }
 
-func referenceHandler(agent agents.RegistryAgent, w http.ResponseWriter, req *http.Request) {
+func referenceHandler(agent agents.RegistryAgent, usageIndexer *registryserver.UsageIndexer, w http.ResponseWriter, req *http.Request) {
 	start := time.Now()
 	defer func() { logrus.Infof("rendered in %s", time.Since(start)) }()
 	w.Header().Set("Content-Type", "text/html;charset=UTF-8")
@@ -1298,9 +1511,12 @@ func referenceHandler(agent agents.RegistryAgent, w http.ResponseWriter, req *ht
 		writeErrorPage(w, fmt.Errorf("Could not find metadata for file `%s`. Please contact the Developer Productivity Test Platform.", refMetadataName), http.StatusInternalServerError)
 		return
 	}
+	usage, _ := usageIndexer.Index().Usage(registryserver.ReferenceType, name)
 	ref := struct {
-		Reference api.RegistryReference
-		Metadata  api.RegistryInfo
+		Reference   api.RegistryReference
+		Metadata    api.RegistryInfo
+		Usage       registryserver.Usage
+		Deprecation *deprecationNotice
 	}{
 		Reference: api.RegistryReference{
 			LiteralTestStep: api.LiteralTestStep{
@@ -1320,12 +1536,14 @@ func referenceHandler(agent agents.RegistryAgent, w http.ResponseWriter, req *ht
 			},
 			Documentation: docs[name],
 		},
-		Metadata: metadata[refMetadataName],
+		Metadata:    metadata[refMetadataName],
+		Usage:       usage,
+		Deprecation: newDeprecationNotice(agent.GetDeprecations().References, registryserver.ReferenceType, name, time.Now()),
 	}
 	writePage(w, "Registry Step Help Page", page, ref)
 }
 
-func chainHandler(agent agents.RegistryAgent, w http.ResponseWriter, req *http.Request) {
+func chainHandler(agent agents.RegistryAgent, usageIndexer *registryserver.UsageIndexer, w http.ResponseWriter, req *http.Request) {
 	start := time.Now()
 	defer func() { logrus.Infof("rendered in %s", time.Since(start)) }()
 	w.Header().Set("Content-Type", "text/html;charset=UTF-8")
@@ -1354,21 +1572,26 @@ func chainHandler(agent agents.RegistryAgent, w http.ResponseWriter, req *http.R
 		writeErrorPage(w, fmt.Errorf("Could not find metadata for file `%s`. Please contact the Developer Productivity Test Platform.", chainMetadataName), http.StatusInternalServerError)
 		return
 	}
+	usage, _ := usageIndexer.Index().Usage(registryserver.ChainType, name)
 	chain := struct {
-		Chain    api.RegistryChain
-		Metadata api.RegistryInfo
+		Chain       api.RegistryChain
+		Metadata    api.RegistryInfo
+		Usage       registryserver.Usage
+		Deprecation *deprecationNotice
 	}{
 		Chain: api.RegistryChain{
 			As:            name,
 			Documentation: docs[name],
 			Steps:         chains[name].Steps,
 		},
-		Metadata: metadata[chainMetadataName],
+		Metadata:    metadata[chainMetadataName],
+		Usage:       usage,
+		Deprecation: newDeprecationNotice(agent.GetDeprecations().Chains, registryserver.ChainType, name, time.Now()),
 	}
 	writePage(w, "Registry Chain Help Page", page, chain)
 }
 
-func workflowHandler(agent agents.RegistryAgent, w http.ResponseWriter, req *http.Request) {
+func workflowHandler(agent agents.RegistryAgent, usageIndexer *registryserver.UsageIndexer, w http.ResponseWriter, req *http.Request) {
 	start := time.Now()
 	defer func() { logrus.Infof("rendered in %s", time.Since(start)) }()
 	w.Header().Set("Content-Type", "text/html;charset=UTF-8")
@@ -1400,9 +1623,12 @@ func workflowHandler(agent agents.RegistryAgent, w http.ResponseWriter, req *htt
 		writeErrorPage(w, fmt.Errorf("Could not find metadata for file `%s`. Please contact the Developer Productivity Test Platform.", workflowMetadataName), http.StatusInternalServerError)
 		return
 	}
+	usage, _ := usageIndexer.Index().Usage(registryserver.WorkflowType, name)
 	workflow := struct {
-		Workflow workflowJob
-		Metadata api.RegistryInfo
+		Workflow    workflowJob
+		Metadata    api.RegistryInfo
+		Usage       registryserver.Usage
+		Deprecation *deprecationNotice
 	}{
 		Workflow: workflowJob{
 			RegistryWorkflow: api.RegistryWorkflow{
@@ -1411,11 +1637,29 @@ func workflowHandler(agent agents.RegistryAgent, w http.ResponseWriter, req *htt
 				Steps:         workflows[name],
 			},
 			Type: workflowType},
-		Metadata: metadata[workflowMetadataName],
+		Metadata:    metadata[workflowMetadataName],
+		Usage:       usage,
+		Deprecation: newDeprecationNotice(agent.GetDeprecations().Workflows, registryserver.WorkflowType, name, time.Now()),
 	}
 	writePage(w, "Registry Workflow Help Page", page, workflow)
 }
 
+func deprecatedHandler(agent agents.RegistryAgent, usageIndexer *registryserver.UsageIndexer, w http.ResponseWriter, _ *http.Request) {
+	start := time.Now()
+	defer func() { logrus.Infof("rendered in %s", time.Since(start)) }()
+	w.Header().Set("Content-Type", "text/html;charset=UTF-8")
+	page, err := baseTemplate.Clone()
+	if err != nil {
+		writeErrorPage(w, fmt.Errorf("Failed to render page: %w", err), http.StatusInternalServerError)
+		return
+	}
+	if page, err = page.Parse(deprecatedPage); err != nil {
+		writeErrorPage(w, fmt.Errorf("Failed to render page: %w", err), http.StatusInternalServerError)
+		return
+	}
+	writePage(w, "Deprecated Registry Components", page, deprecationNotices(agent.GetDeprecations(), usageIndexer.Index(), time.Now()))
+}
+
 func findConfigForJob(testName string, config api.ReleaseBuildConfiguration) (api.MultiStageTestConfiguration, error) {
 	for _, test := range config.Tests {
 		if test.As == testName {
@@ -1,15 +1,20 @@
 package webreg
 
 import (
+	"bytes"
 	"fmt"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 
 	"k8s.io/utils/pointer"
 
 	"github.com/openshift/ci-tools/pkg/api"
+	"github.com/openshift/ci-tools/pkg/config"
 	"github.com/openshift/ci-tools/pkg/registry"
+	registryserver "github.com/openshift/ci-tools/pkg/registry/server"
 )
 
 func TestGetDependencyDataItems(t *testing.T) {
@@ -214,11 +219,26 @@ func TestGetEnvironmentDataItems(t *testing.T) {
 		},
 	}
 
+	step4 := api.TestStep{
+		LiteralTestStep: &api.LiteralTestStep{
+			As: "step-4",
+			Environment: []api.StepParameter{
+				{
+					Name:          "var3",
+					Documentation: "var3 documentation",
+					Type:          api.StepParameterTypeEnum,
+					AllowedValues: []string{"a", "b"},
+				},
+			},
+		},
+	}
+
 	registrySteps := registry.ReferenceByName{
 		stepWithoutVars.As: *stepWithoutVars.LiteralTestStep,
 		step1.As:           *step1.LiteralTestStep,
 		step2.As:           *step2.LiteralTestStep,
 		step3.As:           *step3.LiteralTestStep,
+		step4.As:           *step4.LiteralTestStep,
 	}
 
 	stepPtr := func(name string) *api.LiteralTestStep {
@@ -301,6 +321,18 @@ func TestGetEnvironmentDataItems(t *testing.T) {
 				},
 			},
 		},
+		{
+			description: "Step with a typed value",
+			inputSteps:  []api.TestStep{{LiteralTestStep: stepPtr("step-4")}},
+			expected: map[string]environmentLine{
+				"var3": {
+					Documentation: "var3 documentation",
+					Type:          api.StepParameterTypeEnum,
+					AllowedValues: []string{"a", "b"},
+					Steps:         []string{"step-4"},
+				},
+			},
+		},
 	}
 
 	t.Parallel()
@@ -316,3 +348,63 @@ func TestGetEnvironmentDataItems(t *testing.T) {
 		})
 	}
 }
+
+func TestDeprecationNotices(t *testing.T) {
+	old, older := "old", "older"
+	refs := registry.ReferenceByName{old: {}, older: {}, "new": {}}
+	chains := registry.ChainByName{"chain": {Steps: []api.TestStep{{Reference: &old}}}}
+	workflows := registry.WorkflowByName{}
+	metadata := api.Metadata{Org: "org", Repo: "repo", Branch: "main"}
+	configs := config.ByOrgRepo{"org": {"repo": {{
+		Metadata: metadata,
+		Tests: []api.TestStepConfiguration{{
+			As:                          "e2e",
+			MultiStageTestConfiguration: &api.MultiStageTestConfiguration{Test: []api.TestStep{{Chain: pointer.String("chain")}}},
+		}},
+	}}}}
+	deprecations := registry.Deprecations{
+		References: map[string]api.RegistryDeprecation{
+			older: {Reason: "<b>older</b> than old", RemovalDate: "2025-01-01"},
+			old:   {Reason: "old", Replacement: "new", RemovalDate: "2025-12-01"},
+		},
+	}
+	now := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	notices := deprecationNotices(deprecations, registryserver.NewUsageIndex(refs, chains, workflows, configs), now)
+	expected := []deprecationNotice{{
+		RegistryDeprecation: deprecations.References[old],
+		Type:                registryserver.ReferenceType,
+		Name:                old,
+		Tests:               []registryserver.TestUsage{{Metadata: metadata, Test: "e2e", Jobs: []string{"pull-ci-org-repo-main-e2e"}}},
+	}, {
+		RegistryDeprecation: deprecations.References[older],
+		Type:                registryserver.ReferenceType,
+		Name:                older,
+		Removed:             true,
+		Tests:               []registryserver.TestUsage{},
+	}}
+	if diff := cmp.Diff(expected, notices); diff != "" {
+		t.Fatalf("notices differ from expected:\n%s", diff)
+	}
+
+	page, err := baseTemplate.Clone()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if page, err = page.Parse(deprecatedPage); err != nil {
+		t.Fatal(err)
+	}
+	var out bytes.Buffer
+	if err := page.Execute(&out, notices); err != nil {
+		t.Fatal(err)
+	}
+	for _, expected := range []string{
+		`<a href="/reference/new" style="font-family:monospace">new</a>`,
+		`<span class="badge badge-warning" title="old">deprecated</span>`,
+		`<span class="badge badge-danger" title="&lt;b&gt;older&lt;/b&gt; than old">removed</span>`,
+		`org/repo@main: e2e</a>`,
+	} {
+		if !strings.Contains(out.String(), expected) {
+			t.Errorf("rendered page does not contain %q:\n%s", expected, out.String())
+		}
+	}
+}
@@ -33,6 +33,10 @@ const ciOperatorReferenceYaml = "# The list of base images describe\n" +
 	"binary_build_commands_list:\n" +
 	"    - commands: ' '\n" +
 	"      ref: ' '\n" +
+	"# BuildBackend selects the system which builds the images. When unset,\n" +
+	"# the default of the build cluster is used, which is OpenShift Builds\n" +
+	"# unless the cluster is configured otherwise.\n" +
+	"build_backend: ' '\n" +
 	"# BuildImagesIfAffected means images are only built if their corresponding cmd tools are affected by code changes.\n" +
 	"# When enabled, the tool-detector package analyzes git changes to determine which images should be built.\n" +
 	"# The image name (To field) should match the cmd tool name for this to work correctly.\n" +
@@ -233,6 +237,12 @@ const ciOperatorReferenceYaml = "# The list of base images describe\n" +
 	"      optional: true\n" +
 	"      # Ref is an optional string linking to the extra_ref in \"org.repo\" format that this belongs to\n" +
 	"      ref: ' '\n" +
+	"      # SBOM requests a software bill of materials for the image once it is\n" +
+	"      # built. The SBOM is stored as an artifact of the job, attached to the\n" +
+	"      # image and promoted with it.\n" +
+	"      sbom:\n" +
+	"        # Format is the format of the SBOM, spdx or cyclonedx. Defaults to spdx.\n" +
+	"        format: ' '\n" +
 	"      to: ' '\n" +
 	"# Operator describes the operator bundle(s) that is built by the project\n" +
 	"operator:\n" +
@@ -323,7 +333,17 @@ const ciOperatorReferenceYaml = "# The list of base images describe\n" +
 	"# RawSteps are literal Steps that should be\n" +
 	"# included in the final pipeline.\n" +
 	"raw_steps:\n" +
-	"    - bundle_source_step:\n" +
+	"    - artifact_serve_step:\n" +
+	"        # From is the image holding the artifacts. The image serves them itself,\n" +
+	"        # like the RPM server does, so it must provide bash and python 2 or 3.\n" +
+	"        from: ' '\n" +
+	"        # Name identifies the artifacts. Test steps which list the name in\n" +
+	"        # their served_artifacts find the URL to the served directory in the\n" +
+	"        # ARTIFACTS_URL_<NAME> environment variable.\n" +
+	"        name: ' '\n" +
+	"        # Path is the absolute path of the directory in the image which is served.\n" +
+	"        path: ' '\n" +
+	"      bundle_source_step:\n" +
 	"        # Substitutions contains pullspecs that need to be replaced by images\n" +
 	"        # in the CI cluster for operator bundle images\n" +
 	"        substitutions:\n" +
@@ -474,6 +494,12 @@ const ciOperatorReferenceYaml = "# The list of base images describe\n" +
 	"        optional: true\n" +
 	"        # Ref is an optional string linking to the extra_ref in \"org.repo\" format that this belongs to\n" +
 	"        ref: ' '\n" +
+	"        # SBOM requests a software bill of materials for the image once it is\n" +
+	"        # built. The SBOM is stored as an artifact of the job, attached to the\n" +
+	"        # image and promoted with it.\n" +
+	"        sbom:\n" +
+	"            # Format is the format of the SBOM, spdx or cyclonedx. Defaults to spdx.\n" +
+	"            format: ' '\n" +
 	"        to: ' '\n" +
 	"      release_images_tag_step:\n" +
 	"        # IncludeBuiltImages determines if the release we assemble will include\n" +
@@ -620,6 +646,16 @@ const ciOperatorReferenceYaml = "# The list of base images describe\n" +
 	"            # all previous `pre` and `test` steps were successful. The given step must explicitly\n" +
 	"            # ask for being skipped by setting the OptionalOnSuccess flag to true.\n" +
 	"            allow_skip_on_success: false\n" +
+	"            # Budgets limit how long each phase of the test can run.\n" +
+	"            budgets:\n" +
+	"                # Post limits the time the `post` steps can take. This time is reserved\n" +
+	"                # at the end of the job: `pre` and `test` steps are interrupted early\n" +
+	"                # enough for the `post` steps to tear down what they set up.\n" +
+	"                post: 0s\n" +
+	"                # Pre limits the time the `pre` steps can take.\n" +
+	"                pre: 0s\n" +
+	"                # Test limits the time the `test` steps can take.\n" +
+	"                test: 0s\n" +
 	"            # ClusterProfile defines the profile/cloud provider for end-to-end test steps.\n" +
 	"            cluster_profile: ' '\n" +
 	"            # Dependencies holds override values for dependency parameters.\n" +
@@ -659,12 +695,22 @@ const ciOperatorReferenceYaml = "# The list of base images describe\n" +
 	"                  commands: ' '\n" +
 	"                  # Environment has the values of parameters for the observer.\n" +
 	"                  env:\n" +
-	"                    - # Default if not set, optional, makes the parameter not required if set.\n" +
+	"                    - # AllowedValues lists the values of an `enum` parameter.\n" +
+	"                      allowed_values:\n" +
+	"                        - \"\"\n" +
+	"                      # Default if not set, optional, makes the parameter not required if set.\n" +
 	"                      default: \"\"\n" +
 	"                      # Documentation is a textual description of the parameter.\n" +
 	"                      documentation: ' '\n" +
 	"                      # Name of the environment variable.\n" +
 	"                      name: ' '\n" +
+	"                      # Pattern is the regular expression the values of a `regex` parameter\n" +
+	"                      # must match entirely.\n" +
+	"                      pattern: ' '\n" +
+	"                      # Type of the values of the parameter, optional. The default and the\n" +
+	"                      # values set for typed parameters are validated, an empty value is always\n" +
+	"                      # valid and leaves the parameter unset.\n" +
+	"                      type: ' '\n" +
 	"                  # From is the container image that will be used for this observer.\n" +
 	"                  from: ' '\n" +
 	"                  # FromImage is a literal ImageStreamTag reference to use for this observer.\n" +
@@ -744,12 +790,22 @@ const ciOperatorReferenceYaml = "# The list of base images describe\n" +
 	"                        - \"\"\n" +
 	"                  # Environment lists parameters that should be set by the test.\n" +
 	"                  env:\n" +
-	"                    - # Default if not set, optional, makes the parameter not required if set.\n" +
+	"                    - # AllowedValues lists the values of an `enum` parameter.\n" +
+	"                      allowed_values:\n" +
+	"                        - \"\"\n" +
+	"                      # Default if not set, optional, makes the parameter not required if set.\n" +
 	"                      default: \"\"\n" +
 	"                      # Documentation is a textual description of the parameter.\n" +
 	"                      documentation: ' '\n" +
 	"                      # Name of the environment variable.\n" +
 	"                      name: ' '\n" +
+	"                      # Pattern is the regular expression the values of a `regex` parameter\n" +
+	"                      # must match entirely.\n" +
+	"                      pattern: ' '\n" +
+	"                      # Type of the values of the parameter, optional. The default and the\n" +
+	"                      # values set for typed parameters are validated, an empty value is always\n" +
+	"                      # valid and leaves the parameter unset.\n" +
+	"                      type: ' '\n" +
 	"                  # From is the container image that will be used for this step.\n" +
 	"                  from: ' '\n" +
 	"                  # FromImage is a literal ImageStreamTag reference to use for this step.\n" +
@@ -785,6 +841,12 @@ const ciOperatorReferenceYaml = "# The list of base images describe\n" +
 	"                  # flag is set to true in MultiStageTestConfiguration. This option is\n" +
 	"                  # applicable to `post` steps.\n" +
 	"                  optional_on_success: false\n" +
+	"                  # ParallelGroup is the name of the group of steps this step is executed\n" +
+	"                  # concurrently with. Consecutive steps in a phase which share the same\n" +
+	"                  # group are started together and the phase only moves on when all of them\n" +
+	"                  # have finished. This is only set when resolving `parallel` blocks and\n" +
+	"                  # configurations setting it by hand are rejected.\n" +
+	"                  parallel_group: ' '\n" +
 	"                  # Resources defines the resource requirements for the step.\n" +
 	"                  resources:\n" +
 	"                    # Limits are resource limits applied to an individual step in the job.\n" +
@@ -795,11 +857,43 @@ const ciOperatorReferenceYaml = "# The list of base images describe\n" +
 	"                    # These are directly used in creating the Pods that execute the Job.\n" +
 	"                    requests:\n" +
 	"                        \"\": \"\"\n" +
+	"                  # Retry determines whether the step is executed again when it fails.\n" +
+	"                  retry:\n" +
+	"                    # Backoff is how long to wait between attempts.\n" +
+	"                    backoff: 0s\n" +
+	"                    # ExitCodes lists the exit codes of the test container which cause the\n" +
+	"                    # step to be retried.\n" +
+	"                    exit_codes:\n" +
+	"                        - 0\n" +
+	"                    # MaxAttempts is the number of times the step is executed at most,\n" +
+	"                    # including the first attempt.\n" +
+	"                    max_attempts: 0\n" +
+	"                    # Reasons lists the failure reasons, such as `pod_pending`, which cause\n" +
+	"                    # the step to be retried.\n" +
+	"                    reasons:\n" +
+	"                        - \"\"\n" +
 	"                  # RunAsScript defines if this step should be executed as a script mounted\n" +
 	"                  # in the test container instead of being executed directly via bash\n" +
 	"                  run_as_script: false\n" +
+	"                  # ServedArtifacts lists the served artifacts of the configuration which\n" +
+	"                  # the step uses. The URL of each is exposed in the\n" +
+	"                  # ARTIFACTS_URL_<NAME> environment variable.\n" +
+	"                  served_artifacts:\n" +
+	"                    - \"\"\n" +
 	"                  # Timeout is how long the we will wait before aborting a job with SIGINT.\n" +
 	"                  timeout: 0s\n" +
+	"                  # When lists conditions on parameters of the step which must all hold\n" +
+	"                  # for it to be executed. Steps whose conditions do not hold are skipped\n" +
+	"                  # without creating a pod.\n" +
+	"                  when:\n" +
+	"                    - # Env is the name of the parameter, either declared by the step or set\n" +
+	"                      # in the environment of the test.\n" +
+	"                      env: ' '\n" +
+	"                      # Operator is either `in` or `notin`.\n" +
+	"                      operator: ' '\n" +
+	"                      # Values are the values the parameter is compared to.\n" +
+	"                      values:\n" +
+	"                        - \"\"\n" +
 	"            # Pre is the array of test steps run to set up the environment for the test.\n" +
 	"            pre:\n" +
 	"                - # As is the name of the LiteralTestStep.\n" +
@@ -852,12 +946,22 @@ const ciOperatorReferenceYaml = "# The list of base images describe\n" +
 	"                        - \"\"\n" +
 	"                  # Environment lists parameters that should be set by the test.\n" +
 	"                  env:\n" +
-	"                    - # Default if not set, optional, makes the parameter not required if set.\n" +
+	"                    - # AllowedValues lists the values of an `enum` parameter.\n" +
+	"                      allowed_values:\n" +
+	"                        - \"\"\n" +
+	"                      # Default if not set, optional, makes the parameter not required if set.\n" +
 	"                      default: \"\"\n" +
 	"                      # Documentation is a textual description of the parameter.\n" +
 	"                      documentation: ' '\n" +
 	"                      # Name of the environment variable.\n" +
 	"                      name: ' '\n" +
+	"                      # Pattern is the regular expression the values of a `regex` parameter\n" +
+	"                      # must match entirely.\n" +
+	"                      pattern: ' '\n" +
+	"                      # Type of the values of the parameter, optional. The default and the\n" +
+	"                      # values set for typed parameters are validated, an empty value is always\n" +
+	"                      # valid and leaves the parameter unset.\n" +
+	"                      type: ' '\n" +
 	"                  # From is the container image that will be used for this step.\n" +
 	"                  from: ' '\n" +
 	"                  # FromImage is a literal ImageStreamTag reference to use for this step.\n" +
@@ -893,6 +997,12 @@ const ciOperatorReferenceYaml = "# The list of base images describe\n" +
 	"                  # flag is set to true in MultiStageTestConfiguration. This option is\n" +
 	"                  # applicable to `post` steps.\n" +
 	"                  optional_on_success: false\n" +
+	"                  # ParallelGroup is the name of the group of steps this step is executed\n" +
+	"                  # concurrently with. Consecutive steps in a phase which share the same\n" +
+	"                  # group are started together and the phase only moves on when all of them\n" +
+	"                  # have finished. This is only set when resolving `parallel` blocks and\n" +
+	"                  # configurations setting it by hand are rejected.\n" +
+	"                  parallel_group: ' '\n" +
 	"                  # Resources defines the resource requirements for the step.\n" +
 	"                  resources:\n" +
 	"                    # Limits are resource limits applied to an individual step in the job.\n" +
@@ -903,11 +1013,43 @@ const ciOperatorReferenceYaml = "# The list of base images describe\n" +
 	"                    # These are directly used in creating the Pods that execute the Job.\n" +
 	"                    requests:\n" +
 	"                        \"\": \"\"\n" +
+	"                  # Retry determines whether the step is executed again when it fails.\n" +
+	"                  retry:\n" +
+	"                    # Backoff is how long to wait between attempts.\n" +
+	"                    backoff: 0s\n" +
+	"                    # ExitCodes lists the exit codes of the test container which cause the\n" +
+	"                    # step to be retried.\n" +
+	"                    exit_codes:\n" +
+	"                        - 0\n" +
+	"                    # MaxAttempts is the number of times the step is executed at most,\n" +
+	"                    # including the first attempt.\n" +
+	"                    max_attempts: 0\n" +
+	"                    # Reasons lists the failure reasons, such as `pod_pending`, which cause\n" +
+	"                    # the step to be retried.\n" +
+	"                    reasons:\n" +
+	"                        - \"\"\n" +
 	"                  # RunAsScript defines if this step should be executed as a script mounted\n" +
 	"                  # in the test container instead of being executed directly via bash\n" +
 	"                  run_as_script: false\n" +
+	"                  # ServedArtifacts lists the served artifacts of the configuration which\n" +
+	"                  # the step uses. The URL of each is exposed in the\n" +
+	"                  # ARTIFACTS_URL_<NAME> environment variable.\n" +
+	"                  served_artifacts:\n" +
+	"                    - \"\"\n" +
 	"                  # Timeout is how long the we will wait before aborting a job with SIGINT.\n" +
 	"                  timeout: 0s\n" +
+	"                  # When lists conditions on parameters of the step which must all hold\n" +
+	"                  # for it to be executed. Steps whose conditions do not hold are skipped\n" +
+	"                  # without creating a pod.\n" +
+	"                  when:\n" +
+	"                    - # Env is the name of the parameter, either declared by the step or set\n" +
+	"                      # in the environment of the test.\n" +
+	"                      env: ' '\n" +
+	"                      # Operator is either `in` or `notin`.\n" +
+	"                      operator: ' '\n" +
+	"                      # Values are the values the parameter is compared to.\n" +
+	"                      values:\n" +
+	"                        - \"\"\n" +
 	"            # Test is the array of test steps that define the actual test.\n" +
 	"            test:\n" +
 	"                - # As is the name of the LiteralTestStep.\n" +
@@ -960,12 +1102,22 @@ const ciOperatorReferenceYaml = "# The list of base images describe\n" +
 	"                        - \"\"\n" +
 	"                  # Environment lists parameters that should be set by the test.\n" +
 	"                  env:\n" +
-	"                    - # Default if not set, optional, makes the parameter not required if set.\n" +
+	"                    - # AllowedValues lists the values of an `enum` parameter.\n" +
+	"                      allowed_values:\n" +
+	"                        - \"\"\n" +
+	"                      # Default if not set, optional, makes the parameter not required if set.\n" +
 	"                      default: \"\"\n" +
 	"                      # Documentation is a textual description of the parameter.\n" +
 	"                      documentation: ' '\n" +
 	"                      # Name of the environment variable.\n" +
 	"                      name: ' '\n" +
+	"                      # Pattern is the regular expression the values of a `regex` parameter\n" +
+	"                      # must match entirely.\n" +
+	"                      pattern: ' '\n" +
+	"                      # Type of the values of the parameter, optional. The default and the\n" +
+	"                      # values set for typed parameters are validated, an empty value is always\n" +
+	"                      # valid and leaves the parameter unset.\n" +
+	"                      type: ' '\n" +
 	"                  # From is the container image that will be used for this step.\n" +
 	"                  from: ' '\n" +
 	"                  # FromImage is a literal ImageStreamTag reference to use for this step.\n" +
@@ -1001,6 +1153,12 @@ const ciOperatorReferenceYaml = "# The list of base images describe\n" +
 	"                  # flag is set to true in MultiStageTestConfiguration. This option is\n" +
 	"                  # applicable to `post` steps.\n" +
 	"                  optional_on_success: false\n" +
+	"                  # ParallelGroup is the name of the group of steps this step is executed\n" +
+	"                  # concurrently with. Consecutive steps in a phase which share the same\n" +
+	"                  # group are started together and the phase only moves on when all of them\n" +
+	"                  # have finished. This is only set when resolving `parallel` blocks and\n" +
+	"                  # configurations setting it by hand are rejected.\n" +
+	"                  parallel_group: ' '\n" +
 	"                  # Resources defines the resource requirements for the step.\n" +
 	"                  resources:\n" +
 	"                    # Limits are resource limits applied to an individual step in the job.\n" +
@@ -1011,13 +1169,77 @@ const ciOperatorReferenceYaml = "# The list of base images describe\n" +
 	"                    # These are directly used in creating the Pods that execute the Job.\n" +
 	"                    requests:\n" +
 	"                        \"\": \"\"\n" +
+	"                  # Retry determines whether the step is executed again when it fails.\n" +
+	"                  retry:\n" +
+	"                    # Backoff is how long to wait between attempts.\n" +
+	"                    backoff: 0s\n" +
+	"                    # ExitCodes lists the exit codes of the test container which cause the\n" +
+	"                    # step to be retried.\n" +
+	"                    exit_codes:\n" +
+	"                        - 0\n" +
+	"                    # MaxAttempts is the number of times the step is executed at most,\n" +
+	"                    # including the first attempt.\n" +
+	"                    max_attempts: 0\n" +
+	"                    # Reasons lists the failure reasons, such as `pod_pending`, which cause\n" +
+	"                    # the step to be retried.\n" +
+	"                    reasons:\n" +
+	"                        - \"\"\n" +
 	"                  # RunAsScript defines if this step should be executed as a script mounted\n" +
 	"                  # in the test container instead of being executed directly via bash\n" +
 	"                  run_as_script: false\n" +
+	"                  # ServedArtifacts lists the served artifacts of the configuration which\n" +
+	"                  # the step uses. The URL of each is exposed in the\n" +
+	"                  # ARTIFACTS_URL_<NAME> environment variable.\n" +
+	"                  served_artifacts:\n" +
+	"                    - \"\"\n" +
 	"                  # Timeout is how long the we will wait before aborting a job with SIGINT.\n" +
 	"                  timeout: 0s\n" +
+	"                  # When lists conditions on parameters of the step which must all hold\n" +
+	"                  # for it to be executed. Steps whose conditions do not hold are skipped\n" +
+	"                  # without creating a pod.\n" +
+	"                  when:\n" +
+	"                    - # Env is the name of the parameter, either declared by the step or set\n" +
+	"                      # in the environment of the test.\n" +
+	"                      env: ' '\n" +
+	"                      # Operator is either `in` or `notin`.\n" +
+	"                      operator: ' '\n" +
+	"                      # Values are the values the parameter is compared to.\n" +
+	"                      values:\n" +
+	"                        - \"\"\n" +
 	"            # Override job timeout\n" +
 	"            timeout: 0s\n" +
+	"        # Matrix describes parameters whose combinations of values each generate\n" +
+	"        # a copy of this test, with the values set in its environment.\n" +
+	"        # Only applicable to multi-stage tests.\n" +
+	"        matrix:\n" +
+	"            # Dimensions are the parameters and the values they take. The name of\n" +
+	"            # each generated test is the name of the original test followed by the\n" +
+	"            # names of the values of the combination, in the order of the dimensions.\n" +
+	"            dimensions:\n" +
+	"                - # Env is the name of the parameter set in the environment of the tests.\n" +
+	"                  env: ' '\n" +
+	"                  # Values are the values the parameter takes.\n" +
+	"                  values:\n" +
+	"                    - # Name identifies the value in the names of the generated tests.\n" +
+	"                      name: ' '\n" +
+	"                      # Value is set as the parameter. Defaults to the name.\n" +
+	"                      value: \"\"\n" +
+	"            # Overrides change the tests generated for some combinations.\n" +
+	"            overrides:\n" +
+	"                - # AlwaysRun overrides whether matching tests run on every PR.\n" +
+	"                  always_run: false\n" +
+	"                  # Env sets additional parameters in the environment of matching tests.\n" +
+	"                  env:\n" +
+	"                    \"\": \"\"\n" +
+	"                  # Exclude removes the matching combinations.\n" +
+	"                  exclude: true\n" +
+	"                  # Match selects combinations by the names of their values, keyed by the\n" +
+	"                  # parameter of the dimension. Dimensions which are not listed match any\n" +
+	"                  # value.\n" +
+	"                  match:\n" +
+	"                    \"\": \"\"\n" +
+	"                  # Optional overrides whether matching tests are optional.\n" +
+	"                  optional: false\n" +
 	"        # MinimumInterval to wait between two runs of the job. Consecutive\n" +
 	"        # jobs are run at `minimum_interval` + `duration of previous job`\n" +
 	"        # apart. Setting this field will create a periodic job instead of a\n" +
@@ -1072,6 +1294,13 @@ const ciOperatorReferenceYaml = "# The list of base images describe\n" +
 	"        release_controller: true\n" +
 	"        # RestrictNetworkAccess restricts network access to RedHat intranet.\n" +
 	"        restrict_network_access: false\n" +
+	"        # ResultCache allows a passing result of this test to be reused by later\n" +
+	"        # executions whose configuration and images are identical, instead of\n" +
+	"        # running the test again. Only applicable to container and multi-stage tests.\n" +
+	"        result_cache:\n" +
+	"            # MaxAge is how long after it passed a result can be reused.\n" +
+	"            # Defaults to 24h.\n" +
+	"            max_age: 0s\n" +
 	"        # Retry is a configuration entry for retrying periodic prowjobs\n" +
 	"        retry:\n" +
 	"            interval: ' '\n" +
@@ -1113,6 +1342,16 @@ const ciOperatorReferenceYaml = "# The list of base images describe\n" +
 	"            # all previous `pre` and `test` steps were successful. The given step must explicitly\n" +
 	"            # ask for being skipped by setting the OptionalOnSuccess flag to true.\n" +
 	"            allow_skip_on_success: false\n" +
+	"            # Budgets limit how long each phase of the test can run.\n" +
+	"            budgets:\n" +
+	"                # Post limits the time the `post` steps can take. This time is reserved\n" +
+	"                # at the end of the job: `pre` and `test` steps are interrupted early\n" +
+	"                # enough for the `post` steps to tear down what they set up.\n" +
+	"                post: 0s\n" +
+	"                # Pre limits the time the `pre` steps can take.\n" +
+	"                pre: 0s\n" +
+	"                # Test limits the time the `test` steps can take.\n" +
+	"                test: 0s\n" +
 	"            # ClusterProfile defines the profile/cloud provider for end-to-end test steps.\n" +
 	"            cluster_profile: ' '\n" +
 	"            # Dependencies holds override values for dependency parameters.\n" +
@@ -1191,9 +1430,14 @@ const ciOperatorReferenceYaml = "# The list of base images describe\n" +
 	"                        - \"\"\n" +
 	"                  env:\n" +
 	"                    # LiteralTestStep is a full test step definition.\n" +
-	"                    - default: \"\"\n" +
+	"                    - allowed_values:\n" +
+	"                        # LiteralTestStep is a full test step definition.\n" +
+	"                        - \"\"\n" +
+	"                      default: \"\"\n" +
 	"                      documentation: ' '\n" +
 	"                      name: ' '\n" +
+	"                      pattern: ' '\n" +
+	"                      type: ' '\n" +
 	"                  from: ' '\n" +
 	"                  from_image:\n" +
 	"                    # LiteralTestStep is a full test step definition.\n" +
@@ -1214,6 +1458,103 @@ const ciOperatorReferenceYaml = "# The list of base images describe\n" +
 	"                    # LiteralTestStep is a full test step definition.\n" +
 	"                    - \"\"\n" +
 	"                  optional_on_success: false\n" +
+	"                  # Parallel is a group of steps which are executed concurrently. Until\n" +
+	"                  # the entrypoint-wrapper image supporting this is rolled out everywhere,\n" +
+	"                  # the steps are executed sequentially unless ci-operator is run with\n" +
+	"                  # --parallel-step-groups.\n" +
+	"                  parallel:\n" +
+	"                    # LiteralTestStep is a full test step definition.\n" +
+	"                    - as: ' '\n" +
+	"                      best_effort: false\n" +
+	"                      cli: ' '\n" +
+	"                      commands: ' '\n" +
+	"                      credentials:\n" +
+	"                        # LiteralTestStep is a full test step definition.\n" +
+	"                        - as: ' '\n" +
+	"                          bundle: ' '\n" +
+	"                          collection: ' '\n" +
+	"                          field: ' '\n" +
+	"                          group: ' '\n" +
+	"                          mount_path: ' '\n" +
+	"                          name: ' '\n" +
+	"                          namespace: ' '\n" +
+	"                      dependencies:\n" +
+	"                        # LiteralTestStep is a full test step definition.\n" +
+	"                        - env: ' '\n" +
+	"                          name: ' '\n" +
+	"                      dnsConfig:\n" +
+	"                        # LiteralTestStep is a full test step definition.\n" +
+	"                        nameservers:\n" +
+	"                            # LiteralTestStep is a full test step definition.\n" +
+	"                            - \"\"\n" +
+	"                        searches:\n" +
+	"                            # LiteralTestStep is a full test step definition.\n" +
+	"                            - \"\"\n" +
+	"                      env:\n" +
+	"                        # LiteralTestStep is a full test step definition.\n" +
+	"                        - allowed_values:\n" +
+	"                            # LiteralTestStep is a full test step definition.\n" +
+	"                            - \"\"\n" +
+	"                          default: \"\"\n" +
+	"                          documentation: ' '\n" +
+	"                          name: ' '\n" +
+	"                          pattern: ' '\n" +
+	"                          type: ' '\n" +
+	"                      from: ' '\n" +
+	"                      from_image:\n" +
+	"                        # LiteralTestStep is a full test step definition.\n" +
+	"                        as: ' '\n" +
+	"                        name: ' '\n" +
+	"                        namespace: ' '\n" +
+	"                        tag: ' '\n" +
+	"                      grace_period: 0s\n" +
+	"                      leases:\n" +
+	"                        # LiteralTestStep is a full test step definition.\n" +
+	"                        - env: ' '\n" +
+	"                          resource_type: ' '\n" +
+	"                      nested_podman: true\n" +
+	"                      no_kubeconfig: false\n" +
+	"                      node_architecture: \"\"\n" +
+	"                      # Observers are the observers that should be running\n" +
+	"                      observers:\n" +
+	"                        # LiteralTestStep is a full test step definition.\n" +
+	"                        - \"\"\n" +
+	"                      optional_on_success: false\n" +
+	"                      parallel_group: ' '\n" +
+	"                      # Reference is the name of a step reference.\n" +
+	"                      ref: \"\"\n" +
+	"                      # Resources defines the resource requirements for the step.\n" +
+	"                      resources:\n" +
+	"                        # LiteralTestStep is a full test step definition.\n" +
+	"                        limits:\n" +
+	"                            # LiteralTestStep is a full test step definition.\n" +
+	"                            \"\": \"\"\n" +
+	"                        requests:\n" +
+	"                            # LiteralTestStep is a full test step definition.\n" +
+	"                            \"\": \"\"\n" +
+	"                      retry:\n" +
+	"                        # LiteralTestStep is a full test step definition.\n" +
+	"                        backoff: 0s\n" +
+	"                        exit_codes:\n" +
+	"                            # LiteralTestStep is a full test step definition.\n" +
+	"                            - 0\n" +
+	"                        max_attempts: 0\n" +
+	"                        reasons:\n" +
+	"                            # LiteralTestStep is a full test step definition.\n" +
+	"                            - \"\"\n" +
+	"                      run_as_script: false\n" +
+	"                      served_artifacts:\n" +
+	"                        # LiteralTestStep is a full test step definition.\n" +
+	"                        - \"\"\n" +
+	"                      timeout: 0s\n" +
+	"                      when:\n" +
+	"                        # LiteralTestStep is a full test step definition.\n" +
+	"                        - env: ' '\n" +
+	"                          operator: ' '\n" +
+	"                          values:\n" +
+	"                            # LiteralTestStep is a full test step definition.\n" +
+	"                            - \"\"\n" +
+	"                  parallel_group: ' '\n" +
 	"                  # Reference is the name of a step reference.\n" +
 	"                  ref: \"\"\n" +
 	"                  # Resources defines the resource requirements for the step.\n" +
@@ -1225,8 +1566,33 @@ const ciOperatorReferenceYaml = "# The list of base images describe\n" +
 	"                    requests:\n" +
 	"                        # LiteralTestStep is a full test step definition.\n" +
 	"                        \"\": \"\"\n" +
+	"                  retry:\n" +
+	"                    # LiteralTestStep is a full test step definition.\n" +
+	"                    backoff: 0s\n" +
+	"                    exit_codes:\n" +
+	"                        # LiteralTestStep is a full test step definition.\n" +
+	"                        - 0\n" +
+	"                    max_attempts: 0\n" +
+	"                    reasons:\n" +
+	"                        # LiteralTestStep is a full test step definition.\n" +
+	"                        - \"\"\n" +
 	"                  run_as_script: false\n" +
+	"                  served_artifacts:\n" +
+	"                    # LiteralTestStep is a full test step definition.\n" +
+	"                    - \"\"\n" +
 	"                  timeout: 0s\n" +
+	"                  # When lists conditions which must all hold for the step, or for each\n" +
+	"                  # step of the chain or parallel group, to be executed. They are added\n" +
+	"                  # to the conditions of the steps when the test is resolved.\n" +
+	"                  when:\n" +
+	"                    - # Env is the name of the parameter, either declared by the step or set\n" +
+	"                      # in the environment of the test.\n" +
+	"                      env: ' '\n" +
+	"                      # Operator is either `in` or `notin`.\n" +
+	"                      operator: ' '\n" +
+	"                      # Values are the values the parameter is compared to.\n" +
+	"                      values:\n" +
+	"                        - \"\"\n" +
 	"            # Pre is the array of test steps run to set up the environment for the test.\n" +
 	"            pre:\n" +
 	"                # LiteralTestStep is a full test step definition.\n" +
@@ -1262,9 +1628,14 @@ const ciOperatorReferenceYaml = "# The list of base images describe\n" +
 	"                        - \"\"\n" +
 	"                  env:\n" +
 	"                    # LiteralTestStep is a full test step definition.\n" +
-	"                    - default: \"\"\n" +
+	"                    - allowed_values:\n" +
+	"                        # LiteralTestStep is a full test step definition.\n" +
+	"                        - \"\"\n" +
+	"                      default: \"\"\n" +
 	"                      documentation: ' '\n" +
 	"                      name: ' '\n" +
+	"                      pattern: ' '\n" +
+	"                      type: ' '\n" +
 	"                  from: ' '\n" +
 	"                  from_image:\n" +
 	"                    # LiteralTestStep is a full test step definition.\n" +
@@ -1285,6 +1656,103 @@ const ciOperatorReferenceYaml = "# The list of base images describe\n" +
 	"                    # LiteralTestStep is a full test step definition.\n" +
 	"                    - \"\"\n" +
 	"                  optional_on_success: false\n" +
+	"                  # Parallel is a group of steps which are executed concurrently. Until\n" +
+	"                  # the entrypoint-wrapper image supporting this is rolled out everywhere,\n" +
+	"                  # the steps are executed sequentially unless ci-operator is run with\n" +
+	"                  # --parallel-step-groups.\n" +
+	"                  parallel:\n" +
+	"                    # LiteralTestStep is a full test step definition.\n" +
+	"                    - as: ' '\n" +
+	"                      best_effort: false\n" +
+	"                      cli: ' '\n" +
+	"                      commands: ' '\n" +
+	"                      credentials:\n" +
+	"                        # LiteralTestStep is a full test step definition.\n" +
+	"                        - as: ' '\n" +
+	"                          bundle: ' '\n" +
+	"                          collection: ' '\n" +
+	"                          field: ' '\n" +
+	"                          group: ' '\n" +
+	"                          mount_path: ' '\n" +
+	"                          name: ' '\n" +
+	"                          namespace: ' '\n" +
+	"                      dependencies:\n" +
+	"                        # LiteralTestStep is a full test step definition.\n" +
+	"                        - env: ' '\n" +
+	"                          name: ' '\n" +
+	"                      dnsConfig:\n" +
+	"                        # LiteralTestStep is a full test step definition.\n" +
+	"                        nameservers:\n" +
+	"                            # LiteralTestStep is a full test step definition.\n" +
+	"                            - \"\"\n" +
+	"                        searches:\n" +
+	"                            # LiteralTestStep is a full test step definition.\n" +
+	"                            - \"\"\n" +
+	"                      env:\n" +
+	"                        # LiteralTestStep is a full test step definition.\n" +
+	"                        - allowed_values:\n" +
+	"                            # LiteralTestStep is a full test step definition.\n" +
+	"                            - \"\"\n" +
+	"                          default: \"\"\n" +
+	"                          documentation: ' '\n" +
+	"                          name: ' '\n" +
+	"                          pattern: ' '\n" +
+	"                          type: ' '\n" +
+	"                      from: ' '\n" +
+	"                      from_image:\n" +
+	"                        # LiteralTestStep is a full test step definition.\n" +
+	"                        as: ' '\n" +
+	"                        name: ' '\n" +
+	"                        namespace: ' '\n" +
+	"                        tag: ' '\n" +
+	"                      grace_period: 0s\n" +
+	"                      leases:\n" +
+	"                        # LiteralTestStep is a full test step definition.\n" +
+	"                        - env: ' '\n" +
+	"                          resource_type: ' '\n" +
+	"                      nested_podman: true\n" +
+	"                      no_kubeconfig: false\n" +
+	"                      node_architecture: \"\"\n" +
+	"                      # Observers are the observers that should be running\n" +
+	"                      observers:\n" +
+	"                        # LiteralTestStep is a full test step definition.\n" +
+	"                        - \"\"\n" +
+	"                      optional_on_success: false\n" +
+	"                      parallel_group: ' '\n" +
+	"                      # Reference is the name of a step reference.\n" +
+	"                      ref: \"\"\n" +
+	"                      # Resources defines the resource requirements for the step.\n" +
+	"                      resources:\n" +
+	"                        # LiteralTestStep is a full test step definition.\n" +
+	"                        limits:\n" +
+	"                            # LiteralTestStep is a full test step definition.\n" +
+	"                            \"\": \"\"\n" +
+	"                        requests:\n" +
+	"                            # LiteralTestStep is a full test step definition.\n" +
+	"                            \"\": \"\"\n" +
+	"                      retry:\n" +
+	"                        # LiteralTestStep is a full test step definition.\n" +
+	"                        backoff: 0s\n" +
+	"                        exit_codes:\n" +
+	"                            # LiteralTestStep is a full test step definition.\n" +
+	"                            - 0\n" +
+	"                        max_attempts: 0\n" +
+	"                        reasons:\n" +
+	"                            # LiteralTestStep is a full test step definition.\n" +
+	"                            - \"\"\n" +
+	"                      run_as_script: false\n" +
+	"                      served_artifacts:\n" +
+	"                        # LiteralTestStep is a full test step definition.\n" +
+	"                        - \"\"\n" +
+	"                      timeout: 0s\n" +
+	"                      when:\n" +
+	"                        # LiteralTestStep is a full test step definition.\n" +
+	"                        - env: ' '\n" +
+	"                          operator: ' '\n" +
+	"                          values:\n" +
+	"                            # LiteralTestStep is a full test step definition.\n" +
+	"                            - \"\"\n" +
+	"                  parallel_group: ' '\n" +
 	"                  # Reference is the name of a step reference.\n" +
 	"                  ref: \"\"\n" +
 	"                  # Resources defines the resource requirements for the step.\n" +
@@ -1296,8 +1764,33 @@ const ciOperatorReferenceYaml = "# The list of base images describe\n" +
 	"                    requests:\n" +
 	"                        # LiteralTestStep is a full test step definition.\n" +
 	"                        \"\": \"\"\n" +
+	"                  retry:\n" +
+	"                    # LiteralTestStep is a full test step definition.\n" +
+	"                    backoff: 0s\n" +
+	"                    exit_codes:\n" +
+	"                        # LiteralTestStep is a full test step definition.\n" +
+	"                        - 0\n" +
+	"                    max_attempts: 0\n" +
+	"                    reasons:\n" +
+	"                        # LiteralTestStep is a full test step definition.\n" +
+	"                        - \"\"\n" +
 	"                  run_as_script: false\n" +
+	"                  served_artifacts:\n" +
+	"                    # LiteralTestStep is a full test step definition.\n" +
+	"                    - \"\"\n" +
 	"                  timeout: 0s\n" +
+	"                  # When lists conditions which must all hold for the step, or for each\n" +
+	"                  # step of the chain or parallel group, to be executed. They are added\n" +
+	"                  # to the conditions of the steps when the test is resolved.\n" +
+	"                  when:\n" +
+	"                    - # Env is the name of the parameter, either declared by the step or set\n" +
+	"                      # in the environment of the test.\n" +
+	"                      env: ' '\n" +
+	"                      # Operator is either `in` or `notin`.\n" +
+	"                      operator: ' '\n" +
+	"                      # Values are the values the parameter is compared to.\n" +
+	"                      values:\n" +
+	"                        - \"\"\n" +
 	"            # Test is the array of test steps that define the actual test.\n" +
 	"            test:\n" +
 	"                # LiteralTestStep is a full test step definition.\n" +
@@ -1333,9 +1826,14 @@ const ciOperatorReferenceYaml = "# The list of base images describe\n" +
 	"                        - \"\"\n" +
 	"                  env:\n" +
 	"                    # LiteralTestStep is a full test step definition.\n" +
-	"                    - default: \"\"\n" +
+	"                    - allowed_values:\n" +
+	"                        # LiteralTestStep is a full test step definition.\n" +
+	"                        - \"\"\n" +
+	"                      default: \"\"\n" +
 	"                      documentation: ' '\n" +
 	"                      name: ' '\n" +
+	"                      pattern: ' '\n" +
+	"                      type: ' '\n" +
 	"                  from: ' '\n" +
 	"                  from_image:\n" +
 	"                    # LiteralTestStep is a full test step definition.\n" +
@@ -1356,6 +1854,103 @@ const ciOperatorReferenceYaml = "# The list of base images describe\n" +
 	"                    # LiteralTestStep is a full test step definition.\n" +
 	"                    - \"\"\n" +
 	"                  optional_on_success: false\n" +
+	"                  # Parallel is a group of steps which are executed concurrently. Until\n" +
+	"                  # the entrypoint-wrapper image supporting this is rolled out everywhere,\n" +
+	"                  # the steps are executed sequentially unless ci-operator is run with\n" +
+	"                  # --parallel-step-groups.\n" +
+	"                  parallel:\n" +
+	"                    # LiteralTestStep is a full test step definition.\n" +
+	"                    - as: ' '\n" +
+	"                      best_effort: false\n" +
+	"                      cli: ' '\n" +
+	"                      commands: ' '\n" +
+	"                      credentials:\n" +
+	"                        # LiteralTestStep is a full test step definition.\n" +
+	"                        - as: ' '\n" +
+	"                          bundle: ' '\n" +
+	"                          collection: ' '\n" +
+	"                          field: ' '\n" +
+	"                          group: ' '\n" +
+	"                          mount_path: ' '\n" +
+	"                          name: ' '\n" +
+	"                          namespace: ' '\n" +
+	"                      dependencies:\n" +
+	"                        # LiteralTestStep is a full test step definition.\n" +
+	"                        - env: ' '\n" +
+	"                          name: ' '\n" +
+	"                      dnsConfig:\n" +
+	"                        # LiteralTestStep is a full test step definition.\n" +
+	"                        nameservers:\n" +
+	"                            # LiteralTestStep is a full test step definition.\n" +
+	"                            - \"\"\n" +
+	"                        searches:\n" +
+	"                            # LiteralTestStep is a full test step definition.\n" +
+	"                            - \"\"\n" +
+	"                      env:\n" +
+	"                        # LiteralTestStep is a full test step definition.\n" +
+	"                        - allowed_values:\n" +
+	"                            # LiteralTestStep is a full test step definition.\n" +
+	"                            - \"\"\n" +
+	"                          default: \"\"\n" +
+	"                          documentation: ' '\n" +
+	"                          name: ' '\n" +
+	"                          pattern: ' '\n" +
+	"                          type: ' '\n" +
+	"                      from: ' '\n" +
+	"                      from_image:\n" +
+	"                        # LiteralTestStep is a full test step definition.\n" +
+	"                        as: ' '\n" +
+	"                        name: ' '\n" +
+	"                        namespace: ' '\n" +
+	"                        tag: ' '\n" +
+	"                      grace_period: 0s\n" +
+	"                      leases:\n" +
+	"                        # LiteralTestStep is a full test step definition.\n" +
+	"                        - env: ' '\n" +
+	"                          resource_type: ' '\n" +
+	"                      nested_podman: true\n" +
+	"                      no_kubeconfig: false\n" +
+	"                      node_architecture: \"\"\n" +
+	"                      # Observers are the observers that should be running\n" +
+	"                      observers:\n" +
+	"                        # LiteralTestStep is a full test step definition.\n" +
+	"                        - \"\"\n" +
+	"                      optional_on_success: false\n" +
+	"                      parallel_group: ' '\n" +
+	"                      # Reference is the name of a step reference.\n" +
+	"                      ref: \"\"\n" +
+	"                      # Resources defines the resource requirements for the step.\n" +
+	"                      resources:\n" +
+	"                        # LiteralTestStep is a full test step definition.\n" +
+	"                        limits:\n" +
+	"                            # LiteralTestStep is a full test step definition.\n" +
+	"                            \"\": \"\"\n" +
+	"                        requests:\n" +
+	"                            # LiteralTestStep is a full test step definition.\n" +
+	"                            \"\": \"\"\n" +
+	"                      retry:\n" +
+	"                        # LiteralTestStep is a full test step definition.\n" +
+	"                        backoff: 0s\n" +
+	"                        exit_codes:\n" +
+	"                            # LiteralTestStep is a full test step definition.\n" +
+	"                            - 0\n" +
+	"                        max_attempts: 0\n" +
+	"                        reasons:\n" +
+	"                            # LiteralTestStep is a full test step definition.\n" +
+	"                            - \"\"\n" +
+	"                      run_as_script: false\n" +
+	"                      served_artifacts:\n" +
+	"                        # LiteralTestStep is a full test step definition.\n" +
+	"                        - \"\"\n" +
+	"                      timeout: 0s\n" +
+	"                      when:\n" +
+	"                        # LiteralTestStep is a full test step definition.\n" +
+	"                        - env: ' '\n" +
+	"                          operator: ' '\n" +
+	"                          values:\n" +
+	"                            # LiteralTestStep is a full test step definition.\n" +
+	"                            - \"\"\n" +
+	"                  parallel_group: ' '\n" +
 	"                  # Reference is the name of a step reference.\n" +
 	"                  ref: \"\"\n" +
 	"                  # Resources defines the resource requirements for the step.\n" +
@@ -1367,8 +1962,33 @@ const ciOperatorReferenceYaml = "# The list of base images describe\n" +
 	"                    requests:\n" +
 	"                        # LiteralTestStep is a full test step definition.\n" +
 	"                        \"\": \"\"\n" +
+	"                  retry:\n" +
+	"                    # LiteralTestStep is a full test step definition.\n" +
+	"                    backoff: 0s\n" +
+	"                    exit_codes:\n" +
+	"                        # LiteralTestStep is a full test step definition.\n" +
+	"                        - 0\n" +
+	"                    max_attempts: 0\n" +
+	"                    reasons:\n" +
+	"                        # LiteralTestStep is a full test step definition.\n" +
+	"                        - \"\"\n" +
 	"                  run_as_script: false\n" +
+	"                  served_artifacts:\n" +
+	"                    # LiteralTestStep is a full test step definition.\n" +
+	"                    - \"\"\n" +
 	"                  timeout: 0s\n" +
+	"                  # When lists conditions which must all hold for the step, or for each\n" +
+	"                  # step of the chain or parallel group, to be executed. They are added\n" +
+	"                  # to the conditions of the steps when the test is resolved.\n" +
+	"                  when:\n" +
+	"                    - # Env is the name of the parameter, either declared by the step or set\n" +
+	"                      # in the environment of the test.\n" +
+	"                      env: ' '\n" +
+	"                      # Operator is either `in` or `notin`.\n" +
+	"                      operator: ' '\n" +
+	"                      # Values are the values the parameter is compared to.\n" +
+	"                      values:\n" +
+	"                        - \"\"\n" +
 	"            # Workflow is the name of the workflow to be used for this configuration. For fields defined in both\n" +
 	"            # the config and the workflow, the fields from the config will override what is set in Workflow.\n" +
 	"            workflow: \"\"\n" +
@@ -1462,6 +2082,19 @@ const ciOperatorReferenceYaml = "# The list of base images describe\n" +
 	"rpm_build_location_list:\n" +
 	"    - location: ' '\n" +
 	"      ref: ' '\n" +
+	"# ServedArtifacts are directories of built images which are served over\n" +
+	"# HTTP to the tests of the job, so build outputs like binaries, charts or\n" +
+	"# bundles can be shared between steps without baking them into images.\n" +
+	"served_artifacts:\n" +
+	"    - # From is the image holding the artifacts. The image serves them itself,\n" +
+	"      # like the RPM server does, so it must provide bash and python 2 or 3.\n" +
+	"      from: ' '\n" +
+	"      # Name identifies the artifacts. Test steps which list the name in\n" +
+	"      # their served_artifacts find the URL to the served directory in the\n" +
+	"      # ARTIFACTS_URL_<NAME> environment variable.\n" +
+	"      name: ' '\n" +
+	"      # Path is the absolute path of the directory in the image which is served.\n" +
+	"      path: ' '\n" +
 	"# ReleaseTagConfiguration determines how the\n" +
 	"# full release is assembled.\n" +
 	"tag_specification:\n" +
@@ -1560,6 +2193,16 @@ const ciOperatorReferenceYaml = "# The list of base images describe\n" +
 	"        # all previous `pre` and `test` steps were successful. The given step must explicitly\n" +
 	"        # ask for being skipped by setting the OptionalOnSuccess flag to true.\n" +
 	"        allow_skip_on_success: false\n" +
+	"        # Budgets limit how long each phase of the test can run.\n" +
+	"        budgets:\n" +
+	"            # Post limits the time the `post` steps can take. This time is reserved\n" +
+	"            # at the end of the job: `pre` and `test` steps are interrupted early\n" +
+	"            # enough for the `post` steps to tear down what they set up.\n" +
+	"            post: 0s\n" +
+	"            # Pre limits the time the `pre` steps can take.\n" +
+	"            pre: 0s\n" +
+	"            # Test limits the time the `test` steps can take.\n" +
+	"            test: 0s\n" +
 	"        # ClusterProfile defines the profile/cloud provider for end-to-end test steps.\n" +
 	"        cluster_profile: ' '\n" +
 	"        # Dependencies holds override values for dependency parameters.\n" +
@@ -1599,12 +2242,22 @@ const ciOperatorReferenceYaml = "# The list of base images describe\n" +
 	"              commands: ' '\n" +
 	"              # Environment has the values of parameters for the observer.\n" +
 	"              env:\n" +
-	"                - # Default if not set, optional, makes the parameter not required if set.\n" +
+	"                - # AllowedValues lists the values of an `enum` parameter.\n" +
+	"                  allowed_values:\n" +
+	"                    - \"\"\n" +
+	"                  # Default if not set, optional, makes the parameter not required if set.\n" +
 	"                  default: \"\"\n" +
 	"                  # Documentation is a textual description of the parameter.\n" +
 	"                  documentation: ' '\n" +
 	"                  # Name of the environment variable.\n" +
 	"                  name: ' '\n" +
+	"                  # Pattern is the regular expression the values of a `regex` parameter\n" +
+	"                  # must match entirely.\n" +
+	"                  pattern: ' '\n" +
+	"                  # Type of the values of the parameter, optional. The default and the\n" +
+	"                  # values set for typed parameters are validated, an empty value is always\n" +
+	"                  # valid and leaves the parameter unset.\n" +
+	"                  type: ' '\n" +
 	"              # From is the container image that will be used for this observer.\n" +
 	"              from: ' '\n" +
 	"              # FromImage is a literal ImageStreamTag reference to use for this observer.\n" +
@@ -1684,12 +2337,22 @@ const ciOperatorReferenceYaml = "# The list of base images describe\n" +
 	"                    - \"\"\n" +
 	"              # Environment lists parameters that should be set by the test.\n" +
 	"              env:\n" +
-	"                - # Default if not set, optional, makes the parameter not required if set.\n" +
+	"                - # AllowedValues lists the values of an `enum` parameter.\n" +
+	"                  allowed_values:\n" +
+	"                    - \"\"\n" +
+	"                  # Default if not set, optional, makes the parameter not required if set.\n" +
 	"                  default: \"\"\n" +
 	"                  # Documentation is a textual description of the parameter.\n" +
 	"                  documentation: ' '\n" +
 	"                  # Name of the environment variable.\n" +
 	"                  name: ' '\n" +
+	"                  # Pattern is the regular expression the values of a `regex` parameter\n" +
+	"                  # must match entirely.\n" +
+	"                  pattern: ' '\n" +
+	"                  # Type of the values of the parameter, optional. The default and the\n" +
+	"                  # values set for typed parameters are validated, an empty value is always\n" +
+	"                  # valid and leaves the parameter unset.\n" +
+	"                  type: ' '\n" +
 	"              # From is the container image that will be used for this step.\n" +
 	"              from: ' '\n" +
 	"              # FromImage is a literal ImageStreamTag reference to use for this step.\n" +
@@ -1725,6 +2388,12 @@ const ciOperatorReferenceYaml = "# The list of base images describe\n" +
 	"              # flag is set to true in MultiStageTestConfiguration. This option is\n" +
 	"              # applicable to `post` steps.\n" +
 	"              optional_on_success: false\n" +
+	"              # ParallelGroup is the name of the group of steps this step is executed\n" +
+	"              # concurrently with. Consecutive steps in a phase which share the same\n" +
+	"              # group are started together and the phase only moves on when all of them\n" +
+	"              # have finished. This is only set when resolving `parallel` blocks and\n" +
+	"              # configurations setting it by hand are rejected.\n" +
+	"              parallel_group: ' '\n" +
 	"              # Resources defines the resource requirements for the step.\n" +
 	"              resources:\n" +
 	"                # Limits are resource limits applied to an individual step in the job.\n" +
@@ -1735,11 +2404,43 @@ const ciOperatorReferenceYaml = "# The list of base images describe\n" +
 	"                # These are directly used in creating the Pods that execute the Job.\n" +
 	"                requests:\n" +
 	"                    \"\": \"\"\n" +
+	"              # Retry determines whether the step is executed again when it fails.\n" +
+	"              retry:\n" +
+	"                # Backoff is how long to wait between attempts.\n" +
+	"                backoff: 0s\n" +
+	"                # ExitCodes lists the exit codes of the test container which cause the\n" +
+	"                # step to be retried.\n" +
+	"                exit_codes:\n" +
+	"                    - 0\n" +
+	"                # MaxAttempts is the number of times the step is executed at most,\n" +
+	"                # including the first attempt.\n" +
+	"                max_attempts: 0\n" +
+	"                # Reasons lists the failure reasons, such as `pod_pending`, which cause\n" +
+	"                # the step to be retried.\n" +
+	"                reasons:\n" +
+	"                    - \"\"\n" +
 	"              # RunAsScript defines if this step should be executed as a script mounted\n" +
 	"              # in the test container instead of being executed directly via bash\n" +
 	"              run_as_script: false\n" +
+	"              # ServedArtifacts lists the served artifacts of the configuration which\n" +
+	"              # the step uses. The URL of each is exposed in the\n" +
+	"              # ARTIFACTS_URL_<NAME> environment variable.\n" +
+	"              served_artifacts:\n" +
+	"                - \"\"\n" +
 	"              # Timeout is how long the we will wait before aborting a job with SIGINT.\n" +
 	"              timeout: 0s\n" +
+	"              # When lists conditions on parameters of the step which must all hold\n" +
+	"              # for it to be executed. Steps whose conditions do not hold are skipped\n" +
+	"              # without creating a pod.\n" +
+	"              when:\n" +
+	"                - # Env is the name of the parameter, either declared by the step or set\n" +
+	"                  # in the environment of the test.\n" +
+	"                  env: ' '\n" +
+	"                  # Operator is either `in` or `notin`.\n" +
+	"                  operator: ' '\n" +
+	"                  # Values are the values the parameter is compared to.\n" +
+	"                  values:\n" +
+	"                    - \"\"\n" +
 	"        # Pre is the array of test steps run to set up the environment for the test.\n" +
 	"        pre:\n" +
 	"            - # As is the name of the LiteralTestStep.\n" +
@@ -1792,12 +2493,22 @@ const ciOperatorReferenceYaml = "# The list of base images describe\n" +
 	"                    - \"\"\n" +
 	"              # Environment lists parameters that should be set by the test.\n" +
 	"              env:\n" +
-	"                - # Default if not set, optional, makes the parameter not required if set.\n" +
+	"                - # AllowedValues lists the values of an `enum` parameter.\n" +
+	"                  allowed_values:\n" +
+	"                    - \"\"\n" +
+	"                  # Default if not set, optional, makes the parameter not required if set.\n" +
 	"                  default: \"\"\n" +
 	"                  # Documentation is a textual description of the parameter.\n" +
 	"                  documentation: ' '\n" +
 	"                  # Name of the environment variable.\n" +
 	"                  name: ' '\n" +
+	"                  # Pattern is the regular expression the values of a `regex` parameter\n" +
+	"                  # must match entirely.\n" +
+	"                  pattern: ' '\n" +
+	"                  # Type of the values of the parameter, optional. The default and the\n" +
+	"                  # values set for typed parameters are validated, an empty value is always\n" +
+	"                  # valid and leaves the parameter unset.\n" +
+	"                  type: ' '\n" +
 	"              # From is the container image that will be used for this step.\n" +
 	"              from: ' '\n" +
 	"              # FromImage is a literal ImageStreamTag reference to use for this step.\n" +
@@ -1833,6 +2544,12 @@ const ciOperatorReferenceYaml = "# The list of base images describe\n" +
 	"              # flag is set to true in MultiStageTestConfiguration. This option is\n" +
 	"              # applicable to `post` steps.\n" +
 	"              optional_on_success: false\n" +
+	"              # ParallelGroup is the name of the group of steps this step is executed\n" +
+	"              # concurrently with. Consecutive steps in a phase which share the same\n" +
+	"              # group are started together and the phase only moves on when all of them\n" +
+	"              # have finished. This is only set when resolving `parallel` blocks and\n" +
+	"              # configurations setting it by hand are rejected.\n" +
+	"              parallel_group: ' '\n" +
 	"              # Resources defines the resource requirements for the step.\n" +
 	"              resources:\n" +
 	"                # Limits are resource limits applied to an individual step in the job.\n" +
@@ -1843,11 +2560,43 @@ const ciOperatorReferenceYaml = "# The list of base images describe\n" +
 	"                # These are directly used in creating the Pods that execute the Job.\n" +
 	"                requests:\n" +
 	"                    \"\": \"\"\n" +
+	"              # Retry determines whether the step is executed again when it fails.\n" +
+	"              retry:\n" +
+	"                # Backoff is how long to wait between attempts.\n" +
+	"                backoff: 0s\n" +
+	"                # ExitCodes lists the exit codes of the test container which cause the\n" +
+	"                # step to be retried.\n" +
+	"                exit_codes:\n" +
+	"                    - 0\n" +
+	"                # MaxAttempts is the number of times the step is executed at most,\n" +
+	"                # including the first attempt.\n" +
+	"                max_attempts: 0\n" +
+	"                # Reasons lists the failure reasons, such as `pod_pending`, which cause\n" +
+	"                # the step to be retried.\n" +
+	"                reasons:\n" +
+	"                    - \"\"\n" +
 	"              # RunAsScript defines if this step should be executed as a script mounted\n" +
 	"              # in the test container instead of being executed directly via bash\n" +
 	"              run_as_script: false\n" +
+	"              # ServedArtifacts lists the served artifacts of the configuration which\n" +
+	"              # the step uses. The URL of each is exposed in the\n" +
+	"              # ARTIFACTS_URL_<NAME> environment variable.\n" +
+	"              served_artifacts:\n" +
+	"                - \"\"\n" +
 	"              # Timeout is how long the we will wait before aborting a job with SIGINT.\n" +
 	"              timeout: 0s\n" +
+	"              # When lists conditions on parameters of the step which must all hold\n" +
+	"              # for it to be executed. Steps whose conditions do not hold are skipped\n" +
+	"              # without creating a pod.\n" +
+	"              when:\n" +
+	"                - # Env is the name of the parameter, either declared by the step or set\n" +
+	"                  # in the environment of the test.\n" +
+	"                  env: ' '\n" +
+	"                  # Operator is either `in` or `notin`.\n" +
+	"                  operator: ' '\n" +
+	"                  # Values are the values the parameter is compared to.\n" +
+	"                  values:\n" +
+	"                    - \"\"\n" +
 	"        # Test is the array of test steps that define the actual test.\n" +
 	"        test:\n" +
 	"            - # As is the name of the LiteralTestStep.\n" +
@@ -1900,12 +2649,22 @@ const ciOperatorReferenceYaml = "# The list of base images describe\n" +
 	"                    - \"\"\n" +
 	"              # Environment lists parameters that should be set by the test.\n" +
 	"              env:\n" +
-	"                - # Default if not set, optional, makes the parameter not required if set.\n" +
+	"                - # AllowedValues lists the values of an `enum` parameter.\n" +
+	"                  allowed_values:\n" +
+	"                    - \"\"\n" +
+	"                  # Default if not set, optional, makes the parameter not required if set.\n" +
 	"                  default: \"\"\n" +
 	"                  # Documentation is a textual description of the parameter.\n" +
 	"                  documentation: ' '\n" +
 	"                  # Name of the environment variable.\n" +
 	"                  name: ' '\n" +
+	"                  # Pattern is the regular expression the values of a `regex` parameter\n" +
+	"                  # must match entirely.\n" +
+	"                  pattern: ' '\n" +
+	"                  # Type of the values of the parameter, optional. The default and the\n" +
+	"                  # values set for typed parameters are validated, an empty value is always\n" +
+	"                  # valid and leaves the parameter unset.\n" +
+	"                  type: ' '\n" +
 	"              # From is the container image that will be used for this step.\n" +
 	"              from: ' '\n" +
 	"              # FromImage is a literal ImageStreamTag reference to use for this step.\n" +
@@ -1941,6 +2700,12 @@ const ciOperatorReferenceYaml = "# The list of base images describe\n" +
 	"              # flag is set to true in MultiStageTestConfiguration. This option is\n" +
 	"              # applicable to `post` steps.\n" +
 	"              optional_on_success: false\n" +
+	"              # ParallelGroup is the name of the group of steps this step is executed\n" +
+	"              # concurrently with. Consecutive steps in a phase which share the same\n" +
+	"              # group are started together and the phase only moves on when all of them\n" +
+	"              # have finished. This is only set when resolving `parallel` blocks and\n" +
+	"              # configurations setting it by hand are rejected.\n" +
+	"              parallel_group: ' '\n" +
 	"              # Resources defines the resource requirements for the step.\n" +
 	"              resources:\n" +
 	"                # Limits are resource limits applied to an individual step in the job.\n" +
@@ -1951,13 +2716,77 @@ const ciOperatorReferenceYaml = "# The list of base images describe\n" +
 	"                # These are directly used in creating the Pods that execute the Job.\n" +
 	"                requests:\n" +
 	"                    \"\": \"\"\n" +
+	"              # Retry determines whether the step is executed again when it fails.\n" +
+	"              retry:\n" +
+	"                # Backoff is how long to wait between attempts.\n" +
+	"                backoff: 0s\n" +
+	"                # ExitCodes lists the exit codes of the test container which cause the\n" +
+	"                # step to be retried.\n" +
+	"                exit_codes:\n" +
+	"                    - 0\n" +
+	"                # MaxAttempts is the number of times the step is executed at most,\n" +
+	"                # including the first attempt.\n" +
+	"                max_attempts: 0\n" +
+	"                # Reasons lists the failure reasons, such as `pod_pending`, which cause\n" +
+	"                # the step to be retried.\n" +
+	"                reasons:\n" +
+	"                    - \"\"\n" +
 	"              # RunAsScript defines if this step should be executed as a script mounted\n" +
 	"              # in the test container instead of being executed directly via bash\n" +
 	"              run_as_script: false\n" +
+	"              # ServedArtifacts lists the served artifacts of the configuration which\n" +
+	"              # the step uses. The URL of each is exposed in the\n" +
+	"              # ARTIFACTS_URL_<NAME> environment variable.\n" +
+	"              served_artifacts:\n" +
+	"                - \"\"\n" +
 	"              # Timeout is how long the we will wait before aborting a job with SIGINT.\n" +
 	"              timeout: 0s\n" +
+	"              # When lists conditions on parameters of the step which must all hold\n" +
+	"              # for it to be executed. Steps whose conditions do not hold are skipped\n" +
+	"              # without creating a pod.\n" +
+	"              when:\n" +
+	"                - # Env is the name of the parameter, either declared by the step or set\n" +
+	"                  # in the environment of the test.\n" +
+	"                  env: ' '\n" +
+	"                  # Operator is either `in` or `notin`.\n" +
+	"                  operator: ' '\n" +
+	"                  # Values are the values the parameter is compared to.\n" +
+	"                  values:\n" +
+	"                    - \"\"\n" +
 	"        # Override job timeout\n" +
 	"        timeout: 0s\n" +
+	"      # Matrix describes parameters whose combinations of values each generate\n" +
+	"      # a copy of this test, with the values set in its environment.\n" +
+	"      # Only applicable to multi-stage tests.\n" +
+	"      matrix:\n" +
+	"        # Dimensions are the parameters and the values they take. The name of\n" +
+	"        # each generated test is the name of the original test followed by the\n" +
+	"        # names of the values of the combination, in the order of the dimensions.\n" +
+	"        dimensions:\n" +
+	"            - # Env is the name of the parameter set in the environment of the tests.\n" +
+	"              env: ' '\n" +
+	"              # Values are the values the parameter takes.\n" +
+	"              values:\n" +
+	"                - # Name identifies the value in the names of the generated tests.\n" +
+	"                  name: ' '\n" +
+	"                  # Value is set as the parameter. Defaults to the name.\n" +
+	"                  value: \"\"\n" +
+	"        # Overrides change the tests generated for some combinations.\n" +
+	"        overrides:\n" +
+	"            - # AlwaysRun overrides whether matching tests run on every PR.\n" +
+	"              always_run: false\n" +
+	"              # Env sets additional parameters in the environment of matching tests.\n" +
+	"              env:\n" +
+	"                \"\": \"\"\n" +
+	"              # Exclude removes the matching combinations.\n" +
+	"              exclude: true\n" +
+	"              # Match selects combinations by the names of their values, keyed by the\n" +
+	"              # parameter of the dimension. Dimensions which are not listed match any\n" +
+	"              # value.\n" +
+	"              match:\n" +
+	"                \"\": \"\"\n" +
+	"              # Optional overrides whether matching tests are optional.\n" +
+	"              optional: false\n" +
 	"      # MinimumInterval to wait between two runs of the job. Consecutive\n" +
 	"      # jobs are run at `minimum_interval` + `duration of previous job`\n" +
 	"      # apart. Setting this field will create a periodic job instead of a\n" +
@@ -2012,6 +2841,13 @@ const ciOperatorReferenceYaml = "# The list of base images describe\n" +
 	"      release_controller: true\n" +
 	"      # RestrictNetworkAccess restricts network access to RedHat intranet.\n" +
 	"      restrict_network_access: false\n" +
+	"      # ResultCache allows a passing result of this test to be reused by later\n" +
+	"      # executions whose configuration and images are identical, instead of\n" +
+	"      # running the test again. Only applicable to container and multi-stage tests.\n" +
+	"      result_cache:\n" +
+	"        # MaxAge is how long after it passed a result can be reused.\n" +
+	"        # Defaults to 24h.\n" +
+	"        max_age: 0s\n" +
 	"      # Retry is a configuration entry for retrying periodic prowjobs\n" +
 	"      retry:\n" +
 	"        interval: ' '\n" +
@@ -2053,6 +2889,16 @@ const ciOperatorReferenceYaml = "# The list of base images describe\n" +
 	"        # all previous `pre` and `test` steps were successful. The given step must explicitly\n" +
 	"        # ask for being skipped by setting the OptionalOnSuccess flag to true.\n" +
 	"        allow_skip_on_success: false\n" +
+	"        # Budgets limit how long each phase of the test can run.\n" +
+	"        budgets:\n" +
+	"            # Post limits the time the `post` steps can take. This time is reserved\n" +
+	"            # at the end of the job: `pre` and `test` steps are interrupted early\n" +
+	"            # enough for the `post` steps to tear down what they set up.\n" +
+	"            post: 0s\n" +
+	"            # Pre limits the time the `pre` steps can take.\n" +
+	"            pre: 0s\n" +
+	"            # Test limits the time the `test` steps can take.\n" +
+	"            test: 0s\n" +
 	"        # ClusterProfile defines the profile/cloud provider for end-to-end test steps.\n" +
 	"        cluster_profile: ' '\n" +
 	"        # Dependencies holds override values for dependency parameters.\n" +
@@ -2131,9 +2977,14 @@ const ciOperatorReferenceYaml = "# The list of base images describe\n" +
 	"                    - \"\"\n" +
 	"              env:\n" +
 	"                # LiteralTestStep is a full test step definition.\n" +
-	"                - default: \"\"\n" +
+	"                - allowed_values:\n" +
+	"                    # LiteralTestStep is a full test step definition.\n" +
+	"                    - \"\"\n" +
+	"                  default: \"\"\n" +
 	"                  documentation: ' '\n" +
 	"                  name: ' '\n" +
+	"                  pattern: ' '\n" +
+	"                  type: ' '\n" +
 	"              from: ' '\n" +
 	"              from_image:\n" +
 	"                # LiteralTestStep is a full test step definition.\n" +
@@ -2154,6 +3005,103 @@ const ciOperatorReferenceYaml = "# The list of base images describe\n" +
 	"                # LiteralTestStep is a full test step definition.\n" +
 	"                - \"\"\n" +
 	"              optional_on_success: false\n" +
+	"              # Parallel is a group of steps which are executed concurrently. Until\n" +
+	"              # the entrypoint-wrapper image supporting this is rolled out everywhere,\n" +
+	"              # the steps are executed sequentially unless ci-operator is run with\n" +
+	"              # --parallel-step-groups.\n" +
+	"              parallel:\n" +
+	"                # LiteralTestStep is a full test step definition.\n" +
+	"                - as: ' '\n" +
+	"                  best_effort: false\n" +
+	"                  cli: ' '\n" +
+	"                  commands: ' '\n" +
+	"                  credentials:\n" +
+	"                    # LiteralTestStep is a full test step definition.\n" +
+	"                    - as: ' '\n" +
+	"                      bundle: ' '\n" +
+	"                      collection: ' '\n" +
+	"                      field: ' '\n" +
+	"                      group: ' '\n" +
+	"                      mount_path: ' '\n" +
+	"                      name: ' '\n" +
+	"                      namespace: ' '\n" +
+	"                  dependencies:\n" +
+	"                    # LiteralTestStep is a full test step definition.\n" +
+	"                    - env: ' '\n" +
+	"                      name: ' '\n" +
+	"                  dnsConfig:\n" +
+	"                    # LiteralTestStep is a full test step definition.\n" +
+	"                    nameservers:\n" +
+	"                        # LiteralTestStep is a full test step definition.\n" +
+	"                        - \"\"\n" +
+	"                    searches:\n" +
+	"                        # LiteralTestStep is a full test step definition.\n" +
+	"                        - \"\"\n" +
+	"                  env:\n" +
+	"                    # LiteralTestStep is a full test step definition.\n" +
+	"                    - allowed_values:\n" +
+	"                        # LiteralTestStep is a full test step definition.\n" +
+	"                        - \"\"\n" +
+	"                      default: \"\"\n" +
+	"                      documentation: ' '\n" +
+	"                      name: ' '\n" +
+	"                      pattern: ' '\n" +
+	"                      type: ' '\n" +
+	"                  from: ' '\n" +
+	"                  from_image:\n" +
+	"                    # LiteralTestStep is a full test step definition.\n" +
+	"                    as: ' '\n" +
+	"                    name: ' '\n" +
+	"                    namespace: ' '\n" +
+	"                    tag: ' '\n" +
+	"                  grace_period: 0s\n" +
+	"                  leases:\n" +
+	"                    # LiteralTestStep is a full test step definition.\n" +
+	"                    - env: ' '\n" +
+	"                      resource_type: ' '\n" +
+	"                  nested_podman: true\n" +
+	"                  no_kubeconfig: false\n" +
+	"                  node_architecture: \"\"\n" +
+	"                  # Observers are the observers that should be running\n" +
+	"                  observers:\n" +
+	"                    # LiteralTestStep is a full test step definition.\n" +
+	"                    - \"\"\n" +
+	"                  optional_on_success: false\n" +
+	"                  parallel_group: ' '\n" +
+	"                  # Reference is the name of a step reference.\n" +
+	"                  ref: \"\"\n" +
+	"                  # Resources defines the resource requirements for the step.\n" +
+	"                  resources:\n" +
+	"                    # LiteralTestStep is a full test step definition.\n" +
+	"                    limits:\n" +
+	"                        # LiteralTestStep is a full test step definition.\n" +
+	"                        \"\": \"\"\n" +
+	"                    requests:\n" +
+	"                        # LiteralTestStep is a full test step definition.\n" +
+	"                        \"\": \"\"\n" +
+	"                  retry:\n" +
+	"                    # LiteralTestStep is a full test step definition.\n" +
+	"                    backoff: 0s\n" +
+	"                    exit_codes:\n" +
+	"                        # LiteralTestStep is a full test step definition.\n" +
+	"                        - 0\n" +
+	"                    max_attempts: 0\n" +
+	"                    reasons:\n" +
+	"                        # LiteralTestStep is a full test step definition.\n" +
+	"                        - \"\"\n" +
+	"                  run_as_script: false\n" +
+	"                  served_artifacts:\n" +
+	"                    # LiteralTestStep is a full test step definition.\n" +
+	"                    - \"\"\n" +
+	"                  timeout: 0s\n" +
+	"                  when:\n" +
+	"                    # LiteralTestStep is a full test step definition.\n" +
+	"                    - env: ' '\n" +
+	"                      operator: ' '\n" +
+	"                      values:\n" +
+	"                        # LiteralTestStep is a full test step definition.\n" +
+	"                        - \"\"\n" +
+	"              parallel_group: ' '\n" +
 	"              # Reference is the name of a step reference.\n" +
 	"              ref: \"\"\n" +
 	"              # Resources defines the resource requirements for the step.\n" +
@@ -2165,8 +3113,33 @@ const ciOperatorReferenceYaml = "# The list of base images describe\n" +
 	"                requests:\n" +
 	"                    # LiteralTestStep is a full test step definition.\n" +
 	"                    \"\": \"\"\n" +
+	"              retry:\n" +
+	"                # LiteralTestStep is a full test step definition.\n" +
+	"                backoff: 0s\n" +
+	"                exit_codes:\n" +
+	"                    # LiteralTestStep is a full test step definition.\n" +
+	"                    - 0\n" +
+	"                max_attempts: 0\n" +
+	"                reasons:\n" +
+	"                    # LiteralTestStep is a full test step definition.\n" +
+	"                    - \"\"\n" +
 	"              run_as_script: false\n" +
+	"              served_artifacts:\n" +
+	"                # LiteralTestStep is a full test step definition.\n" +
+	"                - \"\"\n" +
 	"              timeout: 0s\n" +
+	"              # When lists conditions which must all hold for the step, or for each\n" +
+	"              # step of the chain or parallel group, to be executed. They are added\n" +
+	"              # to the conditions of the steps when the test is resolved.\n" +
+	"              when:\n" +
+	"                - # Env is the name of the parameter, either declared by the step or set\n" +
+	"                  # in the environment of the test.\n" +
+	"                  env: ' '\n" +
+	"                  # Operator is either `in` or `notin`.\n" +
+	"                  operator: ' '\n" +
+	"                  # Values are the values the parameter is compared to.\n" +
+	"                  values:\n" +
+	"                    - \"\"\n" +
 	"        # Pre is the array of test steps run to set up the environment for the test.\n" +
 	"        pre:\n" +
 	"            # LiteralTestStep is a full test step definition.\n" +
@@ -2202,9 +3175,14 @@ const ciOperatorReferenceYaml = "# The list of base images describe\n" +
 	"                    - \"\"\n" +
 	"              env:\n" +
 	"                # LiteralTestStep is a full test step definition.\n" +
-	"                - default: \"\"\n" +
+	"                - allowed_values:\n" +
+	"                    # LiteralTestStep is a full test step definition.\n" +
+	"                    - \"\"\n" +
+	"                  default: \"\"\n" +
 	"                  documentation: ' '\n" +
 	"                  name: ' '\n" +
+	"                  pattern: ' '\n" +
+	"                  type: ' '\n" +
 	"              from: ' '\n" +
 	"              from_image:\n" +
 	"                # LiteralTestStep is a full test step definition.\n" +
@@ -2225,6 +3203,103 @@ const ciOperatorReferenceYaml = "# The list of base images describe\n" +
 	"                # LiteralTestStep is a full test step definition.\n" +
 	"                - \"\"\n" +
 	"              optional_on_success: false\n" +
+	"              # Parallel is a group of steps which are executed concurrently. Until\n" +
+	"              # the entrypoint-wrapper image supporting this is rolled out everywhere,\n" +
+	"              # the steps are executed sequentially unless ci-operator is run with\n" +
+	"              # --parallel-step-groups.\n" +
+	"              parallel:\n" +
+	"                # LiteralTestStep is a full test step definition.\n" +
+	"                - as: ' '\n" +
+	"                  best_effort: false\n" +
+	"                  cli: ' '\n" +
+	"                  commands: ' '\n" +
+	"                  credentials:\n" +
+	"                    # LiteralTestStep is a full test step definition.\n" +
+	"                    - as: ' '\n" +
+	"                      bundle: ' '\n" +
+	"                      collection: ' '\n" +
+	"                      field: ' '\n" +
+	"                      group: ' '\n" +
+	"                      mount_path: ' '\n" +
+	"                      name: ' '\n" +
+	"                      namespace: ' '\n" +
+	"                  dependencies:\n" +
+	"                    # LiteralTestStep is a full test step definition.\n" +
+	"                    - env: ' '\n" +
+	"                      name: ' '\n" +
+	"                  dnsConfig:\n" +
+	"                    # LiteralTestStep is a full test step definition.\n" +
+	"                    nameservers:\n" +
+	"                        # LiteralTestStep is a full test step definition.\n" +
+	"                        - \"\"\n" +
+	"                    searches:\n" +
+	"                        # LiteralTestStep is a full test step definition.\n" +
+	"                        - \"\"\n" +
+	"                  env:\n" +
+	"                    # LiteralTestStep is a full test step definition.\n" +
+	"                    - allowed_values:\n" +
+	"                        # LiteralTestStep is a full test step definition.\n" +
+	"                        - \"\"\n" +
+	"                      default: \"\"\n" +
+	"                      documentation: ' '\n" +
+	"                      name: ' '\n" +
+	"                      pattern: ' '\n" +
+	"                      type: ' '\n" +
+	"                  from: ' '\n" +
+	"                  from_image:\n" +
+	"                    # LiteralTestStep is a full test step definition.\n" +
+	"                    as: ' '\n" +
+	"                    name: ' '\n" +
+	"                    namespace: ' '\n" +
+	"                    tag: ' '\n" +
+	"                  grace_period: 0s\n" +
+	"                  leases:\n" +
+	"                    # LiteralTestStep is a full test step definition.\n" +
+	"                    - env: ' '\n" +
+	"                      resource_type: ' '\n" +
+	"                  nested_podman: true\n" +
+	"                  no_kubeconfig: false\n" +
+	"                  node_architecture: \"\"\n" +
+	"                  # Observers are the observers that should be running\n" +
+	"                  observers:\n" +
+	"                    # LiteralTestStep is a full test step definition.\n" +
+	"                    - \"\"\n" +
+	"                  optional_on_success: false\n" +
+	"                  parallel_group: ' '\n" +
+	"                  # Reference is the name of a step reference.\n" +
+	"                  ref: \"\"\n" +
+	"                  # Resources defines the resource requirements for the step.\n" +
+	"                  resources:\n" +
+	"                    # LiteralTestStep is a full test step definition.\n" +
+	"                    limits:\n" +
+	"                        # LiteralTestStep is a full test step definition.\n" +
+	"                        \"\": \"\"\n" +
+	"                    requests:\n" +
+	"                        # LiteralTestStep is a full test step definition.\n" +
+	"                        \"\": \"\"\n" +
+	"                  retry:\n" +
+	"                    # LiteralTestStep is a full test step definition.\n" +
+	"                    backoff: 0s\n" +
+	"                    exit_codes:\n" +
+	"                        # LiteralTestStep is a full test step definition.\n" +
+	"                        - 0\n" +
+	"                    max_attempts: 0\n" +
+	"                    reasons:\n" +
+	"                        # LiteralTestStep is a full test step definition.\n" +
+	"                        - \"\"\n" +
+	"                  run_as_script: false\n" +
+	"                  served_artifacts:\n" +
+	"                    # LiteralTestStep is a full test step definition.\n" +
+	"                    - \"\"\n" +
+	"                  timeout: 0s\n" +
+	"                  when:\n" +
+	"                    # LiteralTestStep is a full test step definition.\n" +
+	"                    - env: ' '\n" +
+	"                      operator: ' '\n" +
+	"                      values:\n" +
+	"                        # LiteralTestStep is a full test step definition.\n" +
+	"                        - \"\"\n" +
+	"              parallel_group: ' '\n" +
 	"              # Reference is the name of a step reference.\n" +
 	"              ref: \"\"\n" +
 	"              # Resources defines the resource requirements for the step.\n" +
@@ -2236,8 +3311,33 @@ const ciOperatorReferenceYaml = "# The list of base images describe\n" +
 	"                requests:\n" +
 	"                    # LiteralTestStep is a full test step definition.\n" +
 	"                    \"\": \"\"\n" +
+	"              retry:\n" +
+	"                # LiteralTestStep is a full test step definition.\n" +
+	"                backoff: 0s\n" +
+	"                exit_codes:\n" +
+	"                    # LiteralTestStep is a full test step definition.\n" +
+	"                    - 0\n" +
+	"                max_attempts: 0\n" +
+	"                reasons:\n" +
+	"                    # LiteralTestStep is a full test step definition.\n" +
+	"                    - \"\"\n" +
 	"              run_as_script: false\n" +
+	"              served_artifacts:\n" +
+	"                # LiteralTestStep is a full test step definition.\n" +
+	"                - \"\"\n" +
 	"              timeout: 0s\n" +
+	"              # When lists conditions which must all hold for the step, or for each\n" +
+	"              # step of the chain or parallel group, to be executed. They are added\n" +
+	"              # to the conditions of the steps when the test is resolved.\n" +
+	"              when:\n" +
+	"                - # Env is the name of the parameter, either declared by the step or set\n" +
+	"                  # in the environment of the test.\n" +
+	"                  env: ' '\n" +
+	"                  # Operator is either `in` or `notin`.\n" +
+	"                  operator: ' '\n" +
+	"                  # Values are the values the parameter is compared to.\n" +
+	"                  values:\n" +
+	"                    - \"\"\n" +
 	"        # Test is the array of test steps that define the actual test.\n" +
 	"        test:\n" +
 	"            # LiteralTestStep is a full test step definition.\n" +
@@ -2273,9 +3373,14 @@ const ciOperatorReferenceYaml = "# The list of base images describe\n" +
 	"                    - \"\"\n" +
 	"              env:\n" +
 	"                # LiteralTestStep is a full test step definition.\n" +
-	"                - default: \"\"\n" +
+	"                - allowed_values:\n" +
+	"                    # LiteralTestStep is a full test step definition.\n" +
+	"                    - \"\"\n" +
+	"                  default: \"\"\n" +
 	"                  documentation: ' '\n" +
 	"                  name: ' '\n" +
+	"                  pattern: ' '\n" +
+	"                  type: ' '\n" +
 	"              from: ' '\n" +
 	"              from_image:\n" +
 	"                # LiteralTestStep is a full test step definition.\n" +
@@ -2296,6 +3401,103 @@ const ciOperatorReferenceYaml = "# The list of base images describe\n" +
 	"                # LiteralTestStep is a full test step definition.\n" +
 	"                - \"\"\n" +
 	"              optional_on_success: false\n" +
+	"              # Parallel is a group of steps which are executed concurrently. Until\n" +
+	"              # the entrypoint-wrapper image supporting this is rolled out everywhere,\n" +
+	"              # the steps are executed sequentially unless ci-operator is run with\n" +
+	"              # --parallel-step-groups.\n" +
+	"              parallel:\n" +
+	"                # LiteralTestStep is a full test step definition.\n" +
+	"                - as: ' '\n" +
+	"                  best_effort: false\n" +
+	"                  cli: ' '\n" +
+	"                  commands: ' '\n" +
+	"                  credentials:\n" +
+	"                    # LiteralTestStep is a full test step definition.\n" +
+	"                    - as: ' '\n" +
+	"                      bundle: ' '\n" +
+	"                      collection: ' '\n" +
+	"                      field: ' '\n" +
+	"                      group: ' '\n" +
+	"                      mount_path: ' '\n" +
+	"                      name: ' '\n" +
+	"                      namespace: ' '\n" +
+	"                  dependencies:\n" +
+	"                    # LiteralTestStep is a full test step definition.\n" +
+	"                    - env: ' '\n" +
+	"                      name: ' '\n" +
+	"                  dnsConfig:\n" +
+	"                    # LiteralTestStep is a full test step definition.\n" +
+	"                    nameservers:\n" +
+	"                        # LiteralTestStep is a full test step definition.\n" +
+	"                        - \"\"\n" +
+	"                    searches:\n" +
+	"                        # LiteralTestStep is a full test step definition.\n" +
+	"                        - \"\"\n" +
+	"                  env:\n" +
+	"                    # LiteralTestStep is a full test step definition.\n" +
+	"                    - allowed_values:\n" +
+	"                        # LiteralTestStep is a full test step definition.\n" +
+	"                        - \"\"\n" +
+	"                      default: \"\"\n" +
+	"                      documentation: ' '\n" +
+	"                      name: ' '\n" +
+	"                      pattern: ' '\n" +
+	"                      type: ' '\n" +
+	"                  from: ' '\n" +
+	"                  from_image:\n" +
+	"                    # LiteralTestStep is a full test step definition.\n" +
+	"                    as: ' '\n" +
+	"                    name: ' '\n" +
+	"                    namespace: ' '\n" +
+	"                    tag: ' '\n" +
+	"                  grace_period: 0s\n" +
+	"                  leases:\n" +
+	"                    # LiteralTestStep is a full test step definition.\n" +
+	"                    - env: ' '\n" +
+	"                      resource_type: ' '\n" +
+	"                  nested_podman: true\n" +
+	"                  no_kubeconfig: false\n" +
+	"                  node_architecture: \"\"\n" +
+	"                  # Observers are the observers that should be running\n" +
+	"                  observers:\n" +
+	"                    # LiteralTestStep is a full test step definition.\n" +
+	"                    - \"\"\n" +
+	"                  optional_on_success: false\n" +
+	"                  parallel_group: ' '\n" +
+	"                  # Reference is the name of a step reference.\n" +
+	"                  ref: \"\"\n" +
+	"                  # Resources defines the resource requirements for the step.\n" +
+	"                  resources:\n" +
+	"                    # LiteralTestStep is a full test step definition.\n" +
+	"                    limits:\n" +
+	"                        # LiteralTestStep is a full test step definition.\n" +
+	"                        \"\": \"\"\n" +
+	"                    requests:\n" +
+	"                        # LiteralTestStep is a full test step definition.\n" +
+	"                        \"\": \"\"\n" +
+	"                  retry:\n" +
+	"                    # LiteralTestStep is a full test step definition.\n" +
+	"                    backoff: 0s\n" +
+	"                    exit_codes:\n" +
+	"                        # LiteralTestStep is a full test step definition.\n" +
+	"                        - 0\n" +
+	"                    max_attempts: 0\n" +
+	"                    reasons:\n" +
+	"                        # LiteralTestStep is a full test step definition.\n" +
+	"                        - \"\"\n" +
+	"                  run_as_script: false\n" +
+	"                  served_artifacts:\n" +
+	"                    # LiteralTestStep is a full test step definition.\n" +
+	"                    - \"\"\n" +
+	"                  timeout: 0s\n" +
+	"                  when:\n" +
+	"                    # LiteralTestStep is a full test step definition.\n" +
+	"                    - env: ' '\n" +
+	"                      operator: ' '\n" +
+	"                      values:\n" +
+	"                        # LiteralTestStep is a full test step definition.\n" +
+	"                        - \"\"\n" +
+	"              parallel_group: ' '\n" +
 	"              # Reference is the name of a step reference.\n" +
 	"              ref: \"\"\n" +
 	"              # Resources defines the resource requirements for the step.\n" +
@@ -2307,8 +3509,33 @@ const ciOperatorReferenceYaml = "# The list of base images describe\n" +
 	"                requests:\n" +
 	"                    # LiteralTestStep is a full test step definition.\n" +
 	"                    \"\": \"\"\n" +
+	"              retry:\n" +
+	"                # LiteralTestStep is a full test step definition.\n" +
+	"                backoff: 0s\n" +
+	"                exit_codes:\n" +
+	"                    # LiteralTestStep is a full test step definition.\n" +
+	"                    - 0\n" +
+	"                max_attempts: 0\n" +
+	"                reasons:\n" +
+	"                    # LiteralTestStep is a full test step definition.\n" +
+	"                    - \"\"\n" +
 	"              run_as_script: false\n" +
+	"              served_artifacts:\n" +
+	"                # LiteralTestStep is a full test step definition.\n" +
+	"                - \"\"\n" +
 	"              timeout: 0s\n" +
+	"              # When lists conditions which must all hold for the step, or for each\n" +
+	"              # step of the chain or parallel group, to be executed. They are added\n" +
+	"              # to the conditions of the steps when the test is resolved.\n" +
+	"              when:\n" +
+	"                - # Env is the name of the parameter, either declared by the step or set\n" +
+	"                  # in the environment of the test.\n" +
+	"                  env: ' '\n" +
+	"                  # Operator is either `in` or `notin`.\n" +
+	"                  operator: ' '\n" +
+	"                  # Values are the values the parameter is compared to.\n" +
+	"                  values:\n" +
+	"                    - \"\"\n" +
 	"        # Workflow is the name of the workflow to be used for this configuration. For fields defined in both\n" +
 	"        # the config and the workflow, the fields from the config will override what is set in Workflow.\n" +
 	"        workflow: \"\"\n" +
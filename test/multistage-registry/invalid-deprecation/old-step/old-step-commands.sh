@@ -0,0 +1 @@
+openshift-cluster install